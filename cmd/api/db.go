@@ -1,15 +1,21 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/budhilaw/personal-website-backend/config"
 	"github.com/budhilaw/personal-website-backend/db"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/internal/service"
 	"github.com/budhilaw/personal-website-backend/pkg/logger"
 	_ "github.com/jackc/pgx/v5/stdlib"
-	"github.com/jmoiron/sqlx"
 	"github.com/pressly/goose/v3"
 	"go.uber.org/zap"
 )
@@ -18,7 +24,7 @@ import (
 func handleDBCommand() {
 	// Check if command is provided
 	if len(os.Args) < 2 {
-		fmt.Println("Usage: go run cmd/api/main.go [db:migrate|db:create|db:rollback|db:reset]")
+		fmt.Println("Usage: go run cmd/api/main.go [db:migrate|db:up-to|db:status|db:create|db:rollback|db:reset|db:seed [--force]|db:backup]")
 		os.Exit(1)
 	}
 
@@ -28,6 +34,19 @@ func handleDBCommand() {
 	switch command {
 	case "db:migrate":
 		runMigrations()
+	case "db:up-to":
+		if len(os.Args) < 3 {
+			fmt.Println("Usage: go run cmd/api/main.go db:up-to <version>")
+			os.Exit(1)
+		}
+		version, err := strconv.ParseInt(os.Args[2], 10, 64)
+		if err != nil {
+			fmt.Println("Version must be a migration number, e.g. 20240012")
+			os.Exit(1)
+		}
+		migrateUpTo(version)
+	case "db:status":
+		migrationStatus()
 	case "db:create":
 		if len(os.Args) < 3 {
 			fmt.Println("Usage: go run cmd/api/main.go db:create <migration_name>")
@@ -38,6 +57,10 @@ func handleDBCommand() {
 		rollbackMigration()
 	case "db:reset":
 		resetDatabase()
+	case "db:seed":
+		seedDatabase(slices.Contains(os.Args[2:], "--force"))
+	case "db:backup":
+		backupDatabase()
 	default:
 		// If not a db command, return to continue with normal app flow
 		return
@@ -67,6 +90,56 @@ func runMigrations() {
 	logger.Info("Migrations completed successfully")
 }
 
+// migrateUpTo applies the pending migrations up to and including version
+func migrateUpTo(version int64) {
+	cfg := config.InitConfig()
+
+	// Initialize logger
+	_ = logger.InitLogger(cfg.IsProduction())
+
+	database, err := db.InitDB(cfg)
+	if err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	defer database.Close()
+
+	if err := db.MigrateUpTo(database, version); err != nil {
+		logger.Fatal("Failed to run migrations", zap.Error(err))
+	}
+
+	logger.Info("Migrations completed successfully", zap.Int64("version", version))
+}
+
+// migrationStatus prints every migration with whether and when it was applied
+func migrationStatus() {
+	cfg := config.InitConfig()
+
+	// Initialize logger
+	_ = logger.InitLogger(cfg.IsProduction())
+
+	database, err := db.InitDB(cfg)
+	if err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	defer database.Close()
+
+	statuses, err := db.MigrationStatus(database)
+	if err != nil {
+		logger.Fatal("Failed to read migration status", zap.Error(err))
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "APPLIED AT\tMIGRATION")
+	for _, status := range statuses {
+		appliedAt := "Pending"
+		if status.State == goose.StateApplied {
+			appliedAt = status.AppliedAt.UTC().Format(time.DateTime)
+		}
+		fmt.Fprintf(writer, "%s\t%s\n", appliedAt, status.Source.Path)
+	}
+	writer.Flush()
+}
+
 // createMigration creates a new migration file
 func createMigration(name string, sql bool) {
 	// Initialize logger with development mode
@@ -94,7 +167,7 @@ func rollbackMigration() {
 	}
 	defer database.Close()
 
-	if err := rollback(database); err != nil {
+	if err := db.RollbackMigration(database); err != nil {
 		logger.Fatal("Failed to rollback migration", zap.Error(err))
 	}
 
@@ -117,7 +190,7 @@ func resetDatabase() {
 
 	// First down all migrations
 	logger.Info("Reverting all migrations")
-	if err := goose.Reset(database.DB, "db/migration"); err != nil {
+	if err := db.ResetMigrations(database); err != nil {
 		logger.Fatal("Failed to reset migrations", zap.Error(err))
 	}
 
@@ -130,16 +203,49 @@ func resetDatabase() {
 	logger.Info("Database reset completed successfully")
 }
 
-// rollback rolls back the most recent migration
-func rollback(db *sqlx.DB) error {
-	goose.SetBaseFS(nil)
-	if err := goose.SetDialect("postgres"); err != nil {
-		return fmt.Errorf("failed to set dialect: %w", err)
+// seedDatabase applies migrations and inserts example content; force allows seeding in production
+func seedDatabase(force bool) {
+	cfg := config.InitConfig()
+
+	// Initialize logger
+	_ = logger.InitLogger(cfg.IsProduction())
+
+	database, err := db.InitDB(cfg)
+	if err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	defer database.Close()
+
+	if err := db.RunMigrations(database); err != nil {
+		logger.Fatal("Failed to run migrations", zap.Error(err))
+	}
+
+	if err := db.Seed(context.Background(), database, cfg, force); err != nil {
+		logger.Fatal("Failed to seed database", zap.Error(err))
+	}
+
+	logger.Info("Seeding completed successfully")
+}
+
+// backupDatabase dumps the database to the configured backup storage
+func backupDatabase() {
+	cfg := config.InitConfig()
+
+	// Initialize logger
+	_ = logger.InitLogger(cfg.IsProduction())
+
+	backupRepo, err := repository.NewBackupRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize backup storage", zap.Error(err))
 	}
 
-	if err := goose.Down(db.DB, "db/migration"); err != nil {
-		return fmt.Errorf("failed to rollback migration: %w", err)
+	backup, err := service.NewBackupService(backupRepo, nil, cfg).Create(context.Background())
+	if err != nil {
+		logger.Fatal("Failed to back up database", zap.Error(err))
 	}
 
-	return nil
+	logger.Info("Backup completed successfully",
+		zap.String("name", backup.Name),
+		zap.String("location", backup.Location),
+		zap.Int64("size", backup.Size))
 }
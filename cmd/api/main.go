@@ -1,16 +1,24 @@
 package main
 
 import (
+	"context"
 	"os"
+	"time"
 
 	"github.com/budhilaw/personal-website-backend/config"
 	"github.com/budhilaw/personal-website-backend/db"
+	"github.com/budhilaw/personal-website-backend/internal/cache"
 	"github.com/budhilaw/personal-website-backend/internal/controller"
+	"github.com/budhilaw/personal-website-backend/internal/events"
+	"github.com/budhilaw/personal-website-backend/internal/grpcapi"
+	"github.com/budhilaw/personal-website-backend/internal/jobs"
 	"github.com/budhilaw/personal-website-backend/internal/middleware"
 	"github.com/budhilaw/personal-website-backend/internal/repository"
 	"github.com/budhilaw/personal-website-backend/internal/router"
 	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/budhilaw/personal-website-backend/pkg/errortracking"
 	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
 	"github.com/gofiber/fiber/v2"
 	fiberRecover "github.com/gofiber/fiber/v2/middleware/recover"
 	"go.uber.org/zap"
@@ -21,9 +29,33 @@ func main() {
 	cfg := config.InitConfig()
 
 	// Initialize logger
-	log := logger.InitLogger(cfg.IsProduction())
+	log := logger.InitLoggerWithOptions(logger.Options{
+		Production:     cfg.IsProduction(),
+		Format:         cfg.LogFormat,
+		Level:          cfg.LogLevel,
+		Levels:         cfg.LogLevels,
+		File:           cfg.LogFile,
+		FileMaxSizeMB:  cfg.LogFileMaxSizeMB,
+		FileMaxBackups: cfg.LogFileMaxBackups,
+		FileMaxAgeDays: cfg.LogFileMaxAgeDays,
+		FileCompress:   cfg.LogFileCompress,
+	})
 	defer log.Sync()
 
+	// Report panics and logged errors to Sentry when configured
+	environment := cfg.SentryEnvironment
+	if environment == "" {
+		environment = cfg.AppEnv
+	}
+	if err := errortracking.Init(errortracking.Options{
+		DSN:         cfg.SentryDSN,
+		Environment: environment,
+		SampleRate:  cfg.SentrySampleRate,
+	}); err != nil {
+		logger.Fatal("Failed to initialize Sentry", zap.Error(err))
+	}
+	defer errortracking.Flush(2 * time.Second)
+
 	// Initialize JWT Manager for secret rotation
 	middleware.InitJWTManager(cfg)
 
@@ -39,27 +71,284 @@ func main() {
 	}
 	defer database.Close()
 
-	// Run migrations
-	if err := db.RunMigrations(database); err != nil {
-		logger.Fatal("Failed to run migrations", zap.Error(err))
+	// Bound every repository query and log the slow ones
+	repository.SetQueryLimits(cfg.DBQueryTimeout, cfg.DBSlowQueryThreshold)
+
+	// Public reads go to the replica when one is configured
+	replica, err := db.InitReplicaDB(cfg)
+	if err != nil {
+		logger.Fatal("Failed to connect to read replica", zap.Error(err))
+	}
+	defer db.CloseReplicaDB()
+
+	// Watch the connection so the pool recovers after Postgres restarts
+	db.StartHealthMonitor(cfg.DBHealthCheckInterval)
+	defer db.StopHealthMonitor()
+
+	// Run migrations, unless they're applied separately, e.g. by a release job
+	if cfg.DBAutoMigrate {
+		if err := db.RunMigrations(database); err != nil {
+			logger.Fatal("Failed to run migrations", zap.Error(err))
+		}
+	} else if pending, err := db.HasPendingMigrations(database); err != nil {
+		logger.Warn("Failed to check for pending migrations", zap.Error(err))
+	} else if pending {
+		logger.Warn("Pending migrations are not applied because DB_AUTO_MIGRATE is false; run db:migrate")
 	}
 
 	// Initialize repositories
+	txManager := repository.NewTxManager(database)
 	userRepo := repository.NewUserRepository(database)
 	articleRepo := repository.NewArticleRepository(database)
 	portfolioRepo := repository.NewPortfolioRepository(database)
-	telegramRepo := repository.NewTelegramRepository(cfg, log)
+	subscriberRepo := repository.NewSubscriberRepository(database)
+	campaignRepo := repository.NewCampaignRepository(database)
+	pushSubscriptionRepo := repository.NewPushSubscriptionRepository(database)
+	seriesRepo := repository.NewSeriesRepository(database)
+	resumeRepo := repository.NewResumeRepository(database)
+	usesRepo := repository.NewUsesRepository(database)
+	pageRepo := repository.NewPageRepository(database)
+	linkRepo := repository.NewLinkRepository(database)
+	analyticsRepo := repository.NewAnalyticsRepository(database)
+	redirectRepo := repository.NewRedirectRepository(database)
+	translationRepo := repository.NewTranslationRepository(database)
+	jobRepo := repository.NewJobRepository(database)
+	loginAttemptRepo := repository.NewLoginAttemptRepository(database)
+	loginDeviceRepo := repository.NewLoginDeviceRepository(database)
+	loginEventRepo := repository.NewLoginEventRepository(database)
+	activityPubFollowerRepo := repository.NewActivityPubFollowerRepository(database)
+	syndicationRepo := repository.NewArticleSyndicationRepository(database)
+	discussionRepo := repository.NewArticleDiscussionRepository(database)
+	tagRepo := repository.NewTagRepository(database)
+	importedArticleRepo := repository.NewImportedArticleRepository(database)
+	mediaRepo := repository.NewMediaRepository(database)
+	portfolioImageRepo := repository.NewPortfolioImageRepository(database)
+	oEmbedCacheRepo := repository.NewOEmbedCacheRepository(database)
+	revisionRepo := repository.NewArticleRevisionRepository(database)
+	emailMessageRepo := repository.NewEmailMessageRepository(database)
+	idempotencyRepo := repository.NewIdempotencyRepository(database)
+	accountRepo := repository.NewAccountRepository(database)
+	apiKeyRepo := repository.NewAPIKeyRepository(database)
+	contentAuditRepo := repository.NewContentAuditRepository(database)
+	telegramRepo := repository.NewTelegramRepository(cfg, logger.Named("telegram"))
+	emailRepo := repository.NewEmailRepository(cfg, logger.Named("email"))
+	webhookRepo := repository.NewWebhookRepository(cfg, logger.Named("webhook"))
+	crossPostRepo := repository.NewCrossPostRepository(cfg, logger.Named("crosspost"))
+	contentSourceRepo := repository.NewContentSourceRepository(cfg, logger.Named("content_import"))
+	oEmbedRepo := repository.NewOEmbedRepository(logger.Named("oembed"))
+	spotifyRepo := repository.NewSpotifyRepository(cfg, logger.Named("spotify"))
+	githubRepo := repository.NewGitHubRepository(cfg, logger.Named("github"))
+	geoIPRepo, err := repository.NewGeoIPRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to open GeoIP database", zap.Error(err))
+	}
+	defer geoIPRepo.Close()
+	backupRepo, err := repository.NewBackupRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize backup storage", zap.Error(err))
+	}
+	mediaStorageRepo, err := repository.NewMediaStorageRepository(cfg)
+	if err != nil {
+		logger.Fatal("Failed to initialize media storage", zap.Error(err))
+	}
+
+	// Federation signs deliveries with a persistent key so followers keep trusting the actor
+	var activityPubRepo *repository.ActivityPubRepository
+	var activityPubKeyPEM string
+	if cfg.ActivityPubEnabled {
+		key, err := util.LoadOrCreateRSAKey(cfg.ActivityPubKeyFile)
+		if err != nil {
+			logger.Fatal("Failed to load ActivityPub key", zap.Error(err))
+		}
+		activityPubKeyPEM, err = util.PublicKeyPEM(key)
+		if err != nil {
+			logger.Fatal("Failed to encode ActivityPub public key", zap.Error(err))
+		}
+		activityPubRepo = repository.NewActivityPubRepository(key, service.ActivityPubActorID(cfg)+"#main-key", logger.Named("activitypub"))
+	}
+
+	// Web Push signs notifications with a VAPID key generated on first start
+	var webPushRepo *repository.WebPushRepository
+	if cfg.PushEnabled {
+		privateKey, publicKey, err := util.LoadOrCreateVAPIDKeys(cfg.PushVAPIDKeyFile)
+		if err != nil {
+			logger.Fatal("Failed to load VAPID key", zap.Error(err))
+		}
+		webPushRepo = repository.NewWebPushRepository(privateKey, publicKey, cfg.PushVAPIDSubject, logger.Named("push"))
+	}
+
+	// Frontend revalidation and Cloudflare purges only run when configured
+	var revalidationRepo *repository.RevalidationRepository
+	if cfg.RevalidateURL != "" {
+		revalidationRepo = repository.NewRevalidationRepository(cfg)
+	}
+	var cloudflareRepo *repository.CloudflareRepository
+	if cfg.CloudflareZoneID != "" {
+		cloudflareRepo = repository.NewCloudflareRepository(cfg)
+	}
+
+	markdownRenderer := util.NewMarkdownRenderer(cfg.CodeHighlightStyle)
+
+	// Rendered content is cached in memory and dropped on every replica when it changes
+	var cacheInvalidator *cache.Invalidator
+	if cfg.ContentCacheTTL > 0 {
+		cacheInvalidator = cache.NewInvalidator(repository.NewCacheInvalidationRepository(database), logger.Named("cache"))
+	}
+
+	// Restore login blocks so they survive restarts
+	if err := middleware.GetBruteForceProtector().Persist(context.Background(), loginAttemptRepo); err != nil {
+		logger.Fatal("Failed to load login attempts", zap.Error(err))
+	}
+
+	// Live events for the admin dashboard
+	eventHub := events.NewHub()
+
+	// Background work is queued in Postgres and run by the worker pool
+	jobQueue := jobs.NewQueue(jobRepo, cfg, eventHub, logger.Named("jobs"))
 
 	// Initialize services
-	telegramService := service.NewTelegramService(telegramRepo, cfg, log)
-	authService := service.NewAuthService(userRepo, telegramService, cfg)
-	articleService := service.NewArticleService(articleRepo, userRepo)
-	portfolioService := service.NewPortfolioService(portfolioRepo, userRepo)
+	emailService, err := service.NewEmailService(emailRepo, emailMessageRepo, jobQueue, cfg, logger.Named("email"))
+	if err != nil {
+		logger.Fatal("Failed to load email templates", zap.Error(err))
+	}
+
+	// Register the enabled notification channels
+	var notifiers []service.Notifier
+	if cfg.TelegramEnabled {
+		notifiers = append(notifiers, service.NewTelegramService(telegramRepo))
+	}
+	if cfg.DiscordWebhookURL != "" {
+		notifiers = append(notifiers, service.NewDiscordService(webhookRepo, cfg.DiscordWebhookURL))
+	}
+	if cfg.SlackWebhookURL != "" {
+		notifiers = append(notifiers, service.NewSlackService(webhookRepo, cfg.SlackWebhookURL))
+	}
+	if cfg.EmailEnabled && cfg.NotifyEmailTo != "" {
+		notifiers = append(notifiers, emailService)
+	}
+	notificationService := service.NewNotificationService(cfg, logger.Named("notifications"), jobQueue, eventHub, notifiers...)
+	defer notificationService.Stop()
+	authService := service.NewAuthService(userRepo, loginDeviceRepo, loginEventRepo, geoIPRepo, notificationService, emailService, cfg)
+	activityPubService := service.NewActivityPubService(activityPubFollowerRepo, articleRepo, activityPubRepo, jobQueue, activityPubKeyPEM, cfg)
+	pushService := service.NewPushService(pushSubscriptionRepo, articleRepo, webPushRepo, jobQueue, cfg)
+	revalidationService := service.NewRevalidationService(revalidationRepo, cloudflareRepo, jobQueue, cfg)
+	contentAuditService := service.NewContentAuditService(contentAuditRepo, cfg)
+	articleService := service.NewArticleService(articleRepo, userRepo, seriesRepo, syndicationRepo, discussionRepo, tagRepo, txManager, notificationService, activityPubService, pushService, revalidationService, contentAuditService, jobQueue, markdownRenderer, cacheInvalidator, cfg)
+	portfolioService := service.NewPortfolioService(portfolioRepo, userRepo, portfolioImageRepo, revalidationService, contentAuditService, markdownRenderer, cacheInvalidator, cfg)
+	portfolioImageService := service.NewPortfolioImageService(portfolioImageRepo, portfolioRepo, mediaRepo, txManager, cacheInvalidator, cfg)
+	userService := service.NewUserService(userRepo)
+	accountService := service.NewAccountService(accountRepo, userRepo, cfg)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, cfg)
+	newsletterService := service.NewNewsletterService(subscriberRepo, emailService, cfg)
+	campaignService := service.NewCampaignService(campaignRepo, articleRepo, emailService, markdownRenderer, jobQueue, cfg)
+	seriesService := service.NewSeriesService(seriesRepo, articleRepo)
+	resumeService := service.NewResumeService(resumeRepo)
+	usesService := service.NewUsesService(usesRepo)
+	pageService := service.NewPageService(pageRepo, revalidationService)
+	linkService := service.NewLinkService(linkRepo, markdownRenderer, cfg)
+	analyticsService := service.NewAnalyticsService(analyticsRepo, eventHub, cfg)
+	telegramBotService := service.NewTelegramBotService(telegramRepo, analyticsService, newsletterService, middleware.GetBruteForceProtector(), cfg)
+	redirectService := service.NewRedirectService(redirectRepo)
+	ogImageService := service.NewOGImageService(articleRepo, userRepo, cfg)
+	translationService := service.NewTranslationService(translationRepo, articleRepo, pageRepo, markdownRenderer, cfg)
+	jobService := service.NewJobService(jobRepo)
+	backupService := service.NewBackupService(backupRepo, jobQueue, cfg)
+	syndicationService := service.NewSyndicationService(syndicationRepo, articleRepo, crossPostRepo, cfg)
+	mediaService := service.NewMediaService(mediaRepo, mediaStorageRepo, jobQueue, cfg)
+	oEmbedService := service.NewOEmbedService(oEmbedRepo, oEmbedCacheRepo, cfg)
+	nowPlayingService := service.NewNowPlayingService(spotifyRepo)
+	githubActivityService := service.NewGitHubActivityService(githubRepo, cfg)
+	discussionService := service.NewDiscussionService(githubRepo, discussionRepo, articleRepo, cacheInvalidator, cfg)
+	previewService := service.NewPreviewService(articleService, cfg)
+	revisionService := service.NewRevisionService(revisionRepo, articleRepo)
+	contentImportService := service.NewContentImportService(contentSourceRepo, importedArticleRepo, articleRepo, userRepo, articleService, jobQueue, cfg)
+	systemService := service.NewSystemService(database, replica)
+	healthService := service.NewHealthService(database, replica, telegramRepo, mediaStorageRepo, cfg)
+
+	// Register job handlers and start the workers
+	jobQueue.Register(service.JobArticlePublished, articleService.HandlePublishedJob)
+	jobQueue.Register(service.JobSendNotification, notificationService.HandleJob)
+	jobQueue.Register(service.JobSendEmail, emailService.HandleJob)
+	jobQueue.Register(service.JobSendCampaignBatch, campaignService.HandleBatchJob)
+	jobQueue.Register(service.JobDeliverActivity, activityPubService.HandleDeliveryJob)
+	jobQueue.Register(service.JobSendPush, pushService.HandleDeliveryJob)
+	jobQueue.Register(service.JobRevalidate, revalidationService.HandleRevalidateJob)
+	jobQueue.Register(service.JobPurgeCache, revalidationService.HandlePurgeCacheJob)
+	jobQueue.Register(service.JobContentImport, contentImportService.HandleJob)
+	jobQueue.Register(service.JobProcessMedia, mediaService.HandleProcessJob)
+	jobQueue.Register(service.JobCreateBackup, backupService.HandleJob)
+	jobQueue.Start()
+	defer jobQueue.Stop()
+
+	if cacheInvalidator != nil {
+		cacheInvalidator.Start()
+		defer cacheInvalidator.Stop()
+	}
+
+	// Periodic tasks
+	scheduler := jobs.NewScheduler(cfg, logger.Named("jobs.scheduler"))
+	scheduler.Register("bruteforce_cleanup", time.Hour, func(ctx context.Context) error {
+		return middleware.GetBruteForceProtector().Cleanup(ctx)
+	})
+	scheduler.Register("jwt_rotation", time.Hour, func(ctx context.Context) error {
+		return middleware.RotateJWTSecrets()
+	})
+	scheduler.Register("idempotency_cleanup", time.Hour, idempotencyRepo.DeleteExpired)
+	scheduler.Register("account_purge", time.Hour, accountService.PurgeDeleted)
+	scheduler.Register("api_key_usage_cleanup", time.Hour, apiKeyService.PurgeUsage)
+	scheduler.Register("content_audit_purge", time.Hour, contentAuditService.Purge)
+	scheduler.Register("publish_scheduled", time.Minute, articleService.PublishScheduled)
+	if cfg.SpotifyRefreshToken != "" {
+		scheduler.Register("spotify_now_playing", cfg.SpotifyRefreshInterval, nowPlayingService.Refresh)
+	}
+	if cfg.GiscusRepo != "" {
+		scheduler.Register("giscus_sync", cfg.GiscusSyncInterval, discussionService.Sync)
+	}
+	scheduler.Start()
+	defer scheduler.Stop()
 
 	// Initialize controllers
 	authController := controller.NewAuthController(authService, cfg)
-	articleController := controller.NewArticleController(articleService)
+	articleController := controller.NewArticleController(articleService, translationService, analyticsService)
 	portfolioController := controller.NewPortfolioController(portfolioService)
+	portfolioImageController := controller.NewPortfolioImageController(portfolioImageService)
+	userController := controller.NewUserController(userService)
+	accountController := controller.NewAccountController(accountService)
+	newsletterController := controller.NewNewsletterController(newsletterService)
+	campaignController := controller.NewCampaignController(campaignService)
+	seriesController := controller.NewSeriesController(seriesService)
+	resumeController := controller.NewResumeController(resumeService)
+	usesController := controller.NewUsesController(usesService)
+	pageController := controller.NewPageController(pageService, translationService)
+	linkController := controller.NewLinkController(linkService)
+	analyticsController := controller.NewAnalyticsController(analyticsService, cfg)
+	redirectController := controller.NewRedirectController(redirectService)
+	ogImageController := controller.NewOGImageController(ogImageService)
+	translationController := controller.NewTranslationController(translationService)
+	jobController := controller.NewJobController(jobService)
+	schedulerController := controller.NewSchedulerController(scheduler)
+	backupController := controller.NewBackupController(backupService)
+	securityController := controller.NewSecurityController(middleware.GetBruteForceProtector())
+	telegramController := controller.NewTelegramController(telegramBotService)
+	pushController := controller.NewPushController(pushService)
+	eventsController := controller.NewEventsController(eventHub)
+	systemController := controller.NewSystemController(systemService)
+	activityPubController := controller.NewActivityPubController(activityPubService)
+	syndicationController := controller.NewSyndicationController(syndicationService)
+	contentImportController := controller.NewContentImportController(contentImportService)
+	mediaController := controller.NewMediaController(mediaService)
+	oEmbedController := controller.NewOEmbedController(oEmbedService)
+	nowPlayingController := controller.NewNowPlayingController(nowPlayingService)
+	githubActivityController := controller.NewGitHubActivityController(githubActivityService)
+	previewController := controller.NewPreviewController(previewService)
+	revisionController := controller.NewRevisionController(revisionService)
+	emailController := controller.NewEmailController(emailService)
+	wellKnownController := controller.NewWellKnownController(cfg)
+	revalidationController := controller.NewRevalidationController(revalidationService)
+	apiKeyController := controller.NewAPIKeyController(apiKeyService)
+	healthController := controller.NewHealthController(healthService)
+	contentAuditController := controller.NewContentAuditController(contentAuditService)
+	formController := controller.NewFormController(cfg)
 
 	// Initialize Fiber app
 	app := fiber.New(fiber.Config{
@@ -74,24 +363,91 @@ func main() {
 	})
 
 	// Use global middlewares
-	app.Use(fiberRecover.New())
+	app.Use(fiberRecover.New(fiberRecover.Config{
+		EnableStackTrace:  true,
+		StackTraceHandler: middleware.ReportPanic,
+	}))
+	app.Use(middleware.ErrorTracking())
 	app.Use(middleware.ZapLogger())
 
+	// Classify bots so analytics and rate limits can tell them from human traffic
+	app.Use(middleware.BotDetection(cfg))
+
 	// Security middleware
-	app.Use(middleware.Security(cfg.FrontendURL))
+	app.Use(middleware.Security(cfg.CORSOrigins()))
 	app.Use(middleware.Helmet())
-	app.Use(middleware.RateLimiter())
+
+	// Rate limit counters are kept in Redis when configured so limits hold across replicas
+	rateLimitStorage := middleware.NewRateLimitStorage(cfg)
+	defer db.CloseRedis()
 
 	// Brute force protection
 	app.Use(middleware.BruteForceProtection())
 	app.Use(middleware.TrackLoginAttempt())
 
 	// Setup routes
-	router.SetupRoutes(app, authController, articleController, portfolioController, cfg)
+	router.SetupRoutes(app, router.Controllers{
+		Auth:           authController,
+		Account:        accountController,
+		Article:        articleController,
+		Portfolio:      portfolioController,
+		PortfolioImage: portfolioImageController,
+		User:           userController,
+		Newsletter:     newsletterController,
+		Campaign:       campaignController,
+		Series:         seriesController,
+		Resume:         resumeController,
+		Uses:           usesController,
+		Page:           pageController,
+		Link:           linkController,
+		Analytics:      analyticsController,
+		Redirect:       redirectController,
+		OGImage:        ogImageController,
+		Translation:    translationController,
+		Job:            jobController,
+		Scheduler:      schedulerController,
+		Backup:         backupController,
+		Security:       securityController,
+		Telegram:       telegramController,
+		Push:           pushController,
+		Events:         eventsController,
+		System:         systemController,
+		ActivityPub:    activityPubController,
+		Syndication:    syndicationController,
+		ContentImport:  contentImportController,
+		Media:          mediaController,
+		OEmbed:         oEmbedController,
+		NowPlaying:     nowPlayingController,
+		GitHubActivity: githubActivityController,
+		Preview:        previewController,
+		Revision:       revisionController,
+		Email:          emailController,
+		WellKnown:      wellKnownController,
+		Revalidation:   revalidationController,
+		APIKey:         apiKeyController,
+		Health:         healthController,
+		ContentAudit:   contentAuditController,
+		Form:           formController,
+	}, rateLimitStorage, idempotencyRepo, apiKeyRepo, userRepo, replica, cfg)
+
+	// Typed gRPC API for internal consumers such as the CLI or bots
+	if cfg.GRPCPort != "" {
+		grpcServer, err := grpcapi.NewServer(grpcapi.Services{
+			Auth:      authService,
+			Article:   articleService,
+			Portfolio: portfolioService,
+		}, userRepo, cfg)
+		if err != nil {
+			logger.Fatal("Failed to initialize gRPC server", zap.Error(err))
+		}
+		if err := serveGRPC(grpcServer, cfg.GRPCPort); err != nil {
+			logger.Fatal("Failed to start gRPC server", zap.Error(err))
+		}
+		defer grpcServer.GracefulStop()
+	}
 
 	// Start server
-	logger.Info("Starting server", zap.String("port", cfg.Port))
-	if err := app.Listen(":" + cfg.Port); err != nil {
+	if err := startServer(app, cfg); err != nil {
 		logger.Fatal("Failed to start server", zap.Error(err))
 	}
 }
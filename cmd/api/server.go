@@ -0,0 +1,106 @@
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/acme/autocert"
+	"google.golang.org/grpc"
+)
+
+// startServer serves the app over plain HTTP, a provided certificate, or Let's Encrypt autocert
+func startServer(app *fiber.App, cfg config.Config) error {
+	addr := ":" + cfg.Port
+
+	switch {
+	case len(cfg.AutocertDomains()) > 0:
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.AutocertDomains()...),
+			Cache:      autocert.DirCache(cfg.TLSAutocertCacheDir),
+			Email:      cfg.TLSAutocertEmail,
+		}
+
+		// HTTP-01 challenges and redirects to HTTPS; TLS-ALPN-01 works without it
+		serveHTTP(cfg.TLSHTTPPort, manager.HTTPHandler(nil))
+
+		ln, err := tls.Listen("tcp", addr, manager.TLSConfig())
+		if err != nil {
+			return err
+		}
+
+		logger.Info("Starting HTTPS server with autocert",
+			zap.String("port", cfg.Port),
+			zap.Strings("domains", cfg.AutocertDomains()),
+		)
+		return app.Listener(ln)
+
+	case cfg.TLSCertFile != "" || cfg.TLSKeyFile != "":
+		serveHTTP(cfg.TLSHTTPPort, redirectToHTTPS(cfg.Port))
+
+		logger.Info("Starting HTTPS server", zap.String("port", cfg.Port))
+		return app.ListenTLS(addr, cfg.TLSCertFile, cfg.TLSKeyFile)
+
+	default:
+		logger.Info("Starting server", zap.String("port", cfg.Port))
+		return app.Listen(addr)
+	}
+}
+
+// serveHTTP runs a plain HTTP listener next to the HTTPS server; an empty port disables it
+func serveHTTP(port string, handler http.Handler) {
+	if port == "" {
+		return
+	}
+
+	server := &http.Server{
+		Addr:              ":" + port,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		logger.Info("Starting HTTP redirect server", zap.String("port", port))
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("HTTP redirect server stopped", zap.Error(err))
+		}
+	}()
+}
+
+// serveGRPC runs the gRPC server on its own port next to the Fiber app
+func serveGRPC(server *grpc.Server, port string) error {
+	ln, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		logger.Info("Starting gRPC server", zap.String("port", port))
+		if err := server.Serve(ln); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			logger.Error("gRPC server stopped", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// redirectToHTTPS sends plain HTTP requests to the same URL on the HTTPS port
+func redirectToHTTPS(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if i := strings.LastIndex(host, ":"); i > strings.LastIndex(host, "]") {
+			host = host[:i]
+		}
+		if httpsPort != "443" {
+			host += ":" + httpsPort
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/db"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"github.com/jmoiron/sqlx"
+)
+
+// temporaryPasswordLength is the length of generated passwords
+const temporaryPasswordLength = 16
+
+// command is a CLI subcommand
+type command struct {
+	usage string
+	args  int // Minimum number of arguments
+	run   func(ctx context.Context, database *sqlx.DB, cfg config.Config, args []string) error
+}
+
+var commands = map[string]command{
+	"create-user": {
+		usage: "create-user <username> <email> <first_name> [owner|admin|user]",
+		args:  3,
+		run:   createUser,
+	},
+	"reset-password": {
+		usage: "reset-password <username>",
+		args:  1,
+		run:   resetPassword,
+	},
+	"promote-admin": {
+		usage: "promote-admin <username>",
+		args:  1,
+		run:   promoteAdmin,
+	},
+	"publish-article": {
+		usage: "publish-article <id|slug>",
+		args:  1,
+		run:   publishArticle,
+	},
+	"import-wordpress": {
+		usage: "import-wordpress <export.xml> <username>",
+		args:  2,
+		run:   importWordPress,
+	},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok || len(os.Args)-2 < cmd.args {
+		printUsage()
+		os.Exit(1)
+	}
+
+	cfg := config.InitConfig()
+	_ = logger.InitLogger(cfg.IsProduction())
+
+	database, err := db.InitDB(cfg)
+	if err != nil {
+		fmt.Printf("Error: failed to connect to database: %v\n", err)
+		os.Exit(1)
+	}
+	defer database.Close()
+
+	if err := cmd.run(context.Background(), database, cfg, os.Args[2:]); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		database.Close()
+		os.Exit(1)
+	}
+}
+
+// printUsage prints the available commands
+func printUsage() {
+	fmt.Println("Usage: go run ./cmd/cli <command> [arguments]")
+	fmt.Println()
+	fmt.Println("Commands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		fmt.Println("  " + commands[name].usage)
+	}
+}
+
+// createUser creates a user with a temporary password
+func createUser(ctx context.Context, database *sqlx.DB, _ config.Config, args []string) error {
+	user := &model.UserCreate{
+		Username:  args[0],
+		Email:     args[1],
+		FirstName: args[2],
+		Role:      model.RoleUser,
+	}
+	if len(args) > 3 {
+		user.Role = args[3]
+	}
+
+	if !slices.Contains([]string{model.RoleOwner, model.RoleAdmin, model.RoleUser}, user.Role) {
+		return fmt.Errorf("unknown role %q", user.Role)
+	}
+	if err := util.ValidateUsername(user.Username); err != nil {
+		return err
+	}
+	if err := util.ValidateEmail(user.Email); err != nil {
+		return err
+	}
+
+	password, hashedPassword, err := temporaryPassword()
+	if err != nil {
+		return err
+	}
+
+	id, err := repository.NewUserRepository(database).Create(ctx, user, hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created %s %s (%s)\n", user.Role, user.Username, id)
+	fmt.Printf("Temporary password: %s\n", password)
+	return nil
+}
+
+// resetPassword replaces a user's password with a temporary one
+func resetPassword(ctx context.Context, database *sqlx.DB, _ config.Config, args []string) error {
+	userRepo := repository.NewUserRepository(database)
+
+	user, err := userRepo.GetByUsername(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	password, hashedPassword, err := temporaryPassword()
+	if err != nil {
+		return err
+	}
+
+	if err := userRepo.UpdatePassword(ctx, user.ID, hashedPassword); err != nil {
+		return err
+	}
+
+	fmt.Printf("Password reset for %s\n", user.Username)
+	fmt.Printf("Temporary password: %s\n", password)
+	return nil
+}
+
+// promoteAdmin gives a user the admin role
+func promoteAdmin(ctx context.Context, database *sqlx.DB, _ config.Config, args []string) error {
+	userRepo := repository.NewUserRepository(database)
+
+	user, err := userRepo.GetByUsername(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	switch user.Role {
+	case model.RoleOwner:
+		return errors.New("user is an owner; promoting would demote them")
+	case model.RoleAdmin:
+		fmt.Printf("%s is already an admin\n", user.Username)
+		return nil
+	}
+
+	if err := userRepo.UpdateRole(ctx, user.ID, model.RoleAdmin); err != nil {
+		return err
+	}
+
+	fmt.Printf("Promoted %s to admin\n", user.Username)
+	return nil
+}
+
+// publishArticle publishes a draft article by ID or slug
+func publishArticle(ctx context.Context, database *sqlx.DB, _ config.Config, args []string) error {
+	articleRepo := repository.NewArticleRepository(database)
+
+	article, err := articleRepo.GetBySlug(ctx, args[0])
+	if err != nil {
+		article, err = articleRepo.GetByID(ctx, args[0])
+		if err != nil {
+			return errors.New("article not found")
+		}
+	}
+
+	if article.IsPublished {
+		fmt.Printf("%q is already published\n", article.Title)
+		return nil
+	}
+
+	err = articleRepo.Update(ctx, article.ID, &model.ArticleUpdate{
+		Title:         article.Title,
+		Slug:          article.Slug,
+		Content:       article.Content,
+		Excerpt:       article.Excerpt,
+		FeaturedImage: article.FeaturedImage,
+		IsPublished:   true,
+		SeriesID:      article.SeriesID,
+		SeriesOrder:   article.SeriesOrder,
+		SEOMeta:       article.SEOMeta,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Published %q\n", article.Title)
+	return nil
+}
+
+// temporaryPassword generates a password and its hash
+func temporaryPassword() (string, string, error) {
+	password, err := util.GenerateTemporaryPassword(temporaryPasswordLength)
+	if err != nil {
+		return "", "", err
+	}
+
+	hashedPassword, err := util.HashPassword(password)
+	if err != nil {
+		return "", "", err
+	}
+
+	return password, hashedPassword, nil
+}
@@ -0,0 +1,357 @@
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/jobs"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// wxrTimeFormat is the layout of dates in a WordPress export
+	wxrTimeFormat = "2006-01-02 15:04:05"
+	// maxMediaFile bounds each downloaded attachment
+	maxMediaFile = 50 << 20
+)
+
+// blockComment matches the <!-- wp:... --> markers of the block editor
+var blockComment = regexp.MustCompile(`<!-- /?wp:[^>]*-->\n?`)
+
+// wxrExport is the subset of a WordPress WXR export read by the importer
+type wxrExport struct {
+	Tags  []wxrTag  `xml:"channel>tag"`
+	Items []wxrItem `xml:"channel>item"`
+}
+
+// wxrTag is a wp:tag defined by the export, whether or not a post uses it
+type wxrTag struct {
+	Slug string `xml:"tag_slug"`
+	Name string `xml:"tag_name"`
+}
+
+// wxrItem is a post, page or attachment in a WordPress export
+type wxrItem struct {
+	Title         string        `xml:"title"`
+	PostID        string        `xml:"post_id"`
+	PostName      string        `xml:"post_name"`
+	PostType      string        `xml:"post_type"`
+	Status        string        `xml:"status"`
+	PostDateGMT   string        `xml:"post_date_gmt"`
+	ModifiedGMT   string        `xml:"post_modified_gmt"`
+	AttachmentURL string        `xml:"attachment_url"`
+	Encoded       []wxrEncoded  `xml:"encoded"`
+	Categories    []wxrCategory `xml:"category"`
+	PostMeta      []wxrPostMeta `xml:"postmeta"`
+}
+
+// wxrEncoded is a content:encoded or excerpt:encoded element
+type wxrEncoded struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+// wxrCategory is a category or tag assigned to a post
+type wxrCategory struct {
+	Domain   string `xml:"domain,attr"`
+	Nicename string `xml:"nicename,attr"`
+	Name     string `xml:",chardata"`
+}
+
+// wxrPostMeta is a custom field of a post
+type wxrPostMeta struct {
+	Key   string `xml:"meta_key"`
+	Value string `xml:"meta_value"`
+}
+
+// content returns the post body
+func (i wxrItem) content() string {
+	for _, e := range i.Encoded {
+		if strings.Contains(e.XMLName.Space, "/content/") {
+			return e.Value
+		}
+	}
+	return ""
+}
+
+// excerpt returns the hand-written excerpt, if any
+func (i wxrItem) excerpt() string {
+	for _, e := range i.Encoded {
+		if strings.Contains(e.XMLName.Space, "/excerpt/") {
+			return strings.TrimSpace(e.Value)
+		}
+	}
+	return ""
+}
+
+// meta returns the value of a custom field
+func (i wxrItem) meta(key string) string {
+	for _, m := range i.PostMeta {
+		if m.Key == key {
+			return m.Value
+		}
+	}
+	return ""
+}
+
+// mediaLink maps an attachment URL, including the resized copies WordPress generates, to its local upload
+type mediaLink struct {
+	pattern *regexp.Regexp
+	local   string
+}
+
+// importWordPress converts the posts of a WordPress export into articles owned by the given user,
+// with their tags. Attachments are added to the media library, or downloaded into the uploads
+// directory when no media bucket is configured, and references to them are rewritten.
+func importWordPress(ctx context.Context, database *sqlx.DB, cfg config.Config, args []string) error {
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var export wxrExport
+	if err := xml.NewDecoder(file).Decode(&export); err != nil {
+		return fmt.Errorf("failed to parse export: %w", err)
+	}
+
+	author, err := repository.NewUserRepository(database).GetByUsername(ctx, args[1])
+	if err != nil {
+		return fmt.Errorf("user %q not found", args[1])
+	}
+
+	articleRepo := repository.NewArticleRepository(database)
+	tagRepo := repository.NewTagRepository(database)
+	client := &http.Client{Timeout: time.Minute}
+
+	storageRepo, err := repository.NewMediaStorageRepository(cfg)
+	if err != nil {
+		return err
+	}
+	var mediaService service.MediaService
+	if storageRepo != nil {
+		// Image processing is queued for the API server's workers
+		queue := jobs.NewQueue(repository.NewJobRepository(database), cfg, nil, logger.Named("jobs"))
+		mediaService = service.NewMediaService(repository.NewMediaRepository(database), storageRepo, queue, cfg)
+	} else {
+		fmt.Println("No media bucket configured (MEDIA_S3_BUCKET), saving attachments to " + util.UploadDirectory + "/ without media records")
+	}
+
+	// Import attachments first so posts can point at the new copies
+	attachments := make(map[string]string)
+	var links []mediaLink
+	for _, item := range export.Items {
+		if item.PostType != "attachment" || item.AttachmentURL == "" {
+			continue
+		}
+
+		local, err := importMedia(ctx, client, mediaService, item.AttachmentURL, author.ID)
+		if err != nil {
+			fmt.Printf("Warning: keeping remote %s: %v\n", item.AttachmentURL, err)
+			continue
+		}
+
+		attachments[item.PostID] = local
+		ext := path.Ext(item.AttachmentURL)
+		base := strings.TrimSuffix(item.AttachmentURL, ext)
+		links = append(links, mediaLink{
+			pattern: regexp.MustCompile(regexp.QuoteMeta(base) + `(-\d+x\d+)?` + regexp.QuoteMeta(ext)),
+			local:   local,
+		})
+	}
+
+	// Tags defined by the export, including unused ones, keyed by their WordPress slug
+	tags := make(map[string]string)
+	for _, tag := range export.Tags {
+		if _, err := importTag(ctx, tagRepo, tags, tag.Name, tag.Slug); err != nil {
+			return err
+		}
+	}
+
+	var imported, drafts, skipped int
+	for _, item := range export.Items {
+		if item.PostType != "post" {
+			continue
+		}
+
+		var published bool
+		switch item.Status {
+		case "publish":
+			published = true
+		case "draft", "pending", "future", "private":
+		default:
+			// Trashed posts and auto-drafts
+			continue
+		}
+
+		slug := util.GenerateSlug(item.PostName)
+		if slug == "" {
+			slug = util.GenerateSlug(item.Title)
+		}
+		if _, err := articleRepo.GetBySlug(ctx, slug); err == nil {
+			fmt.Printf("Skipping %q: an article with slug %q exists\n", item.Title, slug)
+			skipped++
+			continue
+		}
+
+		content := blockComment.ReplaceAllString(item.content(), "")
+		for _, link := range links {
+			content = link.pattern.ReplaceAllString(content, link.local)
+		}
+
+		id, err := articleRepo.Create(ctx, &model.ArticleCreate{
+			Title:         item.Title,
+			Slug:          slug,
+			Content:       strings.TrimSpace(content),
+			Excerpt:       item.excerpt(),
+			FeaturedImage: attachments[item.meta("_thumbnail_id")],
+			IsPublished:   published,
+		}, author.ID)
+		if err != nil {
+			return fmt.Errorf("failed to import %q: %w", item.Title, err)
+		}
+
+		// Keep the original dates; unpublished posts may not have one
+		if created, err := time.Parse(wxrTimeFormat, item.PostDateGMT); err == nil {
+			updated, err := time.Parse(wxrTimeFormat, item.ModifiedGMT)
+			if err != nil || updated.Before(created) {
+				updated = created
+			}
+			if err := articleRepo.SetDates(ctx, id, created, updated); err != nil {
+				return err
+			}
+		}
+
+		var tagIDs []string
+		for _, category := range item.Categories {
+			if category.Domain != "post_tag" {
+				continue
+			}
+			tagID, err := importTag(ctx, tagRepo, tags, category.Name, category.Nicename)
+			if err != nil {
+				return err
+			}
+			if tagID != "" {
+				tagIDs = append(tagIDs, tagID)
+			}
+		}
+		if len(tagIDs) > 0 {
+			if err := tagRepo.SetArticleTags(ctx, id, tagIDs); err != nil {
+				return err
+			}
+		}
+
+		imported++
+		if !published {
+			drafts++
+		}
+	}
+
+	fmt.Printf("Imported %d articles (%d drafts) with %d tags, skipped %d existing, imported %d media files\n",
+		imported, drafts, len(tags), skipped, len(attachments))
+	return nil
+}
+
+// importTag creates the tag for a WordPress tag once and returns its ID; known maps WordPress
+// slugs to the IDs already imported. Tags with neither a name nor a slug are skipped.
+func importTag(ctx context.Context, tagRepo repository.TagRepository, known map[string]string, name, wpSlug string) (string, error) {
+	name = strings.TrimSpace(name)
+	if wpSlug == "" {
+		wpSlug = name
+	}
+	if name == "" {
+		name = wpSlug
+	}
+	if id, ok := known[wpSlug]; ok {
+		return id, nil
+	}
+
+	slug := util.GenerateSlug(wpSlug)
+	if slug == "" {
+		return "", nil
+	}
+
+	tag, err := tagRepo.Upsert(ctx, name, slug)
+	if err != nil {
+		return "", fmt.Errorf("failed to import tag %q: %w", name, err)
+	}
+	known[wpSlug] = tag.ID
+	return tag.ID, nil
+}
+
+// importMedia fetches a remote attachment and returns the URL of its new copy: a media library
+// entry when mediaService is set, otherwise a file in the uploads directory
+func importMedia(ctx context.Context, client *http.Client, mediaService service.MediaService, url, userID string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMediaFile+1))
+	if err != nil {
+		return "", err
+	}
+	if len(data) > maxMediaFile {
+		return "", fmt.Errorf("larger than %d bytes", maxMediaFile)
+	}
+
+	filename := path.Base(req.URL.Path)
+	if mediaService != nil {
+		contentType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+		if contentType == "" || contentType == "application/octet-stream" {
+			contentType, _, _ = mime.ParseMediaType(http.DetectContentType(data))
+		}
+
+		media, err := mediaService.Import(ctx, filename, contentType, data, userID)
+		if err != nil {
+			return "", err
+		}
+		return media.URL, nil
+	}
+
+	return saveUpload(filename, data)
+}
+
+// saveUpload writes an attachment into the uploads directory and returns its public path
+func saveUpload(filename string, data []byte) (string, error) {
+	if err := os.MkdirAll(util.UploadDirectory, 0755); err != nil {
+		return "", err
+	}
+
+	name, err := util.GenerateFileName(filename)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.WriteFile(filepath.Join(util.UploadDirectory, name), data, 0644); err != nil {
+		return "", err
+	}
+
+	return "/" + util.UploadDirectory + "/" + name, nil
+}
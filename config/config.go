@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -16,6 +18,27 @@ type Config struct {
 	AppEnv  string `mapstructure:"APP_ENV"`
 	Port    string `mapstructure:"PORT"`
 
+	// IANA timezone of the site, e.g. "Asia/Jakarta": scheduled times without a UTC offset,
+	// archive months and dates in notifications and emails use it
+	SiteTimezone string `mapstructure:"SITE_TIMEZONE"`
+
+	// Logging: LOG_FORMAT is json or console and LOG_LEVEL the default level, both picked by
+	// APP_ENV when empty. LOG_LEVELS overrides named loggers, e.g. "jobs=debug,http=warn".
+	// LOG_FILE writes to a size-rotated file instead of stderr.
+	LogFormat         string `mapstructure:"LOG_FORMAT"`
+	LogLevel          string `mapstructure:"LOG_LEVEL"`
+	LogLevels         string `mapstructure:"LOG_LEVELS"`
+	LogFile           string `mapstructure:"LOG_FILE"`
+	LogFileMaxSizeMB  int    `mapstructure:"LOG_FILE_MAX_SIZE_MB"`
+	LogFileMaxBackups int    `mapstructure:"LOG_FILE_MAX_BACKUPS"`
+	LogFileMaxAgeDays int    `mapstructure:"LOG_FILE_MAX_AGE_DAYS"`
+	LogFileCompress   bool   `mapstructure:"LOG_FILE_COMPRESS"`
+
+	// Sentry error tracking, off without a DSN; the environment defaults to APP_ENV
+	SentryDSN         string  `mapstructure:"SENTRY_DSN"`
+	SentryEnvironment string  `mapstructure:"SENTRY_ENVIRONMENT"`
+	SentrySampleRate  float64 `mapstructure:"SENTRY_SAMPLE_RATE"`
+
 	PostgresHost     string `mapstructure:"POSTGRES_HOST"`
 	PostgresPort     string `mapstructure:"POSTGRES_PORT"`
 	PostgresUser     string `mapstructure:"POSTGRES_USER"`
@@ -23,18 +46,330 @@ type Config struct {
 	PostgresDB       string `mapstructure:"POSTGRES_DB"`
 	PostgresSSLMode  string `mapstructure:"POSTGRES_SSL_MODE"`
 
+	// Connection pool limits, shared by the primary and the read replica
+	DBMaxOpenConns    int           `mapstructure:"DB_MAX_OPEN_CONNS"`
+	DBMaxIdleConns    int           `mapstructure:"DB_MAX_IDLE_CONNS"`
+	DBConnMaxLifetime time.Duration `mapstructure:"DB_CONN_MAX_LIFETIME"`
+	DBConnMaxIdleTime time.Duration `mapstructure:"DB_CONN_MAX_IDLE_TIME"`
+
+	// Optional read replica for public GET traffic, as a full connection string
+	PostgresReplicaDSN string `mapstructure:"POSTGRES_REPLICA_DSN"`
+
+	// Startup connection retries with exponential backoff, and the runtime health check interval
+	DBConnectRetries      int           `mapstructure:"DB_CONNECT_RETRIES"`
+	DBConnectBackoff      time.Duration `mapstructure:"DB_CONNECT_BACKOFF"`
+	DBHealthCheckInterval time.Duration `mapstructure:"DB_HEALTH_CHECK_INTERVAL"`
+
+	// Whether startup applies pending migrations, and how long an instance waits while another one migrates
+	DBAutoMigrate          bool          `mapstructure:"DB_AUTO_MIGRATE"`
+	DBMigrationLockTimeout time.Duration `mapstructure:"DB_MIGRATION_LOCK_TIMEOUT"`
+
+	// Per-query timeout and the duration above which queries are logged as slow; 0 disables either
+	DBQueryTimeout       time.Duration `mapstructure:"DB_QUERY_TIMEOUT"`
+	DBSlowQueryThreshold time.Duration `mapstructure:"DB_SLOW_QUERY_THRESHOLD"`
+
+	// Readiness checks give up after HealthCheckTimeout; slower than HealthDegradedLatency counts as degraded
+	HealthCheckTimeout    time.Duration `mapstructure:"HEALTH_CHECK_TIMEOUT"`
+	HealthDegradedLatency time.Duration `mapstructure:"HEALTH_DEGRADED_LATENCY"`
+
 	JWTSecret            string        `mapstructure:"JWT_SECRET"`
 	JWTExpiration        time.Duration `mapstructure:"JWT_EXPIRATION"`
 	JWTRefreshSecret     string        `mapstructure:"JWT_REFRESH_SECRET"`
 	JWTRefreshExpiration time.Duration `mapstructure:"JWT_REFRESH_EXPIRATION"`
 
 	FrontendURL string `mapstructure:"FRONTEND_URL"`
+	APIURL      string `mapstructure:"API_URL"`
+
+	// Secrets store for JWT secrets, DB password and tokens: env (default), docker, vault or ssm.
+	// SECRETS_PATH is the secrets dir (docker), KV v2 path (vault) or parameter prefix (ssm).
+	SecretsProvider string `mapstructure:"SECRETS_PROVIDER"`
+	SecretsPath     string `mapstructure:"SECRETS_PATH"`
+	VaultAddr       string `mapstructure:"VAULT_ADDR"`
+	VaultToken      string `mapstructure:"VAULT_TOKEN"`
+
+	// Serve HTTPS directly: either a certificate/key pair or Let's Encrypt autocert for the listed domains
+	TLSCertFile         string `mapstructure:"TLS_CERT_FILE"`
+	TLSKeyFile          string `mapstructure:"TLS_KEY_FILE"`
+	TLSAutocertDomains  string `mapstructure:"TLS_AUTOCERT_DOMAINS"`
+	TLSAutocertEmail    string `mapstructure:"TLS_AUTOCERT_EMAIL"`
+	TLSAutocertCacheDir string `mapstructure:"TLS_AUTOCERT_CACHE_DIR"`
+	// Plain HTTP port answering ACME challenges and redirecting to HTTPS; empty disables it
+	TLSHTTPPort string `mapstructure:"TLS_HTTP_PORT"`
+
+	// gRPC API for internal consumers on its own port, disabled while empty; served over TLS
+	// with TLS_CERT_FILE/TLS_KEY_FILE when they are set
+	GRPCPort string `mapstructure:"GRPC_PORT"`
+
+	// Frontend path articles are served under, used to build public article links
+	ArticleURLPath string `mapstructure:"ARTICLE_URL_PATH"`
+
+	// ActivityPub federation: the blog is followable as @username@domain
+	ActivityPubEnabled  bool   `mapstructure:"ACTIVITYPUB_ENABLED"`
+	ActivityPubUsername string `mapstructure:"ACTIVITYPUB_USERNAME"`
+	ActivityPubDomain   string `mapstructure:"ACTIVITYPUB_DOMAIN"`
+	ActivityPubKeyFile  string `mapstructure:"ACTIVITYPUB_KEY_FILE"`
+
+	// Web Push notifications for new articles; the VAPID key is generated on first start.
+	// PUSH_VAPID_SUBJECT is a mailto: or https: contact for push services.
+	PushEnabled      bool   `mapstructure:"PUSH_ENABLED"`
+	PushVAPIDKeyFile string `mapstructure:"PUSH_VAPID_KEY_FILE"`
+	PushVAPIDSubject string `mapstructure:"PUSH_VAPID_SUBJECT"`
+
+	// Go profiling endpoints under /api/v1/admin/debug/pprof (owner/admin only)
+	PprofEnabled bool `mapstructure:"PPROF_ENABLED"`
+
+	// Cross-posting credentials; a platform is available once its key is set
+	DevToAPIKey string `mapstructure:"DEVTO_API_KEY"`
+	MediumToken string `mapstructure:"MEDIUM_TOKEN"`
+
+	// Markdown content sync from a local directory or a GitHub repository
+	ContentImportDir           string `mapstructure:"CONTENT_IMPORT_DIR"`
+	ContentImportGitHubRepo    string `mapstructure:"CONTENT_IMPORT_GITHUB_REPO"`
+	ContentImportGitHubBranch  string `mapstructure:"CONTENT_IMPORT_GITHUB_BRANCH"`
+	ContentImportGitHubToken   string `mapstructure:"CONTENT_IMPORT_GITHUB_TOKEN"`
+	ContentImportPath          string `mapstructure:"CONTENT_IMPORT_PATH"`
+	ContentImportAuthor        string `mapstructure:"CONTENT_IMPORT_AUTHOR"`
+	ContentImportWebhookSecret string `mapstructure:"CONTENT_IMPORT_WEBHOOK_SECRET"`
+
+	// Extra comma-separated CORS origins on top of FRONTEND_URL; "https://*.example.com" matches any subdomain
+	CORSAllowedOrigins string `mapstructure:"CORS_ALLOWED_ORIGINS"`
 
 	// Telegram configuration for login activity tracking
 	TelegramEnabled  bool   `mapstructure:"TELEGRAM_ENABLED"`
 	TelegramBotToken string `mapstructure:"TELEGRAM_BOT_TOKEN"`
 	TelegramChatID   string `mapstructure:"TELEGRAM_CHAT_ID"`
 	TelegramTopicID  int    `mapstructure:"TELEGRAM_TOPIC_ID"`
+	// Bot commands arrive on a webhook verified by TELEGRAM_WEBHOOK_SECRET; only the chats in
+	// TELEGRAM_ALLOWED_CHAT_IDS (TELEGRAM_CHAT_ID when empty) are answered
+	TelegramWebhookSecret  string `mapstructure:"TELEGRAM_WEBHOOK_SECRET"`
+	TelegramAllowedChatIDs string `mapstructure:"TELEGRAM_ALLOWED_CHAT_IDS"`
+
+	// Outbound calls to Telegram and chat webhooks: retries with exponential backoff, and a circuit
+	// breaker that stops calling a service for the cooldown after that many consecutive failures
+	OutboundRetryAttempts    int           `mapstructure:"OUTBOUND_RETRY_ATTEMPTS"`
+	OutboundRetryBackoff     time.Duration `mapstructure:"OUTBOUND_RETRY_BACKOFF"`
+	OutboundBreakerThreshold int           `mapstructure:"OUTBOUND_BREAKER_THRESHOLD"`
+	OutboundBreakerCooldown  time.Duration `mapstructure:"OUTBOUND_BREAKER_COOLDOWN"`
+
+	// Additional notification channels
+	DiscordWebhookURL string `mapstructure:"DISCORD_WEBHOOK_URL"`
+	SlackWebhookURL   string `mapstructure:"SLACK_WEBHOOK_URL"`
+	NotifyEmailTo     string `mapstructure:"NOTIFY_EMAIL_TO"`
+
+	// Comma-separated channels (telegram, discord, slack, email) per notification event
+	NotifyLoginSuccessChannels     string `mapstructure:"NOTIFY_LOGIN_SUCCESS_CHANNELS"`
+	NotifyLoginFailureChannels     string `mapstructure:"NOTIFY_LOGIN_FAILURE_CHANNELS"`
+	NotifyArticlePublishedChannels string `mapstructure:"NOTIFY_ARTICLE_PUBLISHED_CHANNELS"`
+
+	// Notification message templates (Go text/template), empty uses the built-in default
+	NotifyTemplateLoginSuccess     string `mapstructure:"NOTIFY_TEMPLATE_LOGIN_SUCCESS"`
+	NotifyTemplateLoginFailure     string `mapstructure:"NOTIFY_TEMPLATE_LOGIN_FAILURE"`
+	NotifyTemplateArticlePublished string `mapstructure:"NOTIFY_TEMPLATE_ARTICLE_PUBLISHED"`
+	NotifyRevealSensitive          bool   `mapstructure:"NOTIFY_REVEAL_SENSITIVE"`
+
+	// Email (SMTP) configuration
+	EmailEnabled bool   `mapstructure:"EMAIL_ENABLED"`
+	SMTPHost     string `mapstructure:"SMTP_HOST"`
+	SMTPPort     int    `mapstructure:"SMTP_PORT"`
+	SMTPUsername string `mapstructure:"SMTP_USERNAME"`
+	SMTPPassword string `mapstructure:"SMTP_PASSWORD"`
+	SMTPFrom     string `mapstructure:"SMTP_FROM"`
+	// Email branding; templates in EMAIL_TEMPLATE_DIR replace the built-in ones with the same name
+	EmailLogoURL     string `mapstructure:"EMAIL_LOGO_URL"`
+	EmailBrandColor  string `mapstructure:"EMAIL_BRAND_COLOR"`
+	EmailTemplateDir string `mapstructure:"EMAIL_TEMPLATE_DIR"`
+	// Shared secret for the bounce webhook; bounce reports are refused while it is empty
+	EmailBounceWebhookSecret string `mapstructure:"EMAIL_BOUNCE_WEBHOOK_SECRET"`
+	// Newsletter campaigns are sent NEWSLETTER_BATCH_SIZE emails every NEWSLETTER_BATCH_INTERVAL
+	NewsletterBatchSize     int           `mapstructure:"NEWSLETTER_BATCH_SIZE"`
+	NewsletterBatchInterval time.Duration `mapstructure:"NEWSLETTER_BATCH_INTERVAL"`
+
+	// Redis configuration, used for shared state across replicas
+	RedisURL string `mapstructure:"REDIS_URL"`
+
+	// Rate limiting configuration per route group
+	RateLimitStorage      string        `mapstructure:"RATE_LIMIT_STORAGE"`
+	RateLimitAuthMax      int           `mapstructure:"RATE_LIMIT_AUTH_MAX"`
+	RateLimitAuthWindow   time.Duration `mapstructure:"RATE_LIMIT_AUTH_WINDOW"`
+	RateLimitPublicMax    int           `mapstructure:"RATE_LIMIT_PUBLIC_MAX"`
+	RateLimitPublicWindow time.Duration `mapstructure:"RATE_LIMIT_PUBLIC_WINDOW"`
+	RateLimitAdminMax     int           `mapstructure:"RATE_LIMIT_ADMIN_MAX"`
+	RateLimitAdminWindow  time.Duration `mapstructure:"RATE_LIMIT_ADMIN_WINDOW"`
+	// Public requests from bots get their own budget per IP when RATE_LIMIT_CRAWLER_MAX is
+	// set, instead of sharing RATE_LIMIT_PUBLIC_MAX with human traffic
+	RateLimitCrawlerMax    int           `mapstructure:"RATE_LIMIT_CRAWLER_MAX"`
+	RateLimitCrawlerWindow time.Duration `mapstructure:"RATE_LIMIT_CRAWLER_WINDOW"`
+	// Requests from crawlers, link previewers, monitors and HTTP libraries aren't counted in
+	// analytics. BOT_USER_AGENTS adds comma-separated, case-insensitive User-Agent substrings
+	// to the built-in list.
+	BotUserAgents string `mapstructure:"BOT_USER_AGENTS"`
+	// Public requests sent with an API key are limited per key instead of per IP. Tiers are
+	// comma-separated name=max pairs, e.g. "basic=1000,pro=10000", counted over a rolling window.
+	APIKeyTiers      string        `mapstructure:"API_KEY_TIERS"`
+	APIKeyRateWindow time.Duration `mapstructure:"API_KEY_RATE_WINDOW"`
+
+	// API v1 deprecation, announced in Deprecation, Sunset and Link headers on every v1 response.
+	// Dates are RFC 3339; v1 isn't deprecated while API_V1_DEPRECATED_AT is empty.
+	APIV1DeprecatedAt   string `mapstructure:"API_V1_DEPRECATED_AT"`
+	APIV1SunsetAt       string `mapstructure:"API_V1_SUNSET_AT"`
+	APIV1DeprecationURL string `mapstructure:"API_V1_DEPRECATION_URL"`
+
+	// Cache-Control max-age for public routes; zero leaves the header unset
+	CacheDetailMaxAge         time.Duration `mapstructure:"CACHE_DETAIL_MAX_AGE"`
+	CacheListMaxAge           time.Duration `mapstructure:"CACHE_LIST_MAX_AGE"`
+	CacheStaleWhileRevalidate time.Duration `mapstructure:"CACHE_STALE_WHILE_REVALIDATE"`
+
+	// How long rendered published articles and portfolios are kept in memory; zero disables it
+	ContentCacheTTL time.Duration `mapstructure:"CONTENT_CACHE_TTL"`
+
+	// Hold logins from unseen devices until approved by email (requires EMAIL_ENABLED)
+	LoginConfirmNewDevice bool `mapstructure:"LOGIN_CONFIRM_NEW_DEVICE"`
+
+	// MaxMind/GeoLite2 City database used to locate login IPs; lookups are skipped when empty
+	GeoIPDBPath string `mapstructure:"GEOIP_DB_PATH"`
+
+	// Visitor analytics configuration
+	AnalyticsEnabled       bool   `mapstructure:"ANALYTICS_ENABLED"`
+	AnalyticsSalt          string `mapstructure:"ANALYTICS_SALT"`
+	AnalyticsCountryHeader string `mapstructure:"ANALYTICS_COUNTRY_HEADER"`
+	// Pageviews per minute on one instance that count as a spike on the admin event stream; 0 disables
+	AnalyticsSpikeThreshold int `mapstructure:"ANALYTICS_SPIKE_THRESHOLD"`
+
+	// Content locales; the default locale is the language of the base content
+	DefaultLocale    string `mapstructure:"DEFAULT_LOCALE"`
+	SupportedLocales string `mapstructure:"SUPPORTED_LOCALES"`
+
+	// Open Graph social card configuration
+	OGSiteName string `mapstructure:"OG_SITE_NAME"`
+	OGCacheDir string `mapstructure:"OG_CACHE_DIR"`
+
+	// robots.txt rules; disallow is a comma-separated list of paths, the sitemap line is left out while empty
+	RobotsDisallow   string `mapstructure:"ROBOTS_DISALLOW"`
+	RobotsSitemapURL string `mapstructure:"ROBOTS_SITEMAP_URL"`
+
+	// security.txt (RFC 9116) fields; not served while the contact is empty. Contact and
+	// preferred languages are comma-separated, expiry is counted from the day it is served.
+	SecurityTxtContact            string        `mapstructure:"SECURITY_TXT_CONTACT"`
+	SecurityTxtPolicy             string        `mapstructure:"SECURITY_TXT_POLICY"`
+	SecurityTxtPreferredLanguages string        `mapstructure:"SECURITY_TXT_PREFERRED_LANGUAGES"`
+	SecurityTxtExpiry             time.Duration `mapstructure:"SECURITY_TXT_EXPIRY"`
+
+	// Chroma style used to highlight code blocks in rendered content
+	CodeHighlightStyle string `mapstructure:"CODE_HIGHLIGHT_STYLE"`
+
+	// Background job queue configuration
+	JobsWorkers      int           `mapstructure:"JOBS_WORKERS"`
+	JobsPollInterval time.Duration `mapstructure:"JOBS_POLL_INTERVAL"`
+	JobsMaxAttempts  int           `mapstructure:"JOBS_MAX_ATTEMPTS"`
+
+	// Comma-separated scheduled tasks that should not run
+	SchedulerDisabledTasks string `mapstructure:"SCHEDULER_DISABLED_TASKS"`
+
+	// Database backups go to S3 when a bucket is set, otherwise to BACKUP_DIR
+	PGDumpPath        string `mapstructure:"PG_DUMP_PATH"`
+	BackupDir         string `mapstructure:"BACKUP_DIR"`
+	BackupS3Endpoint  string `mapstructure:"BACKUP_S3_ENDPOINT"`
+	BackupS3Region    string `mapstructure:"BACKUP_S3_REGION"`
+	BackupS3Bucket    string `mapstructure:"BACKUP_S3_BUCKET"`
+	BackupS3Prefix    string `mapstructure:"BACKUP_S3_PREFIX"`
+	BackupS3AccessKey string `mapstructure:"BACKUP_S3_ACCESS_KEY"`
+	BackupS3SecretKey string `mapstructure:"BACKUP_S3_SECRET_KEY"`
+	BackupS3UseSSL    bool   `mapstructure:"BACKUP_S3_USE_SSL"`
+
+	// Media library bucket; browsers upload straight to it with presigned POST policies
+	MediaS3Endpoint  string `mapstructure:"MEDIA_S3_ENDPOINT"`
+	MediaS3Region    string `mapstructure:"MEDIA_S3_REGION"`
+	MediaS3Bucket    string `mapstructure:"MEDIA_S3_BUCKET"`
+	MediaS3Prefix    string `mapstructure:"MEDIA_S3_PREFIX"`
+	MediaS3AccessKey string `mapstructure:"MEDIA_S3_ACCESS_KEY"`
+	MediaS3SecretKey string `mapstructure:"MEDIA_S3_SECRET_KEY"`
+	MediaS3UseSSL    bool   `mapstructure:"MEDIA_S3_USE_SSL"`
+	// Base URL media is served from (bucket website or CDN); defaults to the bucket's S3 URL
+	MediaPublicURL     string        `mapstructure:"MEDIA_PUBLIC_URL"`
+	MediaPresignExpiry time.Duration `mapstructure:"MEDIA_PRESIGN_EXPIRY"`
+	MediaMaxUploadSize int64         `mapstructure:"MEDIA_MAX_UPLOAD_SIZE"`
+	// Comma-separated content types accepted for upload
+	MediaAllowedTypes string `mapstructure:"MEDIA_ALLOWED_TYPES"`
+
+	// oEmbed proxy configuration; providers is a comma-separated allowlist of youtube, twitter and gist
+	OEmbedProviders string        `mapstructure:"OEMBED_PROVIDERS"`
+	OEmbedCacheTTL  time.Duration `mapstructure:"OEMBED_CACHE_TTL"`
+
+	// Signed preview links for unpublished articles; disabled while the secret is empty
+	PreviewSecret     string        `mapstructure:"PREVIEW_SECRET"`
+	PreviewLinkExpiry time.Duration `mapstructure:"PREVIEW_LINK_EXPIRY"`
+
+	// Lifetime of the tokens that unlock password-protected articles, signed with JWT_SECRET
+	ArticleAccessTokenExpiry time.Duration `mapstructure:"ARTICLE_ACCESS_TOKEN_EXPIRY"`
+
+	// Maximum number of articles featured at the same time
+	FeaturedArticlesMax int `mapstructure:"FEATURED_ARTICLES_MAX"`
+
+	// How long responses to requests sent with an Idempotency-Key are kept for replay
+	IdempotencyKeyTTL time.Duration `mapstructure:"IDEMPOTENCY_KEY_TTL"`
+
+	// How long a deleted account can still be restored before it and its content are purged
+	AccountDeletionGracePeriod time.Duration `mapstructure:"ACCOUNT_DELETION_GRACE_PERIOD"`
+
+	// How long article and portfolio change snapshots are kept; zero keeps them forever
+	ContentAuditRetention time.Duration `mapstructure:"CONTENT_AUDIT_RETENTION"`
+
+	// Spotify "now playing" widget; disabled while the refresh token is empty
+	SpotifyClientID        string        `mapstructure:"SPOTIFY_CLIENT_ID"`
+	SpotifyClientSecret    string        `mapstructure:"SPOTIFY_CLIENT_SECRET"`
+	SpotifyRefreshToken    string        `mapstructure:"SPOTIFY_REFRESH_TOKEN"`
+	SpotifyRefreshInterval time.Duration `mapstructure:"SPOTIFY_REFRESH_INTERVAL"`
+
+	// GitHub activity feed; disabled while the username is empty. The token is optional and
+	// raises the rate limit and adds the yearly contribution total
+	GitHubActivityUsername string        `mapstructure:"GITHUB_ACTIVITY_USERNAME"`
+	GitHubActivityToken    string        `mapstructure:"GITHUB_ACTIVITY_TOKEN"`
+	GitHubActivityCacheTTL time.Duration `mapstructure:"GITHUB_ACTIVITY_CACHE_TTL"`
+
+	// giscus comments from GitHub Discussions; disabled while the repo ("owner/name") is empty.
+	// Discussions in the category are matched to articles by title with the giscus mapping:
+	// pathname, url or title. The token needs read access to the repository's discussions.
+	GiscusRepo           string        `mapstructure:"GISCUS_REPO"`
+	GiscusCategory       string        `mapstructure:"GISCUS_CATEGORY"`
+	GiscusMapping        string        `mapstructure:"GISCUS_MAPPING"`
+	GiscusToken          string        `mapstructure:"GISCUS_TOKEN"`
+	GiscusSyncInterval   time.Duration `mapstructure:"GISCUS_SYNC_INTERVAL"`
+	GiscusRecentComments int           `mapstructure:"GISCUS_RECENT_COMMENTS"`
+
+	// Captcha on public forms; provider is hcaptcha or turnstile, disabled while empty.
+	// Routes is a comma-separated list of newsletter, contact and comments
+	CaptchaProvider string `mapstructure:"CAPTCHA_PROVIDER"`
+	CaptchaSecret   string `mapstructure:"CAPTCHA_SECRET"`
+	CaptchaRoutes   string `mapstructure:"CAPTCHA_ROUTES"`
+
+	// Spam protection on the public forms in FORM_PROTECTION_ROUTES, with the same route names
+	// as CAPTCHA_ROUTES. Submissions are rejected when they fill in the hidden honeypot field,
+	// come sooner than the minimum submit time after their form token was issued, or repeat a
+	// successful submission within the duplicate window. An empty field name or a zero
+	// duration turns that check off.
+	FormProtectionRoutes string        `mapstructure:"FORM_PROTECTION_ROUTES"`
+	FormHoneypotField    string        `mapstructure:"FORM_HONEYPOT_FIELD"`
+	FormMinSubmitTime    time.Duration `mapstructure:"FORM_MIN_SUBMIT_TIME"`
+	FormTokenTTL         time.Duration `mapstructure:"FORM_TOKEN_TTL"`
+	FormDuplicateWindow  time.Duration `mapstructure:"FORM_DUPLICATE_WINDOW"`
+
+	// Next.js on-demand revalidation of frontend paths after content changes; disabled while the
+	// URL is empty. Path lists are comma-separated, with {slug} replaced by the item's slug.
+	RevalidateURL            string `mapstructure:"REVALIDATE_URL"`
+	RevalidateSecret         string `mapstructure:"REVALIDATE_SECRET"`
+	RevalidateArticlePaths   string `mapstructure:"REVALIDATE_ARTICLE_PATHS"`
+	RevalidatePortfolioPaths string `mapstructure:"REVALIDATE_PORTFOLIO_PATHS"`
+	RevalidatePagePaths      string `mapstructure:"REVALIDATE_PAGE_PATHS"`
+
+	// Cloudflare cache purge of the same frontend paths after content changes; disabled while
+	// the zone is empty. The token needs the Zone.Cache Purge permission.
+	CloudflareZoneID   string `mapstructure:"CLOUDFLARE_ZONE_ID"`
+	CloudflareAPIToken string `mapstructure:"CLOUDFLARE_API_TOKEN"`
+
+	// Admin account created by db:seed when it doesn't exist yet
+	SeedAdminUsername string `mapstructure:"SEED_ADMIN_USERNAME"`
+	SeedAdminEmail    string `mapstructure:"SEED_ADMIN_EMAIL"`
+	SeedAdminPassword string `mapstructure:"SEED_ADMIN_PASSWORD"`
 }
 
 // IsProduction returns true if the application is running in production mode
@@ -42,6 +377,199 @@ func (c *Config) IsProduction() bool {
 	return c.AppEnv == "production"
 }
 
+// Locales returns the supported content locales, always including the default locale first
+func (c *Config) Locales() []string {
+	defaultLocale := strings.ToLower(strings.TrimSpace(c.DefaultLocale))
+	locales := []string{defaultLocale}
+	for _, locale := range strings.Split(c.SupportedLocales, ",") {
+		locale = strings.ToLower(strings.TrimSpace(locale))
+		if locale != "" && !slices.Contains(locales, locale) {
+			locales = append(locales, locale)
+		}
+	}
+	return locales
+}
+
+// TelegramAllowedChats returns the chat IDs allowed to send bot commands
+func (c *Config) TelegramAllowedChats() []string {
+	var chats []string
+	for _, chat := range strings.Split(c.TelegramAllowedChatIDs, ",") {
+		if chat = strings.TrimSpace(chat); chat != "" {
+			chats = append(chats, chat)
+		}
+	}
+	if len(chats) == 0 && c.TelegramChatID != "" {
+		chats = append(chats, c.TelegramChatID)
+	}
+	return chats
+}
+
+// CaptchaRouteList returns the routes that require a captcha
+func (c *Config) CaptchaRouteList() []string {
+	var routes []string
+	for _, route := range strings.Split(c.CaptchaRoutes, ",") {
+		if route = strings.TrimSpace(strings.ToLower(route)); route != "" {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+// FormProtectionRouteList returns the routes whose forms are checked for spam
+func (c *Config) FormProtectionRouteList() []string {
+	var routes []string
+	for _, route := range splitList(c.FormProtectionRoutes) {
+		routes = append(routes, strings.ToLower(route))
+	}
+	return routes
+}
+
+// CORSOrigins returns the origins allowed to call the API, always including the frontend first
+func (c *Config) CORSOrigins() []string {
+	var origins []string
+	for _, origin := range append([]string{c.FrontendURL}, strings.Split(c.CORSAllowedOrigins, ",")...) {
+		origin = strings.TrimRight(strings.TrimSpace(origin), "/")
+		if origin != "" && !slices.Contains(origins, origin) {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// MediaURL returns the public URL of a media object
+func (c *Config) MediaURL(key string) string {
+	base := c.MediaPublicURL
+	if base == "" {
+		scheme := "https"
+		if !c.MediaS3UseSSL {
+			scheme = "http"
+		}
+		base = scheme + "://" + c.MediaS3Endpoint + "/" + c.MediaS3Bucket
+	}
+	return strings.TrimRight(base, "/") + "/" + key
+}
+
+// ArticleURL returns the public frontend URL of an article
+func (c *Config) ArticleURL(slug string) string {
+	base := strings.TrimRight(c.FrontendURL, "/")
+	if path := strings.Trim(c.ArticleURLPath, "/"); path != "" {
+		base += "/" + path
+	}
+	return base + "/" + slug
+}
+
+// AutocertDomains returns the domains to request Let's Encrypt certificates for
+func (c *Config) AutocertDomains() []string {
+	var domains []string
+	for _, domain := range strings.Split(c.TLSAutocertDomains, ",") {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		if domain != "" && !slices.Contains(domains, domain) {
+			domains = append(domains, domain)
+		}
+	}
+	return domains
+}
+
+// RobotsDisallowPaths returns the paths robots.txt asks crawlers to stay out of
+func (c *Config) RobotsDisallowPaths() []string {
+	return splitList(c.RobotsDisallow)
+}
+
+// BotUserAgentPatterns returns the extra lowercase User-Agent substrings that mark a bot
+func (c *Config) BotUserAgentPatterns() []string {
+	patterns := splitList(c.BotUserAgents)
+	for i, pattern := range patterns {
+		patterns[i] = strings.ToLower(pattern)
+	}
+	return patterns
+}
+
+// SecurityContacts returns the security.txt contact URIs
+func (c *Config) SecurityContacts() []string {
+	return splitList(c.SecurityTxtContact)
+}
+
+// APIKeyTier is an API key tier and its request limit per API_KEY_RATE_WINDOW
+type APIKeyTier struct {
+	Name string
+	Max  int
+}
+
+// APIKeyTierList returns the API key tiers in the configured order; the first is the default
+func (c *Config) APIKeyTierList() []APIKeyTier {
+	tiers, _ := parseAPIKeyTiers(c.APIKeyTiers)
+	return tiers
+}
+
+// parseAPIKeyTiers parses tiers written as "basic=1000,pro=10000"
+func parseAPIKeyTiers(value string) ([]APIKeyTier, error) {
+	var tiers []APIKeyTier
+	for _, item := range splitList(value) {
+		name, max, ok := strings.Cut(item, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%q must be written as name=max", item)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(max))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("%q must have a positive max", item)
+		}
+		if slices.ContainsFunc(tiers, func(t APIKeyTier) bool { return t.Name == name }) {
+			return nil, fmt.Errorf("tier %q is listed twice", name)
+		}
+		tiers = append(tiers, APIKeyTier{Name: name, Max: n})
+	}
+	return tiers, nil
+}
+
+// Location returns the site timezone, UTC when SITE_TIMEZONE is empty or unknown
+func (c *Config) Location() *time.Location {
+	loc, err := loadSiteLocation(c.SiteTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// loadSiteLocation loads an IANA timezone; the server's Local zone isn't accepted since
+// Postgres can't resolve it
+func loadSiteLocation(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return time.UTC, nil
+	}
+	if name == "Local" {
+		return nil, fmt.Errorf("%q is not an IANA timezone", name)
+	}
+	return time.LoadLocation(name)
+}
+
+// APIV1Deprecation returns when API v1 was deprecated and when it goes away; zero times are unset
+func (c *Config) APIV1Deprecation() (deprecatedAt, sunsetAt time.Time) {
+	deprecatedAt, _ = parseOptionalTime(c.APIV1DeprecatedAt)
+	sunsetAt, _ = parseOptionalTime(c.APIV1SunsetAt)
+	return deprecatedAt, sunsetAt
+}
+
+// parseOptionalTime parses an RFC 3339 setting; empty is the zero time
+func parseOptionalTime(value string) (time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, strings.TrimSpace(value))
+}
+
+// splitList splits a comma-separated setting, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 // LoadConfig loads configuration from environment variables
 func LoadConfig() (config Config, err error) {
 	// Load .env file if it exists
@@ -53,23 +581,286 @@ func LoadConfig() (config Config, err error) {
 	viper.SetDefault("APP_NAME", "Personal Website API")
 	viper.SetDefault("APP_ENV", "development")
 	viper.SetDefault("PORT", "8080")
+	viper.SetDefault("SITE_TIMEZONE", "UTC")
+
+	// Default logging settings
+	viper.SetDefault("LOG_FORMAT", "")
+	viper.SetDefault("LOG_LEVEL", "")
+	viper.SetDefault("LOG_LEVELS", "")
+	viper.SetDefault("LOG_FILE", "")
+	viper.SetDefault("LOG_FILE_MAX_SIZE_MB", 100)
+	viper.SetDefault("LOG_FILE_MAX_BACKUPS", 7)
+	viper.SetDefault("LOG_FILE_MAX_AGE_DAYS", 30)
+	viper.SetDefault("LOG_FILE_COMPRESS", true)
+
+	// Default Sentry settings
+	viper.SetDefault("SENTRY_DSN", "")
+	viper.SetDefault("SENTRY_ENVIRONMENT", "")
+	viper.SetDefault("SENTRY_SAMPLE_RATE", 1.0)
 	viper.SetDefault("POSTGRES_HOST", "localhost")
 	viper.SetDefault("POSTGRES_PORT", "5432")
 	viper.SetDefault("POSTGRES_USER", "postgres")
 	viper.SetDefault("POSTGRES_PASSWORD", "postgres")
 	viper.SetDefault("POSTGRES_DB", "personal_website")
 	viper.SetDefault("POSTGRES_SSL_MODE", "disable")
-	viper.SetDefault("JWT_SECRET", "your-secret-key")
+	viper.SetDefault("DB_MAX_OPEN_CONNS", 25)
+	viper.SetDefault("DB_MAX_IDLE_CONNS", 10)
+	viper.SetDefault("DB_CONN_MAX_LIFETIME", time.Hour)
+	viper.SetDefault("DB_CONN_MAX_IDLE_TIME", time.Minute*30)
+	viper.SetDefault("POSTGRES_REPLICA_DSN", "")
+	viper.SetDefault("DB_CONNECT_RETRIES", 10)
+	viper.SetDefault("DB_CONNECT_BACKOFF", time.Second)
+	viper.SetDefault("DB_HEALTH_CHECK_INTERVAL", time.Second*30)
+	viper.SetDefault("DB_AUTO_MIGRATE", true)
+	viper.SetDefault("DB_MIGRATION_LOCK_TIMEOUT", time.Minute*5)
+	viper.SetDefault("DB_QUERY_TIMEOUT", time.Second*10)
+	viper.SetDefault("DB_SLOW_QUERY_THRESHOLD", time.Millisecond*500)
+	viper.SetDefault("HEALTH_CHECK_TIMEOUT", time.Second*2)
+	viper.SetDefault("HEALTH_DEGRADED_LATENCY", time.Millisecond*250)
+	viper.SetDefault("JWT_SECRET", defaultJWTSecret)
 	viper.SetDefault("JWT_EXPIRATION", time.Hour*24)
-	viper.SetDefault("JWT_REFRESH_SECRET", "your-refresh-secret-key")
+	viper.SetDefault("JWT_REFRESH_SECRET", defaultJWTRefreshSecret)
 	viper.SetDefault("JWT_REFRESH_EXPIRATION", time.Hour*24*7)
 	viper.SetDefault("FRONTEND_URL", "http://localhost:3000")
+	viper.SetDefault("CORS_ALLOWED_ORIGINS", "")
+	viper.SetDefault("ARTICLE_URL_PATH", "/articles/")
+
+	// Default ActivityPub settings
+	viper.SetDefault("ACTIVITYPUB_ENABLED", false)
+	viper.SetDefault("ACTIVITYPUB_USERNAME", "blog")
+	viper.SetDefault("ACTIVITYPUB_DOMAIN", "")
+	viper.SetDefault("ACTIVITYPUB_KEY_FILE", "keys/activitypub.pem")
+
+	// Default Web Push settings
+	viper.SetDefault("PUSH_ENABLED", false)
+	viper.SetDefault("PUSH_VAPID_KEY_FILE", "keys/vapid.key")
+	viper.SetDefault("PUSH_VAPID_SUBJECT", "")
+
+	// Default profiling settings
+	viper.SetDefault("PPROF_ENABLED", false)
+
+	// Default cross-posting settings
+	viper.SetDefault("DEVTO_API_KEY", "")
+	viper.SetDefault("MEDIUM_TOKEN", "")
+
+	// Default content import settings
+	viper.SetDefault("CONTENT_IMPORT_DIR", "")
+	viper.SetDefault("CONTENT_IMPORT_GITHUB_REPO", "")
+	viper.SetDefault("CONTENT_IMPORT_GITHUB_BRANCH", "main")
+	viper.SetDefault("CONTENT_IMPORT_GITHUB_TOKEN", "")
+	viper.SetDefault("CONTENT_IMPORT_PATH", "")
+	viper.SetDefault("CONTENT_IMPORT_AUTHOR", "")
+	viper.SetDefault("CONTENT_IMPORT_WEBHOOK_SECRET", "")
+
+	// Default secrets settings
+	viper.SetDefault("SECRETS_PROVIDER", "env")
+	viper.SetDefault("SECRETS_PATH", "")
+	viper.SetDefault("VAULT_ADDR", "")
+	viper.SetDefault("VAULT_TOKEN", "")
+
+	// Default TLS settings
+	viper.SetDefault("TLS_CERT_FILE", "")
+	viper.SetDefault("TLS_KEY_FILE", "")
+	viper.SetDefault("TLS_AUTOCERT_DOMAINS", "")
+	viper.SetDefault("TLS_AUTOCERT_EMAIL", "")
+	viper.SetDefault("TLS_AUTOCERT_CACHE_DIR", "certs")
+	viper.SetDefault("TLS_HTTP_PORT", "80")
+	viper.SetDefault("API_URL", "http://localhost:8080")
+
+	// Default gRPC settings
+	viper.SetDefault("GRPC_PORT", "")
 
 	// Default Telegram settings
 	viper.SetDefault("TELEGRAM_ENABLED", false)
 	viper.SetDefault("TELEGRAM_BOT_TOKEN", "")
 	viper.SetDefault("TELEGRAM_CHAT_ID", "")
 	viper.SetDefault("TELEGRAM_TOPIC_ID", 0)
+	viper.SetDefault("TELEGRAM_WEBHOOK_SECRET", "")
+	viper.SetDefault("TELEGRAM_ALLOWED_CHAT_IDS", "")
+
+	// Default outbound call settings
+	viper.SetDefault("OUTBOUND_RETRY_ATTEMPTS", 3)
+	viper.SetDefault("OUTBOUND_RETRY_BACKOFF", time.Millisecond*500)
+	viper.SetDefault("OUTBOUND_BREAKER_THRESHOLD", 5)
+	viper.SetDefault("OUTBOUND_BREAKER_COOLDOWN", time.Minute)
+
+	// Default notification settings
+	viper.SetDefault("DISCORD_WEBHOOK_URL", "")
+	viper.SetDefault("SLACK_WEBHOOK_URL", "")
+	viper.SetDefault("NOTIFY_EMAIL_TO", "")
+	viper.SetDefault("NOTIFY_LOGIN_SUCCESS_CHANNELS", "telegram")
+	viper.SetDefault("NOTIFY_LOGIN_FAILURE_CHANNELS", "telegram")
+	viper.SetDefault("NOTIFY_ARTICLE_PUBLISHED_CHANNELS", "telegram")
+	viper.SetDefault("NOTIFY_TEMPLATE_LOGIN_SUCCESS", "")
+	viper.SetDefault("NOTIFY_TEMPLATE_LOGIN_FAILURE", "")
+	viper.SetDefault("NOTIFY_TEMPLATE_ARTICLE_PUBLISHED", "")
+	viper.SetDefault("NOTIFY_REVEAL_SENSITIVE", false)
+
+	// Default email settings
+	viper.SetDefault("EMAIL_ENABLED", false)
+	viper.SetDefault("SMTP_HOST", "")
+	viper.SetDefault("SMTP_PORT", 587)
+	viper.SetDefault("SMTP_USERNAME", "")
+	viper.SetDefault("SMTP_PASSWORD", "")
+	viper.SetDefault("SMTP_FROM", "")
+	viper.SetDefault("EMAIL_LOGO_URL", "")
+	viper.SetDefault("EMAIL_BRAND_COLOR", "#2563eb")
+	viper.SetDefault("EMAIL_TEMPLATE_DIR", "")
+	viper.SetDefault("EMAIL_BOUNCE_WEBHOOK_SECRET", "")
+	viper.SetDefault("NEWSLETTER_BATCH_SIZE", 50)
+	viper.SetDefault("NEWSLETTER_BATCH_INTERVAL", time.Minute)
+
+	// Default Redis settings
+	viper.SetDefault("REDIS_URL", "")
+
+	// Default rate limit settings
+	viper.SetDefault("RATE_LIMIT_STORAGE", "memory")
+	viper.SetDefault("RATE_LIMIT_AUTH_MAX", 10)
+	viper.SetDefault("RATE_LIMIT_AUTH_WINDOW", time.Minute)
+	viper.SetDefault("RATE_LIMIT_PUBLIC_MAX", 300)
+	viper.SetDefault("RATE_LIMIT_PUBLIC_WINDOW", time.Minute)
+	viper.SetDefault("RATE_LIMIT_ADMIN_MAX", 100)
+	viper.SetDefault("RATE_LIMIT_ADMIN_WINDOW", time.Minute)
+	viper.SetDefault("RATE_LIMIT_CRAWLER_MAX", 0)
+	viper.SetDefault("RATE_LIMIT_CRAWLER_WINDOW", time.Minute)
+	viper.SetDefault("API_KEY_TIERS", "basic=1000,pro=10000")
+	viper.SetDefault("API_KEY_RATE_WINDOW", time.Hour)
+	viper.SetDefault("API_V1_DEPRECATED_AT", "")
+	viper.SetDefault("API_V1_SUNSET_AT", "")
+	viper.SetDefault("API_V1_DEPRECATION_URL", "")
+
+	// Default cache settings
+	viper.SetDefault("CACHE_DETAIL_MAX_AGE", time.Hour)
+	viper.SetDefault("CACHE_LIST_MAX_AGE", time.Minute)
+	viper.SetDefault("CACHE_STALE_WHILE_REVALIDATE", time.Hour*24)
+	viper.SetDefault("CONTENT_CACHE_TTL", time.Minute*5)
+
+	// Default login device settings
+	viper.SetDefault("LOGIN_CONFIRM_NEW_DEVICE", false)
+
+	// Default GeoIP settings
+	viper.SetDefault("GEOIP_DB_PATH", "")
+
+	// Default analytics settings
+	viper.SetDefault("ANALYTICS_ENABLED", true)
+	viper.SetDefault("ANALYTICS_SALT", "")
+	viper.SetDefault("ANALYTICS_COUNTRY_HEADER", "CF-IPCountry")
+	viper.SetDefault("ANALYTICS_SPIKE_THRESHOLD", 100)
+
+	// Default locale settings
+	viper.SetDefault("DEFAULT_LOCALE", "en")
+	viper.SetDefault("SUPPORTED_LOCALES", "en")
+
+	// Default Open Graph settings
+	viper.SetDefault("OG_SITE_NAME", "")
+	viper.SetDefault("OG_CACHE_DIR", "cache/og")
+
+	// Default robots.txt and security.txt settings
+	viper.SetDefault("ROBOTS_DISALLOW", "/api/,/go/")
+	viper.SetDefault("ROBOTS_SITEMAP_URL", "")
+	viper.SetDefault("SECURITY_TXT_CONTACT", "")
+	viper.SetDefault("SECURITY_TXT_POLICY", "")
+	viper.SetDefault("SECURITY_TXT_PREFERRED_LANGUAGES", "en")
+	viper.SetDefault("SECURITY_TXT_EXPIRY", 180*24*time.Hour)
+
+	// Default content rendering settings
+	viper.SetDefault("CODE_HIGHLIGHT_STYLE", "github")
+
+	// Default job queue settings
+	viper.SetDefault("JOBS_WORKERS", 2)
+	viper.SetDefault("JOBS_POLL_INTERVAL", time.Second*2)
+	viper.SetDefault("JOBS_MAX_ATTEMPTS", 5)
+	viper.SetDefault("SCHEDULER_DISABLED_TASKS", "")
+
+	// Default backup settings
+	viper.SetDefault("PG_DUMP_PATH", "pg_dump")
+	viper.SetDefault("BACKUP_DIR", "backups")
+	viper.SetDefault("BACKUP_S3_ENDPOINT", "s3.amazonaws.com")
+	viper.SetDefault("BACKUP_S3_REGION", "")
+	viper.SetDefault("BACKUP_S3_BUCKET", "")
+	viper.SetDefault("BACKUP_S3_PREFIX", "backups/")
+	viper.SetDefault("BACKUP_S3_ACCESS_KEY", "")
+	viper.SetDefault("BACKUP_S3_SECRET_KEY", "")
+	viper.SetDefault("BACKUP_S3_USE_SSL", true)
+
+	// Default media settings
+	viper.SetDefault("MEDIA_S3_ENDPOINT", "s3.amazonaws.com")
+	viper.SetDefault("MEDIA_S3_REGION", "")
+	viper.SetDefault("MEDIA_S3_BUCKET", "")
+	viper.SetDefault("MEDIA_S3_PREFIX", "media/")
+	viper.SetDefault("MEDIA_S3_ACCESS_KEY", "")
+	viper.SetDefault("MEDIA_S3_SECRET_KEY", "")
+	viper.SetDefault("MEDIA_S3_USE_SSL", true)
+	viper.SetDefault("MEDIA_PUBLIC_URL", "")
+	viper.SetDefault("MEDIA_PRESIGN_EXPIRY", "15m")
+	viper.SetDefault("MEDIA_MAX_UPLOAD_SIZE", 50<<20)
+	viper.SetDefault("MEDIA_ALLOWED_TYPES", "image/jpeg,image/png,image/gif,image/webp,image/avif")
+
+	// Default oEmbed settings
+	viper.SetDefault("OEMBED_PROVIDERS", "youtube,twitter,gist")
+	viper.SetDefault("OEMBED_CACHE_TTL", "24h")
+
+	// Default preview link settings
+	viper.SetDefault("PREVIEW_SECRET", "")
+	viper.SetDefault("PREVIEW_LINK_EXPIRY", "72h")
+	viper.SetDefault("ARTICLE_ACCESS_TOKEN_EXPIRY", time.Hour)
+
+	// Default featured article settings
+	viper.SetDefault("FEATURED_ARTICLES_MAX", 3)
+
+	// Default idempotency key settings
+	viper.SetDefault("IDEMPOTENCY_KEY_TTL", "24h")
+
+	// Default account deletion settings
+	viper.SetDefault("ACCOUNT_DELETION_GRACE_PERIOD", "720h")
+
+	// Default content audit settings
+	viper.SetDefault("CONTENT_AUDIT_RETENTION", "2160h")
+
+	// Default Spotify settings
+	viper.SetDefault("SPOTIFY_CLIENT_ID", "")
+	viper.SetDefault("SPOTIFY_CLIENT_SECRET", "")
+	viper.SetDefault("SPOTIFY_REFRESH_TOKEN", "")
+	viper.SetDefault("SPOTIFY_REFRESH_INTERVAL", "30s")
+
+	// Default GitHub activity settings
+	viper.SetDefault("GITHUB_ACTIVITY_USERNAME", "")
+	viper.SetDefault("GITHUB_ACTIVITY_TOKEN", "")
+	viper.SetDefault("GITHUB_ACTIVITY_CACHE_TTL", "10m")
+
+	// Default giscus settings
+	viper.SetDefault("GISCUS_REPO", "")
+	viper.SetDefault("GISCUS_CATEGORY", "")
+	viper.SetDefault("GISCUS_MAPPING", "pathname")
+	viper.SetDefault("GISCUS_TOKEN", "")
+	viper.SetDefault("GISCUS_SYNC_INTERVAL", "10m")
+	viper.SetDefault("GISCUS_RECENT_COMMENTS", 3)
+
+	// Default captcha settings
+	viper.SetDefault("CAPTCHA_PROVIDER", "")
+	viper.SetDefault("CAPTCHA_SECRET", "")
+	viper.SetDefault("CAPTCHA_ROUTES", "newsletter,contact,comments")
+	viper.SetDefault("FORM_PROTECTION_ROUTES", "")
+	viper.SetDefault("FORM_HONEYPOT_FIELD", "website")
+	viper.SetDefault("FORM_MIN_SUBMIT_TIME", 3*time.Second)
+	viper.SetDefault("FORM_TOKEN_TTL", 24*time.Hour)
+	viper.SetDefault("FORM_DUPLICATE_WINDOW", 10*time.Minute)
+
+	// Default frontend revalidation settings
+	viper.SetDefault("REVALIDATE_URL", "")
+	viper.SetDefault("REVALIDATE_SECRET", "")
+	viper.SetDefault("REVALIDATE_ARTICLE_PATHS", "/,/articles,/articles/{slug}")
+	viper.SetDefault("REVALIDATE_PORTFOLIO_PATHS", "/,/portfolio,/portfolio/{slug}")
+	viper.SetDefault("REVALIDATE_PAGE_PATHS", "/{slug}")
+
+	// Default Cloudflare settings
+	viper.SetDefault("CLOUDFLARE_ZONE_ID", "")
+	viper.SetDefault("CLOUDFLARE_API_TOKEN", "")
+
+	// Default seed settings
+	viper.SetDefault("SEED_ADMIN_USERNAME", "admin")
+	viper.SetDefault("SEED_ADMIN_EMAIL", "admin@example.com")
+	viper.SetDefault("SEED_ADMIN_PASSWORD", "")
 
 	err = viper.Unmarshal(&config)
 	if err != nil {
@@ -83,11 +874,12 @@ func LoadConfig() (config Config, err error) {
 		}
 	}
 
-	// Print configuration for debugging in development mode
-	if config.AppEnv == "development" {
-		fmt.Printf("Configuration loaded: %+v\n", config)
+	// Secrets from a secret store take precedence over env vars
+	if err = loadSecrets(&config); err != nil {
+		return
 	}
 
+	err = config.Validate()
 	return
 }
 
@@ -0,0 +1,212 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// Secret providers selectable through SECRETS_PROVIDER
+const (
+	SecretsProviderDocker = "docker"
+	SecretsProviderVault  = "vault"
+	SecretsProviderSSM    = "ssm"
+)
+
+// secretsTimeout bounds how long startup waits on the secret store
+const secretsTimeout = 15 * time.Second
+
+// SecretProvider fetches secrets by their environment variable name.
+// Get returns an empty string and no error when the store has no value for the key.
+type SecretProvider interface {
+	Get(ctx context.Context, key string) (string, error)
+}
+
+// secretFields maps the settings that can come from a secret store to their config fields
+func (c *Config) secretFields() map[string]*string {
+	return map[string]*string{
+		"JWT_SECRET":                    &c.JWTSecret,
+		"JWT_REFRESH_SECRET":            &c.JWTRefreshSecret,
+		"POSTGRES_PASSWORD":             &c.PostgresPassword,
+		"POSTGRES_REPLICA_DSN":          &c.PostgresReplicaDSN,
+		"TELEGRAM_BOT_TOKEN":            &c.TelegramBotToken,
+		"TELEGRAM_WEBHOOK_SECRET":       &c.TelegramWebhookSecret,
+		"SMTP_PASSWORD":                 &c.SMTPPassword,
+		"EMAIL_BOUNCE_WEBHOOK_SECRET":   &c.EmailBounceWebhookSecret,
+		"BACKUP_S3_SECRET_KEY":          &c.BackupS3SecretKey,
+		"DEVTO_API_KEY":                 &c.DevToAPIKey,
+		"MEDIUM_TOKEN":                  &c.MediumToken,
+		"CONTENT_IMPORT_GITHUB_TOKEN":   &c.ContentImportGitHubToken,
+		"CONTENT_IMPORT_WEBHOOK_SECRET": &c.ContentImportWebhookSecret,
+		"PREVIEW_SECRET":                &c.PreviewSecret,
+		"SPOTIFY_CLIENT_SECRET":         &c.SpotifyClientSecret,
+		"SPOTIFY_REFRESH_TOKEN":         &c.SpotifyRefreshToken,
+		"GITHUB_ACTIVITY_TOKEN":         &c.GitHubActivityToken,
+		"GISCUS_TOKEN":                  &c.GiscusToken,
+		"CAPTCHA_SECRET":                &c.CaptchaSecret,
+		"REVALIDATE_SECRET":             &c.RevalidateSecret,
+		"CLOUDFLARE_API_TOKEN":          &c.CloudflareAPIToken,
+		"SENTRY_DSN":                    &c.SentryDSN,
+	}
+}
+
+// NewSecretProvider returns the provider selected by SECRETS_PROVIDER, or nil when secrets come from env vars
+func NewSecretProvider(ctx context.Context, cfg Config) (SecretProvider, error) {
+	switch strings.ToLower(cfg.SecretsProvider) {
+	case "", "env":
+		return nil, nil
+	case SecretsProviderDocker:
+		dir := cfg.SecretsPath
+		if dir == "" {
+			dir = "/run/secrets"
+		}
+		return &dockerSecretProvider{dir: dir}, nil
+	case SecretsProviderVault:
+		if cfg.VaultAddr == "" || cfg.VaultToken == "" || cfg.SecretsPath == "" {
+			return nil, errors.New("VAULT_ADDR, VAULT_TOKEN and SECRETS_PATH are required for the vault secrets provider")
+		}
+		return &vaultSecretProvider{
+			addr:   strings.TrimRight(cfg.VaultAddr, "/"),
+			token:  cfg.VaultToken,
+			path:   strings.Trim(cfg.SecretsPath, "/"),
+			client: &http.Client{Timeout: secretsTimeout},
+		}, nil
+	case SecretsProviderSSM:
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		}
+		return &ssmSecretProvider{
+			client: ssm.NewFromConfig(awsCfg),
+			prefix: cfg.SecretsPath,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown SECRETS_PROVIDER %q", cfg.SecretsProvider)
+	}
+}
+
+// loadSecrets overwrites secret settings with values from the configured secret store
+func loadSecrets(cfg *Config) error {
+	ctx, cancel := context.WithTimeout(context.Background(), secretsTimeout)
+	defer cancel()
+
+	provider, err := NewSecretProvider(ctx, *cfg)
+	if err != nil || provider == nil {
+		return err
+	}
+
+	for key, field := range cfg.secretFields() {
+		value, err := provider.Get(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to load %s from %s: %w", key, cfg.SecretsProvider, err)
+		}
+		if value != "" {
+			*field = value
+		}
+	}
+	return nil
+}
+
+// dockerSecretProvider reads Docker/Kubernetes secret files named after the lowercased key,
+// e.g. /run/secrets/jwt_secret
+type dockerSecretProvider struct {
+	dir string
+}
+
+// Get reads the secret file for key
+func (p *dockerSecretProvider) Get(_ context.Context, key string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(p.dir, strings.ToLower(key)))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// vaultSecretProvider reads keys from a single Vault KV v2 secret, e.g. SECRETS_PATH=secret/data/personal-website
+type vaultSecretProvider struct {
+	addr   string
+	token  string
+	path   string
+	client *http.Client
+	data   map[string]string
+}
+
+// Get returns key from the Vault secret, fetching it on first use
+func (p *vaultSecretProvider) Get(ctx context.Context, key string) (string, error) {
+	if p.data == nil {
+		if err := p.fetch(ctx); err != nil {
+			return "", err
+		}
+	}
+	return p.data[key], nil
+}
+
+// fetch reads the whole secret in one request
+func (p *vaultSecretProvider) fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.addr+"/v1/"+p.path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vault returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return err
+	}
+
+	p.data = body.Data.Data
+	if p.data == nil {
+		p.data = map[string]string{}
+	}
+	return nil
+}
+
+// ssmSecretProvider reads SecureString parameters named SECRETS_PATH + key, e.g. /personal-website/JWT_SECRET
+type ssmSecretProvider struct {
+	client *ssm.Client
+	prefix string
+}
+
+// Get reads and decrypts the parameter for key
+func (p *ssmSecretProvider) Get(ctx context.Context, key string) (string, error) {
+	out, err := p.client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(p.prefix + key),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		var notFound *ssmtypes.ParameterNotFound
+		if errors.As(err, &notFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return aws.ToString(out.Parameter.Value), nil
+}
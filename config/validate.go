@@ -0,0 +1,246 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+)
+
+// Insecure defaults that must be replaced before running in production
+const (
+	defaultJWTSecret        = "your-secret-key"
+	defaultJWTRefreshSecret = "your-refresh-secret-key"
+	minJWTSecretLength      = 32
+)
+
+// Validate reports every missing or insecure setting at once so startup fails with a single clear message
+func (c *Config) Validate() error {
+	var problems []string
+	require := func(value, name string) {
+		if strings.TrimSpace(value) == "" {
+			problems = append(problems, name+" is required")
+		}
+	}
+	requireWhen := func(value, name, condition string) {
+		if strings.TrimSpace(value) == "" {
+			problems = append(problems, name+" is required when "+condition)
+		}
+	}
+
+	require(c.Port, "PORT")
+	require(c.PostgresHost, "POSTGRES_HOST")
+	require(c.PostgresUser, "POSTGRES_USER")
+	require(c.PostgresDB, "POSTGRES_DB")
+	require(c.JWTSecret, "JWT_SECRET")
+	require(c.JWTRefreshSecret, "JWT_REFRESH_SECRET")
+
+	if c.JWTSecret != "" && c.JWTSecret == c.JWTRefreshSecret {
+		problems = append(problems, "JWT_SECRET and JWT_REFRESH_SECRET must differ")
+	}
+
+	if c.IsProduction() {
+		if c.JWTSecret == defaultJWTSecret || c.JWTRefreshSecret == defaultJWTRefreshSecret {
+			problems = append(problems, "JWT_SECRET and JWT_REFRESH_SECRET must not use the default values in production")
+		}
+		if len(c.JWTSecret) < minJWTSecretLength || len(c.JWTRefreshSecret) < minJWTSecretLength {
+			problems = append(problems, fmt.Sprintf("JWT_SECRET and JWT_REFRESH_SECRET must be at least %d characters in production", minJWTSecretLength))
+		}
+		require(c.PostgresPassword, "POSTGRES_PASSWORD")
+	}
+
+	if _, err := loadSiteLocation(c.SiteTimezone); err != nil {
+		problems = append(problems, "SITE_TIMEZONE must be an IANA timezone, e.g. UTC or Asia/Jakarta")
+	}
+
+	switch c.LogFormat {
+	case "", "json", "console":
+	default:
+		problems = append(problems, "LOG_FORMAT must be json or console")
+	}
+	if c.LogLevel != "" {
+		if _, err := logger.ParseLevel(c.LogLevel); err != nil {
+			problems = append(problems, "LOG_LEVEL: "+err.Error())
+		}
+	}
+	if _, err := logger.ParseLevels(c.LogLevels); err != nil {
+		problems = append(problems, "LOG_LEVELS: "+err.Error())
+	}
+
+	if c.SentrySampleRate < 0 || c.SentrySampleRate > 1 {
+		problems = append(problems, "SENTRY_SAMPLE_RATE must be between 0 and 1")
+	}
+
+	if c.TelegramEnabled {
+		requireWhen(c.TelegramBotToken, "TELEGRAM_BOT_TOKEN", "TELEGRAM_ENABLED is true")
+		requireWhen(c.TelegramChatID, "TELEGRAM_CHAT_ID", "TELEGRAM_ENABLED is true")
+	}
+	if c.TelegramWebhookSecret != "" {
+		requireWhen(c.TelegramBotToken, "TELEGRAM_BOT_TOKEN", "TELEGRAM_WEBHOOK_SECRET is set")
+	}
+
+	if c.EmailEnabled {
+		requireWhen(c.SMTPHost, "SMTP_HOST", "EMAIL_ENABLED is true")
+		requireWhen(c.SMTPFrom, "SMTP_FROM", "EMAIL_ENABLED is true")
+	}
+
+	if c.RateLimitStorage == "redis" {
+		requireWhen(c.RedisURL, "REDIS_URL", "RATE_LIMIT_STORAGE is redis")
+	}
+
+	if c.RateLimitCrawlerMax < 0 {
+		problems = append(problems, "RATE_LIMIT_CRAWLER_MAX must not be negative")
+	} else if c.RateLimitCrawlerMax > 0 && c.RateLimitCrawlerWindow <= 0 {
+		problems = append(problems, "RATE_LIMIT_CRAWLER_WINDOW must be positive")
+	}
+
+	if c.DBMigrationLockTimeout < time.Second {
+		problems = append(problems, "DB_MIGRATION_LOCK_TIMEOUT must be at least 1s")
+	}
+	if c.HealthCheckTimeout <= 0 {
+		problems = append(problems, "HEALTH_CHECK_TIMEOUT must be positive")
+	}
+
+	if tiers, err := parseAPIKeyTiers(c.APIKeyTiers); err != nil {
+		problems = append(problems, "API_KEY_TIERS: "+err.Error())
+	} else if len(tiers) == 0 {
+		problems = append(problems, "API_KEY_TIERS must list at least one tier")
+	}
+	if c.APIKeyRateWindow <= 0 {
+		problems = append(problems, "API_KEY_RATE_WINDOW must be positive")
+	}
+
+	deprecatedAt, deprecatedErr := parseOptionalTime(c.APIV1DeprecatedAt)
+	sunsetAt, sunsetErr := parseOptionalTime(c.APIV1SunsetAt)
+	switch {
+	case deprecatedErr != nil:
+		problems = append(problems, "API_V1_DEPRECATED_AT must be an RFC 3339 time, e.g. 2025-01-01T00:00:00Z")
+	case sunsetErr != nil:
+		problems = append(problems, "API_V1_SUNSET_AT must be an RFC 3339 time, e.g. 2025-07-01T00:00:00Z")
+	case !sunsetAt.IsZero() && deprecatedAt.IsZero():
+		problems = append(problems, "API_V1_DEPRECATED_AT is required when API_V1_SUNSET_AT is set")
+	case !sunsetAt.IsZero() && !sunsetAt.After(deprecatedAt):
+		problems = append(problems, "API_V1_SUNSET_AT must be after API_V1_DEPRECATED_AT")
+	}
+
+	if c.ActivityPubEnabled {
+		requireWhen(c.ActivityPubUsername, "ACTIVITYPUB_USERNAME", "ACTIVITYPUB_ENABLED is true")
+		requireWhen(c.ActivityPubKeyFile, "ACTIVITYPUB_KEY_FILE", "ACTIVITYPUB_ENABLED is true")
+		// Remote servers only fetch actors over https
+		if !strings.HasPrefix(c.APIURL, "https://") {
+			problems = append(problems, "API_URL must be an https URL when ACTIVITYPUB_ENABLED is true")
+		}
+	}
+
+	if c.PushEnabled {
+		requireWhen(c.PushVAPIDKeyFile, "PUSH_VAPID_KEY_FILE", "PUSH_ENABLED is true")
+		requireWhen(c.PushVAPIDSubject, "PUSH_VAPID_SUBJECT", "PUSH_ENABLED is true")
+	}
+
+	if c.ContentImportWebhookSecret != "" {
+		// Webhook syncs have no signed-in user to own new articles
+		requireWhen(c.ContentImportAuthor, "CONTENT_IMPORT_AUTHOR", "CONTENT_IMPORT_WEBHOOK_SECRET is set")
+	}
+
+	if c.ContentImportDir != "" && c.ContentImportGitHubRepo != "" {
+		problems = append(problems, "CONTENT_IMPORT_DIR and CONTENT_IMPORT_GITHUB_REPO can't both be set")
+	}
+
+	if c.PreviewSecret != "" && len(c.PreviewSecret) < minJWTSecretLength {
+		problems = append(problems, fmt.Sprintf("PREVIEW_SECRET must be at least %d characters", minJWTSecretLength))
+	}
+
+	if c.IdempotencyKeyTTL <= 0 {
+		problems = append(problems, "IDEMPOTENCY_KEY_TTL must be positive")
+	}
+
+	if c.AccountDeletionGracePeriod < 0 {
+		problems = append(problems, "ACCOUNT_DELETION_GRACE_PERIOD can't be negative")
+	}
+
+	if c.RevalidateURL != "" {
+		requireWhen(c.RevalidateSecret, "REVALIDATE_SECRET", "REVALIDATE_URL is set")
+	}
+
+	if c.CloudflareZoneID != "" {
+		requireWhen(c.CloudflareAPIToken, "CLOUDFLARE_API_TOKEN", "CLOUDFLARE_ZONE_ID is set")
+		requireWhen(c.FrontendURL, "FRONTEND_URL", "CLOUDFLARE_ZONE_ID is set")
+	}
+
+	for _, contact := range c.SecurityContacts() {
+		if !strings.HasPrefix(contact, "mailto:") && !strings.HasPrefix(contact, "https://") && !strings.HasPrefix(contact, "tel:") {
+			problems = append(problems, fmt.Sprintf("SECURITY_TXT_CONTACT entry %q must start with mailto:, https:// or tel:", contact))
+		}
+	}
+	if c.SecurityTxtContact != "" && c.SecurityTxtExpiry <= 0 {
+		problems = append(problems, "SECURITY_TXT_EXPIRY must be positive when SECURITY_TXT_CONTACT is set")
+	}
+
+	if c.SpotifyRefreshToken != "" {
+		requireWhen(c.SpotifyClientID, "SPOTIFY_CLIENT_ID", "SPOTIFY_REFRESH_TOKEN is set")
+		requireWhen(c.SpotifyClientSecret, "SPOTIFY_CLIENT_SECRET", "SPOTIFY_REFRESH_TOKEN is set")
+		if c.SpotifyRefreshInterval <= 0 {
+			problems = append(problems, "SPOTIFY_REFRESH_INTERVAL must be positive when SPOTIFY_REFRESH_TOKEN is set")
+		}
+	}
+
+	if c.GitHubActivityUsername != "" && c.GitHubActivityCacheTTL <= 0 {
+		problems = append(problems, "GITHUB_ACTIVITY_CACHE_TTL must be positive when GITHUB_ACTIVITY_USERNAME is set")
+	}
+
+	if c.GiscusRepo != "" {
+		if owner, name, ok := strings.Cut(c.GiscusRepo, "/"); !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+			problems = append(problems, "GISCUS_REPO must be owner/name")
+		}
+		requireWhen(c.GiscusCategory, "GISCUS_CATEGORY", "GISCUS_REPO is set")
+		requireWhen(c.GiscusToken, "GISCUS_TOKEN", "GISCUS_REPO is set")
+		switch c.GiscusMapping {
+		case "pathname", "url", "title":
+		default:
+			problems = append(problems, "GISCUS_MAPPING must be pathname, url or title")
+		}
+		if c.GiscusSyncInterval <= 0 {
+			problems = append(problems, "GISCUS_SYNC_INTERVAL must be positive when GISCUS_REPO is set")
+		}
+		if c.GiscusRecentComments < 0 || c.GiscusRecentComments > 20 {
+			problems = append(problems, "GISCUS_RECENT_COMMENTS must be between 0 and 20")
+		}
+	}
+
+	switch strings.ToLower(c.CaptchaProvider) {
+	case "":
+	case "hcaptcha", "turnstile":
+		requireWhen(c.CaptchaSecret, "CAPTCHA_SECRET", "CAPTCHA_PROVIDER is set")
+	default:
+		problems = append(problems, "CAPTCHA_PROVIDER must be hcaptcha or turnstile")
+	}
+
+	if c.ArticleAccessTokenExpiry <= 0 {
+		problems = append(problems, "ARTICLE_ACCESS_TOKEN_EXPIRY must be positive")
+	}
+
+	if c.FormMinSubmitTime < 0 {
+		problems = append(problems, "FORM_MIN_SUBMIT_TIME must not be negative")
+	} else if c.FormMinSubmitTime > 0 && c.FormTokenTTL <= c.FormMinSubmitTime {
+		problems = append(problems, "FORM_TOKEN_TTL must be longer than FORM_MIN_SUBMIT_TIME")
+	}
+	if c.FormDuplicateWindow < 0 {
+		problems = append(problems, "FORM_DUPLICATE_WINDOW must not be negative")
+	}
+
+	// Chroma silently falls back to its default style for unknown names
+	if _, ok := styles.Registry[strings.ToLower(c.CodeHighlightStyle)]; !ok {
+		problems = append(problems, "CODE_HIGHLIGHT_STYLE must be a chroma style name, e.g. github, monokai or dracula")
+	}
+
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		problems = append(problems, "TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
@@ -18,44 +18,114 @@ var (
 	DBPool *sqlx.DB
 )
 
-// InitDB initializes the database connection pool
+// maxConnectBackoff caps the wait between startup connection attempts
+const maxConnectBackoff = 30 * time.Second
+
+var (
+	// ReplicaPool is the optional read-replica connection pool
+	ReplicaPool *sqlx.DB
+
+	// idleConns is the configured idle pool size, restored after health check resets
+	idleConns int
+
+	// migrationLockTimeout is how long migrations wait for another instance to finish migrating
+	migrationLockTimeout = 5 * time.Minute
+)
+
+// InitDB initializes the database connection pool, retrying with backoff while Postgres is unreachable
 func InitDB(cfg config.Config) (*sqlx.DB, error) {
 	if DBPool != nil {
 		return DBPool, nil
 	}
 
-	// Create a connection pool
-	db, err := sqlx.Open("pgx", cfg.GetPostgresConnString())
+	db, err := openPool(cfg.GetPostgresConnString(), cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database connection: %w", err)
-	}
-
-	// Configure connection pooling
-	db.SetMaxOpenConns(25)                  // Maximum number of open connections to the database
-	db.SetMaxIdleConns(10)                  // Maximum number of connections in the idle connection pool
-	db.SetConnMaxLifetime(time.Hour)        // Maximum time a connection may be reused
-	db.SetConnMaxIdleTime(30 * time.Minute) // Maximum time a connection may be idle
-
-	// Test the connection
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err := db.PingContext(ctx); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+		return nil, err
 	}
 
 	logger.Info("Database connection pool established",
 		zap.String("host", cfg.PostgresHost),
 		zap.String("database", cfg.PostgresDB),
-		zap.Int("max_open_conns", 25),
-		zap.Int("max_idle_conns", 10))
+		zap.Int("max_open_conns", cfg.DBMaxOpenConns),
+		zap.Int("max_idle_conns", cfg.DBMaxIdleConns))
 
 	// Set the global pool
 	DBPool = db
+	idleConns = cfg.DBMaxIdleConns
+	migrationLockTimeout = cfg.DBMigrationLockTimeout
+	healthy.Store(true)
+
+	return db, nil
+}
+
+// InitReplicaDB opens the read-replica pool; it returns nil when no replica is configured
+func InitReplicaDB(cfg config.Config) (*sqlx.DB, error) {
+	if ReplicaPool != nil || cfg.PostgresReplicaDSN == "" {
+		return ReplicaPool, nil
+	}
+
+	db, err := openPool(cfg.PostgresReplicaDSN, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("read replica: %w", err)
+	}
+
+	logger.Info("Read replica connection pool established")
 
+	ReplicaPool = db
 	return db, nil
 }
 
+// CloseReplicaDB closes the read-replica pool
+func CloseReplicaDB() error {
+	if ReplicaPool != nil {
+		logger.Info("Closing read replica connection pool")
+		return ReplicaPool.Close()
+	}
+	return nil
+}
+
+// openPool opens and configures a connection pool, retrying the first ping with backoff
+func openPool(dsn string, cfg config.Config) (*sqlx.DB, error) {
+	db, err := sqlx.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database connection: %w", err)
+	}
+
+	// Configure connection pooling
+	db.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	db.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	db.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.DBConnMaxIdleTime)
+
+	// Test the connection, backing off between attempts
+	backoff := cfg.DBConnectBackoff
+	for attempt := 1; ; attempt++ {
+		err = ping(db)
+		if err == nil {
+			return db, nil
+		}
+		if attempt > cfg.DBConnectRetries {
+			db.Close()
+			return nil, fmt.Errorf("failed to ping database after %d attempts: %w", attempt, err)
+		}
+
+		logger.Warn("Database not reachable, retrying",
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
+}
+
+// ping checks the connection with a short timeout
+func ping(db *sqlx.DB) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return db.PingContext(ctx)
+}
+
 // GetDB returns the database connection pool
 func GetDB() *sqlx.DB {
 	return DBPool
@@ -70,26 +140,7 @@ func CloseDB() error {
 	return nil
 }
 
-// RunMigrations runs the database migrations
-func RunMigrations(db *sqlx.DB) error {
-	// Set up goose
-	goose.SetBaseFS(nil)
-
-	// Set the migration directory
-	if err := goose.SetDialect("postgres"); err != nil {
-		return fmt.Errorf("failed to set dialect: %w", err)
-	}
-
-	// Run migrations
-	if err := goose.Up(db.DB, "db/migration"); err != nil {
-		return fmt.Errorf("failed to run migrations: %w", err)
-	}
-
-	logger.Info("Database migrations completed")
-	return nil
-}
-
-// CreateMigration creates a new migration file
+// CreateMigration creates a new migration file in db/migration; it runs from the repository root
 func CreateMigration(name string, sql bool) error {
 	var ext string
 	if sql {
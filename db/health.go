@@ -0,0 +1,80 @@
+package db
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	// healthy reports whether the last database ping succeeded
+	healthy atomic.Bool
+
+	monitorStop chan struct{}
+	monitorDone sync.WaitGroup
+)
+
+// Healthy returns whether the database answered the most recent health check
+func Healthy() bool {
+	return healthy.Load()
+}
+
+// StartHealthMonitor pings the global pool every interval. When a ping fails the idle
+// connections are dropped so the pool reconnects from scratch once Postgres is back.
+func StartHealthMonitor(interval time.Duration) {
+	if DBPool == nil || interval <= 0 || monitorStop != nil {
+		return
+	}
+
+	monitorStop = make(chan struct{})
+	monitorDone.Add(1)
+
+	go func() {
+		defer monitorDone.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-monitorStop:
+				return
+			case <-ticker.C:
+				checkHealth()
+			}
+		}
+	}()
+}
+
+// StopHealthMonitor stops the health monitor and waits for it to exit
+func StopHealthMonitor() {
+	if monitorStop == nil {
+		return
+	}
+
+	close(monitorStop)
+	monitorDone.Wait()
+	monitorStop = nil
+}
+
+// checkHealth pings the pool and resets it after a failure
+func checkHealth() {
+	err := ping(DBPool)
+	if err == nil {
+		if !healthy.Swap(true) {
+			logger.Info("Database connection restored")
+		}
+		return
+	}
+
+	if healthy.Swap(false) {
+		logger.Error("Database health check failed", zap.Error(err))
+	}
+
+	// Drop idle connections that may point at a restarted server
+	DBPool.SetMaxIdleConns(0)
+	DBPool.SetMaxIdleConns(idleConns)
+}
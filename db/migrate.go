@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+	"go.uber.org/zap"
+)
+
+// migrationFiles holds the SQL migrations compiled into the binary, so it can migrate from any directory
+//
+//go:embed migration/*.sql
+var migrationFiles embed.FS
+
+// newMigrationProvider returns a goose provider reading the embedded migrations. Migrating holds
+// a Postgres advisory lock, so when several instances start together one migrates and the
+// others wait for it, then find nothing left to apply.
+func newMigrationProvider(db *sqlx.DB) (*goose.Provider, error) {
+	migrations, err := fs.Sub(migrationFiles, "migration")
+	if err != nil {
+		return nil, err
+	}
+
+	// The lock is retried every second
+	locker, err := lock.NewPostgresSessionLocker(
+		lock.WithLockTimeout(1, uint64(max(migrationLockTimeout/time.Second, 1))),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := goose.NewProvider(goose.DialectPostgres, db.DB, migrations, goose.WithSessionLocker(locker))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+	return provider, nil
+}
+
+// RunMigrations applies every pending migration
+func RunMigrations(db *sqlx.DB) error {
+	provider, err := newMigrationProvider(db)
+	if err != nil {
+		return err
+	}
+
+	results, err := provider.Up(context.Background())
+	logMigrationResults(results)
+	if err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	logger.Info("Database migrations completed")
+	return nil
+}
+
+// HasPendingMigrations reports whether any embedded migration hasn't been applied
+func HasPendingMigrations(db *sqlx.DB) (bool, error) {
+	provider, err := newMigrationProvider(db)
+	if err != nil {
+		return false, err
+	}
+
+	return provider.HasPending(context.Background())
+}
+
+// MigrateUpTo applies the pending migrations up to and including version
+func MigrateUpTo(db *sqlx.DB, version int64) error {
+	provider, err := newMigrationProvider(db)
+	if err != nil {
+		return err
+	}
+
+	results, err := provider.UpTo(context.Background(), version)
+	logMigrationResults(results)
+	if err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	return nil
+}
+
+// RollbackMigration rolls back the most recent migration
+func RollbackMigration(db *sqlx.DB) error {
+	provider, err := newMigrationProvider(db)
+	if err != nil {
+		return err
+	}
+
+	result, err := provider.Down(context.Background())
+	if result != nil {
+		logMigrationResults([]*goose.MigrationResult{result})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to rollback migration: %w", err)
+	}
+
+	return nil
+}
+
+// ResetMigrations rolls back every applied migration
+func ResetMigrations(db *sqlx.DB) error {
+	provider, err := newMigrationProvider(db)
+	if err != nil {
+		return err
+	}
+
+	results, err := provider.DownTo(context.Background(), 0)
+	logMigrationResults(results)
+	if err != nil {
+		return fmt.Errorf("failed to reset migrations: %w", err)
+	}
+
+	return nil
+}
+
+// MigrationStatus returns every embedded migration with whether and when it was applied
+func MigrationStatus(db *sqlx.DB) ([]*goose.MigrationStatus, error) {
+	provider, err := newMigrationProvider(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider.Status(context.Background())
+}
+
+// logMigrationResults logs each applied or rolled back migration
+func logMigrationResults(results []*goose.MigrationResult) {
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+		logger.Info("Migrated",
+			zap.String("direction", result.Direction),
+			zap.String("file", result.Source.Path),
+			zap.Duration("duration", result.Duration))
+	}
+}
@@ -0,0 +1,14 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE portfolios ADD COLUMN IF NOT EXISTS category VARCHAR(100);
+
+CREATE INDEX IF NOT EXISTS idx_portfolios_category ON portfolios(LOWER(category));
+
+-- Technology filters are case-insensitive, so index the lowercased document
+CREATE INDEX IF NOT EXISTS idx_portfolios_technologies ON portfolios USING GIN ((LOWER(technologies::text)::jsonb));
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP INDEX IF EXISTS idx_portfolios_technologies;
+DROP INDEX IF EXISTS idx_portfolios_category;
+ALTER TABLE portfolios DROP COLUMN IF EXISTS category;
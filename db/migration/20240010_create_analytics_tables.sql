@@ -0,0 +1,22 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE IF NOT EXISTS analytics_daily_pageviews (
+    day DATE NOT NULL,
+    path VARCHAR(255) NOT NULL,
+    referrer VARCHAR(255) NOT NULL DEFAULT '',
+    country VARCHAR(2) NOT NULL DEFAULT '',
+    views INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, path, referrer, country)
+);
+
+-- Visitor hashes are salted per day, so they can't be linked across days
+CREATE TABLE IF NOT EXISTS analytics_daily_visitors (
+    day DATE NOT NULL,
+    visitor_hash CHAR(64) NOT NULL,
+    PRIMARY KEY (day, visitor_hash)
+);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS analytics_daily_visitors;
+DROP TABLE IF EXISTS analytics_daily_pageviews;
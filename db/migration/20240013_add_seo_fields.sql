@@ -0,0 +1,27 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE articles
+    ADD COLUMN IF NOT EXISTS meta_title VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS meta_description VARCHAR(320),
+    ADD COLUMN IF NOT EXISTS canonical_url VARCHAR(2048),
+    ADD COLUMN IF NOT EXISTS og_image VARCHAR(2048);
+
+ALTER TABLE portfolios
+    ADD COLUMN IF NOT EXISTS meta_title VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS meta_description VARCHAR(320),
+    ADD COLUMN IF NOT EXISTS canonical_url VARCHAR(2048),
+    ADD COLUMN IF NOT EXISTS og_image VARCHAR(2048);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE portfolios
+    DROP COLUMN IF EXISTS og_image,
+    DROP COLUMN IF EXISTS canonical_url,
+    DROP COLUMN IF EXISTS meta_description,
+    DROP COLUMN IF EXISTS meta_title;
+
+ALTER TABLE articles
+    DROP COLUMN IF EXISTS og_image,
+    DROP COLUMN IF EXISTS canonical_url,
+    DROP COLUMN IF EXISTS meta_description,
+    DROP COLUMN IF EXISTS meta_title;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- Support keyset pagination ordered by (created_at, id)
+CREATE INDEX IF NOT EXISTS idx_articles_created_at_id ON articles(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_portfolios_created_at_id ON portfolios(created_at DESC, id DESC);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP INDEX IF EXISTS idx_portfolios_created_at_id;
+DROP INDEX IF EXISTS idx_articles_created_at_id;
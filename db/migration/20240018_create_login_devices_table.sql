@@ -0,0 +1,21 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- Devices a user has logged in from, identified by a hash of the user agent and IP subnet
+CREATE TABLE IF NOT EXISTS login_devices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    fingerprint CHAR(64) NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    last_ip VARCHAR(45) NOT NULL,
+    location VARCHAR(255) NOT NULL DEFAULT '',
+    confirm_token VARCHAR(64) UNIQUE,
+    confirmation_sent_at TIMESTAMP WITH TIME ZONE,
+    confirmed_at TIMESTAMP WITH TIME ZONE,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, fingerprint)
+);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS login_devices;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- Normalized search queries per day; nothing identifies who searched
+CREATE TABLE IF NOT EXISTS analytics_daily_searches (
+    day DATE NOT NULL,
+    query VARCHAR(100) NOT NULL,
+    searches INTEGER NOT NULL DEFAULT 0,
+    zero_results INTEGER NOT NULL DEFAULT 0,
+    results INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, query)
+);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS analytics_daily_searches;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- Fediverse accounts following the blog actor
+CREATE TABLE IF NOT EXISTS activitypub_followers (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor_id TEXT NOT NULL UNIQUE,
+    inbox TEXT NOT NULL,
+    shared_inbox TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS activitypub_followers;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- Articles synced from Markdown files, keyed by the file path in the content source
+CREATE TABLE IF NOT EXISTS imported_articles (
+    path TEXT PRIMARY KEY,
+    article_id UUID NOT NULL UNIQUE REFERENCES articles(id) ON DELETE CASCADE,
+    checksum VARCHAR(64) NOT NULL,
+    imported_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS imported_articles;
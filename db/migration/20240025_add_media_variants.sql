@@ -0,0 +1,14 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- Image dimensions and alternative formats generated after upload
+ALTER TABLE media
+    ADD COLUMN IF NOT EXISTS width INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS height INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS variants JSONB NOT NULL DEFAULT '[]';
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE media
+    DROP COLUMN IF EXISTS variants,
+    DROP COLUMN IF EXISTS height,
+    DROP COLUMN IF EXISTS width;
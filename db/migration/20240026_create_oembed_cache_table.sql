@@ -0,0 +1,12 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- oEmbed responses fetched from providers, keyed by the embedded URL
+CREATE TABLE IF NOT EXISTS oembed_cache (
+    url TEXT PRIMARY KEY,
+    response JSONB NOT NULL,
+    fetched_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS oembed_cache;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- Table of contents built from the article's headings when it is saved;
+-- NULL for articles saved before this migration, which build it on read
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS toc JSONB;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE articles DROP COLUMN IF EXISTS toc;
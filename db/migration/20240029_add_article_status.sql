@@ -0,0 +1,27 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- Editorial workflow status replaces the published flag; is_published is kept as
+-- a generated column so existing queries and filters keep working
+ALTER TABLE articles
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    ADD COLUMN IF NOT EXISTS scheduled_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE articles SET status = 'published' WHERE is_published;
+
+ALTER TABLE articles DROP COLUMN is_published;
+ALTER TABLE articles ADD COLUMN is_published BOOLEAN GENERATED ALWAYS AS (status = 'published') STORED;
+ALTER TABLE articles ADD CONSTRAINT articles_status_check
+    CHECK (status IN ('draft', 'in_review', 'scheduled', 'published', 'archived'));
+
+CREATE INDEX IF NOT EXISTS idx_articles_scheduled_at ON articles(scheduled_at) WHERE status = 'scheduled';
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP INDEX IF EXISTS idx_articles_scheduled_at;
+ALTER TABLE articles DROP CONSTRAINT IF EXISTS articles_status_check;
+ALTER TABLE articles DROP COLUMN is_published;
+ALTER TABLE articles ADD COLUMN is_published BOOLEAN DEFAULT FALSE;
+UPDATE articles SET is_published = (status = 'published');
+ALTER TABLE articles
+    DROP COLUMN IF EXISTS scheduled_at,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- Featured articles are pinned to the homepage, most recently featured first
+ALTER TABLE articles
+    ADD COLUMN IF NOT EXISTS is_featured BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS featured_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_articles_featured_at ON articles(featured_at DESC) WHERE is_featured;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP INDEX IF EXISTS idx_articles_featured_at;
+ALTER TABLE articles
+    DROP COLUMN IF EXISTS featured_at,
+    DROP COLUMN IF EXISTS is_featured;
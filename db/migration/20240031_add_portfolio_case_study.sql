@@ -0,0 +1,18 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- Case-study details; gallery is an ordered array of media library IDs
+ALTER TABLE portfolios
+    ADD COLUMN IF NOT EXISTS content TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS role VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS duration VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS metrics JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN IF NOT EXISTS gallery JSONB NOT NULL DEFAULT '[]';
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE portfolios
+    DROP COLUMN IF EXISTS gallery,
+    DROP COLUMN IF EXISTS metrics,
+    DROP COLUMN IF EXISTS duration,
+    DROP COLUMN IF EXISTS role,
+    DROP COLUMN IF EXISTS content;
@@ -0,0 +1,37 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- Ordered screenshot gallery of a portfolio, replacing the gallery array of media IDs
+CREATE TABLE IF NOT EXISTS portfolio_images (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    portfolio_id UUID NOT NULL REFERENCES portfolios(id) ON DELETE CASCADE,
+    media_id UUID NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    alt VARCHAR(255) NOT NULL DEFAULT '',
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (portfolio_id, media_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_portfolio_images_portfolio ON portfolio_images(portfolio_id, position);
+
+INSERT INTO portfolio_images (portfolio_id, media_id, position)
+SELECT p.id, g.media_id::uuid, g.position - 1
+FROM portfolios p
+CROSS JOIN LATERAL jsonb_array_elements_text(p.gallery) WITH ORDINALITY AS g(media_id, position)
+JOIN media m ON m.id = g.media_id::uuid
+ON CONFLICT (portfolio_id, media_id) DO NOTHING;
+
+ALTER TABLE portfolios DROP COLUMN IF EXISTS gallery;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE portfolios ADD COLUMN IF NOT EXISTS gallery JSONB NOT NULL DEFAULT '[]';
+
+UPDATE portfolios p
+SET gallery = (
+    SELECT jsonb_agg(i.media_id ORDER BY i.position, i.created_at)
+    FROM portfolio_images i
+    WHERE i.portfolio_id = p.id
+)
+WHERE EXISTS (SELECT 1 FROM portfolio_images i WHERE i.portfolio_id = p.id);
+
+DROP TABLE IF EXISTS portfolio_images;
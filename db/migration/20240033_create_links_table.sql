@@ -0,0 +1,23 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- Shared links with a short commentary, lighter than an article
+CREATE TABLE IF NOT EXISTS links (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    url TEXT NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    commentary TEXT NOT NULL DEFAULT '',
+    tags JSONB NOT NULL DEFAULT '[]',
+    is_published BOOLEAN NOT NULL DEFAULT FALSE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_links_published_at ON links(published_at DESC) WHERE is_published;
+-- Tag filters are case-insensitive, so index the lowercased document
+CREATE INDEX IF NOT EXISTS idx_links_tags ON links USING GIN ((LOWER(tags::text)::jsonb));
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS links;
@@ -0,0 +1,32 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+CREATE TABLE IF NOT EXISTS newsletter_campaigns (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    subject VARCHAR(255) NOT NULL,
+    content TEXT NOT NULL,
+    article_id UUID REFERENCES articles(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+-- One row per subscriber a campaign goes to; the id doubles as the open tracking token
+CREATE TABLE IF NOT EXISTS newsletter_campaign_recipients (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    campaign_id UUID NOT NULL REFERENCES newsletter_campaigns(id) ON DELETE CASCADE,
+    subscriber_id UUID NOT NULL REFERENCES subscribers(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    sent_at TIMESTAMP WITH TIME ZONE,
+    opened_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (campaign_id, subscriber_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_newsletter_campaign_recipients_pending ON newsletter_campaign_recipients(campaign_id) WHERE status = 'pending';
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS newsletter_campaign_recipients;
+DROP TABLE IF EXISTS newsletter_campaigns;
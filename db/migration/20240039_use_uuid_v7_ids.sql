@@ -0,0 +1,83 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- New rows get time-ordered UUIDv7 IDs. The API generates them itself; the defaults cover
+-- rows inserted by SQL, such as seeds and campaign recipients. Existing rows keep their
+-- UUIDv4 IDs, which stay valid, because IDs appear in URLs and in other tables' references.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION uuid_generate_v7() RETURNS uuid AS $$
+BEGIN
+    -- 48-bit Unix milliseconds over the random bytes of a v4 UUID, with the version set to 7
+    RETURN encode(
+        set_bit(
+            set_bit(
+                overlay(uuid_send(uuid_generate_v4())
+                        PLACING substring(int8send(floor(extract(epoch FROM clock_timestamp()) * 1000)::bigint) FROM 3)
+                        FROM 1 FOR 6),
+                52, 1),
+            53, 1),
+        'hex')::uuid;
+END
+$$ LANGUAGE plpgsql VOLATILE;
+-- +goose StatementEnd
+
+ALTER TABLE users ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE articles ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE portfolios ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE subscribers ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE series ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE experiences ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE educations ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE certifications ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE skills ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE pages ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE redirects ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE slug_history ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE jobs ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE login_attempts ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE login_devices ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE login_events ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE activitypub_followers ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE article_syndications ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE media ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE article_revisions ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE portfolio_images ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE links ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE uses_categories ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE uses_items ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE email_messages ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE newsletter_campaigns ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE newsletter_campaign_recipients ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+ALTER TABLE push_subscriptions ALTER COLUMN id SET DEFAULT uuid_generate_v7();
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE users ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE articles ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE portfolios ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE subscribers ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE series ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE experiences ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE educations ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE certifications ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE skills ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE pages ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE redirects ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE slug_history ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE jobs ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE login_attempts ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE login_devices ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE login_events ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE activitypub_followers ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE article_syndications ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE media ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE article_revisions ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE portfolio_images ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE links ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE uses_categories ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE uses_items ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE email_messages ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE newsletter_campaigns ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE newsletter_campaign_recipients ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+ALTER TABLE push_subscriptions ALTER COLUMN id SET DEFAULT uuid_generate_v4();
+
+DROP FUNCTION IF EXISTS uuid_generate_v7();
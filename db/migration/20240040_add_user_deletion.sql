@@ -0,0 +1,17 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS deletion_requested_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS deletion_content VARCHAR(20),
+    ADD COLUMN IF NOT EXISTS deletion_reassign_to UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_users_deletion_requested_at ON users(deletion_requested_at) WHERE deletion_requested_at IS NOT NULL;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP INDEX IF EXISTS idx_users_deletion_requested_at;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS deletion_reassign_to,
+    DROP COLUMN IF EXISTS deletion_content,
+    DROP COLUMN IF EXISTS deletion_requested_at;
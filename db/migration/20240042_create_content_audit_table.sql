@@ -0,0 +1,22 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- Full snapshots of articles and portfolios before and after each change. Entries outlive
+-- the content they describe, so entity_id has no foreign key.
+CREATE TABLE IF NOT EXISTS content_audit (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v7(),
+    entity_type VARCHAR(20) NOT NULL,
+    entity_id UUID NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    changed_fields JSONB NOT NULL DEFAULT '[]',
+    before JSONB,
+    after JSONB,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_content_audit_entity ON content_audit(entity_type, entity_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_content_audit_created_at ON content_audit(created_at);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS content_audit;
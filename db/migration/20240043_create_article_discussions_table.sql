@@ -0,0 +1,15 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- The GitHub Discussion holding an article's giscus comments, refreshed by the sync task
+CREATE TABLE IF NOT EXISTS article_discussions (
+    article_id UUID PRIMARY KEY REFERENCES articles(id) ON DELETE CASCADE,
+    discussion_number INTEGER NOT NULL,
+    url TEXT NOT NULL,
+    comment_count INTEGER NOT NULL DEFAULT 0,
+    recent_comments JSONB NOT NULL DEFAULT '[]',
+    synced_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP TABLE IF EXISTS article_discussions;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- Password-protected articles are listed without content until unlocked; NULL means public
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS password_hash TEXT;
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+ALTER TABLE articles DROP COLUMN IF EXISTS password_hash;
@@ -0,0 +1,30 @@
+-- +goose Up
+-- SQL in this section is executed when the migration is applied.
+-- Running view counts, bumped with each pageview of a path ending in the slug, so listings
+-- can sort by views without summing the analytics rollups
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS views BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE portfolios ADD COLUMN IF NOT EXISTS views BIGINT NOT NULL DEFAULT 0;
+
+UPDATE articles a SET views = v.views
+FROM (
+    SELECT regexp_replace(RTRIM(path, '/'), '^.*/', '') AS slug, SUM(views) AS views
+    FROM analytics_daily_pageviews GROUP BY 1
+) v
+WHERE v.slug = a.slug;
+
+UPDATE portfolios p SET views = v.views
+FROM (
+    SELECT regexp_replace(RTRIM(path, '/'), '^.*/', '') AS slug, SUM(views) AS views
+    FROM analytics_daily_pageviews GROUP BY 1
+) v
+WHERE v.slug = p.slug;
+
+CREATE INDEX IF NOT EXISTS idx_articles_views ON articles(views);
+CREATE INDEX IF NOT EXISTS idx_portfolios_views ON portfolios(views);
+
+-- +goose Down
+-- SQL in this section is executed when the migration is rolled back.
+DROP INDEX IF EXISTS idx_portfolios_views;
+DROP INDEX IF EXISTS idx_articles_views;
+ALTER TABLE portfolios DROP COLUMN IF EXISTS views;
+ALTER TABLE articles DROP COLUMN IF EXISTS views;
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// RedisClient is the global Redis client
+	RedisClient redis.UniversalClient
+)
+
+// InitRedis initializes the Redis client from the configured URL
+func InitRedis(cfg config.Config) (redis.UniversalClient, error) {
+	if RedisClient != nil {
+		return RedisClient, nil
+	}
+
+	if cfg.RedisURL == "" {
+		return nil, fmt.Errorf("REDIS_URL is not configured")
+	}
+
+	options, err := redis.ParseURL(cfg.RedisURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse redis url: %w", err)
+	}
+
+	client := redis.NewClient(options)
+
+	// Test the connection
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, fmt.Errorf("failed to ping redis: %w", err)
+	}
+
+	logger.Info("Redis connection established")
+
+	// Set the global client
+	RedisClient = client
+
+	return client, nil
+}
+
+// CloseRedis closes the Redis client
+func CloseRedis() error {
+	if RedisClient != nil {
+		logger.Info("Closing Redis connection")
+		return RedisClient.Close()
+	}
+	return nil
+}
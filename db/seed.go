@@ -0,0 +1,157 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// Seed errors
+var (
+	ErrSeedProduction       = errors.New("refusing to seed a production database without --force")
+	ErrSeedPasswordRequired = errors.New("SEED_ADMIN_PASSWORD must be set to seed the admin user")
+)
+
+// seedArticle is an example article inserted by Seed
+type seedArticle struct {
+	title     string
+	excerpt   string
+	content   string
+	published bool
+}
+
+// seedPortfolio is an example portfolio inserted by Seed
+type seedPortfolio struct {
+	title        string
+	description  string
+	category     string
+	projectURL   string
+	githubURL    string
+	technologies []string
+}
+
+var seedArticles = []seedArticle{
+	{
+		title:     "Hello, World",
+		excerpt:   "A first post to check that everything renders.",
+		content:   "# Hello, World\n\nThis is an example article created by `db:seed`.\n\n```go\nfmt.Println(\"hello\")\n```",
+		published: true,
+	},
+	{
+		title:     "Building a Personal Website Backend in Go",
+		excerpt:   "Notes on the stack behind this site: Fiber, PostgreSQL and goose migrations.",
+		content:   "## Stack\n\n- Fiber for HTTP\n- PostgreSQL with sqlx\n- goose for migrations\n\nEverything else is plain Go.",
+		published: true,
+	},
+	{
+		title:     "Draft: Ideas for Next Month",
+		excerpt:   "An unpublished draft, only visible to admins.",
+		content:   "- Write about caching\n- Try out a new editor theme",
+		published: false,
+	},
+}
+
+var seedPortfolios = []seedPortfolio{
+	{
+		title:        "Personal Website",
+		description:  "The API and frontend behind this website.",
+		category:     "web",
+		projectURL:   "https://example.com",
+		githubURL:    "https://github.com/example/personal-website",
+		technologies: []string{"Go", "PostgreSQL", "Fiber"},
+	},
+	{
+		title:        "CLI Toolkit",
+		description:  "A collection of small command line tools.",
+		category:     "tooling",
+		githubURL:    "https://github.com/example/cli-toolkit",
+		technologies: []string{"Go"},
+	},
+}
+
+// Seed inserts an admin user and example content for local development and CI.
+// It is idempotent: existing users, articles and portfolios are left untouched.
+// Production databases are only seeded when force is set.
+func Seed(ctx context.Context, db *sqlx.DB, cfg config.Config, force bool) error {
+	if cfg.IsProduction() && !force {
+		return ErrSeedProduction
+	}
+	if cfg.SeedAdminPassword == "" {
+		return ErrSeedPasswordRequired
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	hashedPassword, err := util.HashPassword(cfg.SeedAdminPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash admin password: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO users (username, password, email, first_name, is_admin, role)
+			  VALUES ($1, $2, $3, $4, TRUE, 'owner')
+			  ON CONFLICT DO NOTHING`,
+		cfg.SeedAdminUsername, hashedPassword, cfg.SeedAdminEmail, "Admin")
+	if err != nil {
+		return fmt.Errorf("failed to seed admin user: %w", err)
+	}
+
+	var userID string
+	if err := tx.QueryRowContext(ctx, `SELECT id FROM users WHERE username = $1`, cfg.SeedAdminUsername).Scan(&userID); err != nil {
+		return fmt.Errorf("failed to load admin user: %w", err)
+	}
+
+	articles := 0
+	for _, article := range seedArticles {
+		result, err := tx.ExecContext(ctx, `INSERT INTO articles (title, slug, content, excerpt, status, user_id, published_at)
+				  VALUES ($1, $2, $3, $4, CASE WHEN $5 THEN 'published' ELSE 'draft' END, $6, CASE WHEN $5 THEN NOW() END)
+				  ON CONFLICT (slug) DO NOTHING`,
+			article.title, util.GenerateSlug(article.title), article.content, article.excerpt, article.published, userID)
+		if err != nil {
+			return fmt.Errorf("failed to seed article %q: %w", article.title, err)
+		}
+		if affected, _ := result.RowsAffected(); affected > 0 {
+			articles++
+		}
+	}
+
+	portfolios := 0
+	for _, portfolio := range seedPortfolios {
+		technologies, err := json.Marshal(portfolio.technologies)
+		if err != nil {
+			return err
+		}
+
+		result, err := tx.ExecContext(ctx, `INSERT INTO portfolios (title, slug, description, category, project_url, github_url, technologies, is_published, user_id)
+				  VALUES ($1, $2, $3, $4, $5, $6, $7, TRUE, $8)
+				  ON CONFLICT (slug) DO NOTHING`,
+			portfolio.title, util.GenerateSlug(portfolio.title), portfolio.description, portfolio.category,
+			portfolio.projectURL, portfolio.githubURL, technologies, userID)
+		if err != nil {
+			return fmt.Errorf("failed to seed portfolio %q: %w", portfolio.title, err)
+		}
+		if affected, _ := result.RowsAffected(); affected > 0 {
+			portfolios++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	logger.Info("Database seeded",
+		zap.String("admin", cfg.SeedAdminUsername),
+		zap.Int("articles_created", articles),
+		zap.Int("portfolios_created", portfolios))
+	return nil
+}
@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"go.uber.org/zap"
+)
+
+// Listener reconnects back off from minReconnect up to maxReconnect
+const (
+	minReconnect = time.Second
+	maxReconnect = 30 * time.Second
+)
+
+// invalidatable is a cache an Invalidator drops entries from
+type invalidatable interface {
+	Delete(keys ...string)
+	Clear()
+	setActive(active bool)
+}
+
+// invalidation is the payload broadcast for a change; no keys drops the whole cache
+type invalidation struct {
+	Cache string   `json:"cache"`
+	Keys  []string `json:"keys,omitempty"`
+}
+
+// Invalidator drops cached entries on every instance, this one included, when content changes.
+// Invalidations are sent through Postgres in the caller's transaction, so they arrive once the
+// change is committed. Caches are only used while the listener is connected, since
+// invalidations sent in the meantime would be missed.
+type Invalidator struct {
+	repo   repository.CacheInvalidationRepository
+	logger *zap.Logger
+
+	mutex  sync.RWMutex
+	stores map[string]invalidatable
+
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+// NewInvalidator creates a new Invalidator
+func NewInvalidator(repo repository.CacheInvalidationRepository, logger *zap.Logger) *Invalidator {
+	return &Invalidator{
+		repo:   repo,
+		logger: logger,
+		stores: make(map[string]invalidatable),
+	}
+}
+
+// Register makes a store invalidated under name
+func (i *Invalidator) Register(name string, store invalidatable) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.stores[name] = store
+}
+
+// Invalidate drops keys from the named cache on every instance, or the whole cache without keys
+func (i *Invalidator) Invalidate(ctx context.Context, name string, keys ...string) error {
+	payload, err := json.Marshal(invalidation{Cache: name, Keys: keys})
+	if err != nil {
+		return err
+	}
+	return i.repo.Notify(ctx, string(payload))
+}
+
+// Start listens for invalidations until Stop, reconnecting with backoff when the connection drops
+func (i *Invalidator) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	i.cancel = cancel
+
+	i.wg.Add(1)
+	go func() {
+		defer i.wg.Done()
+
+		backoff := minReconnect
+		for {
+			err := i.repo.Listen(ctx, func() {
+				i.logger.Info("Listening for cache invalidations")
+				backoff = minReconnect
+				i.setActive(true)
+			}, i.handle)
+			i.setActive(false)
+			if ctx.Err() != nil {
+				return
+			}
+
+			i.logger.Warn("Cache invalidation listener disconnected, caches are off until it reconnects",
+				zap.Duration("backoff", backoff),
+				zap.Error(err))
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, maxReconnect)
+		}
+	}()
+}
+
+// Stop stops listening and waits for the listener to exit
+func (i *Invalidator) Stop() {
+	if i.cancel == nil {
+		return
+	}
+
+	i.cancel()
+	i.wg.Wait()
+}
+
+// handle applies an invalidation received from any instance
+func (i *Invalidator) handle(payload string) {
+	var message invalidation
+	if err := json.Unmarshal([]byte(payload), &message); err != nil {
+		i.logger.Error("Invalid cache invalidation", zap.Error(err), zap.String("payload", payload))
+		return
+	}
+
+	i.mutex.RLock()
+	store, ok := i.stores[message.Cache]
+	i.mutex.RUnlock()
+	if !ok {
+		return
+	}
+
+	if len(message.Keys) == 0 {
+		store.Clear()
+		return
+	}
+	store.Delete(message.Keys...)
+}
+
+// setActive turns every registered cache on or off, emptying it
+func (i *Invalidator) setActive(active bool) {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	for _, store := range i.stores {
+		store.setActive(active)
+	}
+}
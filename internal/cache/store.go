@@ -0,0 +1,94 @@
+// Package cache keeps rendered content in memory and drops it on every instance when it changes
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// entry is a cached value and when it expires
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// Store is an in-memory cache whose entries expire after a fixed TTL. It only serves and
+// keeps entries while active, that is while its Invalidator is listening for changes.
+type Store[V any] struct {
+	ttl    time.Duration
+	active atomic.Bool
+
+	mutex   sync.RWMutex
+	entries map[string]entry[V]
+}
+
+// NewStore creates an inactive Store; register it with an Invalidator to activate it
+func NewStore[V any](ttl time.Duration) *Store[V] {
+	return &Store[V]{
+		ttl:     ttl,
+		entries: make(map[string]entry[V]),
+	}
+}
+
+// Get returns the value cached under key, if it hasn't expired
+func (s *Store[V]) Get(key string) (V, bool) {
+	var zero V
+	if !s.active.Load() {
+		return zero, false
+	}
+
+	s.mutex.RLock()
+	cached, ok := s.entries[key]
+	s.mutex.RUnlock()
+
+	if !ok || time.Now().After(cached.expiresAt) {
+		return zero, false
+	}
+	return cached.value, true
+}
+
+// Set caches a value under key for the TTL
+func (s *Store[V]) Set(key string, value V) {
+	if !s.active.Load() {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	// Drop expired entries now and then so removed content doesn't pile up
+	if len(s.entries)%100 == 99 {
+		for key, cached := range s.entries {
+			if now.After(cached.expiresAt) {
+				delete(s.entries, key)
+			}
+		}
+	}
+	s.entries[key] = entry[V]{value: value, expiresAt: now.Add(s.ttl)}
+}
+
+// Delete removes the entries cached under keys
+func (s *Store[V]) Delete(keys ...string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, key := range keys {
+		delete(s.entries, key)
+	}
+}
+
+// Clear removes every entry
+func (s *Store[V]) Clear() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	clear(s.entries)
+}
+
+// setActive turns serving entries on or off; either way the store starts empty
+func (s *Store[V]) setActive(active bool) {
+	s.Clear()
+	s.active.Store(active)
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AccountController handles requests about the signed-in user's own account data
+type AccountController struct {
+	accountService service.AccountService
+}
+
+// NewAccountController creates a new AccountController
+func NewAccountController(accountService service.AccountService) *AccountController {
+	return &AccountController{
+		accountService: accountService,
+	}
+}
+
+// ExportAccount handles account data export requests, sent as a JSON file download
+func (c *AccountController) ExportAccount(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(string)
+
+	export, err := c.accountService.Export(ctx.Context(), userID)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to export account",
+		})
+	}
+
+	body, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to export account",
+		})
+	}
+
+	filename := fmt.Sprintf("account-%s-%s.json", export.Profile.Username, export.ExportedAt.Format(time.DateOnly))
+	ctx.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename=%q`, filename))
+	return ctx.Send(body)
+}
+
+// DeleteAccount handles account deletion requests
+func (c *AccountController) DeleteAccount(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(string)
+
+	var deletion model.AccountDeletion
+	if err := bindAndValidate(ctx, &deletion); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	scheduled, err := c.accountService.RequestDeletion(ctx.Context(), userID, &deletion)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrIncorrectPassword), errors.Is(err, service.ErrInvalidReassignTarget):
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case errors.Is(err, service.ErrOwnerDeletion):
+			return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete account",
+		})
+	}
+
+	return ctx.Status(fiber.StatusAccepted).JSON(scheduled)
+}
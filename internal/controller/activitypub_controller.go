@@ -0,0 +1,107 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+// jrdContentType is the WebFinger response media type
+const jrdContentType = "application/jrd+json"
+
+// ActivityPubController handles fediverse requests
+type ActivityPubController struct {
+	activityPubService service.ActivityPubService
+}
+
+// NewActivityPubController creates a new ActivityPubController
+func NewActivityPubController(activityPubService service.ActivityPubService) *ActivityPubController {
+	return &ActivityPubController{
+		activityPubService: activityPubService,
+	}
+}
+
+// WebFinger handles account lookup requests from remote servers
+func (c *ActivityPubController) WebFinger(ctx *fiber.Ctx) error {
+	document, err := c.activityPubService.WebFinger(ctx.Query("resource"))
+	if err != nil {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Resource not found",
+		})
+	}
+
+	return ctx.JSON(document, jrdContentType)
+}
+
+// GetActor handles get actor requests
+func (c *ActivityPubController) GetActor(ctx *fiber.Ctx) error {
+	return ctx.JSON(c.activityPubService.Actor(), repository.ActivityPubContentType)
+}
+
+// GetOutbox handles get outbox requests
+func (c *ActivityPubController) GetOutbox(ctx *fiber.Ctx) error {
+	outbox, err := c.activityPubService.Outbox(ctx.Context())
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get outbox",
+		})
+	}
+
+	return ctx.JSON(outbox, repository.ActivityPubContentType)
+}
+
+// GetFollowers handles get followers collection requests
+func (c *ActivityPubController) GetFollowers(ctx *fiber.Ctx) error {
+	followers, err := c.activityPubService.Followers(ctx.Context())
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get followers",
+		})
+	}
+
+	return ctx.JSON(followers, repository.ActivityPubContentType)
+}
+
+// GetArticle handles get article object requests
+func (c *ActivityPubController) GetArticle(ctx *fiber.Ctx) error {
+	article, err := c.activityPubService.ArticleObject(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Article not found",
+		})
+	}
+
+	return ctx.JSON(article, repository.ActivityPubContentType)
+}
+
+// Inbox handles activities delivered by remote servers
+func (c *ActivityPubController) Inbox(ctx *fiber.Ctx) error {
+	// Signature verification works on the net/http view of the request
+	req, err := adaptor.ConvertRequest(ctx, true)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request",
+		})
+	}
+
+	err = c.activityPubService.HandleInbox(ctx.Context(), req, ctx.Body())
+	switch {
+	case err == nil:
+		return ctx.SendStatus(fiber.StatusAccepted)
+	case errors.Is(err, service.ErrInvalidSignature):
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Invalid signature",
+		})
+	case errors.Is(err, service.ErrInvalidActivity):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid activity",
+		})
+	default:
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to process activity",
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/middleware"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// defaultAnalyticsDays is the reporting window when no range is given
+const defaultAnalyticsDays = 30
+
+// AnalyticsController handles analytics-related requests
+type AnalyticsController struct {
+	analyticsService service.AnalyticsService
+	countryHeader    string
+}
+
+// NewAnalyticsController creates a new AnalyticsController
+func NewAnalyticsController(analyticsService service.AnalyticsService, cfg config.Config) *AnalyticsController {
+	return &AnalyticsController{
+		analyticsService: analyticsService,
+		countryHeader:    cfg.AnalyticsCountryHeader,
+	}
+}
+
+// TrackPageview handles pageview collection requests
+func (c *AnalyticsController) TrackPageview(ctx *fiber.Ctx) error {
+	// Honour Do Not Track and leave out bots without revealing whether it was recorded
+	if ctx.Get("DNT") == "1" || middleware.IsBot(ctx) {
+		return ctx.SendStatus(fiber.StatusNoContent)
+	}
+
+	var req model.PageviewRequest
+	if err := bindAndValidate(ctx, &req); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	var country string
+	if c.countryHeader != "" {
+		country = ctx.Get(c.countryHeader)
+	}
+
+	err := c.analyticsService.RecordPageview(ctx.Context(), &req, ctx.IP(), ctx.Get("User-Agent"), country)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidPageview) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "A path starting with / is required",
+			})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to record pageview",
+		})
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// GetSummary handles analytics summary requests
+func (c *AnalyticsController) GetSummary(ctx *fiber.Ctx) error {
+	from, to, err := parseAnalyticsRange(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Dates must use the YYYY-MM-DD format",
+		})
+	}
+
+	summary, err := c.analyticsService.Summary(ctx.Context(), from, to)
+	if err != nil {
+		return analyticsErrorResponse(ctx, err)
+	}
+
+	return ctx.JSON(summary)
+}
+
+// GetTopPaths handles top paths requests
+func (c *AnalyticsController) GetTopPaths(ctx *fiber.Ctx) error {
+	return c.top(ctx, "path")
+}
+
+// GetTopReferrers handles top referrers requests
+func (c *AnalyticsController) GetTopReferrers(ctx *fiber.Ctx) error {
+	from, to, err := parseAnalyticsRange(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Dates must use the YYYY-MM-DD format",
+		})
+	}
+
+	limit, err := strconv.Atoi(ctx.Query("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	report, err := c.analyticsService.Referrers(ctx.Context(), from, to, limit)
+	if err != nil {
+		return analyticsErrorResponse(ctx, err)
+	}
+
+	return ctx.JSON(report)
+}
+
+// GetTopCountries handles top countries requests
+func (c *AnalyticsController) GetTopCountries(ctx *fiber.Ctx) error {
+	return c.top(ctx, "country")
+}
+
+// top renders the breakdown for a single dimension
+func (c *AnalyticsController) top(ctx *fiber.Ctx, dimension string) error {
+	from, to, err := parseAnalyticsRange(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Dates must use the YYYY-MM-DD format",
+		})
+	}
+
+	limit, err := strconv.Atoi(ctx.Query("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	items, err := c.analyticsService.Top(ctx.Context(), dimension, from, to, limit)
+	if err != nil {
+		return analyticsErrorResponse(ctx, err)
+	}
+
+	return ctx.JSON(fiber.Map{
+		"from":  from.Format("2006-01-02"),
+		"to":    to.Format("2006-01-02"),
+		"items": items,
+	})
+}
+
+// GetSearches handles top and zero-result search query requests
+func (c *AnalyticsController) GetSearches(ctx *fiber.Ctx) error {
+	from, to, err := parseAnalyticsRange(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Dates must use the YYYY-MM-DD format",
+		})
+	}
+
+	limit, err := strconv.Atoi(ctx.Query("limit", "10"))
+	if err != nil || limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	report, err := c.analyticsService.Searches(ctx.Context(), from, to, limit)
+	if err != nil {
+		return analyticsErrorResponse(ctx, err)
+	}
+
+	return ctx.JSON(report)
+}
+
+// parseAnalyticsRange parses the from/to query parameters, defaulting to the last 30 days
+func parseAnalyticsRange(ctx *fiber.Ctx) (time.Time, time.Time, error) {
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if v := ctx.Query("to"); v != "" {
+		parsed, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(defaultAnalyticsDays - 1))
+	if v := ctx.Query("from"); v != "" {
+		parsed, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		from = parsed
+	}
+
+	return from, to, nil
+}
+
+// analyticsErrorResponse maps analytics service errors to HTTP responses
+func analyticsErrorResponse(ctx *fiber.Ctx, err error) error {
+	if errors.Is(err, service.ErrInvalidDateRange) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "The range must be ordered and span at most a year",
+		})
+	}
+
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": "Failed to load analytics",
+	})
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// APIKeyController handles API key requests of the signed-in user
+type APIKeyController struct {
+	apiKeyService service.APIKeyService
+}
+
+// NewAPIKeyController creates a new APIKeyController
+func NewAPIKeyController(apiKeyService service.APIKeyService) *APIKeyController {
+	return &APIKeyController{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// CreateAPIKey handles create API key requests; the response is the only time the key is shown
+func (c *APIKeyController) CreateAPIKey(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(string)
+
+	var keyReq model.APIKeyCreate
+	if err := bindAndValidate(ctx, &keyReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	key, err := c.apiKeyService.Create(ctx.Context(), userID, &keyReq)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAPIKeyTier) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Unknown API key tier",
+			})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create API key",
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(key)
+}
+
+// ListAPIKeys handles list API keys requests
+func (c *APIKeyController) ListAPIKeys(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(string)
+
+	keys, err := c.apiKeyService.List(ctx.Context(), userID)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list API keys",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"api_keys": keys,
+	})
+}
+
+// RevokeAPIKey handles revoke API key requests
+func (c *APIKeyController) RevokeAPIKey(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(string)
+
+	if err := c.apiKeyService.Revoke(ctx.Context(), userID, ctx.Params("id")); err != nil {
+		return apiKeyErrorResponse(ctx, err, "Failed to revoke API key")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"message": "API key revoked successfully",
+	})
+}
+
+// GetAPIKeyUsage handles API key usage requests
+func (c *APIKeyController) GetAPIKeyUsage(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(string)
+
+	usage, err := c.apiKeyService.Usage(ctx.Context(), userID, ctx.Params("id"))
+	if err != nil {
+		return apiKeyErrorResponse(ctx, err, "Failed to get API key usage")
+	}
+
+	return ctx.JSON(usage)
+}
+
+// apiKeyErrorResponse maps API key service errors to HTTP responses
+func apiKeyErrorResponse(ctx *fiber.Ctx, err error, message string) error {
+	if errors.Is(err, service.ErrAPIKeyNotFound) {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "API key not found",
+		})
+	}
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": message,
+	})
+}
@@ -1,8 +1,10 @@
 package controller
 
 import (
+	"errors"
 	"strconv"
 
+	"github.com/budhilaw/personal-website-backend/internal/middleware"
 	"github.com/budhilaw/personal-website-backend/internal/model"
 	"github.com/budhilaw/personal-website-backend/internal/service"
 	"github.com/gofiber/fiber/v2"
@@ -10,13 +12,17 @@ import (
 
 // ArticleController handles article-related requests
 type ArticleController struct {
-	articleService service.ArticleService
+	articleService     service.ArticleService
+	translationService service.TranslationService
+	analyticsService   service.AnalyticsService
 }
 
 // NewArticleController creates a new ArticleController
-func NewArticleController(articleService service.ArticleService) *ArticleController {
+func NewArticleController(articleService service.ArticleService, translationService service.TranslationService, analyticsService service.AnalyticsService) *ArticleController {
 	return &ArticleController{
-		articleService: articleService,
+		articleService:     articleService,
+		translationService: translationService,
+		analyticsService:   analyticsService,
 	}
 }
 
@@ -25,24 +31,20 @@ func (c *ArticleController) CreateArticle(ctx *fiber.Ctx) error {
 	userID := ctx.Locals("user_id").(string)
 
 	var articleReq model.ArticleCreate
-	if err := ctx.BodyParser(&articleReq); err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	if err := bindAndValidate(ctx, &articleReq); err != nil {
+		return validationErrorResponse(ctx, err)
 	}
 
-	// Validate request
-	if articleReq.Title == "" || articleReq.Content == "" {
+	if err := validateSEO(articleReq.SEOMeta); err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Title and content are required",
+			"error": err.Error(),
 		})
 	}
 
-	id, err := c.articleService.Create(ctx.Context(), &articleReq, userID)
+	role, _ := ctx.Locals("role").(string)
+	id, err := c.articleService.Create(ctx.Context(), &articleReq, userID, role)
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to create article",
-		})
+		return articleWorkflowErrorResponse(ctx, err, "Failed to create article")
 	}
 
 	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
@@ -51,28 +53,40 @@ func (c *ArticleController) CreateArticle(ctx *fiber.Ctx) error {
 	})
 }
 
+// DuplicateArticle handles requests copying an article into a new draft
+func (c *ArticleController) DuplicateArticle(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(string)
+
+	id, err := c.articleService.Duplicate(ctx.Context(), ctx.Params("id"), userID)
+	if err != nil {
+		return articleWorkflowErrorResponse(ctx, err, "Failed to duplicate article")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id":      id,
+		"message": "Article duplicated successfully",
+	})
+}
+
 // UpdateArticle handles update article requests
 func (c *ArticleController) UpdateArticle(ctx *fiber.Ctx) error {
 	id := ctx.Params("id")
 
 	var articleReq model.ArticleUpdate
-	if err := ctx.BodyParser(&articleReq); err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	if err := bindAndValidate(ctx, &articleReq); err != nil {
+		return validationErrorResponse(ctx, err)
 	}
 
-	// Validate request
-	if articleReq.Title == "" || articleReq.Content == "" {
+	if err := validateSEO(articleReq.SEOMeta); err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Title and content are required",
+			"error": err.Error(),
 		})
 	}
 
-	if err := c.articleService.Update(ctx.Context(), id, &articleReq); err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to update article",
-		})
+	actorID := ctx.Locals("user_id").(string)
+	actorRole, _ := ctx.Locals("role").(string)
+	if err := c.articleService.Update(ctx.Context(), id, &articleReq, actorID, actorRole); err != nil {
+		return articleWorkflowErrorResponse(ctx, err, "Failed to update article")
 	}
 
 	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -80,6 +94,128 @@ func (c *ArticleController) UpdateArticle(ctx *fiber.Ctx) error {
 	})
 }
 
+// TransitionArticle handles article workflow status change requests
+func (c *ArticleController) TransitionArticle(ctx *fiber.Ctx) error {
+	var transitionReq model.ArticleTransition
+	if err := bindAndValidate(ctx, &transitionReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	actorID := ctx.Locals("user_id").(string)
+	actorRole, _ := ctx.Locals("role").(string)
+	article, err := c.articleService.Transition(ctx.Context(), ctx.Params("id"), &transitionReq, actorID, actorRole)
+	if err != nil {
+		return articleWorkflowErrorResponse(ctx, err, "Failed to change article status")
+	}
+
+	return ctx.JSON(article)
+}
+
+// SetArticleFeatured handles requests pinning an article to or unpinning it from the featured list
+func (c *ArticleController) SetArticleFeatured(ctx *fiber.Ctx) error {
+	var featureReq model.ArticleFeature
+	if err := bindAndValidate(ctx, &featureReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	article, err := c.articleService.SetFeatured(ctx.Context(), ctx.Params("id"), featureReq.IsFeatured)
+	if err != nil {
+		return articleWorkflowErrorResponse(ctx, err, "Failed to update featured article")
+	}
+
+	return ctx.JSON(article)
+}
+
+// SetArticlePassword handles requests protecting an article with a password
+func (c *ArticleController) SetArticlePassword(ctx *fiber.Ctx) error {
+	var passwordReq model.ArticlePassword
+	if err := bindAndValidate(ctx, &passwordReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	article, err := c.articleService.SetPassword(ctx.Context(), ctx.Params("id"), passwordReq.Password)
+	if err != nil {
+		return articleWorkflowErrorResponse(ctx, err, "Failed to set article password")
+	}
+
+	return ctx.JSON(article)
+}
+
+// RemoveArticlePassword handles requests making a password-protected article public again
+func (c *ArticleController) RemoveArticlePassword(ctx *fiber.Ctx) error {
+	article, err := c.articleService.SetPassword(ctx.Context(), ctx.Params("id"), "")
+	if err != nil {
+		return articleWorkflowErrorResponse(ctx, err, "Failed to remove article password")
+	}
+
+	return ctx.JSON(article)
+}
+
+// UnlockArticle handles requests exchanging an article's password for an access token
+func (c *ArticleController) UnlockArticle(ctx *fiber.Ctx) error {
+	var unlockReq model.ArticleUnlock
+	if err := bindAndValidate(ctx, &unlockReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	access, err := c.articleService.Unlock(ctx.Context(), ctx.Params("slug"), unlockReq.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrContentNotFound):
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Article not found",
+			})
+		case errors.Is(err, service.ErrArticleNotProtected):
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Article is not password protected",
+			})
+		case errors.Is(err, service.ErrWrongArticlePassword):
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Wrong password",
+			})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to unlock article",
+		})
+	}
+
+	return ctx.JSON(access)
+}
+
+// articleWorkflowErrorResponse maps article status errors to HTTP responses
+func articleWorkflowErrorResponse(ctx *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrContentNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Article not found",
+		})
+	case errors.Is(err, service.ErrInvalidTransition):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Article can't move to this status from its current one",
+		})
+	case errors.Is(err, service.ErrTransitionNotAllowed):
+		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "You are not allowed to move this article to this status",
+		})
+	case errors.Is(err, service.ErrInvalidSchedule):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "scheduled_at must be in the future",
+		})
+	case errors.Is(err, service.ErrArticleNotPublished):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Only published articles can be featured",
+		})
+	case errors.Is(err, service.ErrTooManyFeatured):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Maximum number of featured articles reached, unfeature one first",
+		})
+	}
+
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
+
 // DeleteArticle handles delete article requests
 func (c *ArticleController) DeleteArticle(ctx *fiber.Ctx) error {
 	id := ctx.Params("id")
@@ -95,11 +231,26 @@ func (c *ArticleController) DeleteArticle(ctx *fiber.Ctx) error {
 	})
 }
 
-// GetArticle handles get article by ID requests
+// GetArticle handles get article by ID requests; unpublished articles are only shared through preview links
 func (c *ArticleController) GetArticle(ctx *fiber.Ctx) error {
 	id := ctx.Params("id")
 
 	article, err := c.articleService.GetArticleWithAuthor(ctx.Context(), id)
+	if err != nil || !article.IsPublished {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Article not found",
+		})
+	}
+
+	c.localize(ctx, article)
+	c.protect(ctx, article)
+
+	return ctx.JSON(article)
+}
+
+// GetAdminArticle handles get article by ID requests from the admin, whatever its status
+func (c *ArticleController) GetAdminArticle(ctx *fiber.Ctx) error {
+	article, err := c.articleService.GetArticleWithAuthor(ctx.Context(), ctx.Params("id"))
 	if err != nil {
 		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error": "Article not found",
@@ -109,7 +260,7 @@ func (c *ArticleController) GetArticle(ctx *fiber.Ctx) error {
 	return ctx.JSON(article)
 }
 
-// GetArticleBySlug handles get article by slug requests
+// GetArticleBySlug handles get article by slug requests; unpublished articles are only shared through preview links
 func (c *ArticleController) GetArticleBySlug(ctx *fiber.Ctx) error {
 	slug := ctx.Params("slug")
 	if slug == "" {
@@ -119,12 +270,15 @@ func (c *ArticleController) GetArticleBySlug(ctx *fiber.Ctx) error {
 	}
 
 	article, err := c.articleService.GetBySlugWithAuthor(ctx.Context(), slug)
-	if err != nil {
+	if err != nil || !article.IsPublished {
 		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error": "Article not found",
 		})
 	}
 
+	c.localize(ctx, article)
+	c.protect(ctx, article)
+
 	return ctx.JSON(article)
 }
 
@@ -141,15 +295,220 @@ func (c *ArticleController) ListArticles(ctx *fiber.Ctx) error {
 		perPage = 10
 	}
 
+	opts, err := parseListOptions(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid published_after date",
+		})
+	}
+
+	// ?fields= returns only the listed fields, without authors or rendered content
+	if fields := parseFields(ctx); fields != nil {
+		return c.listArticleFields(ctx, fields, page, perPage, opts)
+	}
+
+	// ?after= switches to cursor pagination; an empty value requests the first page
+	if ctx.Context().QueryArgs().Has("after") {
+		return c.listArticlesAfter(ctx, ctx.Query("after"), perPage, opts)
+	}
+
 	// Only list published articles for public
-	articles, total, err := c.articleService.List(ctx.Context(), page, perPage, true)
+	articles, total, err := c.articleService.List(ctx.Context(), page, perPage, true, opts)
+	if err != nil {
+		return listErrorResponse(ctx, err, "Failed to list articles")
+	}
+
+	// Count each search by a person once, not every page of its results
+	if opts.Search != "" && page == 1 && ctx.Get("DNT") != "1" && !middleware.IsBot(ctx) {
+		c.analyticsService.RecordSearch(ctx.Context(), opts.Search, total)
+	}
+
+	return ctx.JSON(model.ArticleList{
+		Articles: c.toPublicResponses(ctx, articles),
+		Total:    total,
+		Page:     page,
+		PerPage:  perPage,
+	})
+}
+
+// Homepage widget limits
+const (
+	defaultWidgetLimit = 5
+	maxWidgetLimit     = 20
+	defaultPopularDays = 30
+	maxPopularDays     = 365
+)
+
+// ListPopularArticles handles requests for the most viewed articles
+func (c *ArticleController) ListPopularArticles(ctx *fiber.Ctx) error {
+	limit, err := strconv.Atoi(ctx.Query("limit", strconv.Itoa(defaultWidgetLimit)))
+	if err != nil || limit < 1 || limit > maxWidgetLimit {
+		limit = defaultWidgetLimit
+	}
+
+	days, err := strconv.Atoi(ctx.Query("days", strconv.Itoa(defaultPopularDays)))
+	if err != nil || days < 1 || days > maxPopularDays {
+		days = defaultPopularDays
+	}
+
+	articles, err := c.articleService.ListPopular(ctx.Context(), days, limit)
 	if err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to list articles",
+			"error": "Failed to list popular articles",
 		})
 	}
 
-	// Convert to response
+	return ctx.JSON(fiber.Map{
+		"articles": c.toPublicResponses(ctx, articles),
+	})
+}
+
+// ListRecentlyUpdatedArticles handles requests for the latest edited articles
+func (c *ArticleController) ListRecentlyUpdatedArticles(ctx *fiber.Ctx) error {
+	limit, err := strconv.Atoi(ctx.Query("limit", strconv.Itoa(defaultWidgetLimit)))
+	if err != nil || limit < 1 || limit > maxWidgetLimit {
+		limit = defaultWidgetLimit
+	}
+
+	articles, err := c.articleService.ListRecentlyUpdated(ctx.Context(), limit)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list recently updated articles",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"articles": c.toPublicResponses(ctx, articles),
+	})
+}
+
+// ListFeaturedArticles handles requests for the featured articles
+func (c *ArticleController) ListFeaturedArticles(ctx *fiber.Ctx) error {
+	articles, err := c.articleService.ListFeatured(ctx.Context())
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list featured articles",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"articles": c.toPublicResponses(ctx, articles),
+	})
+}
+
+// GetArchive handles article archive requests, counting published articles per month
+func (c *ArticleController) GetArchive(ctx *fiber.Ctx) error {
+	months, err := c.articleService.Archive(ctx.Context())
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to load archive",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"months": months,
+	})
+}
+
+// GetArchiveMonth handles requests for the articles published in a month
+func (c *ArticleController) GetArchiveMonth(ctx *fiber.Ctx) error {
+	year, yearErr := strconv.Atoi(ctx.Params("year"))
+	month, monthErr := strconv.Atoi(ctx.Params("month"))
+	if yearErr != nil || monthErr != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Year and month must be numbers",
+		})
+	}
+
+	articles, err := c.articleService.ListByMonth(ctx.Context(), year, month)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidArchiveMonth) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid year or month",
+			})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to load archive",
+		})
+	}
+
+	return ctx.JSON(model.ArticleArchive{
+		Year:     year,
+		Month:    month,
+		Articles: c.toPublicResponses(ctx, articles),
+	})
+}
+
+// listArticlesAfter lists published articles with cursor pagination
+func (c *ArticleController) listArticlesAfter(ctx *fiber.Ctx, cursor string, perPage int, opts model.ListOptions) error {
+	articles, next, err := c.articleService.ListAfter(ctx.Context(), cursor, perPage, true, opts)
+	if err != nil {
+		return listErrorResponse(ctx, err, "Failed to list articles")
+	}
+
+	return ctx.JSON(model.ArticleCursorList{
+		Articles:   c.toPublicResponses(ctx, articles),
+		PerPage:    perPage,
+		NextCursor: next,
+	})
+}
+
+// listArticleFields lists published articles with a sparse fieldset, paginated like ListArticles
+func (c *ArticleController) listArticleFields(ctx *fiber.Ctx, fields []string, page, perPage int, opts model.ListOptions) error {
+	if ctx.Context().QueryArgs().Has("after") {
+		articles, next, err := c.articleService.ListFieldsAfter(ctx.Context(), fields, ctx.Query("after"), perPage, true, opts)
+		if err != nil {
+			return listErrorResponse(ctx, err, "Failed to list articles")
+		}
+
+		return ctx.JSON(model.ArticlePartialCursorList{
+			Articles:   c.localizeFields(ctx, articles),
+			PerPage:    perPage,
+			NextCursor: next,
+		})
+	}
+
+	articles, total, err := c.articleService.ListFields(ctx.Context(), fields, page, perPage, true, opts)
+	if err != nil {
+		return listErrorResponse(ctx, err, "Failed to list articles")
+	}
+
+	// Count each search by a person once, not every page of its results
+	if opts.Search != "" && page == 1 && ctx.Get("DNT") != "1" && !middleware.IsBot(ctx) {
+		c.analyticsService.RecordSearch(ctx.Context(), opts.Search, total)
+	}
+
+	return ctx.JSON(model.ArticlePartialList{
+		Articles: c.localizeFields(ctx, articles),
+		Total:    total,
+		Page:     page,
+		PerPage:  perPage,
+	})
+}
+
+// protect leaves out a password-protected article's content unless the request carries an
+// access token for it in X-Article-Token; the admin API reads articles through GetAdminArticle
+// instead. Protected articles are kept out of shared caches, which would serve one reader's
+// unlocked copy to everyone.
+func (c *ArticleController) protect(ctx *fiber.Ctx, article *model.ArticleResponse) {
+	if !article.IsProtected {
+		return
+	}
+
+	ctx.Set(fiber.HeaderCacheControl, "private, no-store")
+	c.articleService.Redact(article, ctx.Get(middleware.ArticleTokenHeader))
+}
+
+// localizeFields applies the negotiated locale to sparse articles
+func (c *ArticleController) localizeFields(ctx *fiber.Ctx, articles []model.PartialItem) []model.PartialItem {
+	if locale, ok := ctx.Locals("locale").(string); ok {
+		c.translationService.LocalizeArticleFields(ctx.Context(), articles, locale)
+	}
+	return articles
+}
+
+// toPublicResponses loads authors and applies the negotiated locale
+func (c *ArticleController) toPublicResponses(ctx *fiber.Ctx, articles []model.Article) []model.ArticleResponse {
 	var responseArticles []model.ArticleResponse
 	for _, article := range articles {
 		articleResp, err := c.articleService.GetArticleWithAuthor(ctx.Context(), article.ID)
@@ -159,12 +518,15 @@ func (c *ArticleController) ListArticles(ctx *fiber.Ctx) error {
 		responseArticles = append(responseArticles, *articleResp)
 	}
 
-	return ctx.JSON(model.ArticleList{
-		Articles: responseArticles,
-		Total:    total,
-		Page:     page,
-		PerPage:  perPage,
-	})
+	// Translations for the whole page are loaded at once
+	if locale, ok := ctx.Locals("locale").(string); ok {
+		c.translationService.LocalizeArticles(ctx.Context(), responseArticles, locale)
+		ctx.Set(fiber.HeaderContentLanguage, locale)
+	}
+	for i := range responseArticles {
+		c.articleService.Redact(&responseArticles[i], "")
+	}
+	return responseArticles
 }
 
 // ListAdminArticles handles list articles for admin
@@ -182,41 +544,25 @@ func (c *ArticleController) ListAdminArticles(ctx *fiber.Ctx) error {
 		perPage = 10
 	}
 
-	// Get parameter
-	onlyMine := ctx.Query("only_mine", "false") == "true"
-	if onlyMine {
-		// List only user's articles
-		articles, total, err := c.articleService.GetByAuthor(ctx.Context(), userID, page, perPage)
-		if err != nil {
-			return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to list articles",
-			})
-		}
-
-		// Convert to response
-		var responseArticles []model.ArticleResponse
-		for _, article := range articles {
-			articleResp, err := c.articleService.GetArticleWithAuthor(ctx.Context(), article.ID)
-			if err != nil {
-				continue
-			}
-			responseArticles = append(responseArticles, *articleResp)
-		}
-
-		return ctx.JSON(model.ArticleList{
-			Articles: responseArticles,
-			Total:    total,
-			Page:     page,
-			PerPage:  perPage,
+	opts, err := parseListOptions(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid published_after date",
 		})
 	}
 
+	// List only user's articles
+	if ctx.Query("only_mine", "false") == "true" {
+		opts.AuthorID = userID
+	}
+
+	// Filter by workflow status, e.g. status=in_review for the review queue
+	opts.Status = ctx.Query("status")
+
 	// List all articles for admin
-	articles, total, err := c.articleService.List(ctx.Context(), page, perPage, false)
+	articles, total, err := c.articleService.List(ctx.Context(), page, perPage, false, opts)
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to list articles",
-		})
+		return listErrorResponse(ctx, err, "Failed to list articles")
 	}
 
 	// Convert to response
@@ -236,3 +582,14 @@ func (c *ArticleController) ListAdminArticles(ctx *fiber.Ctx) error {
 		PerPage:  perPage,
 	})
 }
+
+// localize applies the locale negotiated on public routes
+func (c *ArticleController) localize(ctx *fiber.Ctx, article *model.ArticleResponse) {
+	locale, ok := ctx.Locals("locale").(string)
+	if !ok {
+		return
+	}
+
+	c.translationService.LocalizeArticle(ctx.Context(), article, locale)
+	ctx.Set(fiber.HeaderContentLanguage, locale)
+}
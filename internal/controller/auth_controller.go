@@ -1,6 +1,10 @@
 package controller
 
 import (
+	"errors"
+	"html/template"
+	"strconv"
+
 	"github.com/budhilaw/personal-website-backend/config"
 	"github.com/budhilaw/personal-website-backend/internal/model"
 	"github.com/budhilaw/personal-website-backend/internal/service"
@@ -25,22 +29,18 @@ func NewAuthController(authService service.AuthService, cfg config.Config) *Auth
 func (c *AuthController) Login(ctx *fiber.Ctx) error {
 	var loginReq model.UserLogin
 
-	if err := ctx.BodyParser(&loginReq); err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
-	}
-
-	// Validate request
-	if loginReq.Username == "" || loginReq.Password == "" {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Username and password are required",
-		})
+	if err := bindAndValidate(ctx, &loginReq); err != nil {
+		return validationErrorResponse(ctx, err)
 	}
 
-	// Login - pass the Fiber context for IP and user agent tracking
-	resp, err := c.authService.Login(ctx.Context(), loginReq.Username, loginReq.Password, ctx)
+	// Login - pass the IP and user agent for tracking
+	resp, err := c.authService.Login(ctx.Context(), loginReq.Username, loginReq.Password, ctx.IP(), ctx.Get("User-Agent"))
 	if err != nil {
+		if errors.Is(err, service.ErrDeviceConfirmationRequired) {
+			return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "New device detected, check your email to approve it and sign in again",
+			})
+		}
 		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"error": "Invalid credentials",
 		})
@@ -49,6 +49,54 @@ func (c *AuthController) Login(ctx *fiber.Ctx) error {
 	return ctx.JSON(resp)
 }
 
+// deviceConfirmationPage asks the user to approve a new device. Opening the emailed link only
+// shows it, so link scanners and previews that fetch the URL can't approve the device.
+var deviceConfirmationPage = template.Must(template.New("confirm-device").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1"><title>Approve new device</title></head>
+<body>
+{{if .Confirmed}}<p>Device confirmed, you can sign in now.</p>
+{{else if .Device}}<h1>Approve new device</h1>
+<p>A sign-in was attempted from {{.Device.UserAgent}}{{with .Device.Location}} in {{.}}{{end}} ({{.Device.LastIP}}).</p>
+<form method="post"><button type="submit">Approve this device</button></form>
+<p>If this wasn't you, ignore this page and change your password.</p>
+{{else}}<p>Invalid or expired confirmation link.</p>
+{{end}}</body>
+</html>
+`))
+
+// deviceConfirmationData is what deviceConfirmationPage renders
+type deviceConfirmationData struct {
+	Device    *model.LoginDevice
+	Confirmed bool
+}
+
+// renderDeviceConfirmation renders deviceConfirmationPage with the given status
+func renderDeviceConfirmation(ctx *fiber.Ctx, status int, data deviceConfirmationData) error {
+	ctx.Type("html")
+	ctx.Status(status)
+	return deviceConfirmationPage.Execute(ctx, data)
+}
+
+// GetDeviceConfirmation shows the device behind a confirmation link with a form to approve it
+func (c *AuthController) GetDeviceConfirmation(ctx *fiber.Ctx) error {
+	device, err := c.authService.GetDeviceConfirmation(ctx.Context(), ctx.Params("token"))
+	if err != nil {
+		return renderDeviceConfirmation(ctx, fiber.StatusBadRequest, deviceConfirmationData{})
+	}
+
+	return renderDeviceConfirmation(ctx, fiber.StatusOK, deviceConfirmationData{Device: device})
+}
+
+// ConfirmDevice approves the device behind a confirmation link
+func (c *AuthController) ConfirmDevice(ctx *fiber.Ctx) error {
+	if err := c.authService.ConfirmDevice(ctx.Context(), ctx.Params("token")); err != nil {
+		return renderDeviceConfirmation(ctx, fiber.StatusBadRequest, deviceConfirmationData{})
+	}
+
+	return renderDeviceConfirmation(ctx, fiber.StatusOK, deviceConfirmationData{Confirmed: true})
+}
+
 // GetProfile handles get profile requests
 func (c *AuthController) GetProfile(ctx *fiber.Ctx) error {
 	userID := ctx.Locals("user_id").(string)
@@ -63,24 +111,45 @@ func (c *AuthController) GetProfile(ctx *fiber.Ctx) error {
 	return ctx.JSON(profile)
 }
 
-// UpdateProfile handles update profile requests
-func (c *AuthController) UpdateProfile(ctx *fiber.Ctx) error {
+// ListLogins handles login history requests for the current user
+func (c *AuthController) ListLogins(ctx *fiber.Ctx) error {
 	userID := ctx.Locals("user_id").(string)
 
-	var profileReq model.ProfileUpdate
-	if err := ctx.BodyParser(&profileReq); err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	// Parse query parameters
+	page, err := strconv.Atoi(ctx.Query("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
 	}
 
-	// Validate request
-	if profileReq.FirstName == "" || profileReq.Email == "" {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "First name and email are required",
+	perPage, err := strconv.Atoi(ctx.Query("per_page", "10"))
+	if err != nil || perPage < 1 || perPage > 100 {
+		perPage = 10
+	}
+
+	logins, total, err := c.authService.ListLogins(ctx.Context(), userID, page, perPage)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list login history",
 		})
 	}
 
+	return ctx.JSON(model.LoginEventList{
+		Logins:  logins,
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+	})
+}
+
+// UpdateProfile handles update profile requests
+func (c *AuthController) UpdateProfile(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(string)
+
+	var profileReq model.ProfileUpdate
+	if err := bindAndValidate(ctx, &profileReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
 	if err := c.authService.UpdateProfile(ctx.Context(), userID, &profileReq); err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to update profile",
@@ -121,21 +190,12 @@ func (c *AuthController) UpdatePassword(ctx *fiber.Ctx) error {
 
 	// Parse request
 	var req struct {
-		CurrentPassword string `json:"current_password"`
-		NewPassword     string `json:"new_password"`
+		CurrentPassword string `json:"current_password" validate:"required"`
+		NewPassword     string `json:"new_password" validate:"required"`
 	}
 
-	if err := ctx.BodyParser(&req); err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
-	}
-
-	// Validate request
-	if req.CurrentPassword == "" || req.NewPassword == "" {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Current password and new password are required",
-		})
+	if err := bindAndValidate(ctx, &req); err != nil {
+		return validationErrorResponse(ctx, err)
 	}
 
 	if err := c.authService.UpdatePassword(ctx.Context(), userID, req.CurrentPassword, req.NewPassword); err != nil {
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// BackupController handles database backup requests
+type BackupController struct {
+	backupService service.BackupService
+}
+
+// NewBackupController creates a new BackupController
+func NewBackupController(backupService service.BackupService) *BackupController {
+	return &BackupController{
+		backupService: backupService,
+	}
+}
+
+// CreateBackup handles create backup requests. The backup runs as a background job and is
+// listed under the returned name once it is done.
+func (c *BackupController) CreateBackup(ctx *fiber.Ctx) error {
+	name, err := c.backupService.Enqueue(ctx.Context())
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to queue backup",
+		})
+	}
+
+	return ctx.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"name": name,
+	})
+}
+
+// ListBackups handles list backups requests
+func (c *BackupController) ListBackups(ctx *fiber.Ctx) error {
+	backups, err := c.backupService.List(ctx.Context())
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list backups",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"backups": backups,
+	})
+}
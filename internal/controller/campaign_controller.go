@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// trackingPixel is a transparent 1x1 GIF
+var trackingPixel = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// CampaignController handles newsletter campaign requests
+type CampaignController struct {
+	campaignService service.CampaignService
+}
+
+// NewCampaignController creates a new CampaignController
+func NewCampaignController(campaignService service.CampaignService) *CampaignController {
+	return &CampaignController{
+		campaignService: campaignService,
+	}
+}
+
+// CreateCampaign handles create campaign requests
+func (c *CampaignController) CreateCampaign(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(string)
+
+	var campaignReq model.CampaignCreate
+	if err := bindAndValidate(ctx, &campaignReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	id, err := c.campaignService.Create(ctx.Context(), &campaignReq, userID)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create campaign",
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id":      id,
+		"message": "Campaign created successfully",
+	})
+}
+
+// CreateCampaignFromArticle handles drafting a campaign from a published article
+func (c *CampaignController) CreateCampaignFromArticle(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(string)
+
+	id, err := c.campaignService.CreateFromArticle(ctx.Context(), ctx.Params("id"), userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrContentNotFound):
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Article not found",
+			})
+		case errors.Is(err, service.ErrArticleNotPublished):
+			return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Article is not published",
+			})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create campaign",
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id":      id,
+		"message": "Campaign created successfully",
+	})
+}
+
+// UpdateCampaign handles update campaign requests
+func (c *CampaignController) UpdateCampaign(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	var campaignReq model.CampaignUpdate
+	if err := bindAndValidate(ctx, &campaignReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.campaignService.Update(ctx.Context(), id, &campaignReq); err != nil {
+		return campaignErrorResponse(ctx, err, "Failed to update campaign")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Campaign updated successfully",
+	})
+}
+
+// DeleteCampaign handles delete campaign requests
+func (c *CampaignController) DeleteCampaign(ctx *fiber.Ctx) error {
+	if err := c.campaignService.Delete(ctx.Context(), ctx.Params("id")); err != nil {
+		return campaignErrorResponse(ctx, err, "Failed to delete campaign")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Campaign deleted successfully",
+	})
+}
+
+// GetCampaign handles get campaign by ID requests
+func (c *CampaignController) GetCampaign(ctx *fiber.Ctx) error {
+	campaign, err := c.campaignService.GetByID(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return campaignErrorResponse(ctx, err, "Failed to get campaign")
+	}
+
+	return ctx.JSON(campaign)
+}
+
+// ListCampaigns handles list campaigns requests
+func (c *CampaignController) ListCampaigns(ctx *fiber.Ctx) error {
+	// Parse query parameters
+	page, err := strconv.Atoi(ctx.Query("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(ctx.Query("per_page", "10"))
+	if err != nil || perPage < 1 {
+		perPage = 10
+	}
+
+	campaigns, err := c.campaignService.List(ctx.Context(), page, perPage)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list campaigns",
+		})
+	}
+
+	return ctx.JSON(campaigns)
+}
+
+// SendCampaign handles requests to send a draft campaign to confirmed subscribers
+func (c *CampaignController) SendCampaign(ctx *fiber.Ctx) error {
+	if err := c.campaignService.Send(ctx.Context(), ctx.Params("id")); err != nil {
+		return campaignErrorResponse(ctx, err, "Failed to send campaign")
+	}
+
+	return ctx.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message": "Campaign is being sent",
+	})
+}
+
+// TrackOpen serves a campaign's tracking pixel and records the open. The pixel is
+// served even when recording fails so mail clients never show a broken image.
+func (c *CampaignController) TrackOpen(ctx *fiber.Ctx) error {
+	_ = c.campaignService.RecordOpen(ctx.Context(), ctx.Params("token"))
+
+	ctx.Set(fiber.HeaderContentType, "image/gif")
+	return ctx.Send(trackingPixel)
+}
+
+// campaignErrorResponse maps campaign service errors to HTTP responses
+func campaignErrorResponse(ctx *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrCampaignNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Campaign not found",
+		})
+	case errors.Is(err, service.ErrCampaignNotDraft):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Campaign has already been sent",
+		})
+	case errors.Is(err, service.ErrEmailDisabled):
+		return ctx.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "Email is disabled",
+		})
+	}
+
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ContentAuditController handles article and portfolio change history requests
+type ContentAuditController struct {
+	auditService service.ContentAuditService
+}
+
+// NewContentAuditController creates a new ContentAuditController
+func NewContentAuditController(auditService service.ContentAuditService) *ContentAuditController {
+	return &ContentAuditController{
+		auditService: auditService,
+	}
+}
+
+// ListAuditEntries handles list audit entries requests, filtered by ?entity_type=, ?entity_id=,
+// ?actor_id= and ?field=, the name of a changed field
+func (c *ContentAuditController) ListAuditEntries(ctx *fiber.Ctx) error {
+	// Parse query parameters
+	page, err := strconv.Atoi(ctx.Query("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(ctx.Query("per_page", "20"))
+	if err != nil || perPage < 1 {
+		perPage = 20
+	}
+
+	filter := model.ContentAuditFilter{
+		EntityType: ctx.Query("entity_type"),
+		EntityID:   ctx.Query("entity_id"),
+		ActorID:    ctx.Query("actor_id"),
+		Field:      ctx.Query("field"),
+	}
+
+	entries, err := c.auditService.List(ctx.Context(), filter, page, perPage)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAuditEntityType) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "entity_type must be article or portfolio",
+			})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list audit entries",
+		})
+	}
+
+	return ctx.JSON(entries)
+}
+
+// GetAuditEntry handles get audit entry requests; the entry includes the before and after snapshots
+func (c *ContentAuditController) GetAuditEntry(ctx *fiber.Ctx) error {
+	entry, err := c.auditService.Get(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		if errors.Is(err, service.ErrAuditEntryNotFound) {
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Audit entry not found",
+			})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get audit entry",
+		})
+	}
+
+	return ctx.JSON(entry)
+}
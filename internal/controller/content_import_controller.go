@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ContentImportController handles Markdown content sync requests
+type ContentImportController struct {
+	contentImportService service.ContentImportService
+}
+
+// NewContentImportController creates a new ContentImportController
+func NewContentImportController(contentImportService service.ContentImportService) *ContentImportController {
+	return &ContentImportController{
+		contentImportService: contentImportService,
+	}
+}
+
+// Import handles manual content sync requests
+func (c *ContentImportController) Import(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(string)
+
+	result, err := c.contentImportService.Sync(ctx.Context(), userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrImportNotConfigured):
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Set CONTENT_IMPORT_DIR or CONTENT_IMPORT_GITHUB_REPO to enable content import",
+			})
+		case errors.Is(err, service.ErrImportInProgress), errors.Is(err, service.ErrEmptyContentSource):
+			return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		case errors.Is(err, service.ErrImportAuthorRequired):
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "CONTENT_IMPORT_AUTHOR does not match a user",
+			})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to import content",
+		})
+	}
+
+	return ctx.JSON(result)
+}
+
+// Webhook handles GitHub push webhooks for the content repository
+func (c *ContentImportController) Webhook(ctx *fiber.Ctx) error {
+	err := c.contentImportService.HandleWebhook(ctx.Context(), ctx.Get("X-GitHub-Event"), ctx.Get("X-Hub-Signature-256"), ctx.Body())
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidWebhookSignature) {
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Invalid signature",
+			})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to queue content import",
+		})
+	}
+
+	return ctx.SendStatus(fiber.StatusAccepted)
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// EmailController handles email delivery requests
+type EmailController struct {
+	emailService *service.EmailService
+}
+
+// NewEmailController creates a new EmailController
+func NewEmailController(emailService *service.EmailService) *EmailController {
+	return &EmailController{
+		emailService: emailService,
+	}
+}
+
+// ListEmails handles list email delivery records requests; without ?status= it lists failed and bounced emails
+func (c *EmailController) ListEmails(ctx *fiber.Ctx) error {
+	// Parse query parameters
+	page, err := strconv.Atoi(ctx.Query("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(ctx.Query("per_page", "10"))
+	if err != nil || perPage < 1 {
+		perPage = 10
+	}
+
+	messages, err := c.emailService.List(ctx.Context(), ctx.Query("status"), page, perPage)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidEmailStatus) {
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "status must be queued, sent, failed or bounced",
+			})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list emails",
+		})
+	}
+
+	return ctx.JSON(messages)
+}
+
+// Bounce handles bounce reports from the mail provider, authenticated by a shared secret
+func (c *EmailController) Bounce(ctx *fiber.Ctx) error {
+	var bounceReq model.EmailBounce
+	if err := bindAndValidate(ctx, &bounceReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.emailService.RecordBounce(ctx.Context(), ctx.Get("X-Webhook-Secret"), &bounceReq); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidWebhookSecret):
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Invalid webhook secret",
+			})
+		case errors.Is(err, service.ErrEmailNotFound):
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Email not found",
+			})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to record bounce",
+		})
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
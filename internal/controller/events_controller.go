@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/events"
+	"github.com/gofiber/fiber/v2"
+)
+
+// sseHeartbeat is how often an idle stream sends a comment so proxies keep it open
+const sseHeartbeat = 15 * time.Second
+
+// EventsController streams live events to the admin dashboard
+type EventsController struct {
+	hub *events.Hub
+}
+
+// NewEventsController creates a new EventsController
+func NewEventsController(hub *events.Hub) *EventsController {
+	return &EventsController{
+		hub: hub,
+	}
+}
+
+// Stream handles the admin Server-Sent Events stream. It runs until the client disconnects.
+func (c *EventsController) Stream(ctx *fiber.Ctx) error {
+	ctx.Set(fiber.HeaderContentType, "text/event-stream")
+	ctx.Set(fiber.HeaderCacheControl, "no-cache")
+	ctx.Set(fiber.HeaderConnection, "keep-alive")
+	// Stop nginx from buffering the stream
+	ctx.Set("X-Accel-Buffering", "no")
+
+	stream, unsubscribe := c.hub.Subscribe()
+
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		heartbeat := time.NewTicker(sseHeartbeat)
+		defer heartbeat.Stop()
+
+		// Ask EventSource clients to reconnect after 5s
+		fmt.Fprint(w, "retry: 5000\n\n")
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event := <-stream:
+				data, err := json.Marshal(event)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+			}
+
+			// Flushing fails once the client is gone
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+// FormController handles form token requests
+type FormController struct {
+	cfg config.Config
+}
+
+// NewFormController creates a new FormController
+func NewFormController(cfg config.Config) *FormController {
+	return &FormController{
+		cfg: cfg,
+	}
+}
+
+// GetToken handles form token requests; a form fetches one when it is shown and submits it
+// in the X-Form-Token header or a form_token field
+func (c *FormController) GetToken(ctx *fiber.Ctx) error {
+	return ctx.JSON(fiber.Map{
+		"token": middleware.NewFormToken(c.cfg),
+	})
+}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GitHubActivityController handles GitHub activity feed requests
+type GitHubActivityController struct {
+	githubActivityService service.GitHubActivityService
+}
+
+// NewGitHubActivityController creates a new GitHubActivityController
+func NewGitHubActivityController(githubActivityService service.GitHubActivityService) *GitHubActivityController {
+	return &GitHubActivityController{
+		githubActivityService: githubActivityService,
+	}
+}
+
+// GetActivity handles get GitHub activity requests
+func (c *GitHubActivityController) GetActivity(ctx *fiber.Ctx) error {
+	activity, err := c.githubActivityService.Get(ctx.Context())
+	if err != nil {
+		if errors.Is(err, service.ErrGitHubRateLimited) {
+			return ctx.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": "GitHub rate limit exceeded, try again later",
+			})
+		}
+		return ctx.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": "Failed to get GitHub activity",
+		})
+	}
+
+	return ctx.JSON(activity)
+}
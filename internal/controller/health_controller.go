@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// HealthController handles liveness and readiness probes
+type HealthController struct {
+	healthService service.HealthService
+}
+
+// NewHealthController creates a new HealthController
+func NewHealthController(healthService service.HealthService) *HealthController {
+	return &HealthController{
+		healthService: healthService,
+	}
+}
+
+// Live handles liveness probes; it answers as long as the process serves requests
+func (c *HealthController) Live(ctx *fiber.Ctx) error {
+	return ctx.JSON(fiber.Map{
+		"status": model.HealthStatusOK,
+	})
+}
+
+// Ready handles readiness probes with the status of every dependency. Only a failing
+// critical dependency returns 503, so a degraded API keeps receiving traffic.
+func (c *HealthController) Ready(ctx *fiber.Ctx) error {
+	readiness := c.healthService.Readiness(ctx.UserContext())
+
+	status := fiber.StatusOK
+	if readiness.Status == model.HealthStatusFailing {
+		status = fiber.StatusServiceUnavailable
+	}
+
+	ctx.Set(fiber.HeaderCacheControl, "no-store")
+	return ctx.Status(status).JSON(readiness)
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// JobController handles background job requests
+type JobController struct {
+	jobService service.JobService
+}
+
+// NewJobController creates a new JobController
+func NewJobController(jobService service.JobService) *JobController {
+	return &JobController{
+		jobService: jobService,
+	}
+}
+
+// ListDeadJobs handles list dead-letter jobs requests
+func (c *JobController) ListDeadJobs(ctx *fiber.Ctx) error {
+	jobs, err := c.jobService.ListDead(ctx.Context())
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list jobs",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"jobs": jobs,
+	})
+}
+
+// RetryJob handles retry dead job requests
+func (c *JobController) RetryJob(ctx *fiber.Ctx) error {
+	if err := c.jobService.Retry(ctx.Context(), ctx.Params("id")); err != nil {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Job not found",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"message": "Job queued for retry",
+	})
+}
+
+// DeleteJob handles delete dead job requests
+func (c *JobController) DeleteJob(ctx *fiber.Ctx) error {
+	if err := c.jobService.Delete(ctx.Context(), ctx.Params("id")); err != nil {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Job not found",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"message": "Job deleted successfully",
+	})
+}
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// LinkController handles shared link requests
+type LinkController struct {
+	linkService service.LinkService
+}
+
+// NewLinkController creates a new LinkController
+func NewLinkController(linkService service.LinkService) *LinkController {
+	return &LinkController{
+		linkService: linkService,
+	}
+}
+
+// CreateLink handles create link requests
+func (c *LinkController) CreateLink(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(string)
+
+	var linkReq model.LinkCreate
+	if err := bindAndValidate(ctx, &linkReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	id, err := c.linkService.Create(ctx.Context(), &linkReq, userID)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create link",
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id":      id,
+		"message": "Link created successfully",
+	})
+}
+
+// UpdateLink handles update link requests
+func (c *LinkController) UpdateLink(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	var linkReq model.LinkUpdate
+	if err := bindAndValidate(ctx, &linkReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.linkService.Update(ctx.Context(), id, &linkReq); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update link",
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Link updated successfully",
+	})
+}
+
+// DeleteLink handles delete link requests
+func (c *LinkController) DeleteLink(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if err := c.linkService.Delete(ctx.Context(), id); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete link",
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Link deleted successfully",
+	})
+}
+
+// GetLink handles get link by ID requests
+func (c *LinkController) GetLink(ctx *fiber.Ctx) error {
+	link, err := c.linkService.GetByID(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Link not found",
+		})
+	}
+
+	return ctx.JSON(link)
+}
+
+// ListLinks handles list published links requests
+func (c *LinkController) ListLinks(ctx *fiber.Ctx) error {
+	return c.listLinks(ctx, true)
+}
+
+// ListAdminLinks handles list links for admin, including drafts
+func (c *LinkController) ListAdminLinks(ctx *fiber.Ctx) error {
+	return c.listLinks(ctx, false)
+}
+
+// listLinks lists links with pagination, optionally filtered with ?tag=
+func (c *LinkController) listLinks(ctx *fiber.Ctx, onlyPublished bool) error {
+	// Parse query parameters
+	page, err := strconv.Atoi(ctx.Query("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(ctx.Query("per_page", "10"))
+	if err != nil || perPage < 1 {
+		perPage = 10
+	}
+
+	links, err := c.linkService.List(ctx.Context(), page, perPage, onlyPublished, ctx.Query("tag"))
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list links",
+		})
+	}
+
+	return ctx.JSON(links)
+}
+
+// GetLinkFeed handles RSS feed requests for the latest links
+func (c *LinkController) GetLinkFeed(ctx *fiber.Ctx) error {
+	feed, err := c.linkService.Feed(ctx.Context())
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to build links feed",
+		})
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/rss+xml; charset=utf-8")
+	return ctx.Send(feed)
+}
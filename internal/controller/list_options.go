@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseListOptions reads ?sort=, ?order=, ?published_after=, ?author= and ?q= from a list request
+func parseListOptions(ctx *fiber.Ctx) (model.ListOptions, error) {
+	opts := model.ListOptions{
+		Sort:   ctx.Query("sort"),
+		Order:  ctx.Query("order"),
+		Author: ctx.Query("author"),
+		Search: strings.TrimSpace(ctx.Query("q")),
+	}
+
+	if v := ctx.Query("published_after"); v != "" {
+		// Accept a plain date or a full timestamp
+		parsed, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			parsed, err = time.Parse(time.RFC3339, v)
+			if err != nil {
+				return opts, err
+			}
+		}
+		opts.PublishedAfter = parsed
+	}
+
+	return opts, nil
+}
+
+// parseFields reads a sparse fieldset such as ?fields=title,slug,excerpt; nil when absent
+func parseFields(ctx *fiber.Ctx) []string {
+	var fields []string
+	for _, field := range strings.Split(ctx.Query("fields"), ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// listErrorResponse maps list service errors to HTTP responses
+func listErrorResponse(ctx *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, util.ErrInvalidCursor):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid cursor",
+		})
+	case errors.Is(err, service.ErrInvalidSort):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid sort, expected sort=created_at|published_at|title|views and order=asc|desc",
+		})
+	case errors.Is(err, service.ErrInvalidFields):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrSortWithCursor):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Sorting is not supported with cursor pagination",
+		})
+	}
+
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": message,
+	})
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MediaController handles media library requests
+type MediaController struct {
+	mediaService service.MediaService
+}
+
+// NewMediaController creates a new MediaController
+func NewMediaController(mediaService service.MediaService) *MediaController {
+	return &MediaController{
+		mediaService: mediaService,
+	}
+}
+
+// ListMedia handles list media requests
+func (c *MediaController) ListMedia(ctx *fiber.Ctx) error {
+	// Parse query parameters
+	page, err := strconv.Atoi(ctx.Query("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(ctx.Query("per_page", "10"))
+	if err != nil || perPage < 1 {
+		perPage = 10
+	}
+
+	media, err := c.mediaService.List(ctx.Context(), page, perPage)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list media",
+		})
+	}
+
+	return ctx.JSON(media)
+}
+
+// PresignUpload handles presigned upload requests
+func (c *MediaController) PresignUpload(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(string)
+
+	var presignReq model.MediaPresignRequest
+	if err := bindAndValidate(ctx, &presignReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	presign, err := c.mediaService.Presign(ctx.Context(), &presignReq, userID)
+	if err != nil {
+		return mediaErrorResponse(ctx, err, "Failed to prepare upload")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(presign)
+}
+
+// ConfirmUpload handles upload confirmation requests
+func (c *MediaController) ConfirmUpload(ctx *fiber.Ctx) error {
+	media, err := c.mediaService.Confirm(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return mediaErrorResponse(ctx, err, "Failed to confirm upload")
+	}
+
+	return ctx.JSON(media)
+}
+
+// mediaErrorResponse maps media service errors to HTTP responses
+func mediaErrorResponse(ctx *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrMediaNotConfigured):
+		return ctx.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "Media storage is not configured",
+		})
+	case errors.Is(err, service.ErrMediaTypeNotAllowed), errors.Is(err, service.ErrMediaTooLarge):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrMediaNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Media not found",
+		})
+	case errors.Is(err, service.ErrMediaNotUploaded):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "File has not been uploaded yet",
+		})
+	}
+
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
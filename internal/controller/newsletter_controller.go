@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// NewsletterController handles newsletter-related requests
+type NewsletterController struct {
+	newsletterService service.NewsletterService
+}
+
+// NewNewsletterController creates a new NewsletterController
+func NewNewsletterController(newsletterService service.NewsletterService) *NewsletterController {
+	return &NewsletterController{
+		newsletterService: newsletterService,
+	}
+}
+
+// Subscribe handles newsletter subscription requests
+func (c *NewsletterController) Subscribe(ctx *fiber.Ctx) error {
+	var req model.SubscribeRequest
+	if err := bindAndValidate(ctx, &req); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.newsletterService.Subscribe(ctx.Context(), req.Email); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to subscribe",
+		})
+	}
+
+	return ctx.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"message": "Please check your inbox to confirm your subscription",
+	})
+}
+
+// Confirm handles subscription confirmation links
+func (c *NewsletterController) Confirm(ctx *fiber.Ctx) error {
+	if err := c.newsletterService.Confirm(ctx.Context(), ctx.Params("token")); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid or expired confirmation link",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"message": "Subscription confirmed",
+	})
+}
+
+// Unsubscribe handles unsubscribe links
+func (c *NewsletterController) Unsubscribe(ctx *fiber.Ctx) error {
+	if err := c.newsletterService.Unsubscribe(ctx.Context(), ctx.Params("token")); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid unsubscribe link",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"message": "You have been unsubscribed",
+	})
+}
+
+// ListSubscribers handles list subscribers for admin
+func (c *NewsletterController) ListSubscribers(ctx *fiber.Ctx) error {
+	// Parse query parameters
+	page, err := strconv.Atoi(ctx.Query("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(ctx.Query("per_page", "10"))
+	if err != nil || perPage < 1 {
+		perPage = 10
+	}
+
+	subscribers, total, err := c.newsletterService.List(ctx.Context(), page, perPage, ctx.Query("status"))
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list subscribers",
+		})
+	}
+
+	return ctx.JSON(model.SubscriberList{
+		Subscribers: subscribers,
+		Total:       total,
+		Page:        page,
+		PerPage:     perPage,
+	})
+}
+
+// ExportSubscribers handles CSV export of subscribers
+func (c *NewsletterController) ExportSubscribers(ctx *fiber.Ctx) error {
+	subscribers, err := c.newsletterService.ListAll(ctx.Context(), ctx.Query("status"))
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to export subscribers",
+		})
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	_ = writer.Write([]string{"email", "status", "subscribed_at", "confirmed_at", "unsubscribed_at"})
+	for _, subscriber := range subscribers {
+		_ = writer.Write([]string{
+			subscriber.Email,
+			subscriber.Status,
+			formatCSVTime(subscriber.CreatedAt),
+			formatCSVTime(subscriber.ConfirmedAt),
+			formatCSVTime(subscriber.UnsubscribedAt),
+		})
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to export subscribers",
+		})
+	}
+
+	ctx.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	ctx.Set(fiber.HeaderContentDisposition, `attachment; filename="subscribers.csv"`)
+	return ctx.Send(buf.Bytes())
+}
+
+// formatCSVTime formats a timestamp for CSV output, leaving zero times empty
+func formatCSVTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// NowPlayingController handles Spotify "now playing" requests
+type NowPlayingController struct {
+	nowPlayingService service.NowPlayingService
+}
+
+// NewNowPlayingController creates a new NowPlayingController
+func NewNowPlayingController(nowPlayingService service.NowPlayingService) *NowPlayingController {
+	return &NowPlayingController{
+		nowPlayingService: nowPlayingService,
+	}
+}
+
+// GetNowPlaying handles get currently playing track requests
+func (c *NowPlayingController) GetNowPlaying(ctx *fiber.Ctx) error {
+	nowPlaying, err := c.nowPlayingService.Get(ctx.Context())
+	if err != nil {
+		return ctx.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": "Failed to get now playing",
+		})
+	}
+
+	return ctx.JSON(nowPlaying)
+}
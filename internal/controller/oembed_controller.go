@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// OEmbedController handles oEmbed proxy requests
+type OEmbedController struct {
+	oEmbedService service.OEmbedService
+}
+
+// NewOEmbedController creates a new OEmbedController
+func NewOEmbedController(oEmbedService service.OEmbedService) *OEmbedController {
+	return &OEmbedController{
+		oEmbedService: oEmbedService,
+	}
+}
+
+// GetOEmbed handles resolve embed requests
+func (c *OEmbedController) GetOEmbed(ctx *fiber.Ctx) error {
+	target := ctx.Query("url")
+	if target == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "url is required",
+		})
+	}
+
+	embed, err := c.oEmbedService.Resolve(ctx.Context(), target)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrEmbedNotAllowed):
+			return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "URL is not from an allowed embed provider",
+			})
+		case errors.Is(err, service.ErrEmbedFailed):
+			return ctx.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+				"error": "Failed to fetch embed from provider",
+			})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to resolve embed",
+		})
+	}
+
+	return ctx.JSON(embed)
+}
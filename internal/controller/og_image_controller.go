@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// OGImageController handles Open Graph image requests
+type OGImageController struct {
+	ogImageService service.OGImageService
+}
+
+// NewOGImageController creates a new OGImageController
+func NewOGImageController(ogImageService service.OGImageService) *OGImageController {
+	return &OGImageController{
+		ogImageService: ogImageService,
+	}
+}
+
+// GetArticleCard handles social card image requests for articles
+func (c *OGImageController) GetArticleCard(ctx *fiber.Ctx) error {
+	data, err := c.ogImageService.ArticleCard(ctx.Context(), ctx.Params("slug"))
+	if err != nil {
+		if errors.Is(err, service.ErrOGImageNotFound) {
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Article not found",
+			})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to render image",
+		})
+	}
+
+	ctx.Set(fiber.HeaderContentType, "image/png")
+	ctx.Set(fiber.HeaderCacheControl, "public, max-age=86400")
+	return ctx.Send(data)
+}
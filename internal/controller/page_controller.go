@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// PageController handles page-related requests
+type PageController struct {
+	pageService        service.PageService
+	translationService service.TranslationService
+}
+
+// NewPageController creates a new PageController
+func NewPageController(pageService service.PageService, translationService service.TranslationService) *PageController {
+	return &PageController{
+		pageService:        pageService,
+		translationService: translationService,
+	}
+}
+
+// CreatePage handles create page requests
+func (c *PageController) CreatePage(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(string)
+
+	var pageReq model.PageCreate
+	if err := bindAndValidate(ctx, &pageReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	id, err := c.pageService.Create(ctx.Context(), &pageReq, userID)
+	if err != nil {
+		return pageErrorResponse(ctx, err, "Failed to create page")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id":      id,
+		"message": "Page created successfully",
+	})
+}
+
+// UpdatePage handles update page requests
+func (c *PageController) UpdatePage(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	var pageReq model.PageUpdate
+	if err := bindAndValidate(ctx, &pageReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.pageService.Update(ctx.Context(), id, &pageReq); err != nil {
+		return pageErrorResponse(ctx, err, "Failed to update page")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Page updated successfully",
+	})
+}
+
+// DeletePage handles delete page requests
+func (c *PageController) DeletePage(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if err := c.pageService.Delete(ctx.Context(), id); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete page",
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Page deleted successfully",
+	})
+}
+
+// GetPage handles get page by ID requests
+func (c *PageController) GetPage(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	page, err := c.pageService.GetByID(ctx.Context(), id)
+	if err != nil {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Page not found",
+		})
+	}
+
+	return ctx.JSON(page)
+}
+
+// GetPageBySlug handles get published page by slug requests
+func (c *PageController) GetPageBySlug(ctx *fiber.Ctx) error {
+	slug := ctx.Params("slug")
+	if slug == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Slug is required",
+		})
+	}
+
+	page, err := c.pageService.GetBySlug(ctx.Context(), slug)
+	if err != nil || !page.IsPublished {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Page not found",
+		})
+	}
+
+	if locale, ok := ctx.Locals("locale").(string); ok {
+		c.translationService.LocalizePage(ctx.Context(), page, locale)
+		ctx.Set(fiber.HeaderContentLanguage, locale)
+	}
+
+	return ctx.JSON(page)
+}
+
+// ListPages handles list pages for admin, including drafts
+func (c *PageController) ListPages(ctx *fiber.Ctx) error {
+	pages, err := c.pageService.List(ctx.Context(), false)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list pages",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"pages": pages,
+	})
+}
+
+// pageErrorResponse maps page service errors to HTTP responses
+func pageErrorResponse(ctx *fiber.Ctx, err error, fallback string) error {
+	if errors.Is(err, service.ErrPageSlugExists) {
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "A page with this slug already exists",
+		})
+	}
+
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
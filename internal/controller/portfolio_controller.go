@@ -25,16 +25,13 @@ func (c *PortfolioController) CreatePortfolio(ctx *fiber.Ctx) error {
 	userID := ctx.Locals("user_id").(string)
 
 	var portfolioReq model.PortfolioCreate
-	if err := ctx.BodyParser(&portfolioReq); err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	if err := bindAndValidate(ctx, &portfolioReq); err != nil {
+		return validationErrorResponse(ctx, err)
 	}
 
-	// Validate request
-	if portfolioReq.Title == "" || portfolioReq.Description == "" {
+	if err := validateSEO(portfolioReq.SEOMeta); err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Title and description are required",
+			"error": err.Error(),
 		})
 	}
 
@@ -56,16 +53,13 @@ func (c *PortfolioController) UpdatePortfolio(ctx *fiber.Ctx) error {
 	id := ctx.Params("id")
 
 	var portfolioReq model.PortfolioUpdate
-	if err := ctx.BodyParser(&portfolioReq); err != nil {
-		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	if err := bindAndValidate(ctx, &portfolioReq); err != nil {
+		return validationErrorResponse(ctx, err)
 	}
 
-	// Validate request
-	if portfolioReq.Title == "" || portfolioReq.Description == "" {
+	if err := validateSEO(portfolioReq.SEOMeta); err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Title and description are required",
+			"error": err.Error(),
 		})
 	}
 
@@ -141,15 +135,87 @@ func (c *PortfolioController) ListPortfolios(ctx *fiber.Ctx) error {
 		perPage = 10
 	}
 
+	opts, err := parseListOptions(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid published_after date",
+		})
+	}
+
+	filter := model.PortfolioFilter{
+		ListOptions: opts,
+		Tech:        ctx.Query("tech"),
+		Category:    ctx.Query("category"),
+	}
+
+	// ?fields= returns only the listed fields, without authors or rendered content
+	if fields := parseFields(ctx); fields != nil {
+		return c.listPortfolioFields(ctx, fields, page, perPage, filter)
+	}
+
+	// ?after= switches to cursor pagination; an empty value requests the first page
+	if ctx.Context().QueryArgs().Has("after") {
+		return c.listPortfoliosAfter(ctx, ctx.Query("after"), perPage, filter)
+	}
+
 	// Only list published portfolios for public
-	portfolios, total, err := c.portfolioService.List(ctx.Context(), page, perPage, true)
+	portfolios, total, err := c.portfolioService.List(ctx.Context(), page, perPage, true, filter)
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to list portfolios",
+		return listErrorResponse(ctx, err, "Failed to list portfolios")
+	}
+
+	return ctx.JSON(model.PortfolioList{
+		Portfolios: c.toResponses(ctx, portfolios),
+		Total:      total,
+		Page:       page,
+		PerPage:    perPage,
+	})
+}
+
+// listPortfoliosAfter lists published portfolios with cursor pagination
+func (c *PortfolioController) listPortfoliosAfter(ctx *fiber.Ctx, cursor string, perPage int, filter model.PortfolioFilter) error {
+	portfolios, next, err := c.portfolioService.ListAfter(ctx.Context(), cursor, perPage, true, filter)
+	if err != nil {
+		return listErrorResponse(ctx, err, "Failed to list portfolios")
+	}
+
+	return ctx.JSON(model.PortfolioCursorList{
+		Portfolios: c.toResponses(ctx, portfolios),
+		PerPage:    perPage,
+		NextCursor: next,
+	})
+}
+
+// listPortfolioFields lists published portfolios with a sparse fieldset, paginated like ListPortfolios
+func (c *PortfolioController) listPortfolioFields(ctx *fiber.Ctx, fields []string, page, perPage int, filter model.PortfolioFilter) error {
+	if ctx.Context().QueryArgs().Has("after") {
+		portfolios, next, err := c.portfolioService.ListFieldsAfter(ctx.Context(), fields, ctx.Query("after"), perPage, true, filter)
+		if err != nil {
+			return listErrorResponse(ctx, err, "Failed to list portfolios")
+		}
+
+		return ctx.JSON(model.PortfolioPartialCursorList{
+			Portfolios: portfolios,
+			PerPage:    perPage,
+			NextCursor: next,
 		})
 	}
 
-	// Convert to response
+	portfolios, total, err := c.portfolioService.ListFields(ctx.Context(), fields, page, perPage, true, filter)
+	if err != nil {
+		return listErrorResponse(ctx, err, "Failed to list portfolios")
+	}
+
+	return ctx.JSON(model.PortfolioPartialList{
+		Portfolios: portfolios,
+		Total:      total,
+		Page:       page,
+		PerPage:    perPage,
+	})
+}
+
+// toResponses loads the author of each portfolio
+func (c *PortfolioController) toResponses(ctx *fiber.Ctx, portfolios []model.Portfolio) []model.PortfolioResponse {
 	var responsePortfolios []model.PortfolioResponse
 	for _, portfolio := range portfolios {
 		portfolioResp, err := c.portfolioService.GetPortfolioWithAuthor(ctx.Context(), portfolio.ID)
@@ -158,13 +224,7 @@ func (c *PortfolioController) ListPortfolios(ctx *fiber.Ctx) error {
 		}
 		responsePortfolios = append(responsePortfolios, *portfolioResp)
 	}
-
-	return ctx.JSON(model.PortfolioList{
-		Portfolios: responsePortfolios,
-		Total:      total,
-		Page:       page,
-		PerPage:    perPage,
-	})
+	return responsePortfolios
 }
 
 // ListAdminPortfolios handles list portfolios for admin
@@ -182,41 +242,28 @@ func (c *PortfolioController) ListAdminPortfolios(ctx *fiber.Ctx) error {
 		perPage = 10
 	}
 
-	// Get parameter
-	onlyMine := ctx.Query("only_mine", "false") == "true"
-	if onlyMine {
-		// List only user's portfolios
-		portfolios, total, err := c.portfolioService.GetByAuthor(ctx.Context(), userID, page, perPage)
-		if err != nil {
-			return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-				"error": "Failed to list portfolios",
-			})
-		}
+	opts, err := parseListOptions(ctx)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid published_after date",
+		})
+	}
 
-		// Convert to response
-		var responsePortfolios []model.PortfolioResponse
-		for _, portfolio := range portfolios {
-			portfolioResp, err := c.portfolioService.GetPortfolioWithAuthor(ctx.Context(), portfolio.ID)
-			if err != nil {
-				continue
-			}
-			responsePortfolios = append(responsePortfolios, *portfolioResp)
-		}
+	filter := model.PortfolioFilter{
+		ListOptions: opts,
+		Tech:        ctx.Query("tech"),
+		Category:    ctx.Query("category"),
+	}
 
-		return ctx.JSON(model.PortfolioList{
-			Portfolios: responsePortfolios,
-			Total:      total,
-			Page:       page,
-			PerPage:    perPage,
-		})
+	// List only user's portfolios
+	if ctx.Query("only_mine", "false") == "true" {
+		filter.AuthorID = userID
 	}
 
 	// List all portfolios for admin (both published and unpublished)
-	portfolios, total, err := c.portfolioService.List(ctx.Context(), page, perPage, false)
+	portfolios, total, err := c.portfolioService.List(ctx.Context(), page, perPage, false, filter)
 	if err != nil {
-		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to list portfolios",
-		})
+		return listErrorResponse(ctx, err, "Failed to list portfolios")
 	}
 
 	// Convert to response
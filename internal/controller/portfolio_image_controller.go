@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// PortfolioImageController handles portfolio gallery requests
+type PortfolioImageController struct {
+	imageService service.PortfolioImageService
+}
+
+// NewPortfolioImageController creates a new PortfolioImageController
+func NewPortfolioImageController(imageService service.PortfolioImageService) *PortfolioImageController {
+	return &PortfolioImageController{
+		imageService: imageService,
+	}
+}
+
+// AddImage handles requests adding an uploaded image to a portfolio's gallery
+func (c *PortfolioImageController) AddImage(ctx *fiber.Ctx) error {
+	var imageReq model.PortfolioImageCreate
+	if err := bindAndValidate(ctx, &imageReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	gallery, err := c.imageService.Add(ctx.Context(), ctx.Params("id"), &imageReq)
+	if err != nil {
+		return portfolioImageErrorResponse(ctx, err, "Failed to add image")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"gallery": gallery,
+	})
+}
+
+// ReorderImages handles requests reordering a portfolio's gallery
+func (c *PortfolioImageController) ReorderImages(ctx *fiber.Ctx) error {
+	var orderReq model.PortfolioImageOrder
+	if err := bindAndValidate(ctx, &orderReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	gallery, err := c.imageService.Reorder(ctx.Context(), ctx.Params("id"), orderReq.ImageIDs)
+	if err != nil {
+		return portfolioImageErrorResponse(ctx, err, "Failed to reorder images")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"gallery": gallery,
+	})
+}
+
+// DeleteImage handles requests removing an image from a portfolio's gallery
+func (c *PortfolioImageController) DeleteImage(ctx *fiber.Ctx) error {
+	if err := c.imageService.Delete(ctx.Context(), ctx.Params("id"), ctx.Params("imageId")); err != nil {
+		return portfolioImageErrorResponse(ctx, err, "Failed to delete image")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"message": "Image removed from gallery",
+	})
+}
+
+// portfolioImageErrorResponse maps portfolio gallery errors to HTTP responses
+func portfolioImageErrorResponse(ctx *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrContentNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Portfolio not found",
+		})
+	case errors.Is(err, service.ErrPortfolioImageNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Image not found",
+		})
+	case errors.Is(err, service.ErrInvalidGallery):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "media_id must be an uploaded image from the media library",
+		})
+	case errors.Is(err, service.ErrPortfolioImageExists):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Image is already in the gallery",
+		})
+	case errors.Is(err, service.ErrInvalidImageOrder):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "image_ids must list every gallery image once",
+		})
+	}
+
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// PreviewController handles preview link requests
+type PreviewController struct {
+	previewService service.PreviewService
+}
+
+// NewPreviewController creates a new PreviewController
+func NewPreviewController(previewService service.PreviewService) *PreviewController {
+	return &PreviewController{
+		previewService: previewService,
+	}
+}
+
+// CreatePreviewLink handles create article preview link requests
+func (c *PreviewController) CreatePreviewLink(ctx *fiber.Ctx) error {
+	link, err := c.previewService.CreateLink(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return previewErrorResponse(ctx, err, "Failed to create preview link")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(link)
+}
+
+// GetPreview handles get article by preview token requests
+func (c *PreviewController) GetPreview(ctx *fiber.Ctx) error {
+	article, err := c.previewService.GetArticle(ctx.Context(), ctx.Params("token"))
+	if err != nil {
+		return previewErrorResponse(ctx, err, "Failed to get preview")
+	}
+
+	// Drafts shared for review must not end up in search results
+	ctx.Set("X-Robots-Tag", "noindex, nofollow")
+	return ctx.JSON(article)
+}
+
+// previewErrorResponse maps preview service errors to HTTP responses
+func previewErrorResponse(ctx *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrContentNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Article not found",
+		})
+	case errors.Is(err, service.ErrInvalidPreviewToken):
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": "Preview link is invalid or has expired",
+		})
+	case errors.Is(err, service.ErrPreviewNotConfigured):
+		return ctx.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"error": "Preview links are not configured",
+		})
+	}
+
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
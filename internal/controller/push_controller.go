@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// PushController handles Web Push subscription requests
+type PushController struct {
+	pushService service.PushService
+}
+
+// NewPushController creates a new PushController
+func NewPushController(pushService service.PushService) *PushController {
+	return &PushController{
+		pushService: pushService,
+	}
+}
+
+// GetPublicKey handles requests for the VAPID public key browsers subscribe with
+func (c *PushController) GetPublicKey(ctx *fiber.Ctx) error {
+	return ctx.JSON(fiber.Map{
+		"public_key": c.pushService.PublicKey(),
+	})
+}
+
+// Subscribe handles push subscription requests
+func (c *PushController) Subscribe(ctx *fiber.Ctx) error {
+	var req model.PushSubscribeRequest
+	if err := bindAndValidate(ctx, &req); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.pushService.Subscribe(ctx.Context(), &req); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to subscribe",
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Subscribed to push notifications",
+	})
+}
+
+// Unsubscribe handles push unsubscription requests
+func (c *PushController) Unsubscribe(ctx *fiber.Ctx) error {
+	var req model.PushUnsubscribeRequest
+	if err := bindAndValidate(ctx, &req); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.pushService.Unsubscribe(ctx.Context(), req.Endpoint); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to unsubscribe",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"message": "Unsubscribed from push notifications",
+	})
+}
@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RedirectController handles short link requests
+type RedirectController struct {
+	redirectService service.RedirectService
+}
+
+// NewRedirectController creates a new RedirectController
+func NewRedirectController(redirectService service.RedirectService) *RedirectController {
+	return &RedirectController{
+		redirectService: redirectService,
+	}
+}
+
+// Follow handles short link visits
+func (c *RedirectController) Follow(ctx *fiber.Ctx) error {
+	redirect, err := c.redirectService.Resolve(ctx.Context(), ctx.Params("code"))
+	if err != nil {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Link not found",
+		})
+	}
+
+	status := fiber.StatusFound
+	if redirect.Permanent {
+		status = fiber.StatusMovedPermanently
+	}
+
+	return ctx.Redirect(redirect.TargetURL, status)
+}
+
+// CreateRedirect handles create redirect requests
+func (c *RedirectController) CreateRedirect(ctx *fiber.Ctx) error {
+	var redirectReq model.RedirectCreate
+	if err := bindAndValidate(ctx, &redirectReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	redirect, err := c.redirectService.Create(ctx.Context(), &redirectReq)
+	if err != nil {
+		return redirectErrorResponse(ctx, err, "Failed to create redirect")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(redirect)
+}
+
+// UpdateRedirect handles update redirect requests
+func (c *RedirectController) UpdateRedirect(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	var redirectReq model.RedirectUpdate
+	if err := bindAndValidate(ctx, &redirectReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.redirectService.Update(ctx.Context(), id, &redirectReq); err != nil {
+		return redirectErrorResponse(ctx, err, "Failed to update redirect")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Redirect updated successfully",
+	})
+}
+
+// DeleteRedirect handles delete redirect requests
+func (c *RedirectController) DeleteRedirect(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if err := c.redirectService.Delete(ctx.Context(), id); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete redirect",
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Redirect deleted successfully",
+	})
+}
+
+// GetRedirect handles get redirect by ID requests
+func (c *RedirectController) GetRedirect(ctx *fiber.Ctx) error {
+	redirect, err := c.redirectService.GetByID(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Redirect not found",
+		})
+	}
+
+	return ctx.JSON(redirect)
+}
+
+// ListRedirects handles list redirects requests
+func (c *RedirectController) ListRedirects(ctx *fiber.Ctx) error {
+	redirects, err := c.redirectService.List(ctx.Context())
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list redirects",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"redirects": redirects,
+	})
+}
+
+// redirectErrorResponse maps redirect service errors to HTTP responses
+func redirectErrorResponse(ctx *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrInvalidRedirect):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrRedirectExists):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
@@ -0,0 +1,250 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ResumeController handles resume-related requests
+type ResumeController struct {
+	resumeService service.ResumeService
+}
+
+// NewResumeController creates a new ResumeController
+func NewResumeController(resumeService service.ResumeService) *ResumeController {
+	return &ResumeController{
+		resumeService: resumeService,
+	}
+}
+
+// GetResume handles get combined resume requests
+func (c *ResumeController) GetResume(ctx *fiber.Ctx) error {
+	resume, err := c.resumeService.GetResume(ctx.Context())
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get resume",
+		})
+	}
+
+	return ctx.JSON(resume)
+}
+
+// CreateExperience handles create experience requests
+func (c *ResumeController) CreateExperience(ctx *fiber.Ctx) error {
+	var expReq model.ExperienceCreate
+	if err := bindAndValidate(ctx, &expReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	id, err := c.resumeService.CreateExperience(ctx.Context(), &expReq)
+	if err != nil {
+		return resumeErrorResponse(ctx, err, "Failed to create experience")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id":      id,
+		"message": "Experience created successfully",
+	})
+}
+
+// UpdateExperience handles update experience requests
+func (c *ResumeController) UpdateExperience(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	var expReq model.ExperienceUpdate
+	if err := bindAndValidate(ctx, &expReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.resumeService.UpdateExperience(ctx.Context(), id, &expReq); err != nil {
+		return resumeErrorResponse(ctx, err, "Failed to update experience")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Experience updated successfully",
+	})
+}
+
+// DeleteExperience handles delete experience requests
+func (c *ResumeController) DeleteExperience(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if err := c.resumeService.DeleteExperience(ctx.Context(), id); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete experience",
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Experience deleted successfully",
+	})
+}
+
+// CreateEducation handles create education requests
+func (c *ResumeController) CreateEducation(ctx *fiber.Ctx) error {
+	var eduReq model.EducationCreate
+	if err := bindAndValidate(ctx, &eduReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	id, err := c.resumeService.CreateEducation(ctx.Context(), &eduReq)
+	if err != nil {
+		return resumeErrorResponse(ctx, err, "Failed to create education")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id":      id,
+		"message": "Education created successfully",
+	})
+}
+
+// UpdateEducation handles update education requests
+func (c *ResumeController) UpdateEducation(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	var eduReq model.EducationUpdate
+	if err := bindAndValidate(ctx, &eduReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.resumeService.UpdateEducation(ctx.Context(), id, &eduReq); err != nil {
+		return resumeErrorResponse(ctx, err, "Failed to update education")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Education updated successfully",
+	})
+}
+
+// DeleteEducation handles delete education requests
+func (c *ResumeController) DeleteEducation(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if err := c.resumeService.DeleteEducation(ctx.Context(), id); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete education",
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Education deleted successfully",
+	})
+}
+
+// CreateCertification handles create certification requests
+func (c *ResumeController) CreateCertification(ctx *fiber.Ctx) error {
+	var certReq model.CertificationCreate
+	if err := bindAndValidate(ctx, &certReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	id, err := c.resumeService.CreateCertification(ctx.Context(), &certReq)
+	if err != nil {
+		return resumeErrorResponse(ctx, err, "Failed to create certification")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id":      id,
+		"message": "Certification created successfully",
+	})
+}
+
+// UpdateCertification handles update certification requests
+func (c *ResumeController) UpdateCertification(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	var certReq model.CertificationUpdate
+	if err := bindAndValidate(ctx, &certReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.resumeService.UpdateCertification(ctx.Context(), id, &certReq); err != nil {
+		return resumeErrorResponse(ctx, err, "Failed to update certification")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Certification updated successfully",
+	})
+}
+
+// DeleteCertification handles delete certification requests
+func (c *ResumeController) DeleteCertification(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if err := c.resumeService.DeleteCertification(ctx.Context(), id); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete certification",
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Certification deleted successfully",
+	})
+}
+
+// CreateSkill handles create skill requests
+func (c *ResumeController) CreateSkill(ctx *fiber.Ctx) error {
+	var skillReq model.SkillCreate
+	if err := bindAndValidate(ctx, &skillReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	id, err := c.resumeService.CreateSkill(ctx.Context(), &skillReq)
+	if err != nil {
+		return resumeErrorResponse(ctx, err, "Failed to create skill")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id":      id,
+		"message": "Skill created successfully",
+	})
+}
+
+// UpdateSkill handles update skill requests
+func (c *ResumeController) UpdateSkill(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	var skillReq model.SkillUpdate
+	if err := bindAndValidate(ctx, &skillReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.resumeService.UpdateSkill(ctx.Context(), id, &skillReq); err != nil {
+		return resumeErrorResponse(ctx, err, "Failed to update skill")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Skill updated successfully",
+	})
+}
+
+// DeleteSkill handles delete skill requests
+func (c *ResumeController) DeleteSkill(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if err := c.resumeService.DeleteSkill(ctx.Context(), id); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete skill",
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Skill deleted successfully",
+	})
+}
+
+// resumeErrorResponse maps resume service errors to HTTP responses
+func resumeErrorResponse(ctx *fiber.Ctx, err error, fallback string) error {
+	if errors.Is(err, service.ErrInvalidResumeEntry) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "End date must not be before start date, and skill level must be between 0 and 5",
+		})
+	}
+
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RevalidationController handles manual frontend revalidation requests
+type RevalidationController struct {
+	revalidationService service.RevalidationService
+}
+
+// NewRevalidationController creates a new RevalidationController
+func NewRevalidationController(revalidationService service.RevalidationService) *RevalidationController {
+	return &RevalidationController{
+		revalidationService: revalidationService,
+	}
+}
+
+// Revalidate handles requests to regenerate frontend paths right away
+func (c *RevalidationController) Revalidate(ctx *fiber.Ctx) error {
+	var req model.RevalidateRequest
+	if err := bindAndValidate(ctx, &req); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.revalidationService.Revalidate(ctx.Context(), req.Paths); err != nil {
+		if errors.Is(err, service.ErrRevalidationNotConfigured) {
+			return ctx.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return ctx.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error": "The frontend failed to revalidate the paths",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"revalidated": req.Paths,
+	})
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RevisionController handles article revision requests
+type RevisionController struct {
+	revisionService service.RevisionService
+}
+
+// NewRevisionController creates a new RevisionController
+func NewRevisionController(revisionService service.RevisionService) *RevisionController {
+	return &RevisionController{
+		revisionService: revisionService,
+	}
+}
+
+// ListRevisions handles list article revisions requests
+func (c *RevisionController) ListRevisions(ctx *fiber.Ctx) error {
+	revisions, err := c.revisionService.ListByArticle(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return revisionErrorResponse(ctx, err, "Failed to list revisions")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"revisions": revisions,
+	})
+}
+
+// DiffRevisions handles compare article revisions requests
+func (c *RevisionController) DiffRevisions(ctx *fiber.Ctx) error {
+	from, to := ctx.Query("from"), ctx.Query("to")
+	if from == "" || to == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "from and to revision IDs are required",
+		})
+	}
+
+	diff, err := c.revisionService.Diff(ctx.Context(), ctx.Params("id"), from, to)
+	if err != nil {
+		return revisionErrorResponse(ctx, err, "Failed to compare revisions")
+	}
+
+	return ctx.JSON(diff)
+}
+
+// revisionErrorResponse maps revision service errors to HTTP responses
+func revisionErrorResponse(ctx *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrContentNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Article not found",
+		})
+	case errors.Is(err, service.ErrRevisionNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Revision not found",
+		})
+	}
+
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
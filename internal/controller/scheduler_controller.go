@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"github.com/budhilaw/personal-website-backend/internal/jobs"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SchedulerController handles scheduled task requests
+type SchedulerController struct {
+	scheduler *jobs.Scheduler
+}
+
+// NewSchedulerController creates a new SchedulerController
+func NewSchedulerController(scheduler *jobs.Scheduler) *SchedulerController {
+	return &SchedulerController{
+		scheduler: scheduler,
+	}
+}
+
+// ListTasks handles list scheduled tasks requests
+func (c *SchedulerController) ListTasks(ctx *fiber.Ctx) error {
+	return ctx.JSON(fiber.Map{
+		"tasks": c.scheduler.Status(),
+	})
+}
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SecurityController handles login block requests
+type SecurityController struct {
+	protector *middleware.BruteForceProtector
+}
+
+// NewSecurityController creates a new SecurityController
+func NewSecurityController(protector *middleware.BruteForceProtector) *SecurityController {
+	return &SecurityController{
+		protector: protector,
+	}
+}
+
+// ListBlocked handles list blocked logins requests
+func (c *SecurityController) ListBlocked(ctx *fiber.Ctx) error {
+	blocked, err := c.protector.Blocked(ctx.Context())
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list blocked logins",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"blocked": blocked,
+	})
+}
+
+// Unblock handles lift login block requests
+func (c *SecurityController) Unblock(ctx *fiber.Ctx) error {
+	if err := c.protector.Unblock(ctx.Context(), ctx.Params("id")); err != nil {
+		if errors.Is(err, middleware.ErrBlockNotFound) {
+			return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Block not found",
+			})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to lift block",
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"message": "Block lifted successfully",
+	})
+}
@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+)
+
+// Limits match the SEO metadata column sizes
+const (
+	maxMetaTitleLength       = 255
+	maxMetaDescriptionLength = 320
+)
+
+// validateSEO validates optional SEO metadata from a request body
+func validateSEO(seo model.SEOMeta) error {
+	if len(seo.MetaTitle) > maxMetaTitleLength {
+		return errors.New("meta_title cannot be longer than 255 characters")
+	}
+	if len(seo.MetaDescription) > maxMetaDescriptionLength {
+		return errors.New("meta_description cannot be longer than 320 characters")
+	}
+	if seo.CanonicalURL != "" && util.ValidateURL(seo.CanonicalURL) != nil {
+		return errors.New("canonical_url must be an absolute http or https URL")
+	}
+	// Uploaded images are served from a relative path
+	if seo.OGImage != "" && !strings.HasPrefix(seo.OGImage, "/") && util.ValidateURL(seo.OGImage) != nil {
+		return errors.New("og_image must be an absolute URL or a path starting with /")
+	}
+	return nil
+}
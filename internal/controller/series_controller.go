@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SeriesController handles series-related requests
+type SeriesController struct {
+	seriesService service.SeriesService
+}
+
+// NewSeriesController creates a new SeriesController
+func NewSeriesController(seriesService service.SeriesService) *SeriesController {
+	return &SeriesController{
+		seriesService: seriesService,
+	}
+}
+
+// CreateSeries handles create series requests
+func (c *SeriesController) CreateSeries(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("user_id").(string)
+
+	var seriesReq model.SeriesCreate
+	if err := bindAndValidate(ctx, &seriesReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	id, err := c.seriesService.Create(ctx.Context(), &seriesReq, userID)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create series",
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id":      id,
+		"message": "Series created successfully",
+	})
+}
+
+// UpdateSeries handles update series requests
+func (c *SeriesController) UpdateSeries(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	var seriesReq model.SeriesUpdate
+	if err := bindAndValidate(ctx, &seriesReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.seriesService.Update(ctx.Context(), id, &seriesReq); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update series",
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Series updated successfully",
+	})
+}
+
+// DeleteSeries handles delete series requests
+func (c *SeriesController) DeleteSeries(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if err := c.seriesService.Delete(ctx.Context(), id); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete series",
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Series deleted successfully",
+	})
+}
+
+// ListSeries handles list series requests
+func (c *SeriesController) ListSeries(ctx *fiber.Ctx) error {
+	series, err := c.seriesService.List(ctx.Context())
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list series",
+		})
+	}
+
+	if series == nil {
+		series = []model.Series{}
+	}
+
+	return ctx.JSON(fiber.Map{
+		"series": series,
+	})
+}
+
+// GetSeries handles get series by ID requests, including drafts
+func (c *SeriesController) GetSeries(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	series, err := c.seriesService.GetWithArticles(ctx.Context(), id, false)
+	if err != nil {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Series not found",
+		})
+	}
+
+	return ctx.JSON(series)
+}
+
+// GetSeriesBySlug handles get series by slug requests
+func (c *SeriesController) GetSeriesBySlug(ctx *fiber.Ctx) error {
+	slug := ctx.Params("slug")
+	if slug == "" {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Slug is required",
+		})
+	}
+
+	// Only published parts are visible publicly
+	series, err := c.seriesService.GetBySlugWithArticles(ctx.Context(), slug, true)
+	if err != nil {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Series not found",
+		})
+	}
+
+	return ctx.JSON(series)
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SyndicationController handles cross-posting requests
+type SyndicationController struct {
+	syndicationService service.SyndicationService
+}
+
+// NewSyndicationController creates a new SyndicationController
+func NewSyndicationController(syndicationService service.SyndicationService) *SyndicationController {
+	return &SyndicationController{
+		syndicationService: syndicationService,
+	}
+}
+
+// ListSyndications handles list article syndications requests
+func (c *SyndicationController) ListSyndications(ctx *fiber.Ctx) error {
+	syndications, err := c.syndicationService.ListByArticle(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return syndicationErrorResponse(ctx, err, "Failed to list syndications")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"syndications": syndications,
+	})
+}
+
+// Syndicate handles cross-post article requests
+func (c *SyndicationController) Syndicate(ctx *fiber.Ctx) error {
+	syndication, err := c.syndicationService.Syndicate(ctx.Context(), ctx.Params("id"), ctx.Params("platform"))
+	if errors.Is(err, service.ErrSyndicationFailed) {
+		// The failed attempt is recorded, return it with the platform's error
+		return ctx.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+			"error":       "The platform rejected the article",
+			"syndication": syndication,
+		})
+	}
+	if err != nil {
+		return syndicationErrorResponse(ctx, err, "Failed to syndicate article")
+	}
+
+	return ctx.JSON(syndication)
+}
+
+// syndicationErrorResponse maps syndication service errors to HTTP responses
+func syndicationErrorResponse(ctx *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrContentNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Article not found",
+		})
+	case errors.Is(err, service.ErrUnknownPlatform):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Platform must be devto or medium",
+		})
+	case errors.Is(err, service.ErrPlatformNotConfigured):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Platform is not configured",
+		})
+	case errors.Is(err, service.ErrArticleNotPublished):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Only published articles can be syndicated",
+		})
+	case errors.Is(err, service.ErrArticleProtected):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Password-protected articles can't be syndicated",
+		})
+	case errors.Is(err, service.ErrAlreadySyndicated):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Article is already on this platform, and it does not support updates",
+		})
+	}
+
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
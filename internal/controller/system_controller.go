@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SystemController handles process diagnostics requests
+type SystemController struct {
+	systemService service.SystemService
+}
+
+// NewSystemController creates a new SystemController
+func NewSystemController(systemService service.SystemService) *SystemController {
+	return &SystemController{
+		systemService: systemService,
+	}
+}
+
+// GetStats handles runtime and connection pool stats requests
+func (c *SystemController) GetStats(ctx *fiber.Ctx) error {
+	return ctx.JSON(c.systemService.Stats())
+}
+
+// ListLogLevels handles list log levels requests
+func (c *SystemController) ListLogLevels(ctx *fiber.Ctx) error {
+	return ctx.JSON(fiber.Map{
+		"levels": c.systemService.LogLevels(),
+	})
+}
+
+// SetLogLevel handles change log level requests; the root logger is named "root"
+func (c *SystemController) SetLogLevel(ctx *fiber.Ctx) error {
+	var levelReq model.LogLevelUpdate
+	if err := bindAndValidate(ctx, &levelReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.systemService.SetLogLevel(ctx.Params("name"), levelReq.Level); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return ctx.JSON(fiber.Map{
+		"levels": c.systemService.LogLevels(),
+	})
+}
+
+// ResetLogLevel handles remove log level override requests
+func (c *SystemController) ResetLogLevel(ctx *fiber.Ctx) error {
+	c.systemService.ResetLogLevel(ctx.Params("name"))
+
+	return ctx.JSON(fiber.Map{
+		"levels": c.systemService.LogLevels(),
+	})
+}
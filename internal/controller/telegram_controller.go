@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TelegramController handles Telegram bot webhook requests
+type TelegramController struct {
+	telegramBotService service.TelegramBotService
+}
+
+// NewTelegramController creates a new TelegramController
+func NewTelegramController(telegramBotService service.TelegramBotService) *TelegramController {
+	return &TelegramController{
+		telegramBotService: telegramBotService,
+	}
+}
+
+// Webhook handles bot updates from Telegram, authenticated by the webhook secret token
+func (c *TelegramController) Webhook(ctx *fiber.Ctx) error {
+	var update model.TelegramUpdate
+	if err := ctx.BodyParser(&update); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := c.telegramBotService.HandleUpdate(ctx.Context(), ctx.Get("X-Telegram-Bot-Api-Secret-Token"), &update); err != nil {
+		if errors.Is(err, service.ErrInvalidWebhookSecret) {
+			return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Invalid webhook secret",
+			})
+		}
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to handle update",
+		})
+	}
+
+	return ctx.SendStatus(fiber.StatusOK)
+}
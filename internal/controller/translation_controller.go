@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TranslationController handles translation management requests
+type TranslationController struct {
+	translationService service.TranslationService
+}
+
+// NewTranslationController creates a new TranslationController
+func NewTranslationController(translationService service.TranslationService) *TranslationController {
+	return &TranslationController{
+		translationService: translationService,
+	}
+}
+
+// ListArticleTranslations handles list article translations requests
+func (c *TranslationController) ListArticleTranslations(ctx *fiber.Ctx) error {
+	translations, err := c.translationService.ListArticle(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return translationErrorResponse(ctx, err, "Failed to list translations")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"translations": translations,
+	})
+}
+
+// UpsertArticleTranslation handles create/update article translation requests
+func (c *TranslationController) UpsertArticleTranslation(ctx *fiber.Ctx) error {
+	var translationReq model.TranslationUpsert
+	if err := bindAndValidate(ctx, &translationReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	err := c.translationService.UpsertArticle(ctx.Context(), ctx.Params("id"), ctx.Params("locale"), &translationReq)
+	if err != nil {
+		return translationErrorResponse(ctx, err, "Failed to save translation")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"message": "Translation saved successfully",
+	})
+}
+
+// DeleteArticleTranslation handles delete article translation requests
+func (c *TranslationController) DeleteArticleTranslation(ctx *fiber.Ctx) error {
+	if err := c.translationService.DeleteArticle(ctx.Context(), ctx.Params("id"), ctx.Params("locale")); err != nil {
+		return translationErrorResponse(ctx, err, "Failed to delete translation")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"message": "Translation deleted successfully",
+	})
+}
+
+// ListPageTranslations handles list page translations requests
+func (c *TranslationController) ListPageTranslations(ctx *fiber.Ctx) error {
+	translations, err := c.translationService.ListPage(ctx.Context(), ctx.Params("id"))
+	if err != nil {
+		return translationErrorResponse(ctx, err, "Failed to list translations")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"translations": translations,
+	})
+}
+
+// UpsertPageTranslation handles create/update page translation requests
+func (c *TranslationController) UpsertPageTranslation(ctx *fiber.Ctx) error {
+	var translationReq model.TranslationUpsert
+	if err := bindAndValidate(ctx, &translationReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	err := c.translationService.UpsertPage(ctx.Context(), ctx.Params("id"), ctx.Params("locale"), &translationReq)
+	if err != nil {
+		return translationErrorResponse(ctx, err, "Failed to save translation")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"message": "Translation saved successfully",
+	})
+}
+
+// DeletePageTranslation handles delete page translation requests
+func (c *TranslationController) DeletePageTranslation(ctx *fiber.Ctx) error {
+	if err := c.translationService.DeletePage(ctx.Context(), ctx.Params("id"), ctx.Params("locale")); err != nil {
+		return translationErrorResponse(ctx, err, "Failed to delete translation")
+	}
+
+	return ctx.JSON(fiber.Map{
+		"message": "Translation deleted successfully",
+	})
+}
+
+// translationErrorResponse maps translation service errors to HTTP responses
+func translationErrorResponse(ctx *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrUnsupportedLocale):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Locale must be one of the supported, non-default locales",
+		})
+	case errors.Is(err, service.ErrContentNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Content not found",
+		})
+	}
+
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
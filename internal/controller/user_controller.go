@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// UserController handles user management requests
+type UserController struct {
+	userService service.UserService
+}
+
+// NewUserController creates a new UserController
+func NewUserController(userService service.UserService) *UserController {
+	return &UserController{
+		userService: userService,
+	}
+}
+
+// ListUsers handles list users requests
+func (c *UserController) ListUsers(ctx *fiber.Ctx) error {
+	// Parse query parameters
+	page, err := strconv.Atoi(ctx.Query("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	perPage, err := strconv.Atoi(ctx.Query("per_page", "10"))
+	if err != nil || perPage < 1 {
+		perPage = 10
+	}
+
+	users, total, err := c.userService.List(ctx.Context(), page, perPage)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to list users",
+		})
+	}
+
+	return ctx.JSON(model.UserList{
+		Users:   users,
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+	})
+}
+
+// GetUser handles get user by ID requests
+func (c *UserController) GetUser(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	user, err := c.userService.GetByID(ctx.Context(), id)
+	if err != nil {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	}
+
+	return ctx.JSON(user)
+}
+
+// CreateUser handles create user requests
+func (c *UserController) CreateUser(ctx *fiber.Ctx) error {
+	role, _ := ctx.Locals("role").(string)
+
+	var userReq model.UserCreate
+	if err := bindAndValidate(ctx, &userReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+	if userReq.Role == "" {
+		userReq.Role = model.RoleUser
+	}
+
+	resp, err := c.userService.Create(ctx.Context(), role, &userReq)
+	if err != nil {
+		return userErrorResponse(ctx, err, "Failed to create user")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// UpdateUserRole handles promote/demote requests
+func (c *UserController) UpdateUserRole(ctx *fiber.Ctx) error {
+	actorID := ctx.Locals("user_id").(string)
+	actorRole, _ := ctx.Locals("role").(string)
+	id := ctx.Params("id")
+
+	var roleReq model.UserRoleUpdate
+	if err := bindAndValidate(ctx, &roleReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.userService.UpdateRole(ctx.Context(), actorID, actorRole, id, roleReq.Role); err != nil {
+		return userErrorResponse(ctx, err, "Failed to update user role")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "User role updated successfully",
+	})
+}
+
+// DeactivateUser handles deactivate user requests
+func (c *UserController) DeactivateUser(ctx *fiber.Ctx) error {
+	return c.setActive(ctx, false)
+}
+
+// ActivateUser handles reactivate user requests
+func (c *UserController) ActivateUser(ctx *fiber.Ctx) error {
+	return c.setActive(ctx, true)
+}
+
+// setActive updates the active state of the user in the route params
+func (c *UserController) setActive(ctx *fiber.Ctx, active bool) error {
+	actorID := ctx.Locals("user_id").(string)
+	actorRole, _ := ctx.Locals("role").(string)
+	id := ctx.Params("id")
+
+	if err := c.userService.SetActive(ctx.Context(), actorID, actorRole, id, active); err != nil {
+		return userErrorResponse(ctx, err, "Failed to update user status")
+	}
+
+	message := "User deactivated successfully"
+	if active {
+		message = "User activated successfully"
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": message,
+	})
+}
+
+// userErrorResponse maps user service errors to HTTP responses
+func userErrorResponse(ctx *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrInsufficientRole):
+		return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrCannotModifySelf), errors.Is(err, service.ErrInvalidUser):
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, service.ErrUserExists):
+		return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	case errors.Is(err, repository.ErrUserNotFound):
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "User not found",
+		})
+	}
+
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+// UsesController handles uses page requests
+type UsesController struct {
+	usesService service.UsesService
+}
+
+// NewUsesController creates a new UsesController
+func NewUsesController(usesService service.UsesService) *UsesController {
+	return &UsesController{
+		usesService: usesService,
+	}
+}
+
+// GetUses handles get uses page requests
+func (c *UsesController) GetUses(ctx *fiber.Ctx) error {
+	uses, err := c.usesService.GetUses(ctx.Context())
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get uses",
+		})
+	}
+
+	return ctx.JSON(uses)
+}
+
+// CreateCategory handles create uses category requests
+func (c *UsesController) CreateCategory(ctx *fiber.Ctx) error {
+	var categoryReq model.UsesCategoryCreate
+	if err := bindAndValidate(ctx, &categoryReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	id, err := c.usesService.CreateCategory(ctx.Context(), &categoryReq)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to create category",
+		})
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id":      id,
+		"message": "Category created successfully",
+	})
+}
+
+// UpdateCategory handles update uses category requests
+func (c *UsesController) UpdateCategory(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	var categoryReq model.UsesCategoryUpdate
+	if err := bindAndValidate(ctx, &categoryReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.usesService.UpdateCategory(ctx.Context(), id, &categoryReq); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update category",
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Category updated successfully",
+	})
+}
+
+// DeleteCategory handles delete uses category requests
+func (c *UsesController) DeleteCategory(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if err := c.usesService.DeleteCategory(ctx.Context(), id); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete category",
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Category deleted successfully",
+	})
+}
+
+// CreateItem handles create uses item requests
+func (c *UsesController) CreateItem(ctx *fiber.Ctx) error {
+	var itemReq model.UsesItemCreate
+	if err := bindAndValidate(ctx, &itemReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	id, err := c.usesService.CreateItem(ctx.Context(), &itemReq)
+	if err != nil {
+		return usesErrorResponse(ctx, err, "Failed to create item")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"id":      id,
+		"message": "Item created successfully",
+	})
+}
+
+// UpdateItem handles update uses item requests
+func (c *UsesController) UpdateItem(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	var itemReq model.UsesItemUpdate
+	if err := bindAndValidate(ctx, &itemReq); err != nil {
+		return validationErrorResponse(ctx, err)
+	}
+
+	if err := c.usesService.UpdateItem(ctx.Context(), id, &itemReq); err != nil {
+		return usesErrorResponse(ctx, err, "Failed to update item")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Item updated successfully",
+	})
+}
+
+// DeleteItem handles delete uses item requests
+func (c *UsesController) DeleteItem(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	if err := c.usesService.DeleteItem(ctx.Context(), id); err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete item",
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Item deleted successfully",
+	})
+}
+
+// usesErrorResponse maps uses service errors to HTTP responses
+func usesErrorResponse(ctx *fiber.Ctx, err error, fallback string) error {
+	if errors.Is(err, service.ErrUsesCategoryNotFound) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Category not found",
+		})
+	}
+
+	return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error": fallback,
+	})
+}
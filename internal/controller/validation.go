@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+// errInvalidBody is returned by bindAndValidate when the body can't be parsed
+var errInvalidBody = errors.New("invalid request body")
+
+// bindAndValidate parses the request body into req and checks its validate tags.
+// Errors are meant for validationErrorResponse.
+func bindAndValidate(ctx *fiber.Ctx, req interface{}) error {
+	if err := ctx.BodyParser(req); err != nil {
+		return errInvalidBody
+	}
+
+	return util.ValidateStruct(req)
+}
+
+// validationErrorResponse maps bindAndValidate errors to a 400 response,
+// listing every failed field
+func validationErrorResponse(ctx *fiber.Ctx, err error) error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	fields := make([]model.FieldError, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		fields = append(fields, model.FieldError{
+			Field:   fieldErr.Field(),
+			Rule:    fieldErr.Tag(),
+			Message: fieldErrorMessage(fieldErr),
+		})
+	}
+
+	return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error":  "Validation failed",
+		"fields": fields,
+	})
+}
+
+// fieldErrorMessage renders a human readable message for a failed validate tag
+func fieldErrorMessage(fieldErr validator.FieldError) string {
+	field := fieldErr.Field()
+	switch fieldErr.Tag() {
+	case "required", "required_if":
+		return fmt.Sprintf("%s is required", field)
+	case "email":
+		return fmt.Sprintf("%s must be a valid email address", field)
+	case "url":
+		return fmt.Sprintf("%s must be a valid URL", field)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s", field, strings.Join(strings.Fields(fieldErr.Param()), ", "))
+	case "min":
+		return fmt.Sprintf("%s must be at least %s", field, fieldErr.Param())
+	case "max":
+		return fmt.Sprintf("%s must be at most %s", field, fieldErr.Param())
+	default:
+		return fmt.Sprintf("%s is invalid", field)
+	}
+}
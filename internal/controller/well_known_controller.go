@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/gofiber/fiber/v2"
+)
+
+// WellKnownController serves robots.txt and security.txt from the configuration
+type WellKnownController struct {
+	cfg config.Config
+}
+
+// NewWellKnownController creates a new WellKnownController
+func NewWellKnownController(cfg config.Config) *WellKnownController {
+	return &WellKnownController{
+		cfg: cfg,
+	}
+}
+
+// Robots handles robots.txt requests
+func (c *WellKnownController) Robots(ctx *fiber.Ctx) error {
+	var b strings.Builder
+	b.WriteString("User-agent: *\n")
+
+	disallow := c.cfg.RobotsDisallowPaths()
+	if len(disallow) == 0 {
+		// An empty Disallow allows everything; a group needs at least one rule
+		b.WriteString("Disallow:\n")
+	}
+	for _, path := range disallow {
+		b.WriteString("Disallow: " + path + "\n")
+	}
+
+	if c.cfg.RobotsSitemapURL != "" {
+		b.WriteString("\nSitemap: " + c.cfg.RobotsSitemapURL + "\n")
+	}
+
+	ctx.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+	ctx.Set(fiber.HeaderCacheControl, "public, max-age=3600")
+	return ctx.SendString(b.String())
+}
+
+// SecurityTxt handles security.txt requests, with an expiry that moves forward every day
+func (c *WellKnownController) SecurityTxt(ctx *fiber.Ctx) error {
+	contacts := c.cfg.SecurityContacts()
+	if len(contacts) == 0 {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Not found",
+		})
+	}
+
+	var b strings.Builder
+	for _, contact := range contacts {
+		b.WriteString("Contact: " + contact + "\n")
+	}
+
+	expires := time.Now().UTC().Truncate(24 * time.Hour).Add(c.cfg.SecurityTxtExpiry)
+	b.WriteString("Expires: " + expires.Format(time.RFC3339) + "\n")
+
+	if c.cfg.SecurityTxtPolicy != "" {
+		b.WriteString("Policy: " + c.cfg.SecurityTxtPolicy + "\n")
+	}
+	if c.cfg.SecurityTxtPreferredLanguages != "" {
+		b.WriteString("Preferred-Languages: " + c.cfg.SecurityTxtPreferredLanguages + "\n")
+	}
+	if c.cfg.APIURL != "" {
+		b.WriteString("Canonical: " + strings.TrimRight(c.cfg.APIURL, "/") + "/.well-known/security.txt\n")
+	}
+
+	ctx.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+	ctx.Set(fiber.HeaderCacheControl, "public, max-age=86400")
+	return ctx.SendString(b.String())
+}
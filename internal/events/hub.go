@@ -0,0 +1,77 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Event types streamed to the admin dashboard
+const (
+	TypeLoginSuccess     = "login_success"
+	TypeLoginFailure     = "login_failure"
+	TypeArticlePublished = "article_published"
+	TypeJobFailed        = "job_failed"
+	TypePageviewSpike    = "pageview_spike"
+)
+
+// subscriberBuffer is how many events a slow subscriber may fall behind before events are dropped for it
+const subscriberBuffer = 32
+
+// Event is something that happened on this instance, as streamed to the admin dashboard
+type Event struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+	Time time.Time   `json:"time"`
+}
+
+// Hub fans events out to live subscribers. It only reaches subscribers connected to the
+// same instance, and events published while nobody listens are not kept.
+type Hub struct {
+	mutex       sync.RWMutex
+	subscribers map[chan Event]struct{}
+}
+
+// NewHub creates a new event hub
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Publish sends an event to every subscriber without blocking; subscribers whose buffer is full miss it.
+// Publishing on a nil hub does nothing.
+func (h *Hub) Publish(eventType string, data interface{}) {
+	if h == nil {
+		return
+	}
+
+	event := Event{Type: eventType, Data: data, Time: time.Now()}
+
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	for ch := range h.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe registers a subscriber; the returned function unsubscribes it
+func (h *Hub) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	h.mutex.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.mutex.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mutex.Lock()
+			delete(h.subscribers, ch)
+			h.mutex.Unlock()
+		})
+	}
+}
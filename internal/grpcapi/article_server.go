@@ -0,0 +1,112 @@
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	websitev1 "github.com/budhilaw/personal-website-backend/proto/website/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// articleServer serves website.v1.ArticleService
+type articleServer struct {
+	websitev1.UnimplementedArticleServiceServer
+	articleService service.ArticleService
+}
+
+// ListArticles lists published articles, or all articles for admins asking for unpublished ones
+func (s *articleServer) ListArticles(ctx context.Context, req *websitev1.ListArticlesRequest) (*websitev1.ListArticlesResponse, error) {
+	if req.IncludeUnpublished && !isAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "unpublished articles require an admin token")
+	}
+
+	page, perPage := pagination(req.Page, req.PerPage)
+	opts := model.ListOptions{
+		Author: req.Author,
+		Search: req.Search,
+	}
+
+	articles, total, err := s.articleService.List(ctx, page, perPage, !req.IncludeUnpublished, opts)
+	if err != nil {
+		return nil, listError(ctx, err, "Failed to list articles")
+	}
+
+	resp := &websitev1.ListArticlesResponse{
+		Total:   int32(total),
+		Page:    int32(page),
+		PerPage: int32(perPage),
+	}
+	for _, article := range articles {
+		articleResp, err := s.articleService.GetArticleWithAuthor(ctx, article.ID)
+		if err != nil {
+			continue
+		}
+		if !isAdmin(ctx) {
+			s.articleService.Redact(articleResp, "")
+		}
+		resp.Articles = append(resp.Articles, toArticle(articleResp))
+	}
+
+	return resp, nil
+}
+
+// GetArticle gets an article by ID or slug; unpublished articles are hidden from non-admins
+func (s *articleServer) GetArticle(ctx context.Context, req *websitev1.GetArticleRequest) (*websitev1.Article, error) {
+	var (
+		article *model.ArticleResponse
+		err     error
+	)
+	switch key := req.Key.(type) {
+	case *websitev1.GetArticleRequest_Id:
+		article, err = s.articleService.GetArticleWithAuthor(ctx, key.Id)
+	case *websitev1.GetArticleRequest_Slug:
+		article, err = s.articleService.GetBySlugWithAuthor(ctx, key.Slug)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "id or slug is required")
+	}
+	if err != nil {
+		return nil, lookupError(ctx, err, "Article")
+	}
+
+	if !article.IsPublished && !isAdmin(ctx) {
+		return nil, status.Error(codes.NotFound, "Article not found")
+	}
+
+	// There is no unlock over gRPC; password-protected content is for admins only
+	if !isAdmin(ctx) {
+		s.articleService.Redact(article, "")
+	}
+
+	return toArticle(article), nil
+}
+
+// toArticle converts an article response to its protobuf message
+func toArticle(article *model.ArticleResponse) *websitev1.Article {
+	msg := &websitev1.Article{
+		Id:            article.ID,
+		Title:         article.Title,
+		Slug:          article.Slug,
+		Content:       article.Content,
+		ContentHtml:   article.ContentHTML,
+		Excerpt:       article.Excerpt,
+		FeaturedImage: article.FeaturedImage,
+		Status:        article.Status,
+		IsFeatured:    article.IsFeatured,
+		Author: &websitev1.Author{
+			Id:        article.Author.ID,
+			Username:  article.Author.Username,
+			FirstName: article.Author.FirstName,
+			LastName:  article.Author.LastName,
+			Avatar:    article.Author.Avatar,
+		},
+		CreatedAt: timestamppb.New(article.CreatedAt),
+		UpdatedAt: timestamppb.New(article.UpdatedAt),
+	}
+	if !article.PublishedAt.IsZero() {
+		msg.PublishedAt = timestamppb.New(article.PublishedAt)
+	}
+	return msg
+}
@@ -0,0 +1,103 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"github.com/budhilaw/personal-website-backend/internal/middleware"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	websitev1 "github.com/budhilaw/personal-website-backend/proto/website/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// authServer serves website.v1.AuthService
+type authServer struct {
+	websitev1.UnimplementedAuthServiceServer
+	authService service.AuthService
+}
+
+// Login authenticates a user, sharing the brute force protection of the REST login
+func (s *authServer) Login(ctx context.Context, req *websitev1.LoginRequest) (*websitev1.LoginResponse, error) {
+	if req.Username == "" || req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "username and password are required")
+	}
+
+	ip, userAgent := callerInfo(ctx)
+
+	protector := middleware.GetBruteForceProtector()
+	if blocked, _ := protector.IsBlocked(ip, req.Username); blocked {
+		return nil, status.Error(codes.ResourceExhausted, "too many failed login attempts")
+	}
+
+	resp, err := s.authService.Login(ctx, req.Username, req.Password, ip, userAgent)
+	if err != nil {
+		if errors.Is(err, service.ErrDeviceConfirmationRequired) {
+			return nil, status.Error(codes.PermissionDenied, "new device detected, check your email to approve it and sign in again")
+		}
+		protector.RecordFailedAttempt(ip, req.Username)
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+	protector.RecordSuccessfulAttempt(ip, req.Username)
+
+	return &websitev1.LoginResponse{
+		AccessToken:  resp.AccessToken,
+		RefreshToken: resp.RefreshToken,
+		User: &websitev1.User{
+			Id:        resp.User.ID,
+			Username:  resp.User.Username,
+			Email:     resp.User.Email,
+			FirstName: resp.User.FirstName,
+			LastName:  resp.User.LastName,
+			Avatar:    resp.User.Avatar,
+			Bio:       resp.User.Bio,
+			Role:      resp.User.Role,
+			IsAdmin:   resp.User.IsAdmin,
+		},
+	}, nil
+}
+
+// GetProfile returns the profile of the token's user
+func (s *authServer) GetProfile(ctx context.Context, _ *websitev1.GetProfileRequest) (*websitev1.User, error) {
+	claims := claimsFrom(ctx)
+	if claims == nil {
+		return nil, status.Error(codes.Unauthenticated, "authorization required")
+	}
+
+	profile, err := s.authService.GetProfile(ctx, claims.UserID)
+	if err != nil {
+		return nil, lookupError(ctx, err, "User")
+	}
+
+	return &websitev1.User{
+		Id:        profile.ID,
+		Username:  profile.Username,
+		Email:     profile.Email,
+		FirstName: profile.FirstName,
+		LastName:  profile.LastName,
+		Avatar:    profile.Avatar,
+		Bio:       profile.Bio,
+		Role:      claims.Role,
+		IsAdmin:   claims.IsAdmin,
+	}, nil
+}
+
+// callerInfo returns the caller's IP and user agent for login tracking
+func callerInfo(ctx context.Context) (string, string) {
+	var ip, userAgent string
+	if p, ok := peer.FromContext(ctx); ok {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("user-agent"); len(values) > 0 {
+			userAgent = values[0]
+		}
+	}
+	return ip, userAgent
+}
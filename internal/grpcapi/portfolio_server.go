@@ -0,0 +1,117 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	websitev1 "github.com/budhilaw/personal-website-backend/proto/website/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// portfolioServer serves website.v1.PortfolioService
+type portfolioServer struct {
+	websitev1.UnimplementedPortfolioServiceServer
+	portfolioService service.PortfolioService
+}
+
+// ListPortfolios lists published portfolios, or all portfolios for admins asking for unpublished ones
+func (s *portfolioServer) ListPortfolios(ctx context.Context, req *websitev1.ListPortfoliosRequest) (*websitev1.ListPortfoliosResponse, error) {
+	if req.IncludeUnpublished && !isAdmin(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "unpublished portfolios require an admin token")
+	}
+
+	page, perPage := pagination(req.Page, req.PerPage)
+	filter := model.PortfolioFilter{
+		ListOptions: model.ListOptions{Search: req.Search},
+		Tech:        req.Technology,
+		Category:    req.Category,
+	}
+
+	portfolios, total, err := s.portfolioService.List(ctx, page, perPage, !req.IncludeUnpublished, filter)
+	if err != nil {
+		return nil, listError(ctx, err, "Failed to list portfolios")
+	}
+
+	resp := &websitev1.ListPortfoliosResponse{
+		Total:   int32(total),
+		Page:    int32(page),
+		PerPage: int32(perPage),
+	}
+	for _, portfolio := range portfolios {
+		portfolioResp, err := s.portfolioService.GetPortfolioWithAuthor(ctx, portfolio.ID)
+		if err != nil {
+			continue
+		}
+		resp.Portfolios = append(resp.Portfolios, toPortfolio(portfolioResp))
+	}
+
+	return resp, nil
+}
+
+// GetPortfolio gets a portfolio by ID or slug; unpublished portfolios are hidden from non-admins
+func (s *portfolioServer) GetPortfolio(ctx context.Context, req *websitev1.GetPortfolioRequest) (*websitev1.Portfolio, error) {
+	var (
+		portfolio *model.PortfolioResponse
+		err       error
+	)
+	switch key := req.Key.(type) {
+	case *websitev1.GetPortfolioRequest_Id:
+		portfolio, err = s.portfolioService.GetPortfolioWithAuthor(ctx, key.Id)
+	case *websitev1.GetPortfolioRequest_Slug:
+		portfolio, err = s.portfolioService.GetBySlugWithAuthor(ctx, key.Slug)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "id or slug is required")
+	}
+	if err != nil {
+		return nil, lookupError(ctx, err, "Portfolio")
+	}
+
+	if !portfolio.IsPublished && !isAdmin(ctx) {
+		return nil, status.Error(codes.NotFound, "Portfolio not found")
+	}
+
+	return toPortfolio(portfolio), nil
+}
+
+// toPortfolio converts a portfolio response to its protobuf message
+func toPortfolio(portfolio *model.PortfolioResponse) *websitev1.Portfolio {
+	msg := &websitev1.Portfolio{
+		Id:          portfolio.ID,
+		Title:       portfolio.Title,
+		Slug:        portfolio.Slug,
+		Description: portfolio.Description,
+		Content:     portfolio.Content,
+		ContentHtml: portfolio.ContentHTML,
+		Image:       portfolio.Image,
+		ProjectUrl:  portfolio.ProjectURL,
+		GithubUrl:   portfolio.GithubURL,
+		Category:    portfolio.Category,
+		Role:        portfolio.Role,
+		Duration:    portfolio.Duration,
+		IsPublished: portfolio.IsPublished,
+		Author: &websitev1.Author{
+			Id:        portfolio.Author.ID,
+			Username:  portfolio.Author.Username,
+			FirstName: portfolio.Author.FirstName,
+			LastName:  portfolio.Author.LastName,
+			Avatar:    portfolio.Author.Avatar,
+		},
+		CreatedAt: timestamppb.New(portfolio.CreatedAt),
+		UpdatedAt: timestamppb.New(portfolio.UpdatedAt),
+	}
+
+	// Technologies are stored as a JSON array of names
+	_ = json.Unmarshal(portfolio.Technologies, &msg.Technologies)
+
+	for _, metric := range portfolio.Metrics {
+		msg.Metrics = append(msg.Metrics, &websitev1.PortfolioMetric{
+			Label: metric.Label,
+			Value: metric.Value,
+		})
+	}
+	return msg
+}
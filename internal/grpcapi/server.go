@@ -0,0 +1,153 @@
+package grpcapi
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/middleware"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	websitev1 "github.com/budhilaw/personal-website-backend/proto/website/v1"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Services holds the services exposed over gRPC
+type Services struct {
+	Auth      service.AuthService
+	Article   service.ArticleService
+	Portfolio service.PortfolioService
+}
+
+// claimsKey stores the verified token claims of a call in its context
+type claimsKey struct{}
+
+// NewServer creates a gRPC server with the article, portfolio and auth services registered,
+// using the HTTPS certificate when one is configured
+func NewServer(services Services, userRepo repository.UserRepository, cfg config.Config) (*grpc.Server, error) {
+	opts := []grpc.ServerOption{grpc.ChainUnaryInterceptor(
+		recoverInterceptor,
+		logInterceptor,
+		authInterceptor(userRepo, cfg),
+	)}
+	if cfg.TLSCertFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+	server := grpc.NewServer(opts...)
+
+	websitev1.RegisterArticleServiceServer(server, &articleServer{articleService: services.Article})
+	websitev1.RegisterPortfolioServiceServer(server, &portfolioServer{portfolioService: services.Portfolio})
+	websitev1.RegisterAuthServiceServer(server, &authServer{authService: services.Auth})
+
+	return server, nil
+}
+
+// recoverInterceptor turns a panicking handler into an Internal error instead of crashing the process
+func recoverInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.ErrorContext(ctx, "gRPC handler panicked", zap.Any("panic", r), zap.String("method", info.FullMethod))
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// logInterceptor logs every call with its status and latency
+func logInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	logger.Info("gRPC request",
+		zap.String("method", info.FullMethod),
+		zap.String("code", status.Code(err).String()),
+		zap.Duration("latency", time.Since(start)),
+	)
+	return resp, err
+}
+
+// authInterceptor verifies the bearer token in the "authorization" metadata when one is sent.
+// Calls without a token go through anonymously; handlers decide what needs a login.
+func authInterceptor(userRepo repository.UserRepository, cfg config.Config) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 {
+			return handler(ctx, req)
+		}
+
+		token, ok := strings.CutPrefix(values[0], "Bearer ")
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "invalid authorization format")
+		}
+
+		claims, err := middleware.VerifyToken(token, cfg)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+		}
+		if err := middleware.RefreshClaims(ctx, userRepo, claims); err != nil {
+			if errors.Is(err, middleware.ErrInactiveUser) {
+				return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+			}
+			logger.ErrorContext(ctx, "Failed to load the token's user", zap.Error(err), zap.String("user_id", claims.UserID))
+			return nil, status.Error(codes.Internal, "failed to verify token")
+		}
+
+		ctx = context.WithValue(ctx, claimsKey{}, claims)
+		return handler(context.WithValue(ctx, repository.ActorKey{}, claims.UserID), req)
+	}
+}
+
+// claimsFrom returns the verified token claims of the call, nil for anonymous calls
+func claimsFrom(ctx context.Context) *middleware.JWTClaims {
+	claims, _ := ctx.Value(claimsKey{}).(*middleware.JWTClaims)
+	return claims
+}
+
+// isAdmin reports whether the call was made with an admin token
+func isAdmin(ctx context.Context) bool {
+	claims := claimsFrom(ctx)
+	return claims != nil && claims.IsAdmin
+}
+
+// pagination applies the REST defaults of page 1 and 10 items per page
+func pagination(page, perPage int32) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 10
+	}
+	return int(page), int(perPage)
+}
+
+// lookupError maps a failed lookup to NotFound, logging anything else as Internal
+func lookupError(ctx context.Context, err error, message string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Error(codes.NotFound, message+" not found")
+	}
+	logger.ErrorContext(ctx, "Failed to get "+strings.ToLower(message), zap.Error(err))
+	return status.Error(codes.Internal, "failed to get "+strings.ToLower(message))
+}
+
+// listError maps a failed listing to InvalidArgument for bad options, Internal otherwise
+func listError(ctx context.Context, err error, message string) error {
+	if errors.Is(err, service.ErrInvalidSort) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	logger.ErrorContext(ctx, message, zap.Error(err))
+	return status.Error(codes.Internal, strings.ToLower(message))
+}
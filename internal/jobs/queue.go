@@ -0,0 +1,227 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/events"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	// jobTimeout bounds a single handler run
+	jobTimeout = 5 * time.Minute
+	// lockTimeout is how long a running job may stay locked before it is considered abandoned
+	lockTimeout = 2 * jobTimeout
+	// Retry backoff doubles from baseBackoff up to maxBackoff
+	baseBackoff = 30 * time.Second
+	maxBackoff  = time.Hour
+)
+
+// HandlerFunc processes the payload of a single job
+type HandlerFunc func(ctx context.Context, payload json.RawMessage) error
+
+// Enqueuer schedules background jobs
+type Enqueuer interface {
+	Enqueue(ctx context.Context, jobType string, payload interface{}) error
+	EnqueueAt(ctx context.Context, jobType string, payload interface{}, runAt time.Time) error
+}
+
+// Queue is a job queue processed by a pool of workers. Jobs are persisted
+// in Postgres so they survive restarts and are shared between replicas.
+type Queue struct {
+	jobRepo      repository.JobRepository
+	handlers     map[string]HandlerFunc
+	workers      int
+	pollInterval time.Duration
+	maxAttempts  int
+	events       *events.Hub
+	logger       *zap.Logger
+
+	mutex  sync.RWMutex
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+// NewQueue creates a new job queue; failures are published to hub when one is given
+func NewQueue(jobRepo repository.JobRepository, cfg config.Config, hub *events.Hub, logger *zap.Logger) *Queue {
+	pollInterval := cfg.JobsPollInterval
+	if pollInterval <= 0 {
+		pollInterval = time.Second
+	}
+
+	return &Queue{
+		jobRepo:      jobRepo,
+		handlers:     make(map[string]HandlerFunc),
+		workers:      max(cfg.JobsWorkers, 1),
+		pollInterval: pollInterval,
+		maxAttempts:  max(cfg.JobsMaxAttempts, 1),
+		events:       hub,
+		logger:       logger,
+	}
+}
+
+// Register sets the handler for a job type
+func (q *Queue) Register(jobType string, handler HandlerFunc) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.handlers[jobType] = handler
+}
+
+// Enqueue schedules a job to run as soon as a worker is free
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	return q.EnqueueAt(ctx, jobType, payload, time.Now())
+}
+
+// EnqueueAt schedules a job to run once runAt has passed
+func (q *Queue) EnqueueAt(ctx context.Context, jobType string, payload interface{}, runAt time.Time) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.jobRepo.Enqueue(ctx, jobType, data, q.maxAttempts, runAt)
+	return err
+}
+
+// Start starts the worker pool
+func (q *Queue) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	q.cancel = cancel
+
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go q.work(ctx)
+	}
+
+	q.logger.Info("Job workers started", zap.Int("workers", q.workers))
+}
+
+// Stop stops the worker pool and waits for running jobs to finish
+func (q *Queue) Stop() {
+	if q.cancel == nil {
+		return
+	}
+
+	q.cancel()
+	q.wg.Wait()
+}
+
+// work claims jobs until the queue is empty, then polls
+func (q *Queue) work(ctx context.Context) {
+	defer q.wg.Done()
+
+	ticker := time.NewTicker(q.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for q.runNext(ctx) {
+			if ctx.Err() != nil {
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runNext claims and runs a single job, reporting whether one was found
+func (q *Queue) runNext(ctx context.Context) bool {
+	job, err := q.jobRepo.Claim(ctx, lockTimeout)
+	if err != nil {
+		if ctx.Err() == nil {
+			q.logger.Error("Failed to claim job", zap.Error(err))
+		}
+		return false
+	}
+	if job == nil {
+		return false
+	}
+
+	// Let the job finish on shutdown instead of failing it halfway
+	jobCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), jobTimeout)
+	defer cancel()
+
+	if err := q.run(jobCtx, job); err != nil {
+		q.fail(jobCtx, job, err)
+		return true
+	}
+
+	if err := q.jobRepo.Complete(jobCtx, job.ID); err != nil {
+		q.logger.Error("Failed to complete job", zap.Error(err), zap.String("job_id", job.ID))
+	}
+
+	return true
+}
+
+// run calls the handler registered for the job, turning panics into errors
+func (q *Queue) run(ctx context.Context, job *model.Job) (err error) {
+	q.mutex.RLock()
+	handler, ok := q.handlers[job.Type]
+	q.mutex.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("no handler registered for job type %q", job.Type)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, job.Payload)
+}
+
+// fail schedules a retry with backoff, or buries the job once it is out of attempts
+func (q *Queue) fail(ctx context.Context, job *model.Job, jobErr error) {
+	fields := []zap.Field{
+		zap.Error(jobErr),
+		zap.String("job_id", job.ID),
+		zap.String("job_type", job.Type),
+		zap.Int("attempt", job.Attempts),
+	}
+
+	q.events.Publish(events.TypeJobFailed, map[string]interface{}{
+		"job_id":       job.ID,
+		"job_type":     job.Type,
+		"attempt":      job.Attempts,
+		"max_attempts": job.MaxAttempts,
+		"error":        jobErr.Error(),
+		"permanent":    job.Attempts >= job.MaxAttempts,
+	})
+
+	if job.Attempts >= job.MaxAttempts {
+		q.logger.Error("Job failed permanently", fields...)
+		if err := q.jobRepo.Bury(ctx, job.ID, jobErr.Error()); err != nil {
+			q.logger.Error("Failed to bury job", zap.Error(err), zap.String("job_id", job.ID))
+		}
+		return
+	}
+
+	q.logger.Warn("Job failed, retrying", fields...)
+	runAt := time.Now().Add(backoff(job.Attempts))
+	if err := q.jobRepo.Retry(ctx, job.ID, runAt, jobErr.Error()); err != nil {
+		q.logger.Error("Failed to reschedule job", zap.Error(err), zap.String("job_id", job.ID))
+	}
+}
+
+// backoff returns the delay before the next attempt
+func backoff(attempt int) time.Duration {
+	delay := baseBackoff
+	for i := 1; i < attempt && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
@@ -0,0 +1,186 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"go.uber.org/zap"
+)
+
+// TaskFunc is a periodic task
+type TaskFunc func(ctx context.Context) error
+
+// task is a registered periodic task and its last run
+type task struct {
+	name     string
+	interval time.Duration
+	enabled  bool
+	run      TaskFunc
+
+	running      bool
+	lastRunAt    time.Time
+	lastDuration time.Duration
+	lastError    string
+	nextRunAt    time.Time
+}
+
+// Scheduler runs periodic tasks in-process, one ticker per task
+type Scheduler struct {
+	tasks    []*task
+	disabled []string
+	logger   *zap.Logger
+
+	mutex  sync.RWMutex
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+// NewScheduler creates a new scheduler
+func NewScheduler(cfg config.Config, logger *zap.Logger) *Scheduler {
+	var disabled []string
+	for _, name := range strings.Split(cfg.SchedulerDisabledTasks, ",") {
+		if name = strings.TrimSpace(strings.ToLower(name)); name != "" {
+			disabled = append(disabled, name)
+		}
+	}
+
+	return &Scheduler{
+		disabled: disabled,
+		logger:   logger,
+	}
+}
+
+// Register adds a task running every interval; tasks listed in SCHEDULER_DISABLED_TASKS are kept but never run
+func (s *Scheduler) Register(name string, interval time.Duration, run TaskFunc) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.tasks = append(s.tasks, &task{
+		name:     name,
+		interval: interval,
+		enabled:  interval > 0 && !slices.Contains(s.disabled, name),
+		run:      run,
+	})
+}
+
+// Start starts a ticker for every enabled task
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, t := range s.tasks {
+		if !t.enabled {
+			s.logger.Info("Scheduled task disabled", zap.String("task", t.name))
+			continue
+		}
+
+		t.nextRunAt = time.Now().Add(t.interval)
+		s.wg.Add(1)
+		go s.loop(ctx, t)
+	}
+}
+
+// Stop stops the scheduler and waits for running tasks to finish
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+
+	s.cancel()
+	s.wg.Wait()
+}
+
+// Status reports the state of every registered task
+func (s *Scheduler) Status() []model.ScheduledTask {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	statuses := make([]model.ScheduledTask, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		status := model.ScheduledTask{
+			Name:      t.name,
+			Interval:  t.interval.String(),
+			Enabled:   t.enabled,
+			Running:   t.running,
+			LastError: t.lastError,
+		}
+		if !t.lastRunAt.IsZero() {
+			lastRunAt := t.lastRunAt
+			status.LastRunAt = &lastRunAt
+			status.LastDuration = t.lastDuration.String()
+		}
+		if t.enabled && !t.nextRunAt.IsZero() {
+			nextRunAt := t.nextRunAt
+			status.NextRunAt = &nextRunAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+// loop runs a task on its interval until the scheduler stops
+func (s *Scheduler) loop(ctx context.Context, t *task) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.execute(ctx, t)
+		}
+	}
+}
+
+// execute runs a task once and records the outcome
+func (s *Scheduler) execute(ctx context.Context, t *task) {
+	s.mutex.Lock()
+	t.running = true
+	s.mutex.Unlock()
+
+	start := time.Now()
+	err := runTask(ctx, t.run)
+	duration := time.Since(start)
+
+	s.mutex.Lock()
+	t.running = false
+	t.lastRunAt = start
+	t.lastDuration = duration
+	t.lastError = ""
+	if err != nil {
+		t.lastError = err.Error()
+	}
+	t.nextRunAt = time.Now().Add(t.interval)
+	s.mutex.Unlock()
+
+	if err != nil {
+		s.logger.Error("Scheduled task failed", zap.Error(err), zap.String("task", t.name))
+		return
+	}
+
+	s.logger.Debug("Scheduled task finished", zap.String("task", t.name), zap.Duration("duration", duration))
+}
+
+// runTask calls the task, turning panics into errors
+func runTask(ctx context.Context, run TaskFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("task panicked: %v", r)
+		}
+	}()
+
+	return run(ctx)
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// APIKeyHeader carries an API key on public requests
+const APIKeyHeader = "X-API-Key"
+
+// APIKey rate limits requests sent with an X-API-Key header by the key's tier, counting them
+// over the rolling API_KEY_RATE_WINDOW. Keyed requests skip the per-IP limit of RateLimiter,
+// so it must run first. Requests without the header pass through; unknown or revoked keys are
+// rejected. A key whose tier is no longer configured falls back to the per-IP limit.
+func APIKey(store repository.APIKeyRepository, cfg config.Config) fiber.Handler {
+	limits := make(map[string]int)
+	for _, tier := range cfg.APIKeyTierList() {
+		limits[tier.Name] = tier.Max
+	}
+
+	return func(c *fiber.Ctx) error {
+		secret := c.Get(APIKeyHeader)
+		if secret == "" {
+			return c.Next()
+		}
+
+		key, err := store.GetByHash(c.Context(), util.HashToken(secret))
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && key.RevokedAt != nil) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Invalid API key",
+			})
+		}
+		if err != nil {
+			logger.ErrorContext(c.Context(), "Failed to look up API key", zap.Error(err))
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": "API keys are unavailable, try again later",
+			})
+		}
+
+		limit, ok := limits[key.Tier]
+		if !ok {
+			return c.Next()
+		}
+
+		used, err := store.RecordRequest(c.Context(), key.ID, time.Now(), cfg.APIKeyRateWindow)
+		if err != nil {
+			logger.ErrorContext(c.Context(), "Failed to record API key request", zap.Error(err))
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": "API keys are unavailable, try again later",
+			})
+		}
+
+		c.Set("X-RateLimit-Limit", strconv.Itoa(limit))
+		c.Set("X-RateLimit-Remaining", strconv.Itoa(max(limit-used, 0)))
+		if used > limit {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": "API key request limit reached",
+			})
+		}
+
+		c.Locals("api_key_id", key.ID)
+		return c.Next()
+	}
+}
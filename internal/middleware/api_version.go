@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/gofiber/fiber/v2"
+)
+
+// API version headers; Deprecation follows RFC 9745 and Sunset RFC 8594
+const (
+	APIVersionHeader  = "API-Version"
+	DeprecationHeader = "Deprecation"
+	SunsetHeader      = "Sunset"
+)
+
+// APIVersion tells clients which API version answered the request
+func APIVersion(version string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Set(APIVersionHeader, version)
+		return c.Next()
+	}
+}
+
+// Deprecation marks every API v1 response as deprecated since API_V1_DEPRECATED_AT, with the
+// Sunset date when set, and links the deprecation notice and the same route in API v2
+func Deprecation(cfg config.Config) fiber.Handler {
+	deprecatedAt, sunsetAt := cfg.APIV1Deprecation()
+	if deprecatedAt.IsZero() {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	deprecation := "@" + strconv.FormatInt(deprecatedAt.Unix(), 10)
+	var sunset string
+	if !sunsetAt.IsZero() {
+		sunset = sunsetAt.UTC().Format(http.TimeFormat)
+	}
+
+	return func(c *fiber.Ctx) error {
+		c.Set(DeprecationHeader, deprecation)
+		if sunset != "" {
+			c.Set(SunsetHeader, sunset)
+		}
+
+		links := []string{`<` + strings.Replace(c.Path(), "/api/v1", "/api/v2", 1) + `>; rel="successor-version"`}
+		if cfg.APIV1DeprecationURL != "" {
+			links = append(links, `<`+cfg.APIV1DeprecationURL+`>; rel="deprecation"; type="text/html"`)
+		}
+		c.Append(fiber.HeaderLink, links...)
+
+		return c.Next()
+	}
+}
@@ -1,9 +1,12 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"strings"
 
 	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
 	"github.com/budhilaw/personal-website-backend/pkg/logger"
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
@@ -14,11 +17,15 @@ var (
 	jwtManager *JWTManager
 )
 
+// ErrInactiveUser is returned for tokens whose user was deactivated or deleted after logging in
+var ErrInactiveUser = errors.New("user is inactive or no longer exists")
+
 // JWTClaims represents the JWT claims
 type JWTClaims struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	IsAdmin  bool   `json:"is_admin"`
+	Role     string `json:"role"`
 	jwt.RegisteredClaims
 }
 
@@ -28,24 +35,60 @@ func InitJWTManager(cfg config.Config) {
 	logger.Info("JWT Manager initialized with secret rotation")
 }
 
+// RotateJWTSecrets rotates the JWT secrets when they are due; it is run periodically by the scheduler
+func RotateJWTSecrets() error {
+	if jwtManager == nil {
+		return nil
+	}
+	return jwtManager.RotateIfDue()
+}
+
 // GenerateToken generates a new JWT token
-func GenerateToken(userID string, username string, isAdmin bool, cfg config.Config) (string, error) {
+func GenerateToken(userID string, username string, isAdmin bool, role string, cfg config.Config) (string, error) {
 	if jwtManager == nil {
 		InitJWTManager(cfg)
 	}
-	return jwtManager.GenerateToken(userID, username, isAdmin)
+	return jwtManager.GenerateToken(userID, username, isAdmin, role)
 }
 
 // GenerateRefreshToken generates a new refresh token
-func GenerateRefreshToken(userID string, username string, isAdmin bool, cfg config.Config) (string, error) {
+func GenerateRefreshToken(userID string, username string, isAdmin bool, role string, cfg config.Config) (string, error) {
 	if jwtManager == nil {
 		InitJWTManager(cfg)
 	}
-	return jwtManager.GenerateRefreshToken(userID, username, isAdmin)
+	return jwtManager.GenerateRefreshToken(userID, username, isAdmin, role)
+}
+
+// VerifyToken verifies an access token outside of HTTP requests, e.g. for gRPC calls
+func VerifyToken(tokenString string, cfg config.Config) (*JWTClaims, error) {
+	if jwtManager == nil {
+		InitJWTManager(cfg)
+	}
+	return jwtManager.VerifyToken(tokenString)
+}
+
+// RefreshClaims replaces the identity in verified claims with the user's current one, so
+// demotions, deactivation and deletion take effect before the token expires
+func RefreshClaims(ctx context.Context, userRepo repository.UserRepository, claims *JWTClaims) error {
+	user, err := userRepo.GetByID(ctx, claims.UserID)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return ErrInactiveUser
+	}
+	if err != nil {
+		return err
+	}
+	if !user.IsActive {
+		return ErrInactiveUser
+	}
+
+	claims.Username = user.Username
+	claims.IsAdmin = user.IsAdmin
+	claims.Role = user.Role
+	return nil
 }
 
 // Protected middleware for protecting routes
-func Protected(cfg config.Config) fiber.Handler {
+func Protected(cfg config.Config, userRepo repository.UserRepository) fiber.Handler {
 	// Ensure JWT Manager is initialized
 	if jwtManager == nil {
 		InitJWTManager(cfg)
@@ -79,10 +122,25 @@ func Protected(cfg config.Config) fiber.Handler {
 			})
 		}
 
+		// The token may outlive the user's role or account
+		if err := RefreshClaims(c.Context(), userRepo, claims); err != nil {
+			if errors.Is(err, ErrInactiveUser) {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error": "Invalid or expired token",
+				})
+			}
+			logger.ErrorContext(c.Context(), "Failed to load the token's user", zap.Error(err), zap.String("user_id", claims.UserID))
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to verify token",
+			})
+		}
+
 		// Set claims in context
 		c.Locals("user_id", claims.UserID)
 		c.Locals("username", claims.Username)
 		c.Locals("is_admin", claims.IsAdmin)
+		c.Locals("role", claims.Role)
+		c.Locals(repository.ActorKey{}, claims.UserID)
 
 		return c.Next()
 	}
@@ -101,3 +159,19 @@ func AdminOnly() fiber.Handler {
 		return c.Next()
 	}
 }
+
+// RequireRole middleware restricts routes to users holding one of the given roles
+func RequireRole(roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		role, _ := c.Locals("role").(string)
+		for _, allowed := range roles {
+			if role == allowed {
+				return c.Next()
+			}
+		}
+
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Access denied",
+		})
+	}
+}
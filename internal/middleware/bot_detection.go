@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/gofiber/fiber/v2"
+)
+
+// botUserAgent matches the User-Agent of common search engine and SEO crawlers, link
+// previewers, uptime monitors, headless browsers and HTTP libraries
+var botUserAgent = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|scrap|archiver|headless|phantomjs|lighthouse|` +
+	`facebookexternalhit|embedly|preview|pingdom|uptime|monitor|feedfetcher|curl/|wget/|httpie/|python-|` +
+	`aiohttp|go-http-client|java/|okhttp|axios/|node-fetch|libwww|httpclient`)
+
+// BotDetection classifies each request as coming from a bot or a human, storing the result
+// in c.Locals("bot"). Requests without a User-Agent, or whose User-Agent matches a known bot
+// or one of BOT_USER_AGENTS, are bots.
+func BotDetection(cfg config.Config) fiber.Handler {
+	patterns := cfg.BotUserAgentPatterns()
+
+	return func(c *fiber.Ctx) error {
+		c.Locals("bot", isBotUserAgent(c.Get(fiber.HeaderUserAgent), patterns))
+		return c.Next()
+	}
+}
+
+// IsBot reports whether BotDetection classified the request as coming from a bot
+func IsBot(c *fiber.Ctx) bool {
+	bot, _ := c.Locals("bot").(bool)
+	return bot
+}
+
+// isBotUserAgent reports whether a User-Agent belongs to a bot; patterns are lowercase substrings
+func isBotUserAgent(userAgent string, patterns []string) bool {
+	userAgent = strings.TrimSpace(userAgent)
+	if userAgent == "" || botUserAgent.MatchString(userAgent) {
+		return true
+	}
+
+	userAgent = strings.ToLower(userAgent)
+	for _, pattern := range patterns {
+		if strings.Contains(userAgent, pattern) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,9 +1,14 @@
 package middleware
 
 import (
+	"context"
+	"errors"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
 	"github.com/budhilaw/personal-website-backend/pkg/logger"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
@@ -15,23 +20,19 @@ const (
 	initialBlockDuration  = 30    // Initial block duration in seconds
 	blockMultiplier       = 2     // Multiplier for each subsequent block
 	maxBlockDuration      = 86400 // Maximum block duration in seconds (24 hours)
-	cleanupInterval       = 3600  // Cleanup interval in seconds
 	failedAttemptsTimeout = 1800  // Clear failed attempts after this many seconds
+	storeTimeout          = 5 * time.Second
 )
 
-// LoginAttempt tracks information about login attempts
-type LoginAttempt struct {
-	IP             string
-	Username       string
-	FailedAttempts int
-	LastFailedAt   time.Time
-	BlockedUntil   time.Time
-}
+// ErrBlockNotFound is returned when unblocking an attempt that doesn't exist
+var ErrBlockNotFound = errors.New("block not found")
 
-// BruteForceProtector manages brute force protection
+// BruteForceProtector manages brute force protection. Attempts are checked in
+// memory and written through to the store, when one is attached, so blocks survive restarts.
 type BruteForceProtector struct {
-	attempts   map[string]*LoginAttempt // Key is IP + username
-	ipAttempts map[string]*LoginAttempt // Key is IP only (for IP-based blocking)
+	attempts   map[string]*model.LoginAttempt // Key is IP + username
+	ipAttempts map[string]*model.LoginAttempt // Key is IP only (for IP-based blocking)
+	store      repository.LoginAttemptRepository
 	mutex      sync.RWMutex
 }
 
@@ -44,26 +45,136 @@ var (
 func GetBruteForceProtector() *BruteForceProtector {
 	once.Do(func() {
 		bruteForceProtector = &BruteForceProtector{
-			attempts:   make(map[string]*LoginAttempt),
-			ipAttempts: make(map[string]*LoginAttempt),
+			attempts:   make(map[string]*model.LoginAttempt),
+			ipAttempts: make(map[string]*model.LoginAttempt),
 		}
-		go bruteForceProtector.startCleanupTask()
 	})
 	return bruteForceProtector
 }
 
-// startCleanupTask periodically cleans up old login attempts
-func (b *BruteForceProtector) startCleanupTask() {
-	ticker := time.NewTicker(time.Second * cleanupInterval)
-	defer ticker.Stop()
+// Persist loads the attempts that are still relevant from the store and
+// writes every later change back to it
+func (b *BruteForceProtector) Persist(ctx context.Context, store repository.LoginAttemptRepository) error {
+	attempts, err := store.ListActive(ctx, time.Now().Add(-time.Second*failedAttemptsTimeout))
+	if err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for i := range attempts {
+		attempt := &attempts[i]
+		if attempt.Username == "" {
+			b.ipAttempts[attempt.IP] = attempt
+		} else {
+			b.attempts[attempt.IP+":"+attempt.Username] = attempt
+		}
+	}
+	b.store = store
+
+	logger.Info("Loaded persisted login attempts", zap.Int("count", len(attempts)))
+	return nil
+}
 
-	for range ticker.C {
-		b.cleanup()
+// Blocked lists the stored attempts that are currently blocked
+func (b *BruteForceProtector) Blocked(ctx context.Context) ([]model.LoginAttempt, error) {
+	store := b.getStore()
+	if store == nil {
+		return nil, errors.New("login attempts are not persisted")
 	}
+
+	return store.ListBlocked(ctx)
 }
 
-// cleanup removes expired login attempts
-func (b *BruteForceProtector) cleanup() {
+// Unblock lifts a block by its stored ID and forgets its failed attempts
+func (b *BruteForceProtector) Unblock(ctx context.Context, id string) error {
+	store := b.getStore()
+	if store == nil {
+		return errors.New("login attempts are not persisted")
+	}
+
+	attempt, err := store.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if attempt == nil {
+		return ErrBlockNotFound
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if attempt.Username == "" {
+		delete(b.ipAttempts, attempt.IP)
+	} else {
+		delete(b.attempts, attempt.IP+":"+attempt.Username)
+	}
+
+	logger.Info("Login block lifted",
+		zap.String("ip", attempt.IP),
+		zap.String("username", attempt.Username))
+	return nil
+}
+
+// BlockIP blocks logins from an IP for the given duration, as if it had failed too many times.
+// The block can be lifted early like any other.
+func (b *BruteForceProtector) BlockIP(ip string, duration time.Duration) time.Time {
+	b.mutex.Lock()
+	now := time.Now()
+	attempt, exists := b.ipAttempts[ip]
+	if !exists {
+		attempt = &model.LoginAttempt{IP: ip}
+		b.ipAttempts[ip] = attempt
+	}
+	attempt.FailedAttempts = max(attempt.FailedAttempts, maxFailedAttempts*2)
+	attempt.LastFailedAt = now
+	attempt.BlockedUntil = now.Add(duration)
+	snapshot := *attempt
+	b.mutex.Unlock()
+
+	b.save(snapshot)
+
+	logger.Warn("IP blocked manually",
+		zap.String("ip", ip),
+		zap.Time("blocked_until", snapshot.BlockedUntil))
+	return snapshot.BlockedUntil
+}
+
+// getStore returns the attached store, or nil when attempts only live in memory
+func (b *BruteForceProtector) getStore() repository.LoginAttemptRepository {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.store
+}
+
+// save writes a snapshot of an attempt to the store; failures are logged
+// since the in-memory state still protects this instance
+func (b *BruteForceProtector) save(attempt model.LoginAttempt) {
+	store := b.getStore()
+	if store == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	if err := store.Save(ctx, &attempt); err != nil {
+		logger.Error("Failed to persist login attempt",
+			zap.String("ip", attempt.IP),
+			zap.String("username", attempt.Username),
+			zap.Error(err))
+	}
+}
+
+// Cleanup removes expired login attempts; it is run periodically by the scheduler
+func (b *BruteForceProtector) Cleanup(ctx context.Context) error {
+	if store := b.getStore(); store != nil {
+		if err := store.DeleteExpired(ctx, time.Now().Add(-time.Second*failedAttemptsTimeout)); err != nil {
+			return err
+		}
+	}
+
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
@@ -90,6 +201,7 @@ func (b *BruteForceProtector) cleanup() {
 	logger.Debug("Cleaned up brute force protection cache",
 		zap.Int("remaining_attempts", len(b.attempts)),
 		zap.Int("remaining_ip_attempts", len(b.ipAttempts)))
+	return nil
 }
 
 // IsBlocked checks if a login attempt is blocked
@@ -113,6 +225,14 @@ func (b *BruteForceProtector) IsBlocked(ip, username string) (bool, time.Time) {
 
 // RecordFailedAttempt records a failed login attempt
 func (b *BruteForceProtector) RecordFailedAttempt(ip, username string) {
+	attempt, ipAttempt := b.recordFailedAttempt(ip, username)
+	b.save(attempt)
+	b.save(ipAttempt)
+}
+
+// recordFailedAttempt updates the in-memory counters and returns snapshots
+// of the account and IP attempts for persisting
+func (b *BruteForceProtector) recordFailedAttempt(ip, username string) (model.LoginAttempt, model.LoginAttempt) {
 	b.mutex.Lock()
 	defer b.mutex.Unlock()
 
@@ -122,7 +242,7 @@ func (b *BruteForceProtector) RecordFailedAttempt(ip, username string) {
 	// Update account-specific attempts
 	attempt, exists := b.attempts[key]
 	if !exists {
-		attempt = &LoginAttempt{
+		attempt = &model.LoginAttempt{
 			IP:             ip,
 			Username:       username,
 			FailedAttempts: 0,
@@ -136,7 +256,7 @@ func (b *BruteForceProtector) RecordFailedAttempt(ip, username string) {
 	// Update IP-based attempts
 	ipAttempt, exists := b.ipAttempts[ip]
 	if !exists {
-		ipAttempt = &LoginAttempt{
+		ipAttempt = &model.LoginAttempt{
 			IP:             ip,
 			FailedAttempts: 0,
 		}
@@ -192,19 +312,44 @@ func (b *BruteForceProtector) RecordFailedAttempt(ip, username string) {
 			zap.Time("blocked_until", ipAttempt.BlockedUntil),
 			zap.Duration("block_duration", blockDuration))
 	}
+
+	return *attempt, *ipAttempt
 }
 
 // RecordSuccessfulAttempt resets failed login attempts counter
 func (b *BruteForceProtector) RecordSuccessfulAttempt(ip, username string) {
 	b.mutex.Lock()
-	defer b.mutex.Unlock()
-
 	// Reset account-specific attempts
 	key := ip + ":" + username
+	_, existed := b.attempts[key]
 	delete(b.attempts, key)
+	store := b.store
+	b.mutex.Unlock()
 
 	// We don't reset IP-based attempts on success as one account success
 	// shouldn't clear attempts on other accounts from the same IP
+
+	if !existed || store == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	if err := store.DeleteByKey(ctx, ip, username); err != nil {
+		logger.Error("Failed to clear persisted login attempt",
+			zap.String("ip", ip),
+			zap.String("username", username),
+			zap.Error(err))
+	}
+}
+
+// loginPaths are the login endpoints of every API version
+var loginPaths = []string{"/api/v1/auth/login", "/api/v2/auth/login"}
+
+// isLoginRequest reports whether the request is a login attempt
+func isLoginRequest(c *fiber.Ctx) bool {
+	return c.Method() == fiber.MethodPost && slices.Contains(loginPaths, c.Path())
 }
 
 // BruteForceProtection middleware checks for brute force attacks
@@ -213,7 +358,7 @@ func BruteForceProtection() fiber.Handler {
 
 	return func(c *fiber.Ctx) error {
 		// Only apply to login endpoints
-		if c.Path() == "/api/v1/auth/login" && c.Method() == "POST" {
+		if isLoginRequest(c) {
 			ip := c.IP()
 
 			// Get username from body (we need to check before login attempt)
@@ -251,7 +396,7 @@ func TrackLoginAttempt() fiber.Handler {
 
 	return func(c *fiber.Ctx) error {
 		// Only apply to login endpoints
-		if c.Path() == "/api/v1/auth/login" && c.Method() == "POST" {
+		if isLoginRequest(c) {
 			// Store original path, method and username for later
 			ip := c.IP()
 			path := c.Path()
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CachePolicy describes the Cache-Control header for a route group
+type CachePolicy struct {
+	MaxAge               time.Duration // How long shared and browser caches may serve the response; zero leaves the header unset
+	StaleWhileRevalidate time.Duration // How long a stale response may be served while the cache refetches it
+	NoStore              bool          // Forbid caching entirely
+}
+
+// DetailCachePolicy returns the cache policy for single published items
+func DetailCachePolicy(cfg config.Config) CachePolicy {
+	return CachePolicy{MaxAge: cfg.CacheDetailMaxAge, StaleWhileRevalidate: cfg.CacheStaleWhileRevalidate}
+}
+
+// ListCachePolicy returns the cache policy for lists, which change whenever something is published
+func ListCachePolicy(cfg config.Config) CachePolicy {
+	return CachePolicy{MaxAge: cfg.CacheListMaxAge, StaleWhileRevalidate: cfg.CacheStaleWhileRevalidate}
+}
+
+// NoStoreCachePolicy returns the cache policy for private or per-user responses
+func NoStoreCachePolicy() CachePolicy {
+	return CachePolicy{NoStore: true}
+}
+
+// CacheControl sets the Cache-Control header of a route group. Cacheable policies
+// only apply to successful GET responses, and handlers setting their own header win.
+func CacheControl(policy CachePolicy) fiber.Handler {
+	value := policy.header()
+
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		if value == "" || len(c.Response().Header.Peek(fiber.HeaderCacheControl)) > 0 {
+			return nil
+		}
+		if !policy.NoStore {
+			if c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead {
+				return nil
+			}
+			if c.Response().StatusCode() != fiber.StatusOK {
+				return nil
+			}
+		}
+
+		c.Set(fiber.HeaderCacheControl, value)
+		return nil
+	}
+}
+
+// header renders the policy as a Cache-Control value
+func (p CachePolicy) header() string {
+	if p.NoStore {
+		return "no-store"
+	}
+	if p.MaxAge <= 0 {
+		return ""
+	}
+
+	value := fmt.Sprintf("public, max-age=%d", int(p.MaxAge.Seconds()))
+	if p.StaleWhileRevalidate > 0 {
+		value += fmt.Sprintf(", stale-while-revalidate=%d", int(p.StaleWhileRevalidate.Seconds()))
+	}
+	return value
+}
@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Captcha providers that can be selected with CAPTCHA_PROVIDER
+const (
+	CaptchaHCaptcha  = "hcaptcha"
+	CaptchaTurnstile = "turnstile"
+)
+
+// Public form routes, selected by CAPTCHA_ROUTES and FORM_PROTECTION_ROUTES
+const (
+	FormRouteNewsletter = "newsletter"
+	FormRouteContact    = "contact"
+	FormRouteComments   = "comments"
+)
+
+// CaptchaTokenHeader carries the captcha token for clients that don't send it in the body
+const CaptchaTokenHeader = "X-Captcha-Token"
+
+// captchaVerifyURLs are the siteverify endpoints of each provider; both take the same form and answer alike
+var captchaVerifyURLs = map[string]string{
+	CaptchaHCaptcha:  "https://api.hcaptcha.com/siteverify",
+	CaptchaTurnstile: "https://challenges.cloudflare.com/turnstile/v0/siteverify",
+}
+
+// captchaTokenFields are the body fields a token is read from: our own, then the ones the widgets post
+var captchaTokenFields = []string{"captcha_token", "h-captcha-response", "cf-turnstile-response"}
+
+// Captcha requires a valid captcha token on a route listed in CAPTCHA_ROUTES. The token is
+// read from the X-Captcha-Token header or a captcha_token body field, and checked with the
+// provider before the handler runs. Routes not listed, or no CAPTCHA_PROVIDER, pass through.
+func Captcha(cfg config.Config, route string) fiber.Handler {
+	verifyURL := captchaVerifyURLs[strings.ToLower(cfg.CaptchaProvider)]
+	if verifyURL == "" || !slices.Contains(cfg.CaptchaRouteList(), route) {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+
+	return func(c *fiber.Ctx) error {
+		token := captchaToken(c)
+		if token == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Captcha token is required",
+			})
+		}
+
+		ok, err := verifyCaptcha(c.Context(), client, verifyURL, cfg.CaptchaSecret, token, c.IP())
+		if err != nil {
+			logger.ErrorContext(c.Context(), "Failed to verify captcha", zap.String("route", route), zap.Error(err))
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": "Captcha verification is unavailable, try again later",
+			})
+		}
+		if !ok {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Captcha verification failed",
+			})
+		}
+
+		return c.Next()
+	}
+}
+
+// captchaToken reads the token from the header, or from a JSON or form body field
+func captchaToken(c *fiber.Ctx) string {
+	if token := strings.TrimSpace(c.Get(CaptchaTokenHeader)); token != "" {
+		return token
+	}
+
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEApplicationJSON) {
+		var body map[string]interface{}
+		if err := json.Unmarshal(c.Body(), &body); err != nil {
+			return ""
+		}
+		for _, field := range captchaTokenFields {
+			if token, ok := body[field].(string); ok && strings.TrimSpace(token) != "" {
+				return strings.TrimSpace(token)
+			}
+		}
+		return ""
+	}
+
+	for _, field := range captchaTokenFields {
+		if token := strings.TrimSpace(c.FormValue(field)); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// verifyCaptcha asks the provider whether a token is valid
+func verifyCaptcha(ctx context.Context, client *http.Client, verifyURL, secret, token, remoteIP string) (bool, error) {
+	form := url.Values{}
+	form.Set("secret", secret)
+	form.Set("response", token)
+	form.Set("remoteip", remoteIP)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("captcha provider returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(&result); err != nil {
+		return false, err
+	}
+
+	// A rejected secret is our misconfiguration, not the visitor's fault
+	for _, code := range result.ErrorCodes {
+		if code == "invalid-input-secret" || code == "missing-input-secret" {
+			return false, fmt.Errorf("captcha provider rejected the secret: %s", code)
+		}
+	}
+
+	return result.Success, nil
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// envelopeMetaFields are the pagination fields of v1 list responses, moved to meta in the envelope
+var envelopeMetaFields = []string{"total", "page", "per_page", "next_cursor"}
+
+// Envelope wraps the JSON responses of API v2: successful ones in {"data": ..., "meta": {...}},
+// errors in {"error": {"message": ..., ...}}. List pagination moves from the body to meta, and
+// an object holding a single list, like {"articles": [...]}, becomes that list. Other content
+// types, like feeds and event streams, and empty responses are left alone.
+func Envelope() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			// Render the error here so it is wrapped too
+			if err := c.App().Config().ErrorHandler(c, err); err != nil {
+				return err
+			}
+		}
+
+		resp := c.Response()
+		if resp.IsBodyStream() || len(resp.Body()) == 0 {
+			return nil
+		}
+
+		contentType := string(bytes.TrimSpace(bytes.Split(resp.Header.ContentType(), []byte(";"))[0]))
+		status := resp.StatusCode()
+
+		var envelope fiber.Map
+		switch {
+		case status >= fiber.StatusBadRequest && contentType == fiber.MIMETextPlain:
+			envelope = fiber.Map{"error": fiber.Map{"message": string(resp.Body())}}
+		case contentType != fiber.MIMEApplicationJSON:
+			return nil
+		case status >= fiber.StatusBadRequest:
+			envelope = fiber.Map{"error": errorEnvelope(resp.Body())}
+		default:
+			data, meta := dataEnvelope(resp.Body())
+			envelope = fiber.Map{"data": data, "meta": meta}
+		}
+
+		return c.Status(status).JSON(envelope)
+	}
+}
+
+// dataEnvelope splits a successful response body into its data and meta
+func dataEnvelope(body []byte) (any, fiber.Map) {
+	meta := fiber.Map{}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		// Not an object, e.g. a bare list
+		return json.RawMessage(body), meta
+	}
+
+	for _, name := range envelopeMetaFields {
+		if value, ok := fields[name]; ok {
+			meta[name] = value
+			delete(fields, name)
+		}
+	}
+
+	if len(fields) == 1 {
+		for _, value := range fields {
+			if bytes.HasPrefix(bytes.TrimSpace(value), []byte("[")) {
+				return value, meta
+			}
+		}
+	}
+	return fields, meta
+}
+
+// errorEnvelope turns a v1 error body, {"error": "message", ...}, into {"message": "message", ...}
+func errorEnvelope(body []byte) fiber.Map {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return fiber.Map{"message": json.RawMessage(body)}
+	}
+
+	envelope := fiber.Map{}
+	for name, value := range fields {
+		if name == "error" {
+			name = "message"
+		}
+		envelope[name] = value
+	}
+	return envelope
+}
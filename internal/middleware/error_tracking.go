@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/budhilaw/personal-website-backend/pkg/errortracking"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// ErrorTracking gives each request its own Sentry hub, so errors logged while handling it
+// carry the request. It does nothing when Sentry is off.
+func ErrorTracking() fiber.Handler {
+	if !errortracking.Enabled() {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	return func(c *fiber.Ctx) error {
+		header := http.Header{}
+		c.Request().Header.VisitAll(func(key, value []byte) {
+			header.Add(string(key), string(value))
+		})
+
+		hub := errortracking.NewRequestHub(&http.Request{
+			Method: c.Method(),
+			URL: &url.URL{
+				Scheme:   c.Protocol(),
+				Host:     c.Hostname(),
+				Path:     c.Path(),
+				RawQuery: string(c.Request().URI().QueryString()),
+			},
+			Host:       c.Hostname(),
+			Header:     header,
+			RemoteAddr: c.IP(),
+		})
+		c.Locals(errortracking.HubContextKey, hub)
+
+		return c.Next()
+	}
+}
+
+// ReportPanic is the recover middleware's stack trace handler: it logs the panic and reports it to Sentry
+func ReportPanic(c *fiber.Ctx, recovered interface{}) {
+	logger.Error("Recovered from panic",
+		zap.String("method", c.Method()),
+		zap.String("path", c.Path()),
+		zap.String("panic", fmt.Sprint(recovered)),
+		zap.Stack("stack"),
+	)
+
+	errortracking.CapturePanic(c.Context(), recovered)
+}
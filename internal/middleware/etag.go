@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/etag"
+)
+
+// ETag adds weak ETags, hashed from the response body, to successful GET
+// responses and answers a matching If-None-Match with 304 Not Modified
+func ETag() fiber.Handler {
+	return etag.New(etag.Config{
+		Weak: true,
+		Next: func(c *fiber.Ctx) bool {
+			return c.Method() != fiber.MethodGet && c.Method() != fiber.MethodHead
+		},
+	})
+}
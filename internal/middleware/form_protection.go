@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// FormTokenHeader carries the form token for clients that don't send it in the body
+const FormTokenHeader = "X-Form-Token"
+
+// formTokenField is the body field a form token is read from
+const formTokenField = "form_token"
+
+// NewFormToken returns a token recording when a form was shown, signed so it can't be backdated
+func NewFormToken(cfg config.Config) string {
+	issuedAt := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	return issuedAt + "." + signFormToken(cfg.JWTSecret, issuedAt)
+}
+
+// FormProtection rejects likely spam on a route listed in FORM_PROTECTION_ROUTES: submissions
+// that fill in the hidden FORM_HONEYPOT_FIELD, that come sooner than FORM_MIN_SUBMIT_TIME after
+// their form token was issued, or that repeat a successful submission within
+// FORM_DUPLICATE_WINDOW. Submissions are remembered in storage, so duplicates are caught across
+// replicas when it is Redis. Routes not listed pass through.
+func FormProtection(cfg config.Config, route string, storage fiber.Storage) fiber.Handler {
+	if !slices.Contains(cfg.FormProtectionRouteList(), route) {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	honeypot := cfg.FormHoneypotField
+	// Fields that differ between submissions of the same content
+	ignored := append([]string{formTokenField, honeypot}, captchaTokenFields...)
+
+	return func(c *fiber.Ctx) error {
+		fields := formFields(c)
+
+		if honeypot != "" && fields[honeypot] != nil && fields[honeypot] != "" {
+			logger.InfoContext(c.Context(), "Rejected form submission", zap.String("route", route), zap.String("reason", "honeypot"))
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Submission rejected",
+			})
+		}
+
+		if cfg.FormMinSubmitTime > 0 {
+			token := strings.TrimSpace(c.Get(FormTokenHeader))
+			if token == "" {
+				token, _ = fields[formTokenField].(string)
+			}
+
+			issuedAt, ok := parseFormToken(cfg.JWTSecret, token)
+			switch {
+			case !ok:
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "A valid form token is required",
+				})
+			case time.Since(issuedAt) > cfg.FormTokenTTL:
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "Form token has expired, reload the form",
+				})
+			case time.Since(issuedAt) < cfg.FormMinSubmitTime:
+				logger.InfoContext(c.Context(), "Rejected form submission", zap.String("route", route), zap.String("reason", "too fast"))
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "Form was submitted too quickly, try again",
+				})
+			}
+		}
+
+		if cfg.FormDuplicateWindow <= 0 || fields == nil {
+			return c.Next()
+		}
+
+		key := "form:" + route + ":" + formContentHash(fields, ignored)
+		if seen, err := storage.Get(key); err != nil {
+			logger.ErrorContext(c.Context(), "Failed to check for a duplicate form submission", zap.String("route", route), zap.Error(err))
+		} else if seen != nil {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "This was already submitted",
+			})
+		}
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		// Only successful submissions count, so a rejected one can be fixed and sent again
+		if c.Response().StatusCode() < fiber.StatusBadRequest {
+			if err := storage.Set(key, []byte{1}, cfg.FormDuplicateWindow); err != nil {
+				logger.ErrorContext(c.Context(), "Failed to remember a form submission", zap.String("route", route), zap.Error(err))
+			}
+		}
+		return nil
+	}
+}
+
+// formFields reads the fields of a JSON or form body; nil if it can't be read
+func formFields(c *fiber.Ctx) map[string]interface{} {
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEApplicationJSON) {
+		var fields map[string]interface{}
+		if err := json.Unmarshal(c.Body(), &fields); err != nil {
+			return nil
+		}
+		return fields
+	}
+
+	fields := map[string]interface{}{}
+	if form, err := c.MultipartForm(); err == nil {
+		for name, values := range form.Value {
+			fields[name] = strings.Join(values, ",")
+		}
+		return fields
+	}
+
+	c.Request().PostArgs().VisitAll(func(key, value []byte) {
+		fields[string(key)] = string(value)
+	})
+	return fields
+}
+
+// formContentHash hashes a submission's fields, leaving out the ignored ones, so the same
+// content hashes alike whatever its letter case, spacing or field order
+func formContentHash(fields map[string]interface{}, ignored []string) string {
+	content := make(map[string]interface{}, len(fields))
+	for name, value := range fields {
+		if slices.Contains(ignored, name) {
+			continue
+		}
+		if text, ok := value.(string); ok {
+			value = strings.Join(strings.Fields(strings.ToLower(text)), " ")
+		}
+		content[name] = value
+	}
+
+	// Map keys are marshalled in sorted order
+	encoded, _ := json.Marshal(content)
+
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+// parseFormToken returns when a form token was issued, if its signature is valid
+func parseFormToken(secret, token string) (time.Time, bool) {
+	issuedAt, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(signFormToken(secret, issuedAt))) {
+		return time.Time{}, false
+	}
+
+	millis, err := strconv.ParseInt(issuedAt, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(millis), true
+}
+
+// signFormToken signs the issue time of a form token
+func signFormToken(secret, issuedAt string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("form-token:" + issuedAt))
+	return hex.EncodeToString(mac.Sum(nil))
+}
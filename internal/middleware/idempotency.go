@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Idempotency headers; the replay header marks a response served from storage
+const (
+	IdempotencyKeyHeader    = "Idempotency-Key"
+	IdempotencyReplayHeader = "Idempotent-Replayed"
+)
+
+// Idempotency key settings
+const (
+	maxIdempotencyKeyLength = 255
+	// How long a key stays reserved by a request that never finishes, e.g. after a crash
+	idempotencyLockTimeout = time.Minute
+)
+
+// Idempotency makes a POST sent with an Idempotency-Key header safe to retry. The first
+// request runs and its response is stored for IDEMPOTENCY_KEY_TTL; retries with the same key
+// and body get that response back instead of running again. Keys are scoped to the signed-in
+// user, so it must run after Protected. Requests without the header pass through.
+func Idempotency(store repository.IdempotencyRepository, cfg config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(IdempotencyKeyHeader)
+		if key == "" || c.Method() != fiber.MethodPost {
+			return c.Next()
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Idempotency-Key must be at most 255 characters",
+			})
+		}
+
+		userID, _ := c.Locals("user_id").(string)
+		if userID == "" {
+			return c.Next()
+		}
+
+		hash := requestHash(c)
+		existing, err := store.Reserve(c.Context(), userID, key, hash, idempotencyLockTimeout)
+		if err != nil {
+			logger.ErrorContext(c.Context(), "Failed to reserve idempotency key", zap.Error(err))
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": "Idempotent requests are unavailable, try again later",
+			})
+		}
+
+		if existing != nil {
+			switch {
+			case existing.RequestHash != hash:
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+					"error": "Idempotency-Key was already used for a different request",
+				})
+			case existing.StatusCode == nil:
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+					"error": "A request with this Idempotency-Key is still being processed",
+				})
+			}
+
+			c.Set(IdempotencyReplayHeader, "true")
+			if existing.ContentType != nil {
+				c.Set(fiber.HeaderContentType, *existing.ContentType)
+			}
+			return c.Status(*existing.StatusCode).Send(existing.Response)
+		}
+
+		err = c.Next()
+
+		// Server errors aren't stored so the request can be retried with the same key
+		status := c.Response().StatusCode()
+		if err != nil || status >= fiber.StatusInternalServerError {
+			if releaseErr := store.Release(c.Context(), userID, key); releaseErr != nil {
+				logger.ErrorContext(c.Context(), "Failed to release idempotency key", zap.Error(releaseErr))
+			}
+			return err
+		}
+
+		contentType := string(c.Response().Header.ContentType())
+		response := append([]byte(nil), c.Response().Body()...)
+		if err := store.Complete(c.Context(), userID, key, status, contentType, response, cfg.IdempotencyKeyTTL); err != nil {
+			logger.ErrorContext(c.Context(), "Failed to store idempotent response", zap.Error(err))
+		}
+		return nil
+	}
+}
+
+// requestHash identifies a request by its method, path and body, so a key reused for a
+// different request can be told apart from a retry
+func requestHash(c *fiber.Ctx) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Method()))
+	hash.Write([]byte{0})
+	hash.Write([]byte(c.OriginalURL()))
+	hash.Write([]byte{0})
+	hash.Write(c.Body())
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -33,24 +33,20 @@ func NewJWTManager(cfg config.Config) *JWTManager {
 		config:           cfg,
 	}
 
-	// Start secret rotation in background
-	go manager.rotateSecretsPeriodically()
-
 	return manager
 }
 
-// rotateSecretsPeriodically rotates JWT secrets at the specified interval
-func (m *JWTManager) rotateSecretsPeriodically() {
-	ticker := time.NewTicker(m.rotationInterval / 2) // Check at half the interval
-	defer ticker.Stop()
+// RotateIfDue rotates the secrets once the rotation interval has passed
+func (m *JWTManager) RotateIfDue() error {
+	m.mutex.RLock()
+	due := time.Since(m.secretCreatedAt) >= m.rotationInterval
+	m.mutex.RUnlock()
 
-	for range ticker.C {
-		if time.Since(m.secretCreatedAt) >= m.rotationInterval {
-			if err := m.rotateSecrets(); err != nil {
-				logger.Error("Failed to rotate JWT secrets", zap.Error(err))
-			}
-		}
+	if !due {
+		return nil
 	}
+
+	return m.rotateSecrets()
 }
 
 // rotateSecrets generates a new secret and rotates the existing one
@@ -77,12 +73,13 @@ func (m *JWTManager) rotateSecrets() error {
 }
 
 // GenerateToken generates a new JWT token using the current secret
-func (m *JWTManager) GenerateToken(userID string, username string, isAdmin bool) (string, error) {
+func (m *JWTManager) GenerateToken(userID string, username string, isAdmin bool, role string) (string, error) {
 	// Create token claims
 	claims := JWTClaims{
 		UserID:   userID,
 		Username: username,
 		IsAdmin:  isAdmin,
+		Role:     role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.config.JWTExpiration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -105,12 +102,13 @@ func (m *JWTManager) GenerateToken(userID string, username string, isAdmin bool)
 }
 
 // GenerateRefreshToken generates a new refresh token
-func (m *JWTManager) GenerateRefreshToken(userID string, username string, isAdmin bool) (string, error) {
+func (m *JWTManager) GenerateRefreshToken(userID string, username string, isAdmin bool, role string) (string, error) {
 	// Create token claims
 	claims := JWTClaims{
 		UserID:   userID,
 		Username: username,
 		IsAdmin:  isAdmin,
+		Role:     role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(m.config.JWTRefreshExpiration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
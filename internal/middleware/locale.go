@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Locale negotiates the content locale from the ?lang= query parameter or the
+// Accept-Language header, storing it in c.Locals("locale")
+func Locale(cfg config.Config) fiber.Handler {
+	supported := cfg.Locales()
+	defaultLocale := supported[0]
+
+	return func(c *fiber.Ctx) error {
+		locale := defaultLocale
+		if lang := matchLocale(c.Query("lang"), supported); lang != "" {
+			locale = lang
+		} else if lang := negotiateLocale(c.Get(fiber.HeaderAcceptLanguage), supported); lang != "" {
+			locale = lang
+		}
+
+		c.Locals("locale", locale)
+		c.Vary(fiber.HeaderAcceptLanguage)
+
+		return c.Next()
+	}
+}
+
+// negotiateLocale picks the best supported locale from an Accept-Language header
+func negotiateLocale(header string, supported []string) string {
+	type candidate struct {
+		tag string
+		q   float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{tag: tag, q: q})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+
+	for _, candidate := range candidates {
+		if locale := matchLocale(candidate.tag, supported); locale != "" {
+			return locale
+		}
+	}
+
+	return ""
+}
+
+// matchLocale matches a language tag exactly, then by its primary subtag (en-US -> en)
+func matchLocale(tag string, supported []string) string {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if tag == "" {
+		return ""
+	}
+	if slices.Contains(supported, tag) {
+		return tag
+	}
+
+	primary, _, _ := strings.Cut(tag, "-")
+	if slices.Contains(supported, primary) {
+		return primary
+	}
+
+	return ""
+}
@@ -11,6 +11,8 @@ import (
 
 // ZapLogger is a middleware that logs HTTP requests using zap
 func ZapLogger() fiber.Handler {
+	log := logger.Named("http")
+
 	return func(c *fiber.Ctx) error {
 		start := time.Now()
 
@@ -37,13 +39,13 @@ func ZapLogger() fiber.Handler {
 		// Log based on status code
 		switch {
 		case status >= 500:
-			logger.Error("Server error", fields...)
+			log.Error("Server error", fields...)
 		case status >= 400:
-			logger.Warn("Client error", fields...)
+			log.Warn("Client error", fields...)
 		case status >= 300:
-			logger.Info("Redirection", fields...)
+			log.Info("Redirection", fields...)
 		default:
-			logger.Info("Success", fields...)
+			log.Info("Success", fields...)
 		}
 
 		return err
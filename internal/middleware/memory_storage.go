@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"sync"
+	"time"
+)
+
+// memoryStorage is an in-memory fiber.Storage shared by every rate limiter of the instance, so
+// a rule's counter is the same whichever route group or API version counts the request
+type memoryStorage struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+// memoryEntry is a stored value; a zero expiresAt never expires
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// newMemoryStorage creates a memoryStorage that drops expired entries every minute
+func newMemoryStorage() *memoryStorage {
+	storage := &memoryStorage{entries: make(map[string]memoryEntry)}
+
+	go func() {
+		for range time.Tick(time.Minute) {
+			storage.deleteExpired()
+		}
+	}()
+
+	return storage
+}
+
+// Get gets the value of a key; missing and expired keys return nil
+func (s *memoryStorage) Get(key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok || entry.expired(time.Now()) {
+		return nil, nil
+	}
+	return entry.value, nil
+}
+
+// Set stores a value for exp; zero keeps it until deleted
+func (s *memoryStorage) Set(key string, val []byte, exp time.Duration) error {
+	if key == "" || len(val) == 0 {
+		return nil
+	}
+
+	entry := memoryEntry{value: append([]byte(nil), val...)}
+	if exp > 0 {
+		entry.expiresAt = time.Now().Add(exp)
+	}
+
+	s.mu.Lock()
+	s.entries[key] = entry
+	s.mu.Unlock()
+	return nil
+}
+
+// Delete deletes a key
+func (s *memoryStorage) Delete(key string) error {
+	s.mu.Lock()
+	delete(s.entries, key)
+	s.mu.Unlock()
+	return nil
+}
+
+// Reset deletes every key
+func (s *memoryStorage) Reset() error {
+	s.mu.Lock()
+	s.entries = make(map[string]memoryEntry)
+	s.mu.Unlock()
+	return nil
+}
+
+// Close does nothing; the storage lives as long as the process
+func (s *memoryStorage) Close() error {
+	return nil
+}
+
+// deleteExpired drops the entries past their expiry
+func (s *memoryStorage) deleteExpired() {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, entry := range s.entries {
+		if entry.expired(now) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// expired reports whether the entry has expired at now
+func (e memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
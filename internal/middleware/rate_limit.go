@@ -0,0 +1,109 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/db"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"github.com/gofiber/storage/redis/v3"
+	"go.uber.org/zap"
+)
+
+// RateLimitRule describes the request budget for a route group
+type RateLimitRule struct {
+	Name       string         // Prefix for storage keys so groups don't share counters
+	Max        int            // Maximum number of requests per window
+	Expiration time.Duration  // Length of the window
+	Crawler    *RateLimitRule // Separate budget for requests from bots, or nil to count them with the rest
+	// CountAPIKeys counts requests with an API key too, for budgets an API key's own limit
+	// isn't meant to replace
+	CountAPIKeys bool
+}
+
+// AuthRateLimitRule returns the rate limit rule for authentication routes
+func AuthRateLimitRule(cfg config.Config) RateLimitRule {
+	return RateLimitRule{Name: "auth", Max: cfg.RateLimitAuthMax, Expiration: cfg.RateLimitAuthWindow}
+}
+
+// UnlockRateLimitRule returns the rate limit rule for unlocking password-protected articles,
+// as strict as authentication but counted separately from logins. Requests with an API key
+// count too, so a key can't be used to guess passwords.
+func UnlockRateLimitRule(cfg config.Config) RateLimitRule {
+	return RateLimitRule{Name: "unlock", Max: cfg.RateLimitAuthMax, Expiration: cfg.RateLimitAuthWindow, CountAPIKeys: true}
+}
+
+// PublicRateLimitRule returns the rate limit rule for public routes; bots get the crawler
+// budget when RATE_LIMIT_CRAWLER_MAX is set
+func PublicRateLimitRule(cfg config.Config) RateLimitRule {
+	rule := RateLimitRule{Name: "public", Max: cfg.RateLimitPublicMax, Expiration: cfg.RateLimitPublicWindow}
+	if cfg.RateLimitCrawlerMax > 0 {
+		rule.Crawler = &RateLimitRule{Name: "crawler", Max: cfg.RateLimitCrawlerMax, Expiration: cfg.RateLimitCrawlerWindow}
+	}
+	return rule
+}
+
+// AdminRateLimitRule returns the rate limit rule for admin routes
+func AdminRateLimitRule(cfg config.Config) RateLimitRule {
+	return RateLimitRule{Name: "admin", Max: cfg.RateLimitAdminMax, Expiration: cfg.RateLimitAdminWindow}
+}
+
+// NewRateLimitStorage returns the storage backend for rate limit counters; the in-memory
+// store is shared by every limiter of this instance
+func NewRateLimitStorage(cfg config.Config) fiber.Storage {
+	if cfg.RateLimitStorage != "redis" {
+		return newMemoryStorage()
+	}
+
+	client, err := db.InitRedis(cfg)
+	if err != nil {
+		logger.Error("Failed to initialize Redis rate limit storage, falling back to memory", zap.Error(err))
+		return newMemoryStorage()
+	}
+
+	logger.Info("Using Redis for rate limit storage")
+	return redis.NewFromConnection(client)
+}
+
+// RateLimiter middleware for rate limiting a route group; requests already limited by their API key are
+// skipped unless the rule counts them, and requests classified by BotDetection as bots count against the rule's crawler budget if any
+func RateLimiter(rule RateLimitRule, storage fiber.Storage) fiber.Handler {
+	limit := newLimiter(rule, storage)
+	if rule.Crawler == nil {
+		return limit
+	}
+
+	crawlerLimit := newLimiter(*rule.Crawler, storage)
+	return func(c *fiber.Ctx) error {
+		if IsBot(c) {
+			return crawlerLimit(c)
+		}
+		return limit(c)
+	}
+}
+
+// newLimiter creates the limiter counting requests per IP against a rule
+func newLimiter(rule RateLimitRule, storage fiber.Storage) fiber.Handler {
+	return limiter.New(limiter.Config{
+		Next: func(c *fiber.Ctx) bool {
+			if rule.CountAPIKeys {
+				return false
+			}
+			_, ok := c.Locals("api_key_id").(string)
+			return ok
+		},
+		Max:        rule.Max,
+		Expiration: rule.Expiration,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return "ratelimit:" + rule.Name + ":" + c.IP() // use IP as key, scoped to the group
+		},
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": "Too many requests",
+			})
+		},
+		Storage: storage,
+	})
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/gofiber/fiber/v2"
+)
+
+// withAPIKey marks requests as authenticated by an API key, as APIKey does
+func withAPIKey(c *fiber.Ctx) error {
+	c.Locals("api_key_id", "key-id")
+	return c.Next()
+}
+
+func TestRateLimiterAPIKeys(t *testing.T) {
+	cfg := config.Config{RateLimitAuthMax: 2, RateLimitAuthWindow: time.Minute}
+
+	tests := []struct {
+		name   string
+		rule   RateLimitRule
+		status int // status of the request after the budget is spent
+	}{
+		{"unlock counts keyed requests", UnlockRateLimitRule(cfg), fiber.StatusTooManyRequests},
+		{"other rules skip keyed requests", AuthRateLimitRule(cfg), fiber.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Post("/", withAPIKey, RateLimiter(tt.rule, newMemoryStorage()), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			var status int
+			for i := 0; i <= tt.rule.Max; i++ {
+				resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/", nil))
+				if err != nil {
+					t.Fatalf("request: %v", err)
+				}
+				status = resp.StatusCode
+			}
+			if status != tt.status {
+				t.Errorf("request %d: status %d, want %d", tt.rule.Max+1, status, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jmoiron/sqlx"
+)
+
+// ReadReplica lets repository reads made while handling GET and HEAD requests go to the
+// replica pool. It does nothing when no replica is configured.
+func ReadReplica(replica *sqlx.DB) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if replica != nil && (c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead) {
+			c.Locals(repository.ReadReplicaKey{}, replica)
+		}
+		return c.Next()
+	}
+}
@@ -1,19 +1,24 @@
 package middleware
 
 import (
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/helmet"
-	"github.com/gofiber/fiber/v2/middleware/limiter"
 )
 
+// ArticleTokenHeader carries the access token of a password-protected article
+const ArticleTokenHeader = "X-Article-Token"
+
 // Security middleware for adding security headers and protections
-func Security(frontendURL string) fiber.Handler {
-	// Use cors middleware
+func Security(allowedOrigins []string) fiber.Handler {
+	// Use cors middleware; entries like "https://*.example.com" match any subdomain
 	return cors.New(cors.Config{
-		AllowOrigins:     frontendURL,
+		AllowOrigins:     strings.Join(allowedOrigins, ","),
 		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, " + CaptchaTokenHeader + ", " + FormTokenHeader + ", " + ArticleTokenHeader + ", " + IdempotencyKeyHeader,
+		ExposeHeaders:    strings.Join([]string{IdempotencyReplayHeader, APIVersionHeader, DeprecationHeader, SunsetHeader, fiber.HeaderLink}, ", "),
 		AllowCredentials: true,
 		MaxAge:           86400, // 24 hours
 	})
@@ -28,19 +33,3 @@ func Helmet() fiber.Handler {
 		ReferrerPolicy:        "no-referrer-when-downgrade",
 	})
 }
-
-// RateLimiter middleware for rate limiting
-func RateLimiter() fiber.Handler {
-	return limiter.New(limiter.Config{
-		Max:        100,              // max 100 requests
-		Expiration: 60 * 1000000000, // 1 minute
-		KeyGenerator: func(c *fiber.Ctx) string {
-			return c.IP() // use IP as key
-		},
-		LimitReached: func(c *fiber.Ctx) error {
-			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
-				"error": "Too many requests",
-			})
-		},
-	})
-} 
\ No newline at end of file
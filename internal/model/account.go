@@ -0,0 +1,51 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// What happens to a deleted account's content
+const (
+	DeletionContentReassign = "reassign"
+	DeletionContentDelete   = "delete"
+)
+
+// AccountDeletion represents an account deletion request body. Content is either reassigned
+// to another user or deleted along with the account.
+type AccountDeletion struct {
+	Password   string `json:"password" validate:"required"`
+	Content    string `json:"content" validate:"required,oneof=reassign delete"`
+	ReassignTo string `json:"reassign_to" validate:"required_if=Content reassign,omitempty,uuid"`
+}
+
+// AccountDeletionScheduled describes a deletion waiting out its grace period
+type AccountDeletionScheduled struct {
+	DeleteAfter time.Time `json:"delete_after"`
+	Content     string    `json:"content"`
+	ReassignTo  string    `json:"reassign_to,omitempty"`
+}
+
+// PendingAccountDeletion is an account whose grace period has passed
+type PendingAccountDeletion struct {
+	UserID     string
+	Content    string
+	ReassignTo string
+}
+
+// AccountExport is a copy of everything stored about a user. Content sections hold the rows
+// as stored, so the archive keeps fields the API doesn't return.
+type AccountExport struct {
+	ExportedAt       time.Time       `json:"exported_at"`
+	Profile          User            `json:"profile"`
+	Articles         json.RawMessage `json:"articles"`
+	ArticleRevisions json.RawMessage `json:"article_revisions"`
+	Portfolios       json.RawMessage `json:"portfolios"`
+	Series           json.RawMessage `json:"series"`
+	Pages            json.RawMessage `json:"pages"`
+	Links            json.RawMessage `json:"links"`
+	Media            json.RawMessage `json:"media"`
+	Campaigns        json.RawMessage `json:"campaigns"`
+	LoginHistory     json.RawMessage `json:"login_history"`
+	LoginDevices     json.RawMessage `json:"login_devices"`
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ActivityPubFollower is a fediverse account following the blog
+type ActivityPubFollower struct {
+	ID          string    `json:"id"`
+	ActorID     string    `json:"actor_id"`
+	Inbox       string    `json:"inbox"`
+	SharedInbox string    `json:"shared_inbox,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// RemoteActor is the part of a remote actor document needed to verify and answer it
+type RemoteActor struct {
+	ID        string `json:"id"`
+	Inbox     string `json:"inbox"`
+	Endpoints struct {
+		SharedInbox string `json:"sharedInbox"`
+	} `json:"endpoints"`
+	PublicKey struct {
+		ID           string `json:"id"`
+		Owner        string `json:"owner"`
+		PublicKeyPem string `json:"publicKeyPem"`
+	} `json:"publicKey"`
+}
+
+// Activity is an incoming ActivityPub activity; Object stays raw because its shape depends on Type
+type Activity struct {
+	ID     string          `json:"id"`
+	Type   string          `json:"type"`
+	Actor  string          `json:"actor"`
+	Object json.RawMessage `json:"object"`
+}
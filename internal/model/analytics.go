@@ -0,0 +1,54 @@
+package model
+
+// PageviewRequest represents a pageview reported by the frontend
+type PageviewRequest struct {
+	Path     string `json:"path" validate:"required"`
+	Referrer string `json:"referrer"`
+}
+
+// AnalyticsDailyStat represents the totals for a single day
+type AnalyticsDailyStat struct {
+	Day      string `json:"day"`
+	Views    int    `json:"views"`
+	Visitors int    `json:"visitors"`
+}
+
+// AnalyticsSummary represents totals and a daily breakdown for a date range.
+// Visitors are unique per day, so the total is the sum of daily uniques.
+type AnalyticsSummary struct {
+	From     string               `json:"from"`
+	To       string               `json:"to"`
+	Views    int                  `json:"views"`
+	Visitors int                  `json:"visitors"`
+	Daily    []AnalyticsDailyStat `json:"daily"`
+}
+
+// SearchQueryStat represents how often a query was searched and its latest result count
+type SearchQueryStat struct {
+	Query    string `json:"query"`
+	Searches int    `json:"searches"`
+	Results  int    `json:"results"`
+}
+
+// SearchReport represents the most common and the zero-result search queries for a date range
+type SearchReport struct {
+	From        string            `json:"from"`
+	To          string            `json:"to"`
+	Top         []SearchQueryStat `json:"top"`
+	ZeroResults []SearchQueryStat `json:"zero_results"`
+}
+
+// AnalyticsBreakdown represents the views for a single path, referrer or country
+type AnalyticsBreakdown struct {
+	Key   string `json:"key"`
+	Views int    `json:"views"`
+}
+
+// ReferrerReport represents the referring domains with the most views for a date range. Direct
+// counts the views without an external referrer, like typed URLs, bookmarks and apps.
+type ReferrerReport struct {
+	From   string               `json:"from"`
+	To     string               `json:"to"`
+	Direct int                  `json:"direct"`
+	Items  []AnalyticsBreakdown `json:"items"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"time"
+)
+
+// APIKey identifies a client of the public API; requests sent with it are rate limited by its tier.
+// The key itself is only shown once, when it is created.
+type APIKey struct {
+	ID         string     `json:"id"`
+	UserID     string     `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Tier       string     `json:"tier"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// APIKeyCreate represents API key creation request body; an empty tier uses the first configured tier
+type APIKeyCreate struct {
+	Name string `json:"name" validate:"required,max=100"`
+	Tier string `json:"tier" validate:"max=50"`
+}
+
+// APIKeyCreated is a new API key together with its secret
+type APIKeyCreated struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyUsage is an API key's request count in the current rate limit window and its daily history
+type APIKeyUsage struct {
+	KeyID     string           `json:"key_id"`
+	Tier      string           `json:"tier"`
+	Limit     int              `json:"limit"`
+	Window    string           `json:"window"`
+	Used      int              `json:"used"`
+	Remaining int              `json:"remaining"`
+	Days      []APIKeyUsageDay `json:"days"`
+}
+
+// APIKeyUsageDay is the number of requests made with an API key on a day (UTC)
+type APIKeyUsageDay struct {
+	Date     string `json:"date"`
+	Requests int    `json:"requests"`
+}
@@ -4,57 +4,173 @@ import (
 	"time"
 )
 
+// Article workflow statuses
+const (
+	ArticleStatusDraft     = "draft"
+	ArticleStatusInReview  = "in_review"
+	ArticleStatusScheduled = "scheduled"
+	ArticleStatusPublished = "published"
+	ArticleStatusArchived  = "archived"
+)
+
+// Article is an article; IsPublished is derived from Status for older clients
 type Article struct {
-	ID            string    `json:"id"`
-	Title         string    `json:"title"`
-	Slug          string    `json:"slug"`
-	Content       string    `json:"content"`
-	Excerpt       string    `json:"excerpt,omitempty"`
-	FeaturedImage string    `json:"featured_image,omitempty"`
-	IsPublished   bool      `json:"is_published"`
-	UserID        string    `json:"user_id"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
-	PublishedAt   time.Time `json:"published_at,omitempty"`
-}
-
-// ArticleCreate represents article creation request body
+	ID            string     `json:"id"`
+	Title         string     `json:"title"`
+	Slug          string     `json:"slug"`
+	Content       string     `json:"content"`
+	Excerpt       string     `json:"excerpt,omitempty"`
+	FeaturedImage string     `json:"featured_image,omitempty"`
+	Status        string     `json:"status"`
+	IsPublished   bool       `json:"is_published"`
+	IsFeatured    bool       `json:"is_featured"`
+	UserID        string     `json:"user_id"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	PublishedAt   time.Time  `json:"published_at,omitempty"`
+	ScheduledAt   time.Time  `json:"scheduled_at,omitempty"`
+	SeriesID      string     `json:"series_id,omitempty"`
+	SeriesOrder   int        `json:"series_order,omitempty"`
+	TOC           []TOCEntry `json:"toc,omitempty"`
+	// IsProtected is set when readers need the article's password to see its content
+	IsProtected  bool   `json:"is_protected"`
+	PasswordHash string `json:"-"`
+	SEOMeta
+}
+
+// TOCEntry is a heading in an article's table of contents; Anchor is the id of the rendered heading
+type TOCEntry struct {
+	Level  int    `json:"level"`
+	Anchor string `json:"anchor"`
+	Text   string `json:"text"`
+}
+
+// ArticleCreate represents article creation request body; an empty slug is generated from the title.
+// IsPublished creates the article published instead of as a draft.
 type ArticleCreate struct {
 	Title         string `json:"title" validate:"required"`
+	Slug          string `json:"slug"`
 	Content       string `json:"content" validate:"required"`
 	Excerpt       string `json:"excerpt"`
 	FeaturedImage string `json:"featured_image"`
 	IsPublished   bool   `json:"is_published"`
+	SeriesID      string `json:"series_id"`
+	SeriesOrder   int    `json:"series_order"`
+	SEOMeta
 }
 
-// ArticleUpdate represents article update request body
+// ArticleUpdate represents article update request body; an empty slug is generated from the title.
+// IsPublished publishes the article, or takes a published article back to draft; other statuses are kept.
 type ArticleUpdate struct {
 	Title         string `json:"title" validate:"required"`
+	Slug          string `json:"slug"`
 	Content       string `json:"content" validate:"required"`
 	Excerpt       string `json:"excerpt"`
 	FeaturedImage string `json:"featured_image"`
 	IsPublished   bool   `json:"is_published"`
+	SeriesID      string `json:"series_id"`
+	SeriesOrder   int    `json:"series_order"`
+	SEOMeta
+}
+
+// NextStatus returns the status an article in status current has after the update
+func (u *ArticleUpdate) NextStatus(current string) string {
+	switch {
+	case u.IsPublished:
+		return ArticleStatusPublished
+	case current == ArticleStatusPublished:
+		return ArticleStatusDraft
+	}
+	return current
+}
+
+// ArticleTransition represents a workflow status change request body; ScheduledAt is
+// required when scheduling, and read in the site timezone when it has no UTC offset
+type ArticleTransition struct {
+	Status      string    `json:"status" validate:"required,oneof=draft in_review scheduled published archived"`
+	ScheduledAt LocalTime `json:"scheduled_at"`
+}
+
+// ArticleFeature represents the request body that pins an article to or unpins it from the featured list
+type ArticleFeature struct {
+	IsFeatured bool `json:"is_featured"`
+}
+
+// ArticlePassword represents the request body that protects an article with a password
+type ArticlePassword struct {
+	Password string `json:"password" validate:"required,min=4,max=128"`
+}
+
+// ArticleUnlock represents the request body that unlocks a password-protected article
+type ArticleUnlock struct {
+	Password string `json:"password" validate:"required"`
 }
 
-// ArticleResponse represents article response with author information
+// ArticleAccess is a short-lived token granting access to a password-protected article's content
+type ArticleAccess struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ArticleResponse represents article response with author information;
+// ContentHTML is Content rendered from Markdown with highlighted code blocks
 type ArticleResponse struct {
 	ID            string `json:"id"`
 	Title         string `json:"title"`
 	Slug          string `json:"slug"`
 	Content       string `json:"content"`
+	ContentHTML   string `json:"content_html"`
 	Excerpt       string `json:"excerpt,omitempty"`
 	FeaturedImage string `json:"featured_image,omitempty"`
+	Status        string `json:"status"`
 	IsPublished   bool   `json:"is_published"`
-	Author        struct {
+	IsFeatured    bool   `json:"is_featured"`
+	SEOMeta
+	Author struct {
 		ID        string `json:"id"`
 		Username  string `json:"username"`
 		FirstName string `json:"first_name"`
 		LastName  string `json:"last_name,omitempty"`
 		Avatar    string `json:"avatar,omitempty"`
 	} `json:"author"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	PublishedAt time.Time `json:"published_at,omitempty"`
+	Series *ArticleSeries `json:"series,omitempty"`
+	Tags   []Tag          `json:"tags,omitempty"`
+	TOC    []TOCEntry     `json:"toc"`
+	// IsProtected is set for password-protected articles; Locked when their content was left out
+	IsProtected  bool   `json:"is_protected"`
+	Locked       bool   `json:"locked,omitempty"`
+	PasswordHash string `json:"-"`
+	// Locale is the language of the returned content; AvailableLocales lists translations
+	Locale           string   `json:"locale,omitempty"`
+	AvailableLocales []string `json:"available_locales,omitempty"`
+	// Syndications links the copies cross-posted to other platforms
+	Syndications []ArticleSyndication `json:"syndications,omitempty"`
+	// Discussion holds the giscus comment count and latest comments, when comments are synced
+	Discussion *ArticleDiscussion `json:"discussion,omitempty"`
+	// RedirectedFrom is set when the article was found by a previous slug
+	RedirectedFrom string    `json:"redirected_from,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	PublishedAt    time.Time `json:"published_at,omitempty"`
+	ScheduledAt    time.Time `json:"scheduled_at,omitempty"`
+}
+
+// ArticleLink is a lightweight reference to another article
+type ArticleLink struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
+
+// ArticleSeries describes an article's position within its series
+type ArticleSeries struct {
+	ID       string       `json:"id"`
+	Title    string       `json:"title"`
+	Slug     string       `json:"slug"`
+	Part     int          `json:"part"`
+	Total    int          `json:"total"`
+	Previous *ArticleLink `json:"previous,omitempty"`
+	Next     *ArticleLink `json:"next,omitempty"`
 }
 
 // ArticleList represents a list of articles with pagination
@@ -64,3 +180,39 @@ type ArticleList struct {
 	Page     int               `json:"page"`
 	PerPage  int               `json:"per_page"`
 }
+
+// ArchiveMonth represents the number of articles published in a calendar month of the site timezone
+type ArchiveMonth struct {
+	Year  int `json:"year"`
+	Month int `json:"month"`
+	Count int `json:"count"`
+}
+
+// ArticleArchive represents the articles published in a single month
+type ArticleArchive struct {
+	Year     int               `json:"year"`
+	Month    int               `json:"month"`
+	Articles []ArticleResponse `json:"articles"`
+}
+
+// ArticleCursorList represents a keyset-paginated list of articles
+type ArticleCursorList struct {
+	Articles   []ArticleResponse `json:"articles"`
+	PerPage    int               `json:"per_page"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+// ArticlePartialList is an ArticleList of articles with only the requested fields
+type ArticlePartialList struct {
+	Articles []PartialItem `json:"articles"`
+	Total    int           `json:"total"`
+	Page     int           `json:"page"`
+	PerPage  int           `json:"per_page"`
+}
+
+// ArticlePartialCursorList is an ArticleCursorList of articles with only the requested fields
+type ArticlePartialCursorList struct {
+	Articles   []PartialItem `json:"articles"`
+	PerPage    int           `json:"per_page"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
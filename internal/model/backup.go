@@ -0,0 +1,13 @@
+package model
+
+import (
+	"time"
+)
+
+// Backup represents a stored database dump
+type Backup struct {
+	Name      string    `json:"name"`
+	Size      int64     `json:"size"`
+	Location  string    `json:"location"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+)
+
+// Campaign statuses
+const (
+	CampaignDraft   = "draft"
+	CampaignSending = "sending"
+	CampaignSent    = "sent"
+)
+
+// Campaign recipient statuses
+const (
+	CampaignRecipientPending = "pending"
+	CampaignRecipientSent    = "sent"
+	CampaignRecipientSkipped = "skipped"
+)
+
+// Campaign is a newsletter issue sent to every confirmed subscriber
+type Campaign struct {
+	ID          string `json:"id"`
+	Subject     string `json:"subject"`
+	Content     string `json:"content"`
+	ContentHTML string `json:"content_html,omitempty"`
+	ArticleID   string `json:"article_id,omitempty"`
+	Status      string `json:"status"`
+	UserID      string `json:"user_id,omitempty"`
+	// Delivery counts; opens are counted once per recipient from the tracking pixel
+	Recipients int        `json:"recipients"`
+	SentCount  int        `json:"sent_count"`
+	OpenCount  int        `json:"open_count"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// CampaignCreate represents campaign creation request body; content is Markdown
+type CampaignCreate struct {
+	Subject string `json:"subject" validate:"required,max=255"`
+	Content string `json:"content" validate:"required"`
+}
+
+// CampaignUpdate represents campaign update request body
+type CampaignUpdate = CampaignCreate
+
+// CampaignList represents a list of campaigns with pagination
+type CampaignList struct {
+	Campaigns []Campaign `json:"campaigns"`
+	Total     int        `json:"total"`
+	Page      int        `json:"page"`
+	PerPage   int        `json:"per_page"`
+}
+
+// CampaignRecipient is a subscriber a campaign is being sent to
+type CampaignRecipient struct {
+	ID               string
+	Email            string
+	UnsubscribeToken string
+}
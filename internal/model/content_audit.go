@@ -0,0 +1,52 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Audited content types
+const (
+	AuditEntityArticle   = "article"
+	AuditEntityPortfolio = "portfolio"
+)
+
+// Audited actions
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// ContentAuditEntry is a change to an article or portfolio with the full JSON before and after.
+// Before is null for creations and After for deletions. ActorID is empty for changes made by
+// the API itself, such as publishing scheduled articles.
+type ContentAuditEntry struct {
+	ID            string          `json:"id"`
+	EntityType    string          `json:"entity_type"`
+	EntityID      string          `json:"entity_id"`
+	Action        string          `json:"action"`
+	ActorID       string          `json:"actor_id,omitempty"`
+	ActorUsername string          `json:"actor_username,omitempty"`
+	ChangedFields []string        `json:"changed_fields"`
+	Before        json.RawMessage `json:"before,omitempty"`
+	After         json.RawMessage `json:"after,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// ContentAuditFilter narrows audit listings; empty fields are ignored
+type ContentAuditFilter struct {
+	EntityType string
+	EntityID   string
+	ActorID    string
+	// Field lists the entries that changed the named JSON field, e.g. "title"
+	Field string
+}
+
+// ContentAuditList represents a list of audit entries with pagination; entries leave out the snapshots
+type ContentAuditList struct {
+	Entries []ContentAuditEntry `json:"entries"`
+	Total   int                 `json:"total"`
+	Page    int                 `json:"page"`
+	PerPage int                 `json:"per_page"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+)
+
+// ImportedArticle links an article to the Markdown file it was synced from
+type ImportedArticle struct {
+	Path       string    `json:"path"`
+	ArticleID  string    `json:"article_id"`
+	Checksum   string    `json:"-"`
+	ImportedAt time.Time `json:"imported_at"`
+}
+
+// ArticleFrontMatter is the YAML front matter of an imported Markdown file
+type ArticleFrontMatter struct {
+	Title           string `yaml:"title"`
+	Slug            string `yaml:"slug"`
+	Excerpt         string `yaml:"excerpt"`
+	FeaturedImage   string `yaml:"featured_image"`
+	Draft           bool   `yaml:"draft"`
+	MetaTitle       string `yaml:"meta_title"`
+	MetaDescription string `yaml:"meta_description"`
+	CanonicalURL    string `yaml:"canonical_url"`
+	OGImage         string `yaml:"og_image"`
+}
+
+// ContentImportResult summarizes a content sync
+type ContentImportResult struct {
+	Created   int                  `json:"created"`
+	Updated   int                  `json:"updated"`
+	Unchanged int                  `json:"unchanged"`
+	Deleted   int                  `json:"deleted"`
+	Errors    []ContentImportError `json:"errors,omitempty"`
+}
+
+// ContentImportError is a file that could not be imported
+type ContentImportError struct {
+	Path  string `json:"path"`
+	Error string `json:"error"`
+}
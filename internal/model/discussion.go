@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// ArticleDiscussion is the GitHub Discussion holding an article's giscus comments, as last synced
+type ArticleDiscussion struct {
+	ArticleID      string              `json:"-"`
+	Number         int                 `json:"number"`
+	URL            string              `json:"url"`
+	CommentCount   int                 `json:"comment_count"`
+	RecentComments []DiscussionComment `json:"recent_comments"`
+	SyncedAt       time.Time           `json:"synced_at"`
+}
+
+// DiscussionComment is a comment or reply on a GitHub Discussion; Body is plain text
+type DiscussionComment struct {
+	ID           string    `json:"id"`
+	URL          string    `json:"url"`
+	Author       string    `json:"author"`
+	AuthorAvatar string    `json:"author_avatar,omitempty"`
+	AuthorURL    string    `json:"author_url,omitempty"`
+	Body         string    `json:"body"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// GitHubDiscussion is a discussion in the giscus category. CommentCount includes replies, and
+// RecentComments holds the latest top-level comments, newest first.
+type GitHubDiscussion struct {
+	Number         int
+	Title          string
+	URL            string
+	CommentCount   int
+	RecentComments []DiscussionComment
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+)
+
+// Email delivery statuses
+const (
+	EmailStatusQueued  = "queued"
+	EmailStatusSent    = "sent"
+	EmailStatusFailed  = "failed"
+	EmailStatusBounced = "bounced"
+)
+
+// EmailMessage is the delivery record of a templated email
+type EmailMessage struct {
+	ID        string     `json:"id"`
+	Template  string     `json:"template"`
+	Recipient string     `json:"recipient"`
+	Subject   string     `json:"subject"`
+	Status    string     `json:"status"`
+	Attempts  int        `json:"attempts"`
+	LastError string     `json:"last_error,omitempty"`
+	SentAt    *time.Time `json:"sent_at,omitempty"`
+	BouncedAt *time.Time `json:"bounced_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// EmailMessageList represents a list of email messages with pagination
+type EmailMessageList struct {
+	Messages []EmailMessage `json:"messages"`
+	Total    int            `json:"total"`
+	Page     int            `json:"page"`
+	PerPage  int            `json:"per_page"`
+}
+
+// EmailBounce reports a bounced email, matched by its Message-ID or else by the
+// latest email sent to the recipient
+type EmailBounce struct {
+	MessageID string `json:"message_id" validate:"required_without=Recipient"`
+	Recipient string `json:"recipient" validate:"omitempty,email"`
+	Reason    string `json:"reason" validate:"max=1000"`
+}
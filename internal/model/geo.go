@@ -0,0 +1,21 @@
+package model
+
+// GeoLocation is the approximate location of an IP address
+type GeoLocation struct {
+	CountryCode string `json:"country_code"`
+	Country     string `json:"country"`
+	City        string `json:"city,omitempty"`
+}
+
+// String formats the location as "City, Country (CC)", or "Unknown" when nil
+func (l *GeoLocation) String() string {
+	if l == nil {
+		return "Unknown"
+	}
+
+	location := l.Country + " (" + l.CountryCode + ")"
+	if l.City != "" {
+		location = l.City + ", " + location
+	}
+	return location
+}
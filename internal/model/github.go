@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+)
+
+// GitHubActivity is a GitHub user's recent public activity
+type GitHubActivity struct {
+	Username  string        `json:"username"`
+	Events    []GitHubEvent `json:"events"`
+	Stats     GitHubStats   `json:"stats"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// GitHubEvent is a public GitHub event, flattened for display
+type GitHubEvent struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Action    string    `json:"action,omitempty"`
+	Repo      string    `json:"repo"`
+	RepoURL   string    `json:"repo_url"`
+	Ref       string    `json:"ref,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	Commits   int       `json:"commits,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GitHubStats summarizes the public events GitHub still returns, which go back at most 90 days
+type GitHubStats struct {
+	Commits      int        `json:"commits"`
+	PullRequests int        `json:"pull_requests"`
+	Issues       int        `json:"issues"`
+	Reviews      int        `json:"reviews"`
+	Repositories int        `json:"repositories"`
+	Since        *time.Time `json:"since,omitempty"`
+	// Contributions in the last year from the contribution calendar; only known with a token
+	TotalContributions *int `json:"total_contributions,omitempty"`
+}
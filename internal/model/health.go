@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+)
+
+// Health statuses, from best to worst
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusDegraded = "degraded"
+	HealthStatusFailing  = "failing"
+)
+
+// Readiness is the state of the API's dependencies. Status is failing when a critical
+// dependency fails, and degraded when any other dependency is slow or failing.
+type Readiness struct {
+	Status    string                      `json:"status"`
+	CheckedAt time.Time                   `json:"checked_at"`
+	Checks    map[string]DependencyHealth `json:"checks"`
+}
+
+// DependencyHealth is the result of checking one dependency; Critical dependencies make the API unready
+type DependencyHealth struct {
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
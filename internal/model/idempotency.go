@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// IdempotencyRecord is a request made with an Idempotency-Key and, once it finished, its response.
+// StatusCode is nil while the original request is still being handled.
+type IdempotencyRecord struct {
+	Key         string    `db:"idempotency_key"`
+	UserID      string    `db:"user_id"`
+	RequestHash string    `db:"request_hash"`
+	StatusCode  *int      `db:"status_code"`
+	ContentType *string   `db:"content_type"`
+	Response    []byte    `db:"response"`
+	CreatedAt   time.Time `db:"created_at"`
+	ExpiresAt   time.Time `db:"expires_at"`
+}
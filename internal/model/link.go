@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+)
+
+// Link is a bookmark shared on the blog: someone else's page with a short commentary
+type Link struct {
+	ID             string    `json:"id"`
+	URL            string    `json:"url"`
+	Title          string    `json:"title"`
+	Commentary     string    `json:"commentary"`
+	CommentaryHTML string    `json:"commentary_html"`
+	Tags           []string  `json:"tags"`
+	IsPublished    bool      `json:"is_published"`
+	UserID         string    `json:"user_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	PublishedAt    time.Time `json:"published_at,omitempty"`
+}
+
+// LinkCreate represents link creation request body; Commentary is Markdown
+type LinkCreate struct {
+	URL         string   `json:"url" validate:"required,url"`
+	Title       string   `json:"title" validate:"required,max=255"`
+	Commentary  string   `json:"commentary"`
+	Tags        []string `json:"tags" validate:"max=10,dive,required,max=50"`
+	IsPublished bool     `json:"is_published"`
+}
+
+// LinkUpdate represents link update request body; Commentary is Markdown
+type LinkUpdate struct {
+	URL         string   `json:"url" validate:"required,url"`
+	Title       string   `json:"title" validate:"required,max=255"`
+	Commentary  string   `json:"commentary"`
+	Tags        []string `json:"tags" validate:"max=10,dive,required,max=50"`
+	IsPublished bool     `json:"is_published"`
+}
+
+// LinkList represents a list of links with pagination
+type LinkList struct {
+	Links   []Link `json:"links"`
+	Total   int    `json:"total"`
+	Page    int    `json:"page"`
+	PerPage int    `json:"per_page"`
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// localTimeLayouts are the accepted layouts of a timestamp without a UTC offset
+var localTimeLayouts = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04"}
+
+// LocalTime is a request timestamp that may leave out its UTC offset, like "2025-03-01T09:00".
+// Such a wall-clock time is only placed in a timezone by In; RFC 3339 times keep their offset.
+type LocalTime struct {
+	time.Time
+	wallClock bool
+}
+
+// UnmarshalJSON parses an RFC 3339 timestamp or a wall-clock time without an offset
+func (t *LocalTime) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*t = LocalTime{}
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	for _, layout := range localTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			*t = LocalTime{Time: parsed, wallClock: true}
+			return nil
+		}
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return err
+	}
+	*t = LocalTime{Time: parsed}
+	return nil
+}
+
+// In returns the time, reading a wall-clock time in loc
+func (t LocalTime) In(loc *time.Location) time.Time {
+	if !t.wallClock || t.IsZero() {
+		return t.Time
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+)
+
+// LoginAttempt tracks failed logins for an IP and username.
+// An empty username tracks every login from the IP.
+type LoginAttempt struct {
+	ID             string    `json:"id"`
+	IP             string    `json:"ip"`
+	Username       string    `json:"username,omitempty"`
+	FailedAttempts int       `json:"failed_attempts"`
+	LastFailedAt   time.Time `json:"last_failed_at"`
+	BlockedUntil   time.Time `json:"blocked_until,omitempty"`
+}
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+)
+
+// LoginDevice is a device and network a user has logged in from.
+// Devices without ConfirmedAt are waiting for the user to approve them by email.
+type LoginDevice struct {
+	ID                 string    `json:"id"`
+	UserID             string    `json:"user_id"`
+	Fingerprint        string    `json:"-"`
+	UserAgent          string    `json:"user_agent"`
+	LastIP             string    `json:"last_ip"`
+	Location           string    `json:"location,omitempty"`
+	ConfirmToken       string    `json:"-"`
+	ConfirmationSentAt time.Time `json:"-"`
+	ConfirmedAt        time.Time `json:"confirmed_at,omitempty"`
+	LastSeenAt         time.Time `json:"last_seen_at"`
+	CreatedAt          time.Time `json:"created_at"`
+}
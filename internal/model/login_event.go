@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+)
+
+// LoginEvent is one successful or failed login, kept for the login history
+type LoginEvent struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"-"`
+	Username  string    `json:"username"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	Location  string    `json:"location,omitempty"`
+	Success   bool      `json:"success"`
+	Reason    string    `json:"reason,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LoginEventList represents a list of login events with pagination
+type LoginEventList struct {
+	Logins  []LoginEvent `json:"logins"`
+	Total   int          `json:"total"`
+	Page    int          `json:"page"`
+	PerPage int          `json:"per_page"`
+}
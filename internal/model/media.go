@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+)
+
+// Media statuses
+const (
+	MediaPending = "pending"
+	MediaReady   = "ready"
+)
+
+// Media is a file in the media library
+type Media struct {
+	ID          string         `json:"id"`
+	Key         string         `json:"-"`
+	URL         string         `json:"url"`
+	Filename    string         `json:"filename"`
+	ContentType string         `json:"content_type"`
+	Size        int64          `json:"size"`
+	Width       int            `json:"width,omitempty"`
+	Height      int            `json:"height,omitempty"`
+	Status      string         `json:"status"`
+	Variants    []MediaVariant `json:"variants"`
+	UserID      string         `json:"-"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// MediaVariant is an alternative encoding of a media file, e.g. WebP for <picture> sources
+type MediaVariant struct {
+	ContentType string `json:"content_type"`
+	Key         string `json:"-"`
+	URL         string `json:"url"`
+	Size        int64  `json:"size"`
+}
+
+// MediaPresignRequest represents presigned upload request body
+type MediaPresignRequest struct {
+	Filename    string `json:"filename" validate:"required,max=255"`
+	ContentType string `json:"content_type" validate:"required"`
+	Size        int64  `json:"size" validate:"required,gt=0"`
+}
+
+// MediaPresign tells the client how to upload a file straight to storage.
+// The file is sent as multipart/form-data to UploadURL with Fields followed by the "file" part.
+type MediaPresign struct {
+	Media      *Media            `json:"media"`
+	UploadURL  string            `json:"upload_url"`
+	Method     string            `json:"method"`
+	Fields     map[string]string `json:"fields"`
+	ExpiresAt  time.Time         `json:"expires_at"`
+	ConfirmURL string            `json:"confirm_url"`
+}
+
+// MediaList represents a list of media with pagination
+type MediaList struct {
+	Media   []Media `json:"media"`
+	Total   int     `json:"total"`
+	Page    int     `json:"page"`
+	PerPage int     `json:"per_page"`
+}
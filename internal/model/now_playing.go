@@ -0,0 +1,18 @@
+package model
+
+import (
+	"time"
+)
+
+// NowPlaying is the track currently playing on Spotify
+type NowPlaying struct {
+	IsPlaying     bool      `json:"is_playing"`
+	Title         string    `json:"title,omitempty"`
+	Artists       []string  `json:"artists,omitempty"`
+	Album         string    `json:"album,omitempty"`
+	AlbumImageURL string    `json:"album_image_url,omitempty"`
+	SongURL       string    `json:"song_url,omitempty"`
+	ProgressMs    int       `json:"progress_ms,omitempty"`
+	DurationMs    int       `json:"duration_ms,omitempty"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
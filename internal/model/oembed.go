@@ -0,0 +1,37 @@
+package model
+
+// OEmbed is an oEmbed 1.0 response
+type OEmbed struct {
+	Type            string `json:"type"`
+	Version         string `json:"version"`
+	Title           string `json:"title,omitempty"`
+	AuthorName      string `json:"author_name,omitempty"`
+	AuthorURL       string `json:"author_url,omitempty"`
+	ProviderName    string `json:"provider_name,omitempty"`
+	ProviderURL     string `json:"provider_url,omitempty"`
+	HTML            string `json:"html,omitempty"`
+	Width           int    `json:"width,omitempty"`
+	Height          int    `json:"height,omitempty"`
+	ThumbnailURL    string `json:"thumbnail_url,omitempty"`
+	ThumbnailWidth  int    `json:"thumbnail_width,omitempty"`
+	ThumbnailHeight int    `json:"thumbnail_height,omitempty"`
+}
+
+// Gist is a GitHub gist with its files
+type Gist struct {
+	Description string `json:"description"`
+	HTMLURL     string `json:"html_url"`
+	Owner       struct {
+		Login   string `json:"login"`
+		HTMLURL string `json:"html_url"`
+	} `json:"owner"`
+	Files map[string]GistFile `json:"files"`
+}
+
+// GistFile is a file in a GitHub gist
+type GistFile struct {
+	Filename  string `json:"filename"`
+	Language  string `json:"language"`
+	Content   string `json:"content"`
+	Truncated bool   `json:"truncated"`
+}
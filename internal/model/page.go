@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+)
+
+// Page represents a standalone page such as About, Uses or Now
+type Page struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Slug        string    `json:"slug"`
+	Content     string    `json:"content"`
+	IsPublished bool      `json:"is_published"`
+	UserID      string    `json:"user_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	PublishedAt time.Time `json:"published_at,omitempty"`
+	// Locale is the language of the returned content; AvailableLocales lists translations
+	Locale           string   `json:"locale,omitempty"`
+	AvailableLocales []string `json:"available_locales,omitempty"`
+}
+
+// PageCreate represents page creation request body; the slug defaults to the title
+type PageCreate struct {
+	Title       string `json:"title" validate:"required"`
+	Slug        string `json:"slug"`
+	Content     string `json:"content" validate:"required"`
+	IsPublished bool   `json:"is_published"`
+}
+
+// PageUpdate represents page update request body; the slug defaults to the title
+type PageUpdate struct {
+	Title       string `json:"title" validate:"required"`
+	Slug        string `json:"slug"`
+	Content     string `json:"content" validate:"required"`
+	IsPublished bool   `json:"is_published"`
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+)
+
+// Cursor marks the last item of a keyset-paginated page, ordered newest first
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// Sort directions accepted by list endpoints
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// ListOptions sorts and filters list endpoints; zero values keep the default newest-first order
+type ListOptions struct {
+	Sort           string
+	Order          string
+	PublishedAfter time.Time
+	// Author filters by username, AuthorID by user ID
+	Author   string
+	AuthorID string
+	// Search matches a case-insensitive substring of the title and body
+	Search string
+	// Status filters articles by workflow status
+	Status string
+}
+
+// PartialItem is a list item holding only the fields requested through ?fields=
+type PartialItem map[string]interface{}
@@ -5,63 +5,122 @@ import (
 	"time"
 )
 
+// Portfolio is a project; Content is the long-form Markdown case study
 type Portfolio struct {
-	ID           string          `json:"id"`
-	Title        string          `json:"title"`
-	Slug         string          `json:"slug"`
-	Description  string          `json:"description"`
-	Image        string          `json:"image,omitempty"`
-	ProjectURL   string          `json:"project_url,omitempty"`
-	GithubURL    string          `json:"github_url,omitempty"`
-	Technologies json.RawMessage `json:"technologies,omitempty"`
-	IsPublished  bool            `json:"is_published"`
-	UserID       string          `json:"user_id"`
-	CreatedAt    time.Time       `json:"created_at"`
-	UpdatedAt    time.Time       `json:"updated_at"`
+	ID           string            `json:"id"`
+	Title        string            `json:"title"`
+	Slug         string            `json:"slug"`
+	Description  string            `json:"description"`
+	Content      string            `json:"content,omitempty"`
+	Image        string            `json:"image,omitempty"`
+	ProjectURL   string            `json:"project_url,omitempty"`
+	GithubURL    string            `json:"github_url,omitempty"`
+	Technologies json.RawMessage   `json:"technologies,omitempty"`
+	Category     string            `json:"category,omitempty"`
+	Role         string            `json:"role,omitempty"`
+	Duration     string            `json:"duration,omitempty"`
+	Metrics      []PortfolioMetric `json:"metrics"`
+	IsPublished  bool              `json:"is_published"`
+	UserID       string            `json:"user_id"`
+	SEOMeta
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // PortfolioCreate represents portfolio creation request body
 type PortfolioCreate struct {
-	Title        string   `json:"title" validate:"required"`
-	Description  string   `json:"description" validate:"required"`
-	Image        string   `json:"image"`
-	ProjectURL   string   `json:"project_url"`
-	GithubURL    string   `json:"github_url"`
-	Technologies []string `json:"technologies"`
-	IsPublished  bool     `json:"is_published"`
+	Title        string            `json:"title" validate:"required"`
+	Description  string            `json:"description" validate:"required"`
+	Content      string            `json:"content"`
+	Image        string            `json:"image"`
+	ProjectURL   string            `json:"project_url"`
+	GithubURL    string            `json:"github_url"`
+	Technologies []string          `json:"technologies"`
+	Category     string            `json:"category"`
+	Role         string            `json:"role" validate:"max=255"`
+	Duration     string            `json:"duration" validate:"max=100"`
+	Metrics      []PortfolioMetric `json:"metrics" validate:"max=20,dive"`
+	IsPublished  bool              `json:"is_published"`
+	SEOMeta
 }
 
 // PortfolioUpdate represents portfolio update request body
 type PortfolioUpdate struct {
-	Title        string   `json:"title" validate:"required"`
-	Description  string   `json:"description" validate:"required"`
-	Image        string   `json:"image"`
-	ProjectURL   string   `json:"project_url"`
-	GithubURL    string   `json:"github_url"`
-	Technologies []string `json:"technologies"`
-	IsPublished  bool     `json:"is_published"`
+	Title        string            `json:"title" validate:"required"`
+	Description  string            `json:"description" validate:"required"`
+	Content      string            `json:"content"`
+	Image        string            `json:"image"`
+	ProjectURL   string            `json:"project_url"`
+	GithubURL    string            `json:"github_url"`
+	Technologies []string          `json:"technologies"`
+	Category     string            `json:"category"`
+	Role         string            `json:"role" validate:"max=255"`
+	Duration     string            `json:"duration" validate:"max=100"`
+	Metrics      []PortfolioMetric `json:"metrics" validate:"max=20,dive"`
+	IsPublished  bool              `json:"is_published"`
+	SEOMeta
+}
+
+// PortfolioMetric is an outcome of a project, e.g. {"label": "Page load", "value": "-40%"}
+type PortfolioMetric struct {
+	Label string `json:"label" validate:"required,max=100"`
+	Value string `json:"value" validate:"required,max=100"`
+}
+
+// PortfolioImage is a screenshot in a portfolio's gallery, backed by a media library file
+type PortfolioImage struct {
+	ID       string         `json:"id"`
+	MediaID  string         `json:"media_id"`
+	Key      string         `json:"-"`
+	URL      string         `json:"url"`
+	Alt      string         `json:"alt,omitempty"`
+	Width    int            `json:"width,omitempty"`
+	Height   int            `json:"height,omitempty"`
+	Variants []MediaVariant `json:"variants"`
 }
 
-// PortfolioResponse represents portfolio response with author information
+// PortfolioImageCreate represents the request body adding an uploaded image to a gallery
+type PortfolioImageCreate struct {
+	MediaID string `json:"media_id" validate:"required,uuid"`
+	Alt     string `json:"alt" validate:"max=255"`
+}
+
+// PortfolioImageOrder represents the request body reordering a gallery; it lists every image ID once
+type PortfolioImageOrder struct {
+	ImageIDs []string `json:"image_ids" validate:"required,dive,uuid"`
+}
+
+// PortfolioResponse represents portfolio response with author information;
+// ContentHTML is Content rendered from Markdown. Image falls back to the first gallery image.
 type PortfolioResponse struct {
-	ID           string          `json:"id"`
-	Title        string          `json:"title"`
-	Slug         string          `json:"slug"`
-	Description  string          `json:"description"`
-	Image        string          `json:"image,omitempty"`
-	ProjectURL   string          `json:"project_url,omitempty"`
-	GithubURL    string          `json:"github_url,omitempty"`
-	Technologies json.RawMessage `json:"technologies,omitempty"`
-	IsPublished  bool            `json:"is_published"`
-	Author       struct {
+	ID           string            `json:"id"`
+	Title        string            `json:"title"`
+	Slug         string            `json:"slug"`
+	Description  string            `json:"description"`
+	Content      string            `json:"content,omitempty"`
+	ContentHTML  string            `json:"content_html,omitempty"`
+	Image        string            `json:"image,omitempty"`
+	ProjectURL   string            `json:"project_url,omitempty"`
+	GithubURL    string            `json:"github_url,omitempty"`
+	Technologies json.RawMessage   `json:"technologies,omitempty"`
+	Category     string            `json:"category,omitempty"`
+	Role         string            `json:"role,omitempty"`
+	Duration     string            `json:"duration,omitempty"`
+	Metrics      []PortfolioMetric `json:"metrics"`
+	Gallery      []PortfolioImage  `json:"gallery"`
+	IsPublished  bool              `json:"is_published"`
+	SEOMeta
+	Author struct {
 		ID        string `json:"id"`
 		Username  string `json:"username"`
 		FirstName string `json:"first_name"`
 		LastName  string `json:"last_name,omitempty"`
 		Avatar    string `json:"avatar,omitempty"`
 	} `json:"author"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	// RedirectedFrom is set when the portfolio was found by a previous slug
+	RedirectedFrom string    `json:"redirected_from,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 }
 
 // PortfolioList represents a list of portfolios with pagination
@@ -71,3 +130,32 @@ type PortfolioList struct {
 	Page       int                 `json:"page"`
 	PerPage    int                 `json:"per_page"`
 }
+
+// PortfolioCursorList represents a keyset-paginated list of portfolios
+type PortfolioCursorList struct {
+	Portfolios []PortfolioResponse `json:"portfolios"`
+	PerPage    int                 `json:"per_page"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+// PortfolioPartialList is a PortfolioList of portfolios with only the requested fields
+type PortfolioPartialList struct {
+	Portfolios []PartialItem `json:"portfolios"`
+	Total      int           `json:"total"`
+	Page       int           `json:"page"`
+	PerPage    int           `json:"per_page"`
+}
+
+// PortfolioPartialCursorList is a PortfolioCursorList of portfolios with only the requested fields
+type PortfolioPartialCursorList struct {
+	Portfolios []PartialItem `json:"portfolios"`
+	PerPage    int           `json:"per_page"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+// PortfolioFilter narrows portfolio listings; empty fields are ignored
+type PortfolioFilter struct {
+	ListOptions
+	Tech     string
+	Category string
+}
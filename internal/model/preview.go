@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// PreviewLink is a signed link that lets anyone holding it read an unpublished article
+type PreviewLink struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+)
+
+// PushSubscription is a browser subscribed to Web Push notifications
+type PushSubscription struct {
+	ID        string    `json:"id"`
+	Endpoint  string    `json:"endpoint"`
+	P256dh    string    `json:"-"`
+	Auth      string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PushSubscribeRequest is the browser's PushSubscription as serialized by toJSON()
+type PushSubscribeRequest struct {
+	Endpoint string `json:"endpoint" validate:"required,url,startswith=https://,max=2048"`
+	Keys     struct {
+		P256dh string `json:"p256dh" validate:"required,max=255"`
+		Auth   string `json:"auth" validate:"required,max=255"`
+	} `json:"keys"`
+}
+
+// PushUnsubscribeRequest removes a browser's subscription
+type PushUnsubscribeRequest struct {
+	Endpoint string `json:"endpoint" validate:"required,url"`
+}
+
+// PushMessage is the JSON payload the service worker receives
+type PushMessage struct {
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+	URL   string `json:"url"`
+	Image string `json:"image,omitempty"`
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+)
+
+// Redirect represents a short link served at /go/:code
+type Redirect struct {
+	ID            string     `json:"id"`
+	Code          string     `json:"code"`
+	TargetURL     string     `json:"target_url"`
+	Permanent     bool       `json:"permanent"`
+	Clicks        int64      `json:"clicks"`
+	LastClickedAt *time.Time `json:"last_clicked_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// RedirectCreate represents redirect creation request body; a code is generated when empty
+type RedirectCreate struct {
+	Code      string `json:"code"`
+	TargetURL string `json:"target_url" validate:"required,url"`
+	Permanent bool   `json:"permanent"`
+}
+
+// RedirectUpdate represents redirect update request body
+type RedirectUpdate struct {
+	Code      string `json:"code" validate:"required"`
+	TargetURL string `json:"target_url" validate:"required,url"`
+	Permanent bool   `json:"permanent"`
+}
@@ -0,0 +1,159 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// DateLayout is the YYYY-MM-DD format resume dates are written in
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date. It is written as YYYY-MM-DD and read from either YYYY-MM-DD or an
+// RFC 3339 timestamp, whose time of day is dropped.
+type Date struct {
+	time.Time
+}
+
+// MarshalJSON writes the date as YYYY-MM-DD
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Format(DateLayout))
+}
+
+// UnmarshalJSON reads a YYYY-MM-DD date or an RFC 3339 timestamp; null leaves the date unset
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	parsed, err := time.Parse(DateLayout, value)
+	if err != nil {
+		timestamp, timestampErr := time.Parse(time.RFC3339, value)
+		if timestampErr != nil {
+			return err
+		}
+		parsed = time.Date(timestamp.Year(), timestamp.Month(), timestamp.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	d.Time = parsed
+	return nil
+}
+
+// Experience represents a work experience entry
+type Experience struct {
+	ID          string    `json:"id"`
+	Company     string    `json:"company"`
+	Position    string    `json:"position"`
+	Location    string    `json:"location,omitempty"`
+	Description string    `json:"description,omitempty"`
+	StartDate   Date      `json:"start_date"`
+	EndDate     *Date     `json:"end_date,omitempty"`
+	SortOrder   int       `json:"sort_order"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ExperienceCreate represents experience creation request body
+type ExperienceCreate struct {
+	Company     string `json:"company" validate:"required"`
+	Position    string `json:"position" validate:"required"`
+	Location    string `json:"location"`
+	Description string `json:"description"`
+	StartDate   Date   `json:"start_date" validate:"required"`
+	EndDate     *Date  `json:"end_date"`
+	SortOrder   int    `json:"sort_order"`
+}
+
+// ExperienceUpdate represents experience update request body
+type ExperienceUpdate = ExperienceCreate
+
+// Education represents an education entry
+type Education struct {
+	ID           string    `json:"id"`
+	Institution  string    `json:"institution"`
+	Degree       string    `json:"degree"`
+	FieldOfStudy string    `json:"field_of_study,omitempty"`
+	Description  string    `json:"description,omitempty"`
+	StartDate    Date      `json:"start_date"`
+	EndDate      *Date     `json:"end_date,omitempty"`
+	SortOrder    int       `json:"sort_order"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// EducationCreate represents education creation request body
+type EducationCreate struct {
+	Institution  string `json:"institution" validate:"required"`
+	Degree       string `json:"degree" validate:"required"`
+	FieldOfStudy string `json:"field_of_study"`
+	Description  string `json:"description"`
+	StartDate    Date   `json:"start_date" validate:"required"`
+	EndDate      *Date  `json:"end_date"`
+	SortOrder    int    `json:"sort_order"`
+}
+
+// EducationUpdate represents education update request body
+type EducationUpdate = EducationCreate
+
+// Certification represents a certification entry
+type Certification struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	Issuer        string    `json:"issuer"`
+	IssuedAt      Date      `json:"issued_at"`
+	ExpiresAt     *Date     `json:"expires_at,omitempty"`
+	CredentialID  string    `json:"credential_id,omitempty"`
+	CredentialURL string    `json:"credential_url,omitempty"`
+	SortOrder     int       `json:"sort_order"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// CertificationCreate represents certification creation request body
+type CertificationCreate struct {
+	Name          string `json:"name" validate:"required"`
+	Issuer        string `json:"issuer" validate:"required"`
+	IssuedAt      Date   `json:"issued_at" validate:"required"`
+	ExpiresAt     *Date  `json:"expires_at"`
+	CredentialID  string `json:"credential_id"`
+	CredentialURL string `json:"credential_url"`
+	SortOrder     int    `json:"sort_order"`
+}
+
+// CertificationUpdate represents certification update request body
+type CertificationUpdate = CertificationCreate
+
+// Skill represents a skill entry; Level ranges from 1 to 5, 0 when unrated
+type Skill struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Category  string    `json:"category,omitempty"`
+	Level     int       `json:"level,omitempty"`
+	SortOrder int       `json:"sort_order"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SkillCreate represents skill creation request body
+type SkillCreate struct {
+	Name      string `json:"name" validate:"required"`
+	Category  string `json:"category"`
+	Level     int    `json:"level" validate:"min=0,max=5"`
+	SortOrder int    `json:"sort_order"`
+}
+
+// SkillUpdate represents skill update request body
+type SkillUpdate = SkillCreate
+
+// Resume is the combined resume rendered by the frontend
+type Resume struct {
+	Experiences    []Experience    `json:"experiences"`
+	Educations     []Education     `json:"educations"`
+	Certifications []Certification `json:"certifications"`
+	Skills         []Skill         `json:"skills"`
+}
@@ -0,0 +1,6 @@
+package model
+
+// RevalidateRequest lists frontend paths to regenerate, such as /articles/my-post
+type RevalidateRequest struct {
+	Paths []string `json:"paths" validate:"required,min=1,max=100,dive,required,startswith=/,max=2048"`
+}
@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+// ArticleRevision is the title and content of an article after a save
+type ArticleRevision struct {
+	ID        string    `json:"id"`
+	ArticleID string    `json:"article_id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RevisionDiff describes what changed between two revisions of an article
+type RevisionDiff struct {
+	From    ArticleRevision `json:"from"`
+	To      ArticleRevision `json:"to"`
+	Title   TitleDiff       `json:"title"`
+	Content ContentDiff     `json:"content"`
+}
+
+// TitleDiff is the title before and after
+type TitleDiff struct {
+	Changed bool   `json:"changed"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+}
+
+// ContentDiff is a line diff of the content, as hunks for rendering and as a unified diff
+type ContentDiff struct {
+	Changed   bool       `json:"changed"`
+	Additions int        `json:"additions"`
+	Deletions int        `json:"deletions"`
+	Hunks     []DiffHunk `json:"hunks"`
+	Unified   string     `json:"unified"`
+}
+
+// DiffHunk is a run of changed lines with surrounding context; line numbers start at 1
+type DiffHunk struct {
+	FromLine  int        `json:"from_line"`
+	FromCount int        `json:"from_count"`
+	ToLine    int        `json:"to_line"`
+	ToCount   int        `json:"to_count"`
+	Lines     []DiffLine `json:"lines"`
+}
+
+// DiffLine is a line of a hunk; Op is equal, insert or delete
+type DiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// Diff line operations
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+)
+
+// ScheduledTask reports the state of a periodic task
+type ScheduledTask struct {
+	Name         string     `json:"name"`
+	Interval     string     `json:"interval"`
+	Enabled      bool       `json:"enabled"`
+	Running      bool       `json:"running"`
+	LastRunAt    *time.Time `json:"last_run_at,omitempty"`
+	LastDuration string     `json:"last_duration,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+	NextRunAt    *time.Time `json:"next_run_at,omitempty"`
+}
@@ -0,0 +1,9 @@
+package model
+
+// SEOMeta holds per-page metadata the frontend renders into head tags
+type SEOMeta struct {
+	MetaTitle       string `json:"meta_title,omitempty"`
+	MetaDescription string `json:"meta_description,omitempty"`
+	CanonicalURL    string `json:"canonical_url,omitempty"`
+	OGImage         string `json:"og_image,omitempty"`
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+)
+
+type Series struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Slug        string    `json:"slug"`
+	Description string    `json:"description,omitempty"`
+	UserID      string    `json:"user_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// SeriesCreate represents series creation request body
+type SeriesCreate struct {
+	Title       string `json:"title" validate:"required"`
+	Description string `json:"description"`
+}
+
+// SeriesUpdate represents series update request body
+type SeriesUpdate struct {
+	Title       string `json:"title" validate:"required"`
+	Description string `json:"description"`
+}
+
+// SeriesResponse represents a series with its articles in reading order
+type SeriesResponse struct {
+	ID          string        `json:"id"`
+	Title       string        `json:"title"`
+	Slug        string        `json:"slug"`
+	Description string        `json:"description,omitempty"`
+	Articles    []ArticleLink `json:"articles"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
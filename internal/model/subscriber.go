@@ -0,0 +1,38 @@
+package model
+
+import (
+	"time"
+)
+
+// Subscriber statuses
+const (
+	SubscriberPending      = "pending"
+	SubscriberConfirmed    = "confirmed"
+	SubscriberUnsubscribed = "unsubscribed"
+)
+
+type Subscriber struct {
+	ID                 string    `json:"id"`
+	Email              string    `json:"email"`
+	Status             string    `json:"status"`
+	ConfirmToken       string    `json:"-"`
+	UnsubscribeToken   string    `json:"-"`
+	ConfirmationSentAt time.Time `json:"confirmation_sent_at,omitempty"`
+	ConfirmedAt        time.Time `json:"confirmed_at,omitempty"`
+	UnsubscribedAt     time.Time `json:"unsubscribed_at,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// SubscribeRequest represents newsletter subscription request body
+type SubscribeRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+// SubscriberList represents a list of subscribers with pagination
+type SubscriberList struct {
+	Subscribers []Subscriber `json:"subscribers"`
+	Total       int          `json:"total"`
+	Page        int          `json:"page"`
+	PerPage     int          `json:"per_page"`
+}
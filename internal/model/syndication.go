@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+)
+
+// Platforms articles can be cross-posted to
+const (
+	SyndicationDevTo  = "devto"
+	SyndicationMedium = "medium"
+)
+
+// Syndication statuses
+const (
+	SyndicationPublished = "published"
+	SyndicationFailed    = "failed"
+)
+
+// ArticleSyndication records the cross-posted copy of an article on another platform
+type ArticleSyndication struct {
+	ID        string    `json:"id"`
+	ArticleID string    `json:"-"`
+	Platform  string    `json:"platform"`
+	Status    string    `json:"status"`
+	RemoteID  string    `json:"-"`
+	URL       string    `json:"url,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CrossPost is the article content sent to another platform
+type CrossPost struct {
+	Title        string
+	Markdown     string
+	Description  string
+	CoverImage   string
+	CanonicalURL string
+}
+
+// CrossPostResult identifies the post created on another platform
+type CrossPostResult struct {
+	ID  string
+	URL string
+}
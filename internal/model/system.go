@@ -0,0 +1,62 @@
+package model
+
+import (
+	"time"
+)
+
+// SystemStats is a snapshot of the process, its database pools and outbound calls
+type SystemStats struct {
+	StartedAt     time.Time    `json:"started_at"`
+	UptimeSeconds int64        `json:"uptime_seconds"`
+	GoVersion     string       `json:"go_version"`
+	Goroutines    int          `json:"goroutines"`
+	Memory        MemoryStats  `json:"memory"`
+	Database      DBPoolStats  `json:"database"`
+	Replica       *DBPoolStats `json:"replica,omitempty"`
+	// Outside services called with retries and a circuit breaker
+	Outbound []OutboundStats `json:"outbound"`
+}
+
+// MemoryStats are Go runtime memory figures in bytes
+type MemoryStats struct {
+	HeapAlloc  uint64 `json:"heap_alloc"`
+	HeapInUse  uint64 `json:"heap_in_use"`
+	Sys        uint64 `json:"sys"`
+	TotalAlloc uint64 `json:"total_alloc"`
+	NumGC      uint32 `json:"num_gc"`
+	// PauseTotalMs is the time spent in GC stop-the-world pauses since start
+	PauseTotalMs float64 `json:"pause_total_ms"`
+}
+
+// DBPoolStats are the connection pool counters of a database handle. WaitCount and
+// WaitDurationMs grow when queries wait for a free connection, the sign of a saturated pool.
+type DBPoolStats struct {
+	Healthy           bool    `json:"healthy"`
+	MaxOpen           int     `json:"max_open"`
+	Open              int     `json:"open"`
+	InUse             int     `json:"in_use"`
+	Idle              int     `json:"idle"`
+	WaitCount         int64   `json:"wait_count"`
+	WaitDurationMs    float64 `json:"wait_duration_ms"`
+	MaxIdleClosed     int64   `json:"max_idle_closed"`
+	MaxIdleTimeClosed int64   `json:"max_idle_time_closed"`
+	MaxLifetimeClosed int64   `json:"max_lifetime_closed"`
+}
+
+// LogLevelUpdate is the request body for changing a logger's level at runtime
+type LogLevelUpdate struct {
+	Level string `json:"level" validate:"required,oneof=debug info warn error"`
+}
+
+// OutboundStats are the circuit breaker state and call counters of an outside service.
+// Requests counts calls after retries; Retries counts the extra attempts.
+type OutboundStats struct {
+	Name          string     `json:"name"`
+	State         string     `json:"state"`
+	Requests      int64      `json:"requests"`
+	Failures      int64      `json:"failures"`
+	Retries       int64      `json:"retries"`
+	ShortCircuits int64      `json:"short_circuits"`
+	LastError     string     `json:"last_error,omitempty"`
+	LastFailureAt *time.Time `json:"last_failure_at,omitempty"`
+}
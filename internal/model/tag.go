@@ -0,0 +1,13 @@
+package model
+
+import (
+	"time"
+)
+
+// Tag is a label shared by articles on the same topic
+type Tag struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,19 @@
+package model
+
+// TelegramUpdate is an update delivered to the Telegram bot webhook; only messages are handled
+type TelegramUpdate struct {
+	UpdateID int                      `json:"update_id"`
+	Message  *TelegramIncomingMessage `json:"message"`
+}
+
+// TelegramIncomingMessage is a message sent to the bot
+type TelegramIncomingMessage struct {
+	MessageID int          `json:"message_id"`
+	Chat      TelegramChat `json:"chat"`
+	Text      string       `json:"text"`
+}
+
+// TelegramChat is the chat a message was sent in
+type TelegramChat struct {
+	ID int64 `json:"id"`
+}
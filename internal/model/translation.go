@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+)
+
+// Translation represents localized content for an article or page.
+// Excerpt is only used by articles.
+type Translation struct {
+	Locale    string    `json:"locale"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	Excerpt   string    `json:"excerpt,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TranslationUpsert represents translation create/update request body
+type TranslationUpsert struct {
+	Title   string `json:"title" validate:"required"`
+	Content string `json:"content" validate:"required"`
+	Excerpt string `json:"excerpt"`
+}
@@ -4,6 +4,13 @@ import (
 	"time"
 )
 
+// User roles
+const (
+	RoleOwner = "owner"
+	RoleAdmin = "admin"
+	RoleUser  = "user"
+)
+
 type User struct {
 	ID        string    `json:"id"`
 	Username  string    `json:"username"`
@@ -14,8 +21,12 @@ type User struct {
 	Avatar    string    `json:"avatar,omitempty"`
 	Bio       string    `json:"bio,omitempty"`
 	IsAdmin   bool      `json:"is_admin"`
+	Role      string    `json:"role"`
+	IsActive  bool      `json:"is_active"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// Set while the account is waiting out its deletion grace period
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at,omitempty"`
 }
 
 // UserLogin represents login request body
@@ -51,3 +62,46 @@ type UserResponse struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// UserCreate represents admin user creation request body
+type UserCreate struct {
+	Username  string `json:"username" validate:"required"`
+	Email     string `json:"email" validate:"required,email"`
+	FirstName string `json:"first_name" validate:"required"`
+	LastName  string `json:"last_name"`
+	Role      string `json:"role" validate:"omitempty,oneof=admin user"`
+}
+
+// UserCreateResponse represents the response after creating a user
+type UserCreateResponse struct {
+	User              UserDetail `json:"user"`
+	TemporaryPassword string     `json:"temporary_password"`
+}
+
+// UserRoleUpdate represents role change request body
+type UserRoleUpdate struct {
+	Role string `json:"role" validate:"required,oneof=admin user"`
+}
+
+// UserDetail represents a user as seen by user managers
+type UserDetail struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name,omitempty"`
+	Role      string    `json:"role"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Set while the account is waiting out its deletion grace period
+	DeletionRequestedAt *time.Time `json:"deletion_requested_at,omitempty"`
+}
+
+// UserList represents a list of users with pagination
+type UserList struct {
+	Users   []UserDetail `json:"users"`
+	Total   int          `json:"total"`
+	Page    int          `json:"page"`
+	PerPage int          `json:"per_page"`
+}
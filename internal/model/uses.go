@@ -0,0 +1,55 @@
+package model
+
+import (
+	"time"
+)
+
+// UsesCategory groups the items on the uses page, e.g. Hardware or Editor
+type UsesCategory struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	SortOrder   int        `json:"sort_order"`
+	Items       []UsesItem `json:"items"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// UsesCategoryCreate represents uses category creation request body
+type UsesCategoryCreate struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description"`
+	SortOrder   int    `json:"sort_order"`
+}
+
+// UsesCategoryUpdate represents uses category update request body
+type UsesCategoryUpdate = UsesCategoryCreate
+
+// UsesItem is a piece of hardware, software or a tool on the uses page
+type UsesItem struct {
+	ID          string    `json:"id"`
+	CategoryID  string    `json:"category_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	URL         string    `json:"url,omitempty"`
+	SortOrder   int       `json:"sort_order"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// UsesItemCreate represents uses item creation request body
+type UsesItemCreate struct {
+	CategoryID  string `json:"category_id" validate:"required,uuid"`
+	Name        string `json:"name" validate:"required,max=255"`
+	Description string `json:"description"`
+	URL         string `json:"url" validate:"omitempty,url,max=255"`
+	SortOrder   int    `json:"sort_order"`
+}
+
+// UsesItemUpdate represents uses item update request body
+type UsesItemUpdate = UsesItemCreate
+
+// Uses is the uses page rendered by the frontend: categories with their items, in order
+type Uses struct {
+	Categories []UsesCategory `json:"categories"`
+}
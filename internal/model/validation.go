@@ -0,0 +1,8 @@
+package model
+
+// FieldError describes a request field that failed validation
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
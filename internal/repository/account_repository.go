@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// accountContentTables hold content owned through a user_id column. Deleting a user removes
+// rows of the first four through ON DELETE CASCADE; the others are unlinked.
+var accountContentTables = []string{"articles", "portfolios", "series", "pages", "links", "media", "newsletter_campaigns"}
+
+// articleExportColumns are the article columns in an export; the password hash is only
+// reported as is_protected
+const articleExportColumns = `id, title, slug, content, excerpt, featured_image, status, is_published, is_featured,
+	featured_at, user_id, created_at, updated_at, published_at, scheduled_at, series_id, series_order, toc,
+	password_hash IS NOT NULL AS is_protected, meta_title, meta_description, canonical_url, og_image, views`
+
+// AccountRepository defines methods for exporting and deleting a user's own account
+type AccountRepository interface {
+	Export(ctx context.Context, userID string, export *model.AccountExport) error
+	ScheduleDeletion(ctx context.Context, userID, content, reassignTo string) (time.Time, error)
+	ListDueDeletions(ctx context.Context, requestedBefore time.Time) ([]model.PendingAccountDeletion, error)
+	Purge(ctx context.Context, deletion model.PendingAccountDeletion) error
+}
+
+// accountRepository is the implementation of AccountRepository
+type accountRepository struct {
+	db *sqlx.DB
+}
+
+// NewAccountRepository creates a new AccountRepository
+func NewAccountRepository(db *sqlx.DB) AccountRepository {
+	return &accountRepository{db: db}
+}
+
+// Export fills in the content sections of an export with the user's rows as stored. Secrets
+// such as device confirmation tokens and article password hashes are left out.
+func (r *accountRepository) Export(ctx context.Context, userID string, export *model.AccountExport) error {
+	sections := []struct {
+		dest  *json.RawMessage
+		query string
+	}{
+		{&export.Articles, `SELECT ` + articleExportColumns + ` FROM articles WHERE user_id = $1 ORDER BY created_at`},
+		{&export.ArticleRevisions, `SELECT r.* FROM article_revisions r JOIN articles a ON a.id = r.article_id
+									WHERE a.user_id = $1 ORDER BY r.created_at`},
+		{&export.Portfolios, `SELECT * FROM portfolios WHERE user_id = $1 ORDER BY created_at`},
+		{&export.Series, `SELECT * FROM series WHERE user_id = $1 ORDER BY created_at`},
+		{&export.Pages, `SELECT * FROM pages WHERE user_id = $1 ORDER BY created_at`},
+		{&export.Links, `SELECT * FROM links WHERE user_id = $1 ORDER BY created_at`},
+		{&export.Media, `SELECT * FROM media WHERE user_id = $1 ORDER BY created_at`},
+		{&export.Campaigns, `SELECT * FROM newsletter_campaigns WHERE user_id = $1 ORDER BY created_at`},
+		{&export.LoginHistory, `SELECT id, username, ip, user_agent, location, success, reason, created_at
+								FROM login_events WHERE user_id = $1 ORDER BY created_at`},
+		{&export.LoginDevices, `SELECT id, user_agent, last_ip, location, confirmed_at, last_seen_at, created_at
+								FROM login_devices WHERE user_id = $1 ORDER BY created_at`},
+	}
+
+	for _, section := range sections {
+		query := `SELECT COALESCE(json_agg(row_to_json(t)), '[]'::json) FROM (` + section.query + `) t`
+
+		var rows []byte
+		if err := conn(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(&rows); err != nil {
+			return err
+		}
+		*section.dest = rows
+	}
+
+	return nil
+}
+
+// ScheduleDeletion deactivates an account and records what to do with its content once the
+// grace period has passed. It returns when the deletion was requested.
+func (r *accountRepository) ScheduleDeletion(ctx context.Context, userID, content, reassignTo string) (time.Time, error) {
+	query := `UPDATE users
+			  SET is_active = FALSE, deletion_requested_at = NOW(), deletion_content = $2,
+				  deletion_reassign_to = $3, updated_at = NOW()
+			  WHERE id = $1
+			  RETURNING deletion_requested_at`
+
+	var requestedAt time.Time
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, userID, content, nullString(reassignTo)).Scan(&requestedAt)
+	return requestedAt, err
+}
+
+// ListDueDeletions lists accounts whose deletion was requested before the given time
+func (r *accountRepository) ListDueDeletions(ctx context.Context, requestedBefore time.Time) ([]model.PendingAccountDeletion, error) {
+	query := `SELECT id, deletion_content, deletion_reassign_to
+			  FROM users
+			  WHERE deletion_requested_at < $1
+			  ORDER BY deletion_requested_at`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, requestedBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deletions []model.PendingAccountDeletion
+	for rows.Next() {
+		var deletion model.PendingAccountDeletion
+		var content, reassignTo sql.NullString
+		if err := rows.Scan(&deletion.UserID, &content, &reassignTo); err != nil {
+			return nil, err
+		}
+		deletion.Content = content.String
+		deletion.ReassignTo = reassignTo.String
+		deletions = append(deletions, deletion)
+	}
+
+	return deletions, rows.Err()
+}
+
+// Purge deletes an account for good. Reassigned content goes to the chosen user, or to the
+// oldest owner when that user has been deleted in the meantime; other content is deleted.
+// Uploads and sent campaigns are kept without an owner either way.
+func (r *accountRepository) Purge(ctx context.Context, deletion model.PendingAccountDeletion) error {
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		if deletion.Content == model.DeletionContentReassign {
+			var newOwner string
+			err := tx.QueryRowContext(ctx,
+				`SELECT COALESCE(
+				     (SELECT id FROM users WHERE id = $1 AND deletion_requested_at IS NULL),
+				     (SELECT id FROM users WHERE role = 'owner' ORDER BY created_at LIMIT 1)
+				 )`,
+				nullString(deletion.ReassignTo),
+			).Scan(&newOwner)
+			if err != nil {
+				return err
+			}
+
+			for _, table := range accountContentTables {
+				if _, err := tx.ExecContext(ctx, `UPDATE `+table+` SET user_id = $2 WHERE user_id = $1`, deletion.UserID, newOwner); err != nil {
+					return err
+				}
+			}
+		} else if _, err := tx.ExecContext(ctx, `DELETE FROM links WHERE user_id = $1`, deletion.UserID); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, deletion.UserID)
+		return err
+	})
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// ActivityPubFollowerRepository defines methods for ActivityPub follower repository
+type ActivityPubFollowerRepository interface {
+	Save(ctx context.Context, follower *model.ActivityPubFollower) error
+	DeleteByActor(ctx context.Context, actorID string) error
+	ListInboxes(ctx context.Context) ([]string, error)
+	Count(ctx context.Context) (int, error)
+}
+
+// activityPubFollowerRepository is the implementation of ActivityPubFollowerRepository
+type activityPubFollowerRepository struct {
+	db *sqlx.DB
+}
+
+// NewActivityPubFollowerRepository creates a new ActivityPubFollowerRepository
+func NewActivityPubFollowerRepository(db *sqlx.DB) ActivityPubFollowerRepository {
+	return &activityPubFollowerRepository{db: db}
+}
+
+// Save creates a follower, or refreshes its inboxes when the actor already follows
+func (r *activityPubFollowerRepository) Save(ctx context.Context, follower *model.ActivityPubFollower) error {
+	query := `INSERT INTO activitypub_followers (id, actor_id, inbox, shared_inbox)
+			  VALUES ($1, $2, $3, $4)
+			  ON CONFLICT (actor_id) DO UPDATE SET inbox = EXCLUDED.inbox, shared_inbox = EXCLUDED.shared_inbox`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, newID(), follower.ActorID, follower.Inbox, follower.SharedInbox)
+	return err
+}
+
+// DeleteByActor removes a follower
+func (r *activityPubFollowerRepository) DeleteByActor(ctx context.Context, actorID string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM activitypub_followers WHERE actor_id = $1`, actorID)
+	return err
+}
+
+// ListInboxes lists the distinct inboxes to deliver to, preferring shared inboxes
+func (r *activityPubFollowerRepository) ListInboxes(ctx context.Context) ([]string, error) {
+	query := `SELECT DISTINCT COALESCE(NULLIF(shared_inbox, ''), inbox) FROM activitypub_followers`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var inboxes []string
+	for rows.Next() {
+		var inbox string
+		if err := rows.Scan(&inbox); err != nil {
+			return nil, err
+		}
+		inboxes = append(inboxes, inbox)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return inboxes, nil
+}
+
+// Count counts followers
+func (r *activityPubFollowerRepository) Count(ctx context.Context) (int, error) {
+	var total int
+	err := readConn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM activitypub_followers`).Scan(&total)
+	return total, err
+}
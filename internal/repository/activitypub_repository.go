@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/go-fed/httpsig"
+	"go.uber.org/zap"
+)
+
+// ActivityPubContentType is the media type of ActivityPub documents
+const ActivityPubContentType = "application/activity+json"
+
+// maxActivityPubResponse bounds remote documents read into memory
+const maxActivityPubResponse = 1 << 20
+
+// ActivityPubRepository fetches remote actors and delivers activities, signing every
+// request with the blog actor's key as servers with authorized fetch require
+type ActivityPubRepository struct {
+	httpClient *http.Client
+	key        *rsa.PrivateKey
+	keyID      string
+	logger     *zap.Logger
+}
+
+// NewActivityPubRepository creates a new ActivityPub repository
+func NewActivityPubRepository(key *rsa.PrivateKey, keyID string, logger *zap.Logger) *ActivityPubRepository {
+	return &ActivityPubRepository{
+		httpClient: newPublicClient(10 * time.Second),
+		key:        key,
+		keyID:      keyID,
+		logger:     logger,
+	}
+}
+
+// FetchActor fetches a remote actor document
+func (r *ActivityPubRepository) FetchActor(ctx context.Context, actorURL string) (*model.RemoteActor, error) {
+	if err := checkRemoteURL(actorURL); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, actorURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", ActivityPubContentType)
+
+	resp, err := r.do(req, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("actor fetch returned status %d", resp.StatusCode)
+	}
+
+	var actor model.RemoteActor
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxActivityPubResponse)).Decode(&actor); err != nil {
+		return nil, err
+	}
+	if actor.ID == "" || actor.Inbox == "" {
+		return nil, errors.New("actor document has no id or inbox")
+	}
+
+	return &actor, nil
+}
+
+// Deliver posts an activity to a remote inbox
+func (r *ActivityPubRepository) Deliver(ctx context.Context, inbox string, activity []byte) error {
+	if err := checkRemoteURL(inbox); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, inbox, bytes.NewReader(activity))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", ActivityPubContentType)
+
+	resp, err := r.do(req, activity)
+	if err != nil {
+		r.logger.Error("Failed to deliver activity", zap.String("inbox", inbox), zap.Error(err))
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		r.logger.Error("Inbox returned non-success status",
+			zap.String("inbox", inbox),
+			zap.Int("status_code", resp.StatusCode))
+		return fmt.Errorf("inbox returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// do signs and sends a request; body is the request body for POSTs
+func (r *ActivityPubRepository) do(req *http.Request, body []byte) (*http.Response, error) {
+	req.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("Host", req.URL.Host)
+
+	headers := []string{httpsig.RequestTarget, "host", "date"}
+	if body != nil {
+		headers = append(headers, "digest")
+	}
+
+	// Signers keep per-request state, so each request gets its own
+	signer, _, err := httpsig.NewSigner([]httpsig.Algorithm{httpsig.RSA_SHA256}, httpsig.DigestSha256, headers, httpsig.Signature, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := signer.SignRequest(r.key, r.keyID, req, body); err != nil {
+		return nil, err
+	}
+
+	return r.httpClient.Do(req)
+}
+
+// checkRemoteURL only allows HTTPS URLs; the client also refuses non-public addresses, so remote
+// documents can't point requests at internal services
+func checkRemoteURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("refusing non-https ActivityPub URL %q", raw)
+	}
+	return nil
+}
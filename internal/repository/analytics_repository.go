@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// analyticsDimensions maps breakdown dimensions to their rollup column
+var analyticsDimensions = map[string]string{
+	"path":     "path",
+	"referrer": "referrer",
+	"country":  "country",
+}
+
+// IsAnalyticsDimension reports whether TopBy supports a breakdown dimension
+func IsAnalyticsDimension(dimension string) bool {
+	_, ok := analyticsDimensions[dimension]
+	return ok
+}
+
+// AnalyticsRepository defines methods for analytics repository
+type AnalyticsRepository interface {
+	RecordPageview(ctx context.Context, day time.Time, visitorHash, path, referrer, country string) error
+	Daily(ctx context.Context, from, to time.Time) ([]model.AnalyticsDailyStat, error)
+	TopBy(ctx context.Context, dimension string, from, to time.Time, limit int) ([]model.AnalyticsBreakdown, error)
+	TopReferrers(ctx context.Context, from, to time.Time, limit int) ([]model.AnalyticsBreakdown, int, error)
+	RecordSearch(ctx context.Context, day time.Time, query string, results int) error
+	TopSearches(ctx context.Context, from, to time.Time, zeroResultsOnly bool, limit int) ([]model.SearchQueryStat, error)
+}
+
+// analyticsRepository is the implementation of AnalyticsRepository
+type analyticsRepository struct {
+	db *sqlx.DB
+}
+
+// NewAnalyticsRepository creates a new AnalyticsRepository
+func NewAnalyticsRepository(db *sqlx.DB) AnalyticsRepository {
+	return &analyticsRepository{db: db}
+}
+
+// RecordPageview adds a pageview to the daily rollups
+func (r *analyticsRepository) RecordPageview(ctx context.Context, day time.Time, visitorHash, path, referrer, country string) error {
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO analytics_daily_pageviews (day, path, referrer, country, views)
+			 VALUES ($1, $2, $3, $4, 1)
+			 ON CONFLICT (day, path, referrer, country) DO UPDATE SET views = analytics_daily_pageviews.views + 1`,
+			day, path, referrer, country,
+		)
+		if err != nil {
+			return err
+		}
+
+		// Count the view on the article or portfolio whose slug ends the path
+		slug := strings.TrimRight(path, "/")
+		slug = slug[strings.LastIndex(slug, "/")+1:]
+		if slug != "" {
+			if _, err := tx.ExecContext(ctx, `UPDATE articles SET views = views + 1 WHERE slug = $1`, slug); err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, `UPDATE portfolios SET views = views + 1 WHERE slug = $1`, slug); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO analytics_daily_visitors (day, visitor_hash)
+			 VALUES ($1, $2)
+			 ON CONFLICT DO NOTHING`,
+			day, visitorHash,
+		)
+		return err
+	})
+}
+
+// Daily returns views and unique visitors for every day in the range, including empty days
+func (r *analyticsRepository) Daily(ctx context.Context, from, to time.Time) ([]model.AnalyticsDailyStat, error) {
+	query := `SELECT d::date, COALESCE(v.views, 0), COALESCE(u.visitors, 0)
+			  FROM generate_series($1::date, $2::date, interval '1 day') AS d
+			  LEFT JOIN (
+			      SELECT day, SUM(views) AS views FROM analytics_daily_pageviews
+			      WHERE day BETWEEN $1 AND $2 GROUP BY day
+			  ) v ON v.day = d::date
+			  LEFT JOIN (
+			      SELECT day, COUNT(*) AS visitors FROM analytics_daily_visitors
+			      WHERE day BETWEEN $1 AND $2 GROUP BY day
+			  ) u ON u.day = d::date
+			  ORDER BY 1`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []model.AnalyticsDailyStat{}
+	for rows.Next() {
+		var day time.Time
+		var stat model.AnalyticsDailyStat
+		if err := rows.Scan(&day, &stat.Views, &stat.Visitors); err != nil {
+			return nil, err
+		}
+		stat.Day = day.Format("2006-01-02")
+		stats = append(stats, stat)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// TopBy returns the most viewed paths, referrers or countries in the range
+func (r *analyticsRepository) TopBy(ctx context.Context, dimension string, from, to time.Time, limit int) ([]model.AnalyticsBreakdown, error) {
+	column, ok := analyticsDimensions[dimension]
+	if !ok {
+		return nil, fmt.Errorf("unknown analytics dimension: %s", dimension)
+	}
+
+	query := `SELECT ` + column + `, SUM(views) AS views
+			  FROM analytics_daily_pageviews
+			  WHERE day BETWEEN $1 AND $2
+			  GROUP BY ` + column + `
+			  ORDER BY views DESC, ` + column + ` ASC
+			  LIMIT $3`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanBreakdown(rows)
+}
+
+// TopReferrers returns the most viewed referrers in the range, and the views without one
+func (r *analyticsRepository) TopReferrers(ctx context.Context, from, to time.Time, limit int) ([]model.AnalyticsBreakdown, int, error) {
+	var direct int
+	err := conn(ctx, r.db).QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(views), 0) FROM analytics_daily_pageviews WHERE day BETWEEN $1 AND $2 AND referrer = ''`,
+		from, to,
+	).Scan(&direct)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT referrer, SUM(views) AS views
+			  FROM analytics_daily_pageviews
+			  WHERE day BETWEEN $1 AND $2 AND referrer <> ''
+			  GROUP BY referrer
+			  ORDER BY views DESC, referrer ASC
+			  LIMIT $3`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, from, to, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	referrers, err := scanBreakdown(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return referrers, direct, nil
+}
+
+// scanBreakdown scans and closes rows of a key and its views
+func scanBreakdown(rows *sql.Rows) ([]model.AnalyticsBreakdown, error) {
+	defer rows.Close()
+
+	breakdown := []model.AnalyticsBreakdown{}
+	for rows.Next() {
+		var item model.AnalyticsBreakdown
+		if err := rows.Scan(&item.Key, &item.Views); err != nil {
+			return nil, err
+		}
+		breakdown = append(breakdown, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return breakdown, nil
+}
+
+// RecordSearch adds a search to the daily rollups, keeping the latest result count
+func (r *analyticsRepository) RecordSearch(ctx context.Context, day time.Time, query string, results int) error {
+	zeroResults := 0
+	if results == 0 {
+		zeroResults = 1
+	}
+
+	_, err := conn(ctx, r.db).ExecContext(ctx,
+		`INSERT INTO analytics_daily_searches (day, query, searches, zero_results, results)
+		 VALUES ($1, $2, 1, $3, $4)
+		 ON CONFLICT (day, query) DO UPDATE SET
+		     searches = analytics_daily_searches.searches + 1,
+		     zero_results = analytics_daily_searches.zero_results + EXCLUDED.zero_results,
+		     results = EXCLUDED.results`,
+		day, query, zeroResults, results,
+	)
+	return err
+}
+
+// TopSearches returns the most searched queries in the range, optionally only those that found nothing
+func (r *analyticsRepository) TopSearches(ctx context.Context, from, to time.Time, zeroResultsOnly bool, limit int) ([]model.SearchQueryStat, error) {
+	countColumn := "searches"
+	if zeroResultsOnly {
+		countColumn = "zero_results"
+	}
+
+	// The result count comes from the most recent day the query was searched
+	query := `SELECT query, SUM(` + countColumn + `) AS total,
+			         (ARRAY_AGG(results ORDER BY day DESC))[1]
+			  FROM analytics_daily_searches
+			  WHERE day BETWEEN $1 AND $2
+			  GROUP BY query
+			  HAVING SUM(` + countColumn + `) > 0
+			  ORDER BY total DESC, query ASC
+			  LIMIT $3`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := []model.SearchQueryStat{}
+	for rows.Next() {
+		var stat model.SearchQueryStat
+		if err := rows.Scan(&stat.Query, &stat.Searches, &stat.Results); err != nil {
+			return nil, err
+		}
+		stats = append(stats, stat)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// APIKeyRepository defines methods for API keys and their request counts
+type APIKeyRepository interface {
+	Create(ctx context.Context, userID, name, prefix, keyHash, tier string) (*model.APIKey, error)
+	GetByID(ctx context.Context, id string) (*model.APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*model.APIKey, error)
+	ListByUser(ctx context.Context, userID string) ([]model.APIKey, error)
+	Revoke(ctx context.Context, id string) error
+	RecordRequest(ctx context.Context, id string, at time.Time, window time.Duration) (int, error)
+	CountSince(ctx context.Context, id string, since time.Time) (int, error)
+	DailyUsage(ctx context.Context, id string, since time.Time) ([]model.APIKeyUsageDay, error)
+	DeleteUsageBefore(ctx context.Context, before time.Time) error
+}
+
+// apiKeyRepository is the implementation of APIKeyRepository
+type apiKeyRepository struct {
+	db *sqlx.DB
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository
+func NewAPIKeyRepository(db *sqlx.DB) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+// apiKeyColumns is the column list matching scanAPIKey
+const apiKeyColumns = `id, user_id, name, prefix, tier, created_at, last_used_at, revoked_at`
+
+// scanAPIKey scans a row selected with apiKeyColumns
+func scanAPIKey(row interface{ Scan(...interface{}) error }) (*model.APIKey, error) {
+	var key model.APIKey
+	err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.Name,
+		&key.Prefix,
+		&key.Tier,
+		&key.CreatedAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// Create stores a new API key by its hash
+func (r *apiKeyRepository) Create(ctx context.Context, userID, name, prefix, keyHash, tier string) (*model.APIKey, error) {
+	query := `INSERT INTO api_keys (id, user_id, name, prefix, key_hash, tier)
+			  VALUES ($1, $2, $3, $4, $5, $6)
+			  RETURNING ` + apiKeyColumns
+
+	return scanAPIKey(conn(ctx, r.db).QueryRowContext(ctx, query, newID(), userID, name, prefix, keyHash, tier))
+}
+
+// GetByID gets an API key by ID
+func (r *apiKeyRepository) GetByID(ctx context.Context, id string) (*model.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1`
+	return scanAPIKey(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+}
+
+// GetByHash gets an API key by the hash of its secret
+func (r *apiKeyRepository) GetByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+	return scanAPIKey(conn(ctx, r.db).QueryRowContext(ctx, query, keyHash))
+}
+
+// ListByUser lists a user's API keys, newest first
+func (r *apiKeyRepository) ListByUser(ctx context.Context, userID string) ([]model.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + `
+			  FROM api_keys
+			  WHERE user_id = $1
+			  ORDER BY created_at DESC`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []model.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+
+	return keys, rows.Err()
+}
+
+// Revoke stops an API key from being accepted; revoking twice keeps the first time
+func (r *apiKeyRepository) Revoke(ctx context.Context, id string) error {
+	query := `UPDATE api_keys SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP) WHERE id = $1`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	return err
+}
+
+// RecordRequest counts a request made at the given time in its minute bucket and returns the
+// number of requests made in the window ending then, this one included
+func (r *apiKeyRepository) RecordRequest(ctx context.Context, id string, at time.Time, window time.Duration) (int, error) {
+	// The outer query sees the table as it was before the insert, so the current bucket comes from hit
+	query := `WITH hit AS (
+				  INSERT INTO api_key_usage (api_key_id, bucket, requests)
+				  VALUES ($1, $2, 1)
+				  ON CONFLICT (api_key_id, bucket) DO UPDATE
+				  SET requests = api_key_usage.requests + 1
+				  RETURNING requests
+			  ), used AS (
+				  UPDATE api_keys SET last_used_at = $4 WHERE id = $1
+			  )
+			  SELECT (SELECT requests FROM hit) + COALESCE(SUM(requests), 0)
+			  FROM api_key_usage
+			  WHERE api_key_id = $1 AND bucket > $3 AND bucket < $2`
+
+	bucket := at.UTC().Truncate(time.Minute)
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id, bucket, at.Add(-window).UTC().Truncate(time.Minute), at).Scan(&count)
+	return count, err
+}
+
+// CountSince returns the number of requests made with an API key since the given time,
+// counted in whole minutes
+func (r *apiKeyRepository) CountSince(ctx context.Context, id string, since time.Time) (int, error) {
+	query := `SELECT COALESCE(SUM(requests), 0)
+			  FROM api_key_usage
+			  WHERE api_key_id = $1 AND bucket > $2`
+
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id, since.UTC().Truncate(time.Minute)).Scan(&count)
+	return count, err
+}
+
+// DailyUsage returns an API key's requests per day (UTC) since the given time, oldest first;
+// days without requests are left out
+func (r *apiKeyRepository) DailyUsage(ctx context.Context, id string, since time.Time) ([]model.APIKeyUsageDay, error) {
+	query := `SELECT TO_CHAR(DATE(bucket AT TIME ZONE 'UTC'), 'YYYY-MM-DD'), SUM(requests)
+			  FROM api_key_usage
+			  WHERE api_key_id = $1 AND bucket >= $2
+			  GROUP BY 1
+			  ORDER BY 1`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, id, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	days := []model.APIKeyUsageDay{}
+	for rows.Next() {
+		var day model.APIKeyUsageDay
+		if err := rows.Scan(&day.Date, &day.Requests); err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+
+	return days, rows.Err()
+}
+
+// DeleteUsageBefore deletes request counts older than the given time
+func (r *apiKeyRepository) DeleteUsageBefore(ctx context.Context, before time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM api_key_usage WHERE bucket < $1`, before)
+	return err
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// ArticleDiscussionRepository defines methods for article discussion repository
+type ArticleDiscussionRepository interface {
+	Save(ctx context.Context, discussion *model.ArticleDiscussion) error
+	GetByArticle(ctx context.Context, articleID string) (*model.ArticleDiscussion, error)
+	List(ctx context.Context) ([]model.ArticleDiscussion, error)
+	DeleteSyncedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// articleDiscussionRepository is the implementation of ArticleDiscussionRepository
+type articleDiscussionRepository struct {
+	db *sqlx.DB
+}
+
+// NewArticleDiscussionRepository creates a new ArticleDiscussionRepository
+func NewArticleDiscussionRepository(db *sqlx.DB) ArticleDiscussionRepository {
+	return &articleDiscussionRepository{db: db}
+}
+
+// articleDiscussionColumns is the column list matching scanArticleDiscussion
+const articleDiscussionColumns = `article_id, discussion_number, url, comment_count, recent_comments, synced_at`
+
+// Save stores the synced state of an article's discussion, replacing the previous one
+func (r *articleDiscussionRepository) Save(ctx context.Context, discussion *model.ArticleDiscussion) error {
+	recentComments, err := json.Marshal(discussion.RecentComments)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO article_discussions (article_id, discussion_number, url, comment_count, recent_comments, synced_at)
+			  VALUES ($1, $2, $3, $4, $5, $6)
+			  ON CONFLICT (article_id) DO UPDATE
+			  SET discussion_number = EXCLUDED.discussion_number, url = EXCLUDED.url,
+			      comment_count = EXCLUDED.comment_count, recent_comments = EXCLUDED.recent_comments,
+			      synced_at = EXCLUDED.synced_at`
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, query,
+		discussion.ArticleID,
+		discussion.Number,
+		discussion.URL,
+		discussion.CommentCount,
+		recentComments,
+		discussion.SyncedAt,
+	)
+	return err
+}
+
+// GetByArticle gets an article's discussion, or nil if none was synced
+func (r *articleDiscussionRepository) GetByArticle(ctx context.Context, articleID string) (*model.ArticleDiscussion, error) {
+	query := `SELECT ` + articleDiscussionColumns + `
+			  FROM article_discussions
+			  WHERE article_id = $1`
+
+	discussion, err := scanArticleDiscussion(readConn(ctx, r.db).QueryRowContext(ctx, query, articleID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return discussion, nil
+}
+
+// List lists every synced discussion
+func (r *articleDiscussionRepository) List(ctx context.Context) ([]model.ArticleDiscussion, error) {
+	query := `SELECT ` + articleDiscussionColumns + `
+			  FROM article_discussions`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	discussions := []model.ArticleDiscussion{}
+	for rows.Next() {
+		discussion, err := scanArticleDiscussion(rows)
+		if err != nil {
+			return nil, err
+		}
+		discussions = append(discussions, *discussion)
+	}
+
+	return discussions, rows.Err()
+}
+
+// DeleteSyncedBefore deletes the discussions a sync that started at before didn't find again
+func (r *articleDiscussionRepository) DeleteSyncedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM article_discussions WHERE synced_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// scanArticleDiscussion scans a discussion row selected with articleDiscussionColumns
+func scanArticleDiscussion(row rowScanner) (*model.ArticleDiscussion, error) {
+	var discussion model.ArticleDiscussion
+	var recentComments []byte
+
+	err := row.Scan(
+		&discussion.ArticleID,
+		&discussion.Number,
+		&discussion.URL,
+		&discussion.CommentCount,
+		&recentComments,
+		&discussion.SyncedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(recentComments, &discussion.RecentComments); err != nil {
+		return nil, err
+	}
+
+	return &discussion, nil
+}
@@ -3,7 +3,11 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
 	"time"
 
 	"github.com/budhilaw/personal-website-backend/internal/model"
@@ -16,10 +20,27 @@ type ArticleRepository interface {
 	Create(ctx context.Context, article *model.ArticleCreate, userID string) (string, error)
 	Update(ctx context.Context, id string, article *model.ArticleUpdate) error
 	Delete(ctx context.Context, id string) error
+	SetDates(ctx context.Context, id string, createdAt, updatedAt time.Time) error
+	SetStatus(ctx context.Context, id, status string, scheduledAt time.Time) error
+	ListDue(ctx context.Context, now time.Time) ([]model.Article, error)
+	PublishDue(ctx context.Context, now time.Time) ([]string, error)
+	SetFeatured(ctx context.Context, id string, featured bool) error
+	SetPassword(ctx context.Context, id, passwordHash string) error
+	CountFeatured(ctx context.Context) (int, error)
 	GetByID(ctx context.Context, id string) (*model.Article, error)
 	GetBySlug(ctx context.Context, slug string) (*model.Article, error)
-	List(ctx context.Context, page, perPage int, onlyPublished bool) ([]model.Article, int, error)
+	SlugExists(ctx context.Context, slug string) (bool, error)
+	List(ctx context.Context, page, perPage int, onlyPublished bool, opts model.ListOptions) ([]model.Article, int, error)
+	ListAfter(ctx context.Context, after *model.Cursor, limit int, onlyPublished bool, opts model.ListOptions) ([]model.Article, error)
+	ListFields(ctx context.Context, fields []string, page, perPage int, onlyPublished bool, opts model.ListOptions) ([]model.PartialItem, int, error)
+	ListFieldsAfter(ctx context.Context, fields []string, after *model.Cursor, limit int, onlyPublished bool, opts model.ListOptions) ([]model.PartialItem, error)
 	GetByAuthor(ctx context.Context, userID string, page, perPage int) ([]model.Article, int, error)
+	ListBySeries(ctx context.Context, seriesID string, onlyPublished bool) ([]model.Article, error)
+	Archive(ctx context.Context, loc *time.Location) ([]model.ArchiveMonth, error)
+	ListPublishedBetween(ctx context.Context, from, to time.Time) ([]model.Article, error)
+	ListPopular(ctx context.Context, since time.Time, limit int) ([]model.Article, error)
+	ListRecentlyUpdated(ctx context.Context, limit int) ([]model.Article, error)
+	ListFeatured(ctx context.Context) ([]model.Article, error)
 }
 
 // articleRepository is the implementation of ArticleRepository
@@ -32,30 +53,51 @@ func NewArticleRepository(db *sqlx.DB) ArticleRepository {
 	return &articleRepository{db: db}
 }
 
-// Create creates a new article
+// articleColumns is the column list matching scanArticle
+const articleColumns = `id, title, slug, content, excerpt, featured_image, status, is_published, is_featured, user_id, created_at, updated_at, published_at, scheduled_at, series_id, series_order, toc, COALESCE(password_hash, ''), ` + seoColumns
+
+// Create creates a new article and its first revision
 func (r *articleRepository) Create(ctx context.Context, articleCreate *model.ArticleCreate, userID string) (string, error) {
-	query := `INSERT INTO articles (title, slug, content, excerpt, featured_image, is_published, user_id, published_at) 
-			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8) 
+	query := `INSERT INTO articles (id, title, slug, content, excerpt, featured_image, status, user_id, published_at, series_id, series_order, toc, meta_title, meta_description, canonical_url, og_image)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 			  RETURNING id`
 
-	slug := util.GenerateSlug(articleCreate.Title)
+	slug := articleSlug(articleCreate.Slug, articleCreate.Title)
+	toc, err := json.Marshal(tableOfContents(articleCreate.Content))
+	if err != nil {
+		return "", err
+	}
+
+	status := model.ArticleStatusDraft
 	var publishedAt sql.NullTime
 	if articleCreate.IsPublished {
+		status = model.ArticleStatusPublished
 		publishedAt = sql.NullTime{Time: time.Now(), Valid: true}
 	}
 
-	var id string
-	err := r.db.QueryRowContext(
-		ctx, query,
+	params := []interface{}{
+		newID(),
 		articleCreate.Title,
 		slug,
 		articleCreate.Content,
 		articleCreate.Excerpt,
 		articleCreate.FeaturedImage,
-		articleCreate.IsPublished,
+		status,
 		userID,
 		publishedAt,
-	).Scan(&id)
+		nullString(articleCreate.SeriesID),
+		nullSeriesOrder(articleCreate.SeriesID, articleCreate.SeriesOrder),
+		toc,
+	}
+	params = append(params, seoArgs(articleCreate.SEOMeta)...)
+
+	var id string
+	err = withTx(ctx, r.db, func(tx DBTX) error {
+		if err := tx.QueryRowContext(ctx, query, params...).Scan(&id); err != nil {
+			return err
+		}
+		return recordRevision(ctx, tx, id, articleCreate.Title, articleCreate.Content)
+	})
 	if err != nil {
 		return "", err
 	}
@@ -63,69 +105,244 @@ func (r *articleRepository) Create(ctx context.Context, articleCreate *model.Art
 	return id, nil
 }
 
-// Update updates an article
+// Update updates an article, keeping the previous slug in history when it changes
+// and recording a revision when the title or content changes
 func (r *articleRepository) Update(ctx context.Context, id string, articleUpdate *model.ArticleUpdate) error {
-	// Get current state to check if published state changed
-	var currentState bool
-	err := r.db.QueryRowContext(ctx, "SELECT is_published FROM articles WHERE id = $1", id).Scan(&currentState)
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		// Get current state to check if status or slug changed
+		var currentStatus, currentSlug, currentTitle, currentContent string
+		err := tx.QueryRowContext(ctx, "SELECT status, slug, title, content FROM articles WHERE id = $1 FOR UPDATE", id).
+			Scan(&currentStatus, &currentSlug, &currentTitle, &currentContent)
+		if err != nil {
+			return err
+		}
+
+		// Articles saved before revisions were kept get their current state as the first revision
+		if err := recordRevision(ctx, tx, id, currentTitle, currentContent); err != nil {
+			return err
+		}
+
+		slug := articleSlug(articleUpdate.Slug, articleUpdate.Title)
+		toc, err := json.Marshal(tableOfContents(articleUpdate.Content))
+		if err != nil {
+			return err
+		}
+
+		status := articleUpdate.NextStatus(currentStatus)
+
+		query := `UPDATE articles
+				  SET title = $2, slug = $3, content = $4, excerpt = $5, featured_image = $6, status = $7, updated_at = $8, series_id = $9, series_order = $10,
+				      toc = $11, meta_title = $12, meta_description = $13, canonical_url = $14, og_image = $15,
+				      scheduled_at = CASE WHEN $7 = 'scheduled' THEN scheduled_at END`
+
+		params := []interface{}{
+			id,
+			articleUpdate.Title,
+			slug,
+			articleUpdate.Content,
+			articleUpdate.Excerpt,
+			articleUpdate.FeaturedImage,
+			status,
+			time.Now(),
+			nullString(articleUpdate.SeriesID),
+			nullSeriesOrder(articleUpdate.SeriesID, articleUpdate.SeriesOrder),
+			toc,
+		}
+		params = append(params, seoArgs(articleUpdate.SEOMeta)...)
+
+		// If article is being published now
+		if currentStatus != model.ArticleStatusPublished && status == model.ArticleStatusPublished {
+			query += ", published_at = $16 WHERE id = $1"
+			params = append(params, time.Now())
+		} else {
+			query += " WHERE id = $1"
+		}
+
+		if _, err = tx.ExecContext(ctx, query, params...); err != nil {
+			return err
+		}
+
+		if err := recordRevision(ctx, tx, id, articleUpdate.Title, articleUpdate.Content); err != nil {
+			return err
+		}
+
+		return recordSlugChange(ctx, tx, slugEntityArticle, id, currentSlug, slug)
+	})
+}
+
+// SetStatus moves an article to a workflow status; scheduledAt is only kept for scheduled articles
+func (r *articleRepository) SetStatus(ctx context.Context, id, status string, scheduledAt time.Time) error {
+	query := `UPDATE articles
+			  SET status = $2,
+			      scheduled_at = $3,
+			      published_at = CASE WHEN $2 = 'published' AND status <> 'published' THEN NOW() ELSE published_at END
+			  WHERE id = $1`
+
+	var scheduled sql.NullTime
+	if status == model.ArticleStatusScheduled {
+		scheduled = sql.NullTime{Time: scheduledAt, Valid: true}
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id, status, scheduled)
 	if err != nil {
 		return err
 	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.New("article not found")
+	}
 
-	query := `UPDATE articles 
-			  SET title = $2, slug = $3, content = $4, excerpt = $5, featured_image = $6, is_published = $7, updated_at = $8`
+	return nil
+}
 
-	params := []interface{}{
-		id,
-		articleUpdate.Title,
-		util.GenerateSlug(articleUpdate.Title),
-		articleUpdate.Content,
-		articleUpdate.Excerpt,
-		articleUpdate.FeaturedImage,
-		articleUpdate.IsPublished,
-		time.Now(),
-	}
-
-	// If article is being published now
-	if !currentState && articleUpdate.IsPublished {
-		query += ", published_at = $9 WHERE id = $1"
-		params = append(params, time.Now())
-	} else {
-		query += " WHERE id = $1"
+// SetFeatured pins an article to or unpins it from the featured list
+func (r *articleRepository) SetFeatured(ctx context.Context, id string, featured bool) error {
+	query := `UPDATE articles
+			  SET is_featured = $2,
+			      featured_at = CASE WHEN $2 THEN COALESCE(featured_at, NOW()) END
+			  WHERE id = $1`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id, featured)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.New("article not found")
 	}
 
-	_, err = r.db.ExecContext(ctx, query, params...)
-	return err
+	return nil
+}
+
+// SetPassword sets the hash of an article's password; an empty hash removes the password
+func (r *articleRepository) SetPassword(ctx context.Context, id, passwordHash string) error {
+	query := `UPDATE articles SET password_hash = $2 WHERE id = $1`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id, nullString(passwordHash))
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return errors.New("article not found")
+	}
+
+	return nil
+}
+
+// CountFeatured counts the published articles that are featured
+func (r *articleRepository) CountFeatured(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM articles WHERE is_featured = true AND is_published = true`
+
+	var count int
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// ListDue lists the scheduled articles that are due, locking them until the transaction ends
+func (r *articleRepository) ListDue(ctx context.Context, now time.Time) ([]model.Article, error) {
+	query := `SELECT ` + articleColumns + `
+			  FROM articles
+			  WHERE status = 'scheduled' AND scheduled_at <= $1
+			  FOR UPDATE`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	articles := []model.Article{}
+	for rows.Next() {
+		article, err := scanArticle(rows)
+		if err != nil {
+			return nil, err
+		}
+		articles = append(articles, *article)
+	}
+
+	return articles, rows.Err()
+}
+
+// PublishDue publishes scheduled articles whose time has come, dated when they were scheduled for,
+// and returns their IDs
+func (r *articleRepository) PublishDue(ctx context.Context, now time.Time) ([]string, error) {
+	query := `UPDATE articles
+			  SET status = 'published', published_at = scheduled_at, scheduled_at = NULL
+			  WHERE status = 'scheduled' AND scheduled_at <= $1
+			  RETURNING id`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// SlugExists reports whether an article currently uses the slug
+func (r *articleRepository) SlugExists(ctx context.Context, slug string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM articles WHERE slug = $1)`
+
+	var exists bool
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, slug).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// articleSlug normalizes a requested slug, falling back to one generated from the title
+func articleSlug(slug, title string) string {
+	if slug = util.GenerateSlug(slug); slug != "" {
+		return slug
+	}
+	return util.GenerateSlug(title)
+}
+
+// tableOfContents lists an article's headings with the anchors they are rendered with
+func tableOfContents(content string) []model.TOCEntry {
+	headings := util.MarkdownHeadings(content)
+	toc := make([]model.TOCEntry, 0, len(headings))
+	for _, heading := range headings {
+		toc = append(toc, model.TOCEntry(heading))
+	}
+	return toc
 }
 
 // Delete deletes an article
 func (r *articleRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM articles WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	return err
+}
+
+// SetDates backdates an imported article; a published article counts as published when it was created
+func (r *articleRepository) SetDates(ctx context.Context, id string, createdAt, updatedAt time.Time) error {
+	query := `UPDATE articles
+			  SET created_at = $2, updated_at = $3, published_at = CASE WHEN is_published THEN $2::timestamptz END
+			  WHERE id = $1`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, createdAt, updatedAt)
 	return err
 }
 
 // GetByID gets an article by ID
 func (r *articleRepository) GetByID(ctx context.Context, id string) (*model.Article, error) {
-	query := `SELECT id, title, slug, content, excerpt, featured_image, is_published, user_id, created_at, updated_at, published_at 
-			  FROM articles 
+	query := `SELECT ` + articleColumns + `
+			  FROM articles
 			  WHERE id = $1`
 
-	var article model.Article
-	var publishedAt sql.NullTime
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&article.ID,
-		&article.Title,
-		&article.Slug,
-		&article.Content,
-		&article.Excerpt,
-		&article.FeaturedImage,
-		&article.IsPublished,
-		&article.UserID,
-		&article.CreatedAt,
-		&article.UpdatedAt,
-		&publishedAt,
-	)
+	article, err := scanArticle(readConn(ctx, r.db).QueryRowContext(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New("article not found")
@@ -133,34 +350,25 @@ func (r *articleRepository) GetByID(ctx context.Context, id string) (*model.Arti
 		return nil, err
 	}
 
-	if publishedAt.Valid {
-		article.PublishedAt = publishedAt.Time
-	}
-
-	return &article, nil
+	return article, nil
 }
 
-// GetBySlug gets an article by slug
+// GetBySlug gets an article by slug, falling back to previous slugs.
+// The returned article always carries its current slug.
 func (r *articleRepository) GetBySlug(ctx context.Context, slug string) (*model.Article, error) {
-	query := `SELECT id, title, slug, content, excerpt, featured_image, is_published, user_id, created_at, updated_at, published_at 
-			  FROM articles 
+	query := `SELECT ` + articleColumns + `
+			  FROM articles
 			  WHERE slug = $1`
 
-	var article model.Article
-	var publishedAt sql.NullTime
-	err := r.db.QueryRowContext(ctx, query, slug).Scan(
-		&article.ID,
-		&article.Title,
-		&article.Slug,
-		&article.Content,
-		&article.Excerpt,
-		&article.FeaturedImage,
-		&article.IsPublished,
-		&article.UserID,
-		&article.CreatedAt,
-		&article.UpdatedAt,
-		&publishedAt,
-	)
+	article, err := scanArticle(readConn(ctx, r.db).QueryRowContext(ctx, query, slug))
+	if err == nil {
+		return article, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	id, err := resolveSlugHistory(ctx, readConn(ctx, r.db), slugEntityArticle, slug)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New("article not found")
@@ -168,76 +376,193 @@ func (r *articleRepository) GetBySlug(ctx context.Context, slug string) (*model.
 		return nil, err
 	}
 
-	if publishedAt.Valid {
-		article.PublishedAt = publishedAt.Time
-	}
-
-	return &article, nil
+	return r.GetByID(ctx, id)
 }
 
 // List lists articles with pagination
-func (r *articleRepository) List(ctx context.Context, page, perPage int, onlyPublished bool) ([]model.Article, int, error) {
+func (r *articleRepository) List(ctx context.Context, page, perPage int, onlyPublished bool, opts model.ListOptions) ([]model.Article, int, error) {
 	offset := (page - 1) * perPage
 
-	// Count total
-	countQuery := `SELECT COUNT(*) FROM articles`
-	if onlyPublished {
-		countQuery += ` WHERE is_published = true`
+	order, err := orderClause(articleSortColumns, opts)
+	if err != nil {
+		return nil, 0, err
 	}
 
+	conditions, args := articleConditions(onlyPublished, opts)
+	where := whereClause(conditions)
+
+	// Count total
 	var total int
-	err := r.db.QueryRowContext(ctx, countQuery).Scan(&total)
+	err = readConn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM articles`+where, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Get articles
-	query := `SELECT id, title, slug, content, excerpt, featured_image, is_published, user_id, created_at, updated_at, published_at 
-			  FROM articles`
-	if onlyPublished {
-		query += ` WHERE is_published = true`
+	query := `SELECT ` + articleColumns + `
+			  FROM articles` + where + order +
+		fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+
+	articles, err := r.queryArticles(ctx, query, append(args, perPage, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return articles, total, nil
+}
+
+// ListAfter lists articles older than the cursor, newest first; a nil cursor starts at the newest
+func (r *articleRepository) ListAfter(ctx context.Context, after *model.Cursor, limit int, onlyPublished bool, opts model.ListOptions) ([]model.Article, error) {
+	conditions, args := articleConditions(onlyPublished, opts)
+	if after != nil {
+		args = append(args, after.CreatedAt, after.ID)
+		conditions = append(conditions, fmt.Sprintf(`(created_at, id) < ($%d, $%d)`, len(args)-1, len(args)))
 	}
-	query += ` ORDER BY created_at DESC LIMIT $1 OFFSET $2`
 
-	rows, err := r.db.QueryContext(ctx, query, perPage, offset)
+	query := `SELECT ` + articleColumns + `
+			  FROM articles` + whereClause(conditions) +
+		fmt.Sprintf(` ORDER BY created_at DESC, id DESC LIMIT $%d`, len(args)+1)
+
+	return r.queryArticles(ctx, query, append(args, limit)...)
+}
+
+// articleFields maps the fields selectable through sparse fieldsets to SQL expressions
+var articleFields = map[string]string{
+	"id":               "id",
+	"title":            "title",
+	"slug":             "slug",
+	"content":          "content",
+	"excerpt":          "excerpt",
+	"featured_image":   "featured_image",
+	"status":           "status",
+	"is_published":     "is_published",
+	"is_featured":      "is_featured",
+	"is_protected":     "password_hash IS NOT NULL",
+	"created_at":       "created_at",
+	"updated_at":       "updated_at",
+	"published_at":     "published_at",
+	"series_id":        "series_id",
+	"series_order":     "series_order",
+	"toc":              "toc",
+	"meta_title":       "COALESCE(meta_title, '')",
+	"meta_description": "COALESCE(meta_description, '')",
+	"canonical_url":    "COALESCE(canonical_url, '')",
+	"og_image":         "COALESCE(og_image, '')",
+	"views":            "views",
+}
+
+// protectedArticleFields replaces the fields of articleFields that published listings leave
+// empty for password-protected articles
+var protectedArticleFields = map[string]string{
+	"content": "CASE WHEN password_hash IS NULL THEN content ELSE '' END",
+	"toc":     "CASE WHEN password_hash IS NULL THEN toc ELSE '[]'::jsonb END",
+}
+
+// selectableArticleFields returns the sparse fieldset expressions of a listing
+func selectableArticleFields(onlyPublished bool) map[string]string {
+	if !onlyPublished {
+		return articleFields
+	}
+
+	fields := maps.Clone(articleFields)
+	maps.Copy(fields, protectedArticleFields)
+	return fields
+}
+
+// ListFields lists articles like List, selecting only the requested fields
+func (r *articleRepository) ListFields(ctx context.Context, fields []string, page, perPage int, onlyPublished bool, opts model.ListOptions) ([]model.PartialItem, int, error) {
+	offset := (page - 1) * perPage
+
+	columns, err := fieldList(selectableArticleFields(onlyPublished), fields)
 	if err != nil {
 		return nil, 0, err
 	}
-	defer rows.Close()
 
-	var articles []model.Article
-	for rows.Next() {
-		var article model.Article
-		var publishedAt sql.NullTime
-		err := rows.Scan(
-			&article.ID,
-			&article.Title,
-			&article.Slug,
-			&article.Content,
-			&article.Excerpt,
-			&article.FeaturedImage,
-			&article.IsPublished,
-			&article.UserID,
-			&article.CreatedAt,
-			&article.UpdatedAt,
-			&publishedAt,
-		)
-		if err != nil {
-			return nil, 0, err
-		}
+	order, err := orderClause(articleSortColumns, opts)
+	if err != nil {
+		return nil, 0, err
+	}
 
-		if publishedAt.Valid {
-			article.PublishedAt = publishedAt.Time
-		}
+	conditions, args := articleConditions(onlyPublished, opts)
+	where := whereClause(conditions)
 
-		articles = append(articles, article)
+	var total int
+	err = readConn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM articles`+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	if err := rows.Err(); err != nil {
+	query := `SELECT ` + columns + `
+			  FROM articles` + where + order +
+		fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+
+	items, err := queryFields(ctx, readConn(ctx, r.db), query, append(args, perPage, offset)...)
+	if err != nil {
 		return nil, 0, err
 	}
 
-	return articles, total, nil
+	return items, total, nil
+}
+
+// ListFieldsAfter lists articles like ListAfter, selecting only the requested fields.
+// created_at is always selected for the next cursor.
+func (r *articleRepository) ListFieldsAfter(ctx context.Context, fields []string, after *model.Cursor, limit int, onlyPublished bool, opts model.ListOptions) ([]model.PartialItem, error) {
+	columns, err := fieldList(selectableArticleFields(onlyPublished), append(slices.Clip(fields), "created_at"))
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, args := articleConditions(onlyPublished, opts)
+	if after != nil {
+		args = append(args, after.CreatedAt, after.ID)
+		conditions = append(conditions, fmt.Sprintf(`(created_at, id) < ($%d, $%d)`, len(args)-1, len(args)))
+	}
+
+	query := `SELECT ` + columns + `
+			  FROM articles` + whereClause(conditions) +
+		fmt.Sprintf(` ORDER BY created_at DESC, id DESC LIMIT $%d`, len(args)+1)
+
+	return queryFields(ctx, readConn(ctx, r.db), query, append(args, limit)...)
+}
+
+// articleSortColumns maps the accepted sort keys to SQL expressions
+var articleSortColumns = map[string]string{
+	"created_at":   "created_at",
+	"published_at": "published_at",
+	"title":        "LOWER(title)",
+	"views":        "views",
+}
+
+// articleConditions builds the WHERE conditions for an article listing
+func articleConditions(onlyPublished bool, opts model.ListOptions) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	if onlyPublished {
+		conditions = append(conditions, `is_published = true`)
+	}
+	if !opts.PublishedAfter.IsZero() {
+		args = append(args, opts.PublishedAfter)
+		conditions = append(conditions, fmt.Sprintf(`published_at > $%d`, len(args)))
+	}
+	if opts.Author != "" {
+		args = append(args, opts.Author)
+		conditions = append(conditions, fmt.Sprintf(`user_id = (SELECT id FROM users WHERE username = $%d)`, len(args)))
+	}
+	if opts.Status != "" {
+		args = append(args, opts.Status)
+		conditions = append(conditions, fmt.Sprintf(`status = $%d`, len(args)))
+	}
+	if opts.AuthorID != "" {
+		args = append(args, opts.AuthorID)
+		conditions = append(conditions, fmt.Sprintf(`user_id = $%d`, len(args)))
+	}
+	if opts.Search != "" {
+		args = append(args, likePattern(opts.Search))
+		// The content of password-protected articles is never searched, matches would give away what it says
+		conditions = append(conditions, fmt.Sprintf(`(title ILIKE $%[1]d OR excerpt ILIKE $%[1]d OR (password_hash IS NULL AND content ILIKE $%[1]d))`, len(args)))
+	}
+
+	return conditions, args
 }
 
 // GetByAuthor gets articles by author ID with pagination
@@ -247,55 +572,207 @@ func (r *articleRepository) GetByAuthor(ctx context.Context, userID string, page
 	// Count total
 	countQuery := `SELECT COUNT(*) FROM articles WHERE user_id = $1`
 	var total int
-	err := r.db.QueryRowContext(ctx, countQuery, userID).Scan(&total)
+	err := readConn(ctx, r.db).QueryRowContext(ctx, countQuery, userID).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Get articles
-	query := `SELECT id, title, slug, content, excerpt, featured_image, is_published, user_id, created_at, updated_at, published_at 
-			  FROM articles 
-			  WHERE user_id = $1 
-			  ORDER BY created_at DESC 
+	query := `SELECT ` + articleColumns + `
+			  FROM articles
+			  WHERE user_id = $1
+			  ORDER BY created_at DESC
 			  LIMIT $2 OFFSET $3`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, perPage, offset)
+	articles, err := r.queryArticles(ctx, query, userID, perPage, offset)
 	if err != nil {
 		return nil, 0, err
 	}
+
+	return articles, total, nil
+}
+
+// ListBySeries lists the articles of a series in reading order
+func (r *articleRepository) ListBySeries(ctx context.Context, seriesID string, onlyPublished bool) ([]model.Article, error) {
+	query := `SELECT ` + articleColumns + `
+			  FROM articles
+			  WHERE series_id = $1`
+	if onlyPublished {
+		query += ` AND is_published = true`
+	}
+	query += ` ORDER BY series_order ASC NULLS LAST, published_at ASC, created_at ASC`
+
+	return r.queryArticles(ctx, query, seriesID)
+}
+
+// Archive counts published articles per month in loc, newest month first
+func (r *articleRepository) Archive(ctx context.Context, loc *time.Location) ([]model.ArchiveMonth, error) {
+	query := `SELECT EXTRACT(YEAR FROM published_at AT TIME ZONE $1)::int AS year,
+			         EXTRACT(MONTH FROM published_at AT TIME ZONE $1)::int AS month,
+			         COUNT(*)
+			  FROM articles
+			  WHERE is_published = true AND published_at IS NOT NULL
+			  GROUP BY year, month
+			  ORDER BY year DESC, month DESC`
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query, loc.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	months := []model.ArchiveMonth{}
+	for rows.Next() {
+		var month model.ArchiveMonth
+		if err := rows.Scan(&month.Year, &month.Month, &month.Count); err != nil {
+			return nil, err
+		}
+		months = append(months, month)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return months, nil
+}
+
+// ListPublishedBetween lists articles published in [from, to), newest first
+func (r *articleRepository) ListPublishedBetween(ctx context.Context, from, to time.Time) ([]model.Article, error) {
+	query := `SELECT ` + articleColumns + `
+			  FROM articles
+			  WHERE is_published = true AND published_at >= $1 AND published_at < $2
+			  ORDER BY published_at DESC, id DESC`
+
+	return r.queryArticles(ctx, query, from, to)
+}
+
+// ListPopular lists published articles with the most tracked views since the given day
+func (r *articleRepository) ListPopular(ctx context.Context, since time.Time, limit int) ([]model.Article, error) {
+	query := `SELECT ` + articleColumns + `
+			  FROM articles
+			  JOIN LATERAL (
+			      SELECT SUM(p.views) AS views FROM analytics_daily_pageviews p
+			      WHERE p.day >= $1 AND RTRIM(p.path, '/') LIKE '%/' || articles.slug
+			  ) v ON true
+			  WHERE is_published = true AND v.views > 0
+			  ORDER BY v.views DESC, published_at DESC
+			  LIMIT $2`
+
+	return r.queryArticles(ctx, query, since, limit)
+}
+
+// ListRecentlyUpdated lists published articles edited after they were published, latest edit first
+func (r *articleRepository) ListRecentlyUpdated(ctx context.Context, limit int) ([]model.Article, error) {
+	query := `SELECT ` + articleColumns + `
+			  FROM articles
+			  WHERE is_published = true AND updated_at > COALESCE(published_at, created_at)
+			  ORDER BY updated_at DESC, id DESC
+			  LIMIT $1`
+
+	return r.queryArticles(ctx, query, limit)
+}
+
+// ListFeatured lists published featured articles, most recently featured first
+func (r *articleRepository) ListFeatured(ctx context.Context) ([]model.Article, error) {
+	query := `SELECT ` + articleColumns + `
+			  FROM articles
+			  WHERE is_featured = true AND is_published = true
+			  ORDER BY featured_at DESC, id DESC`
+
+	return r.queryArticles(ctx, query)
+}
+
+// queryArticles runs a query returning a list of articles
+func (r *articleRepository) queryArticles(ctx context.Context, query string, args ...interface{}) ([]model.Article, error) {
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
 	var articles []model.Article
 	for rows.Next() {
-		var article model.Article
-		var publishedAt sql.NullTime
-		err := rows.Scan(
-			&article.ID,
-			&article.Title,
-			&article.Slug,
-			&article.Content,
-			&article.Excerpt,
-			&article.FeaturedImage,
-			&article.IsPublished,
-			&article.UserID,
-			&article.CreatedAt,
-			&article.UpdatedAt,
-			&publishedAt,
-		)
+		article, err := scanArticle(rows)
 		if err != nil {
-			return nil, 0, err
+			return nil, err
 		}
+		articles = append(articles, *article)
+	}
 
-		if publishedAt.Valid {
-			article.PublishedAt = publishedAt.Time
-		}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
 
-		articles = append(articles, article)
+// scanArticle scans an article row selected with articleColumns
+func scanArticle(row rowScanner) (*model.Article, error) {
+	var article model.Article
+	var publishedAt sql.NullTime
+	var scheduledAt sql.NullTime
+	var seriesID sql.NullString
+	var seriesOrder sql.NullInt32
+	var toc []byte
+
+	dest := []interface{}{
+		&article.ID,
+		&article.Title,
+		&article.Slug,
+		&article.Content,
+		&article.Excerpt,
+		&article.FeaturedImage,
+		&article.Status,
+		&article.IsPublished,
+		&article.IsFeatured,
+		&article.UserID,
+		&article.CreatedAt,
+		&article.UpdatedAt,
+		&publishedAt,
+		&scheduledAt,
+		&seriesID,
+		&seriesOrder,
+		&toc,
+		&article.PasswordHash,
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, 0, err
+	err := row.Scan(append(dest, seoDest(&article.SEOMeta)...)...)
+	if err != nil {
+		return nil, err
 	}
 
-	return articles, total, nil
+	if publishedAt.Valid {
+		article.PublishedAt = publishedAt.Time
+	}
+	if scheduledAt.Valid {
+		article.ScheduledAt = scheduledAt.Time
+	}
+	if seriesID.Valid {
+		article.SeriesID = seriesID.String
+	}
+	if seriesOrder.Valid {
+		article.SeriesOrder = int(seriesOrder.Int32)
+	}
+	article.IsProtected = article.PasswordHash != ""
+	if toc != nil {
+		if err := json.Unmarshal(toc, &article.TOC); err != nil {
+			return nil, err
+		}
+	} else {
+		// Saved before tables of contents were stored
+		article.TOC = tableOfContents(article.Content)
+	}
+
+	return &article, nil
+}
+
+// nullString converts an empty string to NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// nullSeriesOrder only stores a series order when the article belongs to a series
+func nullSeriesOrder(seriesID string, order int) sql.NullInt32 {
+	return sql.NullInt32{Int32: int32(order), Valid: seriesID != ""}
 }
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// ArticleRevisionRepository defines methods for article revision repository
+type ArticleRevisionRepository interface {
+	ListByArticle(ctx context.Context, articleID string) ([]model.ArticleRevision, error)
+	Get(ctx context.Context, articleID, id string) (*model.ArticleRevision, error)
+}
+
+// articleRevisionRepository is the implementation of ArticleRevisionRepository
+type articleRevisionRepository struct {
+	db *sqlx.DB
+}
+
+// NewArticleRevisionRepository creates a new ArticleRevisionRepository
+func NewArticleRevisionRepository(db *sqlx.DB) ArticleRevisionRepository {
+	return &articleRevisionRepository{db: db}
+}
+
+// ListByArticle lists an article's revisions, newest first, without their content
+func (r *articleRevisionRepository) ListByArticle(ctx context.Context, articleID string) ([]model.ArticleRevision, error) {
+	query := `SELECT id, article_id, title, '', created_at
+			  FROM article_revisions
+			  WHERE article_id = $1
+			  ORDER BY created_at DESC, id DESC`
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query, articleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []model.ArticleRevision{}
+	for rows.Next() {
+		revision, err := scanArticleRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, *revision)
+	}
+
+	return revisions, rows.Err()
+}
+
+// Get returns a revision of an article, or nil when the article has no such revision
+func (r *articleRevisionRepository) Get(ctx context.Context, articleID, id string) (*model.ArticleRevision, error) {
+	query := `SELECT id, article_id, title, content, created_at
+			  FROM article_revisions
+			  WHERE article_id = $1 AND id = $2`
+
+	revision, err := scanArticleRevision(readConn(ctx, r.db).QueryRowContext(ctx, query, articleID, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// recordRevision stores the title and content of an article unless they match its latest revision.
+// clock_timestamp keeps revisions recorded in the same transaction in order.
+func recordRevision(ctx context.Context, tx DBTX, articleID, title, content string) error {
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO article_revisions (id, article_id, title, content, created_at)
+		 SELECT $1, $2, $3, $4, clock_timestamp()
+		 WHERE NOT EXISTS (
+		     SELECT 1 FROM (
+		         SELECT title, content FROM article_revisions
+		         WHERE article_id = $2
+		         ORDER BY created_at DESC, id DESC
+		         LIMIT 1
+		     ) latest
+		     WHERE latest.title = $3 AND latest.content = $4
+		 )`,
+		newID(), articleID, title, content,
+	)
+	return err
+}
+
+// scanArticleRevision scans an article revision row
+func scanArticleRevision(row rowScanner) (*model.ArticleRevision, error) {
+	var revision model.ArticleRevision
+
+	err := row.Scan(
+		&revision.ID,
+		&revision.ArticleID,
+		&revision.Title,
+		&revision.Content,
+		&revision.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &revision, nil
+}
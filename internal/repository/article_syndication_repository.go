@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// ArticleSyndicationRepository defines methods for article syndication repository
+type ArticleSyndicationRepository interface {
+	Save(ctx context.Context, syndication *model.ArticleSyndication) error
+	Get(ctx context.Context, articleID, platform string) (*model.ArticleSyndication, error)
+	ListByArticle(ctx context.Context, articleID string) ([]model.ArticleSyndication, error)
+}
+
+// articleSyndicationRepository is the implementation of ArticleSyndicationRepository
+type articleSyndicationRepository struct {
+	db *sqlx.DB
+}
+
+// NewArticleSyndicationRepository creates a new ArticleSyndicationRepository
+func NewArticleSyndicationRepository(db *sqlx.DB) ArticleSyndicationRepository {
+	return &articleSyndicationRepository{db: db}
+}
+
+// articleSyndicationColumns is the column list matching scanArticleSyndication
+const articleSyndicationColumns = `id, article_id, platform, status, remote_id, url, error, created_at, updated_at`
+
+// Save records the latest syndication attempt for an article and platform
+func (r *articleSyndicationRepository) Save(ctx context.Context, syndication *model.ArticleSyndication) error {
+	query := `INSERT INTO article_syndications (id, article_id, platform, status, remote_id, url, error)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7)
+			  ON CONFLICT (article_id, platform) DO UPDATE
+			  SET status = EXCLUDED.status, remote_id = EXCLUDED.remote_id, url = EXCLUDED.url,
+			      error = EXCLUDED.error, updated_at = CURRENT_TIMESTAMP
+			  RETURNING ` + articleSyndicationColumns
+
+	row := conn(ctx, r.db).QueryRowContext(ctx, query,
+		newID(),
+		syndication.ArticleID,
+		syndication.Platform,
+		syndication.Status,
+		syndication.RemoteID,
+		syndication.URL,
+		syndication.Error,
+	)
+
+	saved, err := scanArticleSyndication(row)
+	if err != nil {
+		return err
+	}
+
+	*syndication = *saved
+	return nil
+}
+
+// Get gets an article's syndication to a platform, or nil if it was never attempted
+func (r *articleSyndicationRepository) Get(ctx context.Context, articleID, platform string) (*model.ArticleSyndication, error) {
+	query := `SELECT ` + articleSyndicationColumns + `
+			  FROM article_syndications
+			  WHERE article_id = $1 AND platform = $2`
+
+	syndication, err := scanArticleSyndication(conn(ctx, r.db).QueryRowContext(ctx, query, articleID, platform))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return syndication, nil
+}
+
+// ListByArticle lists an article's syndications by platform
+func (r *articleSyndicationRepository) ListByArticle(ctx context.Context, articleID string) ([]model.ArticleSyndication, error) {
+	query := `SELECT ` + articleSyndicationColumns + `
+			  FROM article_syndications
+			  WHERE article_id = $1
+			  ORDER BY platform`
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query, articleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	syndications := []model.ArticleSyndication{}
+	for rows.Next() {
+		syndication, err := scanArticleSyndication(rows)
+		if err != nil {
+			return nil, err
+		}
+		syndications = append(syndications, *syndication)
+	}
+
+	return syndications, rows.Err()
+}
+
+// scanArticleSyndication scans a syndication row selected with articleSyndicationColumns
+func scanArticleSyndication(row rowScanner) (*model.ArticleSyndication, error) {
+	var syndication model.ArticleSyndication
+
+	err := row.Scan(
+		&syndication.ID,
+		&syndication.ArticleID,
+		&syndication.Platform,
+		&syndication.Status,
+		&syndication.RemoteID,
+		&syndication.URL,
+		&syndication.Error,
+		&syndication.CreatedAt,
+		&syndication.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &syndication, nil
+}
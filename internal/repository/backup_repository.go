@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// BackupExtension is the file extension of database dumps
+const BackupExtension = ".dump"
+
+// BackupRepository stores database dumps
+type BackupRepository interface {
+	Save(ctx context.Context, name string, dump io.Reader) (*model.Backup, error)
+	List(ctx context.Context) ([]model.Backup, error)
+}
+
+// NewBackupRepository stores backups in S3 when a bucket is configured, otherwise on local disk
+func NewBackupRepository(cfg config.Config) (BackupRepository, error) {
+	if cfg.BackupS3Bucket == "" {
+		return &localBackupRepository{dir: cfg.BackupDir}, nil
+	}
+
+	client, err := minio.New(cfg.BackupS3Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.BackupS3AccessKey, cfg.BackupS3SecretKey, ""),
+		Secure: cfg.BackupS3UseSSL,
+		Region: cfg.BackupS3Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3BackupRepository{
+		client: client,
+		bucket: cfg.BackupS3Bucket,
+		prefix: cfg.BackupS3Prefix,
+	}, nil
+}
+
+// localBackupRepository keeps backups in a directory
+type localBackupRepository struct {
+	dir string
+}
+
+// Save writes the dump to a temporary file and renames it once complete
+func (r *localBackupRepository) Save(ctx context.Context, name string, dump io.Reader) (*model.Backup, error) {
+	if err := os.MkdirAll(r.dir, 0700); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(r.dir, name)
+	tmp, err := os.CreateTemp(r.dir, name+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	size, err := io.Copy(tmp, dump)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Backup{Name: name, Size: size, Location: "local", CreatedAt: info.ModTime()}, nil
+}
+
+// List lists the backups in the directory, newest first
+func (r *localBackupRepository) List(ctx context.Context) ([]model.Backup, error) {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []model.Backup{}, nil
+		}
+		return nil, err
+	}
+
+	backups := []model.Backup{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), BackupExtension) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		backups = append(backups, model.Backup{
+			Name:      entry.Name(),
+			Size:      info.Size(),
+			Location:  "local",
+			CreatedAt: info.ModTime(),
+		})
+	}
+
+	sortBackups(backups)
+	return backups, nil
+}
+
+// s3BackupRepository keeps backups in an S3-compatible bucket
+type s3BackupRepository struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// Save streams the dump to the bucket; a failing reader aborts the upload
+func (r *s3BackupRepository) Save(ctx context.Context, name string, dump io.Reader) (*model.Backup, error) {
+	info, err := r.client.PutObject(ctx, r.bucket, r.prefix+name, dump, -1, minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Backup{Name: name, Size: info.Size, Location: "s3", CreatedAt: info.LastModified}, nil
+}
+
+// List lists the backups under the prefix, newest first
+func (r *s3BackupRepository) List(ctx context.Context) ([]model.Backup, error) {
+	backups := []model.Backup{}
+	for object := range r.client.ListObjects(ctx, r.bucket, minio.ListObjectsOptions{Prefix: r.prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		if !strings.HasSuffix(object.Key, BackupExtension) {
+			continue
+		}
+
+		backups = append(backups, model.Backup{
+			Name:      strings.TrimPrefix(object.Key, r.prefix),
+			Size:      object.Size,
+			Location:  "s3",
+			CreatedAt: object.LastModified,
+		})
+	}
+
+	sortBackups(backups)
+	return backups, nil
+}
+
+// sortBackups orders backups newest first
+func sortBackups(backups []model.Backup) {
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.After(backups[j].CreatedAt)
+	})
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+)
+
+// cacheInvalidationChannel is the Postgres channel cache invalidations are sent on
+const cacheInvalidationChannel = "cache_invalidation"
+
+// CacheInvalidationRepository defines methods for broadcasting cache invalidations to every instance
+type CacheInvalidationRepository interface {
+	Notify(ctx context.Context, payload string) error
+	Listen(ctx context.Context, onListening func(), handle func(payload string)) error
+}
+
+// cacheInvalidationRepository is the Postgres LISTEN/NOTIFY implementation of CacheInvalidationRepository
+type cacheInvalidationRepository struct {
+	db *sqlx.DB
+}
+
+// NewCacheInvalidationRepository creates a new CacheInvalidationRepository
+func NewCacheInvalidationRepository(db *sqlx.DB) CacheInvalidationRepository {
+	return &cacheInvalidationRepository{
+		db: db,
+	}
+}
+
+// Notify sends a payload to every listener. Inside a transaction Postgres delivers it on commit,
+// and not at all on rollback.
+func (r *cacheInvalidationRepository) Notify(ctx context.Context, payload string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `SELECT pg_notify($1, $2)`, cacheInvalidationChannel, payload)
+	return err
+}
+
+// Listen holds a connection listening on the channel and calls handle with each payload, until
+// ctx is done or the connection fails. onListening is called once notifications are received.
+func (r *cacheInvalidationRepository) Listen(ctx context.Context, onListening func(), handle func(payload string)) error {
+	dbConn, err := r.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer dbConn.Close()
+
+	return dbConn.Raw(func(driverConn any) error {
+		stdlibConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return fmt.Errorf("unexpected driver connection %T", driverConn)
+		}
+		pgxConn := stdlibConn.Conn()
+
+		if _, err := pgxConn.Exec(ctx, "LISTEN "+cacheInvalidationChannel); err != nil {
+			return errors.Join(err, driver.ErrBadConn)
+		}
+		onListening()
+
+		for {
+			notification, err := pgxConn.WaitForNotification(ctx)
+			if err != nil {
+				// Discard the connection rather than return it to the pool still listening
+				return errors.Join(err, driver.ErrBadConn)
+			}
+			handle(notification.Payload)
+		}
+	})
+}
@@ -0,0 +1,263 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// CampaignRepository defines methods for newsletter campaign repository
+type CampaignRepository interface {
+	Create(ctx context.Context, campaign *model.CampaignCreate, articleID, userID string) (string, error)
+	Update(ctx context.Context, id string, campaign *model.CampaignUpdate) (bool, error)
+	Delete(ctx context.Context, id string) (bool, error)
+	GetByID(ctx context.Context, id string) (*model.Campaign, error)
+	List(ctx context.Context, page, perPage int) ([]model.Campaign, int, error)
+	Start(ctx context.Context, id string) (bool, error)
+	NextBatch(ctx context.Context, id string, size int) ([]model.CampaignRecipient, error)
+	MarkRecipientSent(ctx context.Context, recipientID string) error
+	Finish(ctx context.Context, id string) error
+	RecordOpen(ctx context.Context, recipientID string) error
+}
+
+// campaignRepository is the implementation of CampaignRepository
+type campaignRepository struct {
+	db *sqlx.DB
+}
+
+// NewCampaignRepository creates a new CampaignRepository
+func NewCampaignRepository(db *sqlx.DB) CampaignRepository {
+	return &campaignRepository{db: db}
+}
+
+// campaignSelect selects campaigns with their delivery counts, matching scanCampaign
+const campaignSelect = `SELECT c.id, c.subject, c.content, c.article_id, c.status, c.user_id,
+			  COALESCE(r.recipients, 0), COALESCE(r.sent, 0), COALESCE(r.opened, 0),
+			  c.created_at, c.updated_at, c.started_at, c.finished_at
+			  FROM newsletter_campaigns c
+			  LEFT JOIN LATERAL (
+			      SELECT COUNT(*) AS recipients,
+			             COUNT(*) FILTER (WHERE status = 'sent') AS sent,
+			             COUNT(*) FILTER (WHERE opened_at IS NOT NULL) AS opened
+			      FROM newsletter_campaign_recipients
+			      WHERE campaign_id = c.id
+			  ) r ON TRUE`
+
+// Create creates a draft campaign
+func (r *campaignRepository) Create(ctx context.Context, campaign *model.CampaignCreate, articleID, userID string) (string, error) {
+	query := `INSERT INTO newsletter_campaigns (id, subject, content, article_id, user_id)
+			  VALUES ($1, $2, $3, $4, $5)
+			  RETURNING id`
+
+	var id string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, newID(), campaign.Subject, campaign.Content, nullString(articleID), nullString(userID)).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// Update updates a draft campaign, reporting whether there was a draft to update
+func (r *campaignRepository) Update(ctx context.Context, id string, campaign *model.CampaignUpdate) (bool, error) {
+	query := `UPDATE newsletter_campaigns
+			  SET subject = $2, content = $3, updated_at = NOW()
+			  WHERE id = $1 AND status = 'draft'`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id, campaign.Subject, campaign.Content)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// Delete deletes a draft campaign, reporting whether there was a draft to delete
+func (r *campaignRepository) Delete(ctx context.Context, id string) (bool, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM newsletter_campaigns WHERE id = $1 AND status = 'draft'`, id)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// GetByID gets a campaign by ID
+func (r *campaignRepository) GetByID(ctx context.Context, id string) (*model.Campaign, error) {
+	return scanCampaign(conn(ctx, r.db).QueryRowContext(ctx, campaignSelect+` WHERE c.id = $1`, id))
+}
+
+// List lists campaigns newest first
+func (r *campaignRepository) List(ctx context.Context, page, perPage int) ([]model.Campaign, int, error) {
+	offset := (page - 1) * perPage
+
+	// Count total
+	var total int
+	if err := readConn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM newsletter_campaigns`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	// Get campaigns
+	query := campaignSelect + `
+			  ORDER BY c.created_at DESC, c.id DESC
+			  LIMIT $1 OFFSET $2`
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query, perPage, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	campaigns := []model.Campaign{}
+	for rows.Next() {
+		campaign, err := scanCampaign(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		campaigns = append(campaigns, *campaign)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return campaigns, total, nil
+}
+
+// Start moves a draft campaign to sending and adds every confirmed subscriber as a recipient,
+// reporting whether there was a draft to start
+func (r *campaignRepository) Start(ctx context.Context, id string) (bool, error) {
+	var started bool
+	err := withTx(ctx, r.db, func(tx DBTX) error {
+		query := `UPDATE newsletter_campaigns
+				  SET status = 'sending', started_at = NOW(), updated_at = NOW()
+				  WHERE id = $1 AND status = 'draft'`
+
+		result, err := tx.ExecContext(ctx, query, id)
+		if err != nil {
+			return err
+		}
+		rows, err := result.RowsAffected()
+		if err != nil || rows == 0 {
+			return err
+		}
+
+		query = `INSERT INTO newsletter_campaign_recipients (campaign_id, subscriber_id)
+				 SELECT $1, id FROM subscribers WHERE status = 'confirmed'
+				 ON CONFLICT (campaign_id, subscriber_id) DO NOTHING`
+		if _, err := tx.ExecContext(ctx, query, id); err != nil {
+			return err
+		}
+
+		started = true
+		return nil
+	})
+
+	return started, err
+}
+
+// NextBatch returns up to size recipients still waiting for the campaign. Recipients who
+// unsubscribed since the campaign started are skipped instead.
+func (r *campaignRepository) NextBatch(ctx context.Context, id string, size int) ([]model.CampaignRecipient, error) {
+	skip := `UPDATE newsletter_campaign_recipients cr
+			 SET status = 'skipped'
+			 FROM subscribers s
+			 WHERE cr.subscriber_id = s.id AND cr.campaign_id = $1
+			   AND cr.status = 'pending' AND s.status <> 'confirmed'`
+	if _, err := conn(ctx, r.db).ExecContext(ctx, skip, id); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT cr.id, s.email, s.unsubscribe_token
+			  FROM newsletter_campaign_recipients cr
+			  JOIN subscribers s ON s.id = cr.subscriber_id
+			  WHERE cr.campaign_id = $1 AND cr.status = 'pending'
+			  ORDER BY cr.id
+			  LIMIT $2`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, id, size)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var recipients []model.CampaignRecipient
+	for rows.Next() {
+		var recipient model.CampaignRecipient
+		if err := rows.Scan(&recipient.ID, &recipient.Email, &recipient.UnsubscribeToken); err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	return recipients, rows.Err()
+}
+
+// MarkRecipientSent records that a recipient's email was handed to delivery
+func (r *campaignRepository) MarkRecipientSent(ctx context.Context, recipientID string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `UPDATE newsletter_campaign_recipients SET status = 'sent', sent_at = NOW() WHERE id = $1`, recipientID)
+	return err
+}
+
+// Finish marks a campaign sent once every recipient has been handled
+func (r *campaignRepository) Finish(ctx context.Context, id string) error {
+	query := `UPDATE newsletter_campaigns
+			  SET status = 'sent', finished_at = NOW(), updated_at = NOW()
+			  WHERE id = $1 AND status = 'sending'`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	return err
+}
+
+// RecordOpen records the first open of a recipient's email; later opens are ignored
+func (r *campaignRepository) RecordOpen(ctx context.Context, recipientID string) error {
+	query := `UPDATE newsletter_campaign_recipients
+			  SET opened_at = NOW()
+			  WHERE id = $1 AND status = 'sent' AND opened_at IS NULL`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, recipientID)
+	return err
+}
+
+// scanCampaign scans a campaign row selected with campaignSelect
+func scanCampaign(row rowScanner) (*model.Campaign, error) {
+	var campaign model.Campaign
+	var articleID, userID sql.NullString
+	var startedAt, finishedAt sql.NullTime
+
+	err := row.Scan(
+		&campaign.ID,
+		&campaign.Subject,
+		&campaign.Content,
+		&articleID,
+		&campaign.Status,
+		&userID,
+		&campaign.Recipients,
+		&campaign.SentCount,
+		&campaign.OpenCount,
+		&campaign.CreatedAt,
+		&campaign.UpdatedAt,
+		&startedAt,
+		&finishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign.ArticleID = articleID.String
+	campaign.UserID = userID.String
+	campaign.StartedAt = timePtr(startedAt)
+	campaign.FinishedAt = timePtr(finishedAt)
+
+	return &campaign, nil
+}
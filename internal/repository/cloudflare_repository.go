@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+)
+
+const (
+	cloudflareAPIURL = "https://api.cloudflare.com/client/v4"
+	// CloudflarePurgeBatchSize is the most URLs Cloudflare purges in one call on every plan
+	CloudflarePurgeBatchSize = 30
+	// maxCloudflareResponse bounds the API responses read into memory
+	maxCloudflareResponse = 1 << 20
+)
+
+// CloudflareRepository purges cached URLs through the Cloudflare API
+type CloudflareRepository struct {
+	zoneID string
+	token  string
+	client *outboundClient
+}
+
+// NewCloudflareRepository creates a new Cloudflare repository
+func NewCloudflareRepository(cfg config.Config) *CloudflareRepository {
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+
+	return &CloudflareRepository{
+		zoneID: cfg.CloudflareZoneID,
+		token:  cfg.CloudflareAPIToken,
+		client: newOutboundClient("cloudflare", httpClient, cfg),
+	}
+}
+
+// PurgeURLs removes up to CloudflarePurgeBatchSize URLs from the zone's cache
+func (r *CloudflareRepository) PurgeURLs(ctx context.Context, urls []string) error {
+	body, err := json.Marshal(map[string][]string{"files": urls})
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.client.do(ctx, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, cloudflareAPIURL+"/zones/"+r.zoneID+"/purge_cache", bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+r.token)
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool `json:"success"`
+		Errors  []struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxCloudflareResponse)).Decode(&result); err != nil {
+		return fmt.Errorf("cloudflare returned status %d", resp.StatusCode)
+	}
+	if !result.Success {
+		messages := make([]string, 0, len(result.Errors))
+		for _, apiErr := range result.Errors {
+			messages = append(messages, fmt.Sprintf("%d: %s", apiErr.Code, apiErr.Message))
+		}
+		if len(messages) == 0 {
+			return fmt.Errorf("cloudflare returned status %d", resp.StatusCode)
+		}
+		return errors.New("cloudflare purge failed: " + strings.Join(messages, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// ActorKey is the request context key holding the ID of the user making a change, recorded
+// in the content audit trail. The auth middleware sets it.
+type ActorKey struct{}
+
+// ContentAuditRepository defines methods for content audit repository
+type ContentAuditRepository interface {
+	Record(ctx context.Context, entry *model.ContentAuditEntry) error
+	List(ctx context.Context, filter model.ContentAuditFilter, page, perPage int) ([]model.ContentAuditEntry, int, error)
+	GetByID(ctx context.Context, id string) (*model.ContentAuditEntry, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// contentAuditRepository is the implementation of ContentAuditRepository
+type contentAuditRepository struct {
+	db *sqlx.DB
+}
+
+// NewContentAuditRepository creates a new ContentAuditRepository
+func NewContentAuditRepository(db *sqlx.DB) ContentAuditRepository {
+	return &contentAuditRepository{db: db}
+}
+
+// Record stores an audit entry in the caller's transaction, if any, attributed to the actor in ctx
+func (r *contentAuditRepository) Record(ctx context.Context, entry *model.ContentAuditEntry) error {
+	changedFields, err := json.Marshal(entry.ChangedFields)
+	if err != nil {
+		return err
+	}
+
+	var actorID sql.NullString
+	if id, ok := ctx.Value(ActorKey{}).(string); ok && id != "" {
+		actorID = sql.NullString{String: id, Valid: true}
+	}
+
+	query := `INSERT INTO content_audit (id, entity_type, entity_id, action, actor_id, changed_fields, before, after)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			  RETURNING created_at`
+
+	entry.ID = newID()
+	entry.ActorID = actorID.String
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		entry.ID,
+		entry.EntityType,
+		entry.EntityID,
+		entry.Action,
+		actorID,
+		changedFields,
+		nullJSON(entry.Before),
+		nullJSON(entry.After),
+	).Scan(&entry.CreatedAt)
+}
+
+// List lists audit entries, newest first, without their snapshots
+func (r *contentAuditRepository) List(ctx context.Context, filter model.ContentAuditFilter, page, perPage int) ([]model.ContentAuditEntry, int, error) {
+	offset := (page - 1) * perPage
+
+	var conditions []string
+	var args []interface{}
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.EntityType != "" {
+		addCondition(`a.entity_type = $%d`, filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		addCondition(`a.entity_id = $%d`, filter.EntityID)
+	}
+	if filter.ActorID != "" {
+		addCondition(`a.actor_id = $%d`, filter.ActorID)
+	}
+	if filter.Field != "" {
+		addCondition(`a.changed_fields ? $%d`, filter.Field)
+	}
+	where := whereClause(conditions)
+
+	// Count total
+	var total int
+	err := readConn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM content_audit a`+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get entries
+	query := `SELECT a.id, a.entity_type, a.entity_id, a.action, COALESCE(a.actor_id::text, ''), COALESCE(u.username, ''),
+			         a.changed_fields, NULL::jsonb, NULL::jsonb, a.created_at
+			  FROM content_audit a
+			  LEFT JOIN users u ON u.id = a.actor_id` + where + `
+			  ORDER BY a.created_at DESC, a.id DESC` +
+		fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query, append(args, perPage, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	entries := []model.ContentAuditEntry{}
+	for rows.Next() {
+		entry, err := scanContentAuditEntry(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, *entry)
+	}
+
+	return entries, total, rows.Err()
+}
+
+// GetByID gets an audit entry with its snapshots
+func (r *contentAuditRepository) GetByID(ctx context.Context, id string) (*model.ContentAuditEntry, error) {
+	query := `SELECT a.id, a.entity_type, a.entity_id, a.action, COALESCE(a.actor_id::text, ''), COALESCE(u.username, ''),
+			         a.changed_fields, a.before, a.after, a.created_at
+			  FROM content_audit a
+			  LEFT JOIN users u ON u.id = a.actor_id
+			  WHERE a.id = $1`
+
+	entry, err := scanContentAuditEntry(readConn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("audit entry not found")
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// DeleteBefore deletes the entries recorded before a time and returns how many were deleted
+func (r *contentAuditRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM content_audit WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// scanContentAuditEntry scans an audit entry row
+func scanContentAuditEntry(row rowScanner) (*model.ContentAuditEntry, error) {
+	var entry model.ContentAuditEntry
+	var changedFields []byte
+	var before, after []byte
+
+	err := row.Scan(
+		&entry.ID,
+		&entry.EntityType,
+		&entry.EntityID,
+		&entry.Action,
+		&entry.ActorID,
+		&entry.ActorUsername,
+		&changedFields,
+		&before,
+		&after,
+		&entry.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(changedFields, &entry.ChangedFields); err != nil {
+		return nil, err
+	}
+	entry.Before = before
+	entry.After = after
+
+	return &entry, nil
+}
+
+// nullJSON stores an empty JSON document as NULL
+func nullJSON(data json.RawMessage) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return []byte(data)
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"go.uber.org/zap"
+)
+
+const (
+	githubAPIURL = "https://api.github.com"
+	// maxContentFile skips files too large to be an article
+	maxContentFile = 1 << 20
+	// maxContentArchive bounds the repository archive downloaded from GitHub
+	maxContentArchive = 100 << 20
+)
+
+// ContentSourceRepository reads Markdown files from a local directory or a GitHub repository
+type ContentSourceRepository struct {
+	httpClient *http.Client
+	cfg        config.Config
+	logger     *zap.Logger
+}
+
+// NewContentSourceRepository creates a new content source repository
+func NewContentSourceRepository(cfg config.Config, logger *zap.Logger) *ContentSourceRepository {
+	return &ContentSourceRepository{
+		httpClient: &http.Client{
+			Timeout: 2 * time.Minute,
+		},
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Files returns the Markdown files of the configured source keyed by their slash-separated
+// path relative to CONTENT_IMPORT_PATH
+func (r *ContentSourceRepository) Files(ctx context.Context) (map[string][]byte, error) {
+	if r.cfg.ContentImportGitHubRepo != "" {
+		return r.githubFiles(ctx)
+	}
+	return r.dirFiles()
+}
+
+// dirFiles walks the local content directory
+func (r *ContentSourceRepository) dirFiles() (map[string][]byte, error) {
+	root := filepath.Join(r.cfg.ContentImportDir, filepath.FromSlash(r.cfg.ContentImportPath))
+	files := make(map[string][]byte)
+
+	err := filepath.WalkDir(root, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !isMarkdownFile(name) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.Size() > maxContentFile {
+			r.logger.Warn("Skipping oversized content file", zap.String("path", name))
+			return nil
+		}
+
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, name)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// githubFiles downloads the branch as a tarball and extracts the Markdown files
+func (r *ContentSourceRepository) githubFiles(ctx context.Context) (map[string][]byte, error) {
+	url := fmt.Sprintf("%s/repos/%s/tarball/%s", githubAPIURL, r.cfg.ContentImportGitHubRepo, r.cfg.ContentImportGitHubBranch)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if r.cfg.ContentImportGitHubToken != "" {
+		req.Header.Set("Authorization", "Bearer "+r.cfg.ContentImportGitHubToken)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		r.logger.Error("Failed to download content repository", zap.Error(err))
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		r.logger.Error("GitHub returned non-OK status", zap.Int("status_code", resp.StatusCode))
+		return nil, fmt.Errorf("github tarball returned status %d", resp.StatusCode)
+	}
+
+	gz, err := gzip.NewReader(io.LimitReader(resp.Body, maxContentArchive))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	prefix := strings.Trim(r.cfg.ContentImportPath, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+
+	files := make(map[string][]byte)
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg || !isMarkdownFile(header.Name) || header.Size > maxContentFile {
+			continue
+		}
+
+		// Entries sit under a single "<owner>-<repo>-<sha>/" directory
+		_, name, ok := strings.Cut(header.Name, "/")
+		if !ok || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		data, err := io.ReadAll(archive)
+		if err != nil {
+			return nil, err
+		}
+		files[strings.TrimPrefix(name, prefix)] = data
+	}
+
+	return files, nil
+}
+
+// isMarkdownFile reports whether the file name has a Markdown extension
+func isMarkdownFile(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".md", ".markdown":
+		return true
+	}
+	return false
+}
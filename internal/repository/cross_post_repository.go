@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"go.uber.org/zap"
+)
+
+const (
+	devToAPIURL  = "https://dev.to/api"
+	mediumAPIURL = "https://api.medium.com/v1"
+	// maxCrossPostResponse bounds the API responses read into memory
+	maxCrossPostResponse = 1 << 20
+)
+
+// CrossPostRepository publishes articles to dev.to and Medium through their APIs
+type CrossPostRepository struct {
+	httpClient  *http.Client
+	devToAPIKey string
+	mediumToken string
+	logger      *zap.Logger
+}
+
+// NewCrossPostRepository creates a new cross-posting repository
+func NewCrossPostRepository(cfg config.Config, logger *zap.Logger) *CrossPostRepository {
+	return &CrossPostRepository{
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		devToAPIKey: cfg.DevToAPIKey,
+		mediumToken: cfg.MediumToken,
+		logger:      logger,
+	}
+}
+
+// devToArticle is the article payload of the dev.to API
+type devToArticle struct {
+	Title        string `json:"title"`
+	BodyMarkdown string `json:"body_markdown"`
+	Published    bool   `json:"published"`
+	Description  string `json:"description,omitempty"`
+	MainImage    string `json:"main_image,omitempty"`
+	CanonicalURL string `json:"canonical_url,omitempty"`
+}
+
+// PublishDevTo creates a published dev.to article, or updates it when remoteID is set
+func (r *CrossPostRepository) PublishDevTo(ctx context.Context, remoteID string, post *model.CrossPost) (*model.CrossPostResult, error) {
+	payload := map[string]devToArticle{
+		"article": {
+			Title:        post.Title,
+			BodyMarkdown: post.Markdown,
+			Published:    true,
+			Description:  post.Description,
+			MainImage:    post.CoverImage,
+			CanonicalURL: post.CanonicalURL,
+		},
+	}
+
+	method, url := http.MethodPost, devToAPIURL+"/articles"
+	if remoteID != "" {
+		method, url = http.MethodPut, devToAPIURL+"/articles/"+remoteID
+	}
+
+	var created struct {
+		ID  int    `json:"id"`
+		URL string `json:"url"`
+	}
+	headers := map[string]string{"api-key": r.devToAPIKey}
+	if err := r.doJSON(ctx, method, url, headers, payload, &created); err != nil {
+		r.logger.Error("Failed to publish to dev.to", zap.Error(err))
+		return nil, err
+	}
+
+	return &model.CrossPostResult{ID: strconv.Itoa(created.ID), URL: created.URL}, nil
+}
+
+// PublishMedium creates a public Medium post; Medium's API can't update posts
+func (r *CrossPostRepository) PublishMedium(ctx context.Context, post *model.CrossPost) (*model.CrossPostResult, error) {
+	headers := map[string]string{"Authorization": "Bearer " + r.mediumToken}
+
+	// Posts are created under the token owner's account
+	var me struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := r.doJSON(ctx, http.MethodGet, mediumAPIURL+"/me", headers, nil, &me); err != nil {
+		r.logger.Error("Failed to look up Medium user", zap.Error(err))
+		return nil, err
+	}
+
+	payload := map[string]string{
+		"title":         post.Title,
+		"contentFormat": "markdown",
+		// Medium doesn't render the title separately
+		"content":       "# " + post.Title + "\n\n" + post.Markdown,
+		"canonicalUrl":  post.CanonicalURL,
+		"publishStatus": "public",
+	}
+
+	var created struct {
+		Data struct {
+			ID  string `json:"id"`
+			URL string `json:"url"`
+		} `json:"data"`
+	}
+	if err := r.doJSON(ctx, http.MethodPost, mediumAPIURL+"/users/"+me.Data.ID+"/posts", headers, payload, &created); err != nil {
+		r.logger.Error("Failed to publish to Medium", zap.Error(err))
+		return nil, err
+	}
+
+	return &model.CrossPostResult{ID: created.Data.ID, URL: created.Data.URL}, nil
+}
+
+// doJSON sends an optional JSON payload and decodes the JSON response into out
+func (r *CrossPostRepository) doJSON(ctx context.Context, method, url string, headers map[string]string, payload, out interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxCrossPostResponse))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned status %d: %s", method, url, resp.StatusCode, bytes.TrimSpace(data))
+	}
+
+	return json.Unmarshal(data, out)
+}
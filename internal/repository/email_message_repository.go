@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// EmailMessageRepository defines methods for email delivery record repository
+type EmailMessageRepository interface {
+	Create(ctx context.Context, template, recipient, subject string) (string, error)
+	MarkSent(ctx context.Context, id string) error
+	RecordFailure(ctx context.Context, id, lastError string, maxAttempts int) error
+	MarkBounced(ctx context.Context, id, reason string) (bool, error)
+	MarkBouncedByRecipient(ctx context.Context, recipient, reason string) (bool, error)
+	List(ctx context.Context, statuses []string, page, perPage int) ([]model.EmailMessage, int, error)
+}
+
+// emailMessageRepository is the implementation of EmailMessageRepository
+type emailMessageRepository struct {
+	db *sqlx.DB
+}
+
+// NewEmailMessageRepository creates a new EmailMessageRepository
+func NewEmailMessageRepository(db *sqlx.DB) EmailMessageRepository {
+	return &emailMessageRepository{db: db}
+}
+
+// emailMessageColumns is the column list matching scanEmailMessage
+const emailMessageColumns = `id, template, recipient, subject, status, attempts, last_error, sent_at, bounced_at, created_at, updated_at`
+
+// Create records a queued email
+func (r *emailMessageRepository) Create(ctx context.Context, template, recipient, subject string) (string, error) {
+	query := `INSERT INTO email_messages (id, template, recipient, subject)
+			  VALUES ($1, $2, $3, $4)
+			  RETURNING id`
+
+	var id string
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, newID(), template, recipient, subject).Scan(&id); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// MarkSent records a delivery attempt that the SMTP server accepted
+func (r *emailMessageRepository) MarkSent(ctx context.Context, id string) error {
+	query := `UPDATE email_messages
+			  SET status = 'sent', attempts = attempts + 1, last_error = NULL, sent_at = NOW(), updated_at = NOW()
+			  WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	return err
+}
+
+// RecordFailure records a failed delivery attempt; the email is failed once maxAttempts is reached
+func (r *emailMessageRepository) RecordFailure(ctx context.Context, id, lastError string, maxAttempts int) error {
+	query := `UPDATE email_messages
+			  SET attempts = attempts + 1,
+			      status = CASE WHEN attempts + 1 >= $3 THEN 'failed' ELSE 'queued' END,
+			      last_error = $2, updated_at = NOW()
+			  WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, lastError, maxAttempts)
+	return err
+}
+
+// MarkBounced marks an email bounced, reporting whether it exists
+func (r *emailMessageRepository) MarkBounced(ctx context.Context, id, reason string) (bool, error) {
+	query := `UPDATE email_messages
+			  SET status = 'bounced', last_error = $2, bounced_at = NOW(), updated_at = NOW()
+			  WHERE id = $1`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id, nullString(reason))
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// MarkBouncedByRecipient marks the latest email sent to a recipient bounced, reporting whether there was one
+func (r *emailMessageRepository) MarkBouncedByRecipient(ctx context.Context, recipient, reason string) (bool, error) {
+	query := `UPDATE email_messages
+			  SET status = 'bounced', last_error = $2, bounced_at = NOW(), updated_at = NOW()
+			  WHERE id = (
+			      SELECT id FROM email_messages
+			      WHERE LOWER(recipient) = LOWER($1) AND status = 'sent'
+			      ORDER BY created_at DESC
+			      LIMIT 1
+			  )`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, recipient, nullString(reason))
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// List lists emails with the given statuses, newest first
+func (r *emailMessageRepository) List(ctx context.Context, statuses []string, page, perPage int) ([]model.EmailMessage, int, error) {
+	offset := (page - 1) * perPage
+
+	var conditions []string
+	var args []interface{}
+	if len(statuses) > 0 {
+		placeholders := make([]string, len(statuses))
+		for i, status := range statuses {
+			args = append(args, status)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, `status IN (`+strings.Join(placeholders, ", ")+`)`)
+	}
+	where := whereClause(conditions)
+
+	// Count total
+	var total int
+	err := readConn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM email_messages`+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get messages
+	query := `SELECT ` + emailMessageColumns + `
+			  FROM email_messages` + where + `
+			  ORDER BY created_at DESC, id DESC` +
+		fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query, append(args, perPage, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	messages := []model.EmailMessage{}
+	for rows.Next() {
+		message, err := scanEmailMessage(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		messages = append(messages, *message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return messages, total, nil
+}
+
+// scanEmailMessage scans an email message row selected with emailMessageColumns
+func scanEmailMessage(row rowScanner) (*model.EmailMessage, error) {
+	var message model.EmailMessage
+	var lastError sql.NullString
+	var sentAt, bouncedAt sql.NullTime
+
+	err := row.Scan(
+		&message.ID,
+		&message.Template,
+		&message.Recipient,
+		&message.Subject,
+		&message.Status,
+		&message.Attempts,
+		&lastError,
+		&sentAt,
+		&bouncedAt,
+		&message.CreatedAt,
+		&message.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	message.LastError = lastError.String
+	message.SentAt = timePtr(sentAt)
+	message.BouncedAt = timePtr(bouncedAt)
+
+	return &message, nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"go.uber.org/zap"
+)
+
+// EmailRepository handles sending emails over SMTP
+type EmailRepository struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+	logger   *zap.Logger
+}
+
+// NewEmailRepository creates a new email repository
+func NewEmailRepository(cfg config.Config, logger *zap.Logger) *EmailRepository {
+	return &EmailRepository{
+		host:     cfg.SMTPHost,
+		port:     cfg.SMTPPort,
+		username: cfg.SMTPUsername,
+		password: cfg.SMTPPassword,
+		from:     cfg.SMTPFrom,
+		logger:   logger,
+	}
+}
+
+// SendMail sends a plain text email to a single recipient
+func (r *EmailRepository) SendMail(to, subject, body string) error {
+	return r.send("", to, subject, "text/plain; charset=UTF-8", body)
+}
+
+// SendHTMLMail sends an email with HTML and plain text alternatives to a single recipient.
+// messageID becomes the local part of the Message-ID header so bounces can be traced back.
+func (r *EmailRepository) SendHTMLMail(messageID, to, subject, textBody, htmlBody string) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	// Alternatives go from plainest to richest, clients show the last one they support
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=UTF-8", textBody},
+		{"text/html; charset=UTF-8", htmlBody},
+	} {
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return err
+		}
+		if err := qp.Close(); err != nil {
+			return err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return r.send(messageID, to, subject, "multipart/alternative; boundary="+writer.Boundary(), body.String())
+}
+
+// send sends a message with the given body content type
+func (r *EmailRepository) send(messageID, to, subject, contentType, body string) error {
+	addr := fmt.Sprintf("%s:%d", r.host, r.port)
+
+	var auth smtp.Auth
+	if r.username != "" {
+		auth = smtp.PlainAuth("", r.username, r.password, r.host)
+	}
+
+	headers := []string{
+		"From: " + r.from,
+		"To: " + to,
+		"Subject: " + mime.QEncoding.Encode("utf-8", subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: " + contentType,
+	}
+	if messageID != "" {
+		headers = append(headers, "Message-ID: <"+messageID+"@"+r.domain()+">")
+	}
+	message := strings.Join(headers, "\r\n") + "\r\n\r\n" + body
+
+	if err := smtp.SendMail(addr, auth, r.from, []string{to}, []byte(message)); err != nil {
+		r.logger.Error("Failed to send email", zap.Error(err), zap.String("subject", subject))
+		return err
+	}
+
+	r.logger.Debug("Email sent successfully", zap.String("subject", subject))
+	return nil
+}
+
+// domain returns the domain of the sender address, used for Message-IDs
+func (r *EmailRepository) domain() string {
+	if address, err := mail.ParseAddress(r.from); err == nil {
+		if i := strings.LastIndex(address.Address, "@"); i >= 0 {
+			return address.Address[i+1:]
+		}
+	}
+	return "localhost"
+}
+
+// IsPermanentSMTPError reports whether the SMTP server rejected an email for good (a 5xx reply),
+// such as an unknown recipient, so retrying won't help
+func IsPermanentSMTPError(err error) bool {
+	var smtpErr *textproto.Error
+	return errors.As(err, &smtpErr) && smtpErr.Code >= 500
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"net"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/oschwald/geoip2-golang"
+)
+
+// GeoIPRepository defines methods for IP geolocation lookups
+type GeoIPRepository interface {
+	Lookup(ip string) (*model.GeoLocation, error)
+	Close() error
+}
+
+// maxmindGeoIPRepository looks IPs up in a local MaxMind/GeoLite2 City database
+type maxmindGeoIPRepository struct {
+	reader *geoip2.Reader
+}
+
+// noopGeoIPRepository is used when no GeoIP database is configured
+type noopGeoIPRepository struct{}
+
+// NewGeoIPRepository opens the GeoIP database at GEOIP_DB_PATH, or returns
+// a repository that never finds a location when it isn't set
+func NewGeoIPRepository(cfg config.Config) (GeoIPRepository, error) {
+	if cfg.GeoIPDBPath == "" {
+		return noopGeoIPRepository{}, nil
+	}
+
+	reader, err := geoip2.Open(cfg.GeoIPDBPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &maxmindGeoIPRepository{reader: reader}, nil
+}
+
+// Lookup returns the location of an IP; returns nil for private or unknown addresses
+func (r *maxmindGeoIPRepository) Lookup(ip string) (*model.GeoLocation, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil || parsed.IsPrivate() || parsed.IsLoopback() {
+		return nil, nil
+	}
+
+	record, err := r.reader.City(parsed)
+	if err != nil {
+		return nil, err
+	}
+	if record.Country.IsoCode == "" {
+		return nil, nil
+	}
+
+	return &model.GeoLocation{
+		CountryCode: record.Country.IsoCode,
+		Country:     record.Country.Names["en"],
+		City:        record.City.Names["en"],
+	}, nil
+}
+
+// Close closes the database file
+func (r *maxmindGeoIPRepository) Close() error {
+	return r.reader.Close()
+}
+
+// Lookup never finds a location
+func (noopGeoIPRepository) Lookup(ip string) (*model.GeoLocation, error) {
+	return nil, nil
+}
+
+// Close does nothing
+func (noopGeoIPRepository) Close() error {
+	return nil
+}
@@ -0,0 +1,535 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"go.uber.org/zap"
+)
+
+const (
+	// githubEventsPerPage is the most events GitHub returns in one page
+	githubEventsPerPage = 100
+	// githubDiscussionsPerPage and githubDiscussionComments keep a discussions query well within
+	// GitHub's GraphQL node limit; a discussion's replies are counted from its last 100 comments
+	githubDiscussionsPerPage = 50
+	githubDiscussionComments = 100
+	// maxGitHubDiscussionPages bounds a discussions sync to 1000 discussions
+	maxGitHubDiscussionPages = 20
+	// maxGitHubResponse bounds GitHub API responses read into memory
+	maxGitHubResponse = 4 << 20
+)
+
+// GitHubRepository reads public activity and giscus discussions from the GitHub API
+type GitHubRepository struct {
+	token            string
+	discussionsToken string
+	httpClient       *http.Client
+	logger           *zap.Logger
+}
+
+// NewGitHubRepository creates a new GitHub repository
+func NewGitHubRepository(cfg config.Config, logger *zap.Logger) *GitHubRepository {
+	return &GitHubRepository{
+		token:            cfg.GitHubActivityToken,
+		discussionsToken: cfg.GiscusToken,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+// GitHubRateLimit is the rate limit state GitHub reported with a response
+type GitHubRateLimit struct {
+	Remaining int
+	Reset     time.Time
+}
+
+// Exhausted reports whether no requests are left until the limit resets
+func (l GitHubRateLimit) Exhausted() bool {
+	return l.Remaining == 0 && time.Now().Before(l.Reset)
+}
+
+// GitHubEventsResponse is a page of public events. NotModified is set, and Events left empty,
+// when the events haven't changed since the ETag sent with the request.
+type GitHubEventsResponse struct {
+	Events      []model.GitHubEvent
+	ETag        string
+	NotModified bool
+	RateLimit   GitHubRateLimit
+}
+
+// GitHubRateLimitError is returned when GitHub refuses a request because the rate limit is used up
+type GitHubRateLimitError struct {
+	Reset time.Time
+}
+
+func (e *GitHubRateLimitError) Error() string {
+	return "github rate limit exceeded until " + e.Reset.Format(time.RFC3339)
+}
+
+// githubEvent is the part of a public event we use
+type githubEvent struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Repo struct {
+		Name string `json:"name"`
+	} `json:"repo"`
+	Payload struct {
+		Action      string        `json:"action"`
+		Ref         string        `json:"ref"`
+		RefType     string        `json:"ref_type"`
+		Size        int           `json:"size"`
+		Commits     []struct{}    `json:"commits"`
+		PullRequest *githubLinked `json:"pull_request"`
+		Issue       *githubLinked `json:"issue"`
+		Release     *struct {
+			Name    string `json:"name"`
+			TagName string `json:"tag_name"`
+			HTMLURL string `json:"html_url"`
+		} `json:"release"`
+		Forkee *struct {
+			HTMLURL string `json:"html_url"`
+		} `json:"forkee"`
+	} `json:"payload"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// githubLinked is a pull request or issue referenced by an event
+type githubLinked struct {
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+}
+
+// PublicEvents fetches a user's latest public events, newest first. GitHub doesn't count
+// a not-modified answer to a conditional request against the rate limit, so pass the
+// ETag of the last response when there is one.
+func (r *GitHubRepository) PublicEvents(ctx context.Context, username, etag string) (*GitHubEventsResponse, error) {
+	rawURL := fmt.Sprintf("%s/users/%s/events/public?per_page=%d", githubAPIURL, url.PathEscape(username), githubEventsPerPage)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	r.setHeaders(req)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		r.logger.Error("Failed to fetch GitHub events", zap.String("username", username), zap.Error(err))
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	result := &GitHubEventsResponse{
+		ETag:      resp.Header.Get("ETag"),
+		RateLimit: githubRateLimit(resp.Header),
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		result.NotModified = true
+		result.ETag = etag
+		return result, nil
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		if reset, ok := githubRetryAt(resp.Header, result.RateLimit); ok {
+			r.logger.Warn("GitHub rate limit exceeded", zap.Time("reset", reset))
+			return nil, &GitHubRateLimitError{Reset: reset}
+		}
+		fallthrough
+	default:
+		r.logger.Error("GitHub API returned non-OK status",
+			zap.String("username", username),
+			zap.Int("status_code", resp.StatusCode))
+		return nil, fmt.Errorf("github API returned status %d", resp.StatusCode)
+	}
+
+	var events []githubEvent
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxGitHubResponse)).Decode(&events); err != nil {
+		return nil, err
+	}
+
+	result.Events = make([]model.GitHubEvent, 0, len(events))
+	for _, event := range events {
+		result.Events = append(result.Events, toGitHubEvent(event))
+	}
+
+	return result, nil
+}
+
+// TotalContributions returns the contributions in the user's contribution calendar for the
+// last year. The GraphQL API needs a token, so this fails without one.
+func (r *GitHubRepository) TotalContributions(ctx context.Context, username string) (int, error) {
+	if r.token == "" {
+		return 0, errors.New("a GitHub token is required for contribution totals")
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"query":     `query($login: String!) { user(login: $login) { contributionsCollection { contributionCalendar { totalContributions } } } }`,
+		"variables": map[string]string{"login": username},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, githubAPIURL+"/graphql", bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	r.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("github GraphQL API returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data struct {
+			User *struct {
+				ContributionsCollection struct {
+					ContributionCalendar struct {
+						TotalContributions int `json:"totalContributions"`
+					} `json:"contributionCalendar"`
+				} `json:"contributionsCollection"`
+			} `json:"user"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxGitHubResponse)).Decode(&body); err != nil {
+		return 0, err
+	}
+	if len(body.Errors) > 0 {
+		return 0, errors.New("github GraphQL API: " + body.Errors[0].Message)
+	}
+	if body.Data.User == nil {
+		return 0, fmt.Errorf("github user %q not found", username)
+	}
+
+	return body.Data.User.ContributionsCollection.ContributionCalendar.TotalContributions, nil
+}
+
+// githubDiscussionsQuery pages through a category's discussions with their latest comments
+const githubDiscussionsQuery = `query($owner: String!, $name: String!, $category: ID!, $after: String, $perPage: Int!, $comments: Int!) {
+  repository(owner: $owner, name: $name) {
+    discussions(first: $perPage, after: $after, categoryId: $category) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        number
+        title
+        url
+        comments(last: $comments) {
+          totalCount
+          nodes {
+            id
+            url
+            bodyText
+            createdAt
+            isMinimized
+            author { login avatarUrl url }
+            replies { totalCount }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// githubDiscussionComment is the part of a discussion comment we use
+type githubDiscussionComment struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	BodyText    string    `json:"bodyText"`
+	CreatedAt   time.Time `json:"createdAt"`
+	IsMinimized bool      `json:"isMinimized"`
+	Author      *struct {
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatarUrl"`
+		URL       string `json:"url"`
+	} `json:"author"`
+	Replies struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"replies"`
+}
+
+// Discussions lists the discussions of a repository's category, given as "owner/name" and the
+// category's name or slug, with up to recent of each discussion's latest visible comments.
+// The GraphQL API needs the GISCUS_TOKEN.
+func (r *GitHubRepository) Discussions(ctx context.Context, repo, category string, recent int) ([]model.GitHubDiscussion, error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return nil, fmt.Errorf("github repository %q must be owner/name", repo)
+	}
+
+	categoryID, err := r.discussionCategoryID(ctx, owner, name, category)
+	if err != nil {
+		return nil, err
+	}
+
+	var discussions []model.GitHubDiscussion
+	var after *string
+	for page := 0; page < maxGitHubDiscussionPages; page++ {
+		var data struct {
+			Repository *struct {
+				Discussions struct {
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+					Nodes []struct {
+						Number   int    `json:"number"`
+						Title    string `json:"title"`
+						URL      string `json:"url"`
+						Comments struct {
+							TotalCount int                       `json:"totalCount"`
+							Nodes      []githubDiscussionComment `json:"nodes"`
+						} `json:"comments"`
+					} `json:"nodes"`
+				} `json:"discussions"`
+			} `json:"repository"`
+		}
+		err := r.graphQL(ctx, r.discussionsToken, githubDiscussionsQuery, map[string]interface{}{
+			"owner":    owner,
+			"name":     name,
+			"category": categoryID,
+			"after":    after,
+			"perPage":  githubDiscussionsPerPage,
+			"comments": githubDiscussionComments,
+		}, &data)
+		if err != nil {
+			return nil, err
+		}
+		if data.Repository == nil {
+			return nil, fmt.Errorf("github repository %q not found", repo)
+		}
+
+		for _, node := range data.Repository.Discussions.Nodes {
+			discussion := model.GitHubDiscussion{
+				Number:         node.Number,
+				Title:          node.Title,
+				URL:            node.URL,
+				CommentCount:   node.Comments.TotalCount,
+				RecentComments: []model.DiscussionComment{},
+			}
+
+			// Comments come oldest first; collect the latest visible ones newest first
+			for i := len(node.Comments.Nodes) - 1; i >= 0; i-- {
+				comment := node.Comments.Nodes[i]
+				discussion.CommentCount += comment.Replies.TotalCount
+				if comment.IsMinimized || len(discussion.RecentComments) >= recent {
+					continue
+				}
+				discussion.RecentComments = append(discussion.RecentComments, toDiscussionComment(comment))
+			}
+
+			discussions = append(discussions, discussion)
+		}
+
+		pageInfo := data.Repository.Discussions.PageInfo
+		if !pageInfo.HasNextPage {
+			return discussions, nil
+		}
+		after = &pageInfo.EndCursor
+	}
+
+	r.logger.Warn("GitHub discussions truncated", zap.String("repo", repo), zap.Int("discussions", len(discussions)))
+	return discussions, nil
+}
+
+// discussionCategoryID resolves a discussion category by name or slug
+func (r *GitHubRepository) discussionCategoryID(ctx context.Context, owner, name, category string) (string, error) {
+	var data struct {
+		Repository *struct {
+			DiscussionCategories struct {
+				Nodes []struct {
+					ID   string `json:"id"`
+					Name string `json:"name"`
+					Slug string `json:"slug"`
+				} `json:"nodes"`
+			} `json:"discussionCategories"`
+		} `json:"repository"`
+	}
+	query := `query($owner: String!, $name: String!) { repository(owner: $owner, name: $name) { discussionCategories(first: 100) { nodes { id name slug } } } }`
+	if err := r.graphQL(ctx, r.discussionsToken, query, map[string]interface{}{"owner": owner, "name": name}, &data); err != nil {
+		return "", err
+	}
+	if data.Repository == nil {
+		return "", fmt.Errorf("github repository %s/%s not found", owner, name)
+	}
+
+	for _, node := range data.Repository.DiscussionCategories.Nodes {
+		if strings.EqualFold(node.Name, category) || strings.EqualFold(node.Slug, category) {
+			return node.ID, nil
+		}
+	}
+	return "", fmt.Errorf("discussion category %q not found in %s/%s", category, owner, name)
+}
+
+// graphQL runs a GraphQL query with the given token and decodes its data into out
+func (r *GitHubRepository) graphQL(ctx context.Context, token, query string, variables map[string]interface{}, out interface{}) error {
+	if token == "" {
+		return errors.New("the GitHub GraphQL API needs a token")
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, githubAPIURL+"/graphql", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	r.setHeaders(req)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		if reset, ok := githubRetryAt(resp.Header, githubRateLimit(resp.Header)); ok {
+			return &GitHubRateLimitError{Reset: reset}
+		}
+		fallthrough
+	default:
+		return fmt.Errorf("github GraphQL API returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxGitHubResponse)).Decode(&body); err != nil {
+		return err
+	}
+	if len(body.Errors) > 0 {
+		return errors.New("github GraphQL API: " + body.Errors[0].Message)
+	}
+
+	return json.Unmarshal(body.Data, out)
+}
+
+// toDiscussionComment converts a discussion comment; deleted accounts show as GitHub's "ghost"
+func toDiscussionComment(comment githubDiscussionComment) model.DiscussionComment {
+	result := model.DiscussionComment{
+		ID:        comment.ID,
+		URL:       comment.URL,
+		Author:    "ghost",
+		Body:      comment.BodyText,
+		CreatedAt: comment.CreatedAt,
+	}
+	if comment.Author != nil {
+		result.Author = comment.Author.Login
+		result.AuthorAvatar = comment.Author.AvatarURL
+		result.AuthorURL = comment.Author.URL
+	}
+	return result
+}
+
+// setHeaders sets the headers every GitHub API request carries
+func (r *GitHubRepository) setHeaders(req *http.Request) {
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+}
+
+// githubRateLimit reads the rate limit headers of a response
+func githubRateLimit(header http.Header) GitHubRateLimit {
+	limit := GitHubRateLimit{Remaining: -1}
+	if remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining")); err == nil {
+		limit.Remaining = remaining
+	}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		limit.Reset = time.Unix(reset, 0)
+	}
+	return limit
+}
+
+// githubRetryAt tells a rate limited response apart from other refusals and returns when to retry;
+// secondary rate limits send Retry-After instead of exhausting the primary limit
+func githubRetryAt(header http.Header, limit GitHubRateLimit) (time.Time, bool) {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+		return time.Now().Add(time.Duration(seconds) * time.Second), true
+	}
+	if limit.Remaining == 0 {
+		return limit.Reset, true
+	}
+	return time.Time{}, false
+}
+
+// toGitHubEvent flattens an event's payload into the fields shown on the site
+func toGitHubEvent(event githubEvent) model.GitHubEvent {
+	result := model.GitHubEvent{
+		ID:        event.ID,
+		Type:      event.Type,
+		Action:    event.Payload.Action,
+		Repo:      event.Repo.Name,
+		RepoURL:   "https://github.com/" + event.Repo.Name,
+		CreatedAt: event.CreatedAt,
+	}
+
+	switch {
+	case event.Payload.PullRequest != nil:
+		result.Title = event.Payload.PullRequest.Title
+		result.URL = event.Payload.PullRequest.HTMLURL
+	case event.Payload.Issue != nil:
+		result.Title = event.Payload.Issue.Title
+		result.URL = event.Payload.Issue.HTMLURL
+	case event.Payload.Release != nil:
+		result.Title = event.Payload.Release.Name
+		if result.Title == "" {
+			result.Title = event.Payload.Release.TagName
+		}
+		result.URL = event.Payload.Release.HTMLURL
+	case event.Payload.Forkee != nil:
+		result.URL = event.Payload.Forkee.HTMLURL
+	}
+
+	switch event.Type {
+	case "PushEvent":
+		result.Ref = strings.TrimPrefix(event.Payload.Ref, "refs/heads/")
+		result.Commits = event.Payload.Size
+		if result.Commits == 0 {
+			result.Commits = len(event.Payload.Commits)
+		}
+	case "CreateEvent", "DeleteEvent":
+		result.Action = event.Payload.RefType
+		result.Ref = event.Payload.Ref
+	}
+
+	return result
+}
@@ -0,0 +1,9 @@
+package repository
+
+import "github.com/google/uuid"
+
+// newID returns the ID for a new row: a UUIDv7, which starts with the creation time so new
+// rows land at the end of primary key indexes and IDs sort in creation order
+func newID() string {
+	return uuid.Must(uuid.NewV7()).String()
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// IdempotencyRepository defines methods for storing requests made with an Idempotency-Key
+type IdempotencyRepository interface {
+	Reserve(ctx context.Context, userID, key, requestHash string, lockedFor time.Duration) (*model.IdempotencyRecord, error)
+	Complete(ctx context.Context, userID, key string, statusCode int, contentType string, response []byte, ttl time.Duration) error
+	Release(ctx context.Context, userID, key string) error
+	DeleteExpired(ctx context.Context) error
+}
+
+// idempotencyRepository is the implementation of IdempotencyRepository
+type idempotencyRepository struct {
+	db *sqlx.DB
+}
+
+// NewIdempotencyRepository creates a new IdempotencyRepository
+func NewIdempotencyRepository(db *sqlx.DB) IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+// Reserve claims a key for a request being handled, for at most lockedFor. It returns nil
+// when the key is now held by the caller, or the live record of an earlier request using it.
+// An expired record is replaced as if the key were new.
+func (r *idempotencyRepository) Reserve(ctx context.Context, userID, key, requestHash string, lockedFor time.Duration) (*model.IdempotencyRecord, error) {
+	insert := `INSERT INTO idempotency_keys (idempotency_key, user_id, request_hash, expires_at)
+			   VALUES ($1, $2, $3, $4)
+			   ON CONFLICT (user_id, idempotency_key) DO UPDATE
+			   SET request_hash = EXCLUDED.request_hash, status_code = NULL, content_type = NULL,
+				   response = NULL, created_at = CURRENT_TIMESTAMP, expires_at = EXCLUDED.expires_at
+			   WHERE idempotency_keys.expires_at <= CURRENT_TIMESTAMP
+			   RETURNING idempotency_key`
+
+	get := `SELECT idempotency_key, user_id, request_hash, status_code, content_type, response, created_at, expires_at
+			FROM idempotency_keys
+			WHERE user_id = $1 AND idempotency_key = $2 AND expires_at > CURRENT_TIMESTAMP`
+
+	// The earlier request may release its key between the two statements, so try once more
+	for attempt := 0; attempt < 2; attempt++ {
+		var reserved string
+		err := conn(ctx, r.db).QueryRowxContext(ctx, insert, key, userID, requestHash, time.Now().Add(lockedFor)).Scan(&reserved)
+		if err == nil {
+			return nil, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+
+		var record model.IdempotencyRecord
+		err = conn(ctx, r.db).QueryRowxContext(ctx, get, userID, key).StructScan(&record)
+		if err == nil {
+			return &record, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+	}
+
+	return nil, errors.New("idempotency key changed hands while reserving it")
+}
+
+// Complete stores the response of a reserved request and keeps it for ttl
+func (r *idempotencyRepository) Complete(ctx context.Context, userID, key string, statusCode int, contentType string, response []byte, ttl time.Duration) error {
+	query := `UPDATE idempotency_keys
+			  SET status_code = $3, content_type = $4, response = $5, expires_at = $6
+			  WHERE user_id = $1 AND idempotency_key = $2`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, userID, key, statusCode, contentType, response, time.Now().Add(ttl))
+	return err
+}
+
+// Release frees a reserved key whose request failed, so the request can be retried with it
+func (r *idempotencyRepository) Release(ctx context.Context, userID, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE user_id = $1 AND idempotency_key = $2 AND status_code IS NULL`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, userID, key)
+	return err
+}
+
+// DeleteExpired removes stored responses past their expiry
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context) error {
+	query := `DELETE FROM idempotency_keys WHERE expires_at <= CURRENT_TIMESTAMP`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query)
+	return err
+}
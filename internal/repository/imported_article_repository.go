@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// ImportedArticleRepository defines methods for imported article repository
+type ImportedArticleRepository interface {
+	Save(ctx context.Context, imported *model.ImportedArticle) error
+	List(ctx context.Context) ([]model.ImportedArticle, error)
+}
+
+// importedArticleRepository is the implementation of ImportedArticleRepository
+type importedArticleRepository struct {
+	db *sqlx.DB
+}
+
+// NewImportedArticleRepository creates a new ImportedArticleRepository
+func NewImportedArticleRepository(db *sqlx.DB) ImportedArticleRepository {
+	return &importedArticleRepository{db: db}
+}
+
+// Save records the file an article was imported from; a moved file takes over the article
+func (r *importedArticleRepository) Save(ctx context.Context, imported *model.ImportedArticle) error {
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM imported_articles WHERE article_id = $1 AND path <> $2`, imported.ArticleID, imported.Path); err != nil {
+			return err
+		}
+
+		query := `INSERT INTO imported_articles (path, article_id, checksum)
+				  VALUES ($1, $2, $3)
+				  ON CONFLICT (path) DO UPDATE
+				  SET article_id = EXCLUDED.article_id, checksum = EXCLUDED.checksum, imported_at = CURRENT_TIMESTAMP`
+
+		_, err := tx.ExecContext(ctx, query, imported.Path, imported.ArticleID, imported.Checksum)
+		return err
+	})
+}
+
+// List lists every imported article
+func (r *importedArticleRepository) List(ctx context.Context) ([]model.ImportedArticle, error) {
+	query := `SELECT path, article_id, checksum, imported_at FROM imported_articles ORDER BY path`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var imported []model.ImportedArticle
+	for rows.Next() {
+		var item model.ImportedArticle
+		if err := rows.Scan(&item.Path, &item.ArticleID, &item.Checksum, &item.ImportedAt); err != nil {
+			return nil, err
+		}
+		imported = append(imported, item)
+	}
+
+	return imported, rows.Err()
+}
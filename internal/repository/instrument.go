@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// Query limits applied to every repository query, set once at startup by SetQueryLimits
+var (
+	queryTimeout       time.Duration
+	slowQueryThreshold time.Duration
+)
+
+// sqlLiteral matches placeholders, quoted strings and numbers in a query
+var sqlLiteral = regexp.MustCompile(`\$\d+|'(?:[^']|'')*'|\b\d+(?:\.\d+)?\b`)
+
+// SetQueryLimits bounds each query to timeout and logs queries slower than slowThreshold.
+// Zero turns either off.
+func SetQueryLimits(timeout, slowThreshold time.Duration) {
+	queryTimeout = timeout
+	slowQueryThreshold = slowThreshold
+}
+
+// instrumentedConn applies the query limits to a connection, pool or transaction
+type instrumentedConn struct {
+	DBTX
+}
+
+// instrument wraps a connection with the query limits
+func instrument(db DBTX) DBTX {
+	return instrumentedConn{db}
+}
+
+// ExecContext runs a statement under the query timeout
+func (c instrumentedConn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if queryTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, queryTimeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	result, err := c.DBTX.ExecContext(ctx, query, args...)
+	logSlowQuery(ctx, query, start)
+	return result, err
+}
+
+// QueryContext runs a query under the query timeout, which also bounds reading its rows
+func (c instrumentedConn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	ctx = rowsContext(ctx)
+
+	start := time.Now()
+	rows, err := c.DBTX.QueryContext(ctx, query, args...)
+	logSlowQuery(ctx, query, start)
+	return rows, err
+}
+
+// QueryRowContext runs a single-row query under the query timeout
+func (c instrumentedConn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	ctx = rowsContext(ctx)
+
+	start := time.Now()
+	row := c.DBTX.QueryRowContext(ctx, query, args...)
+	logSlowQuery(ctx, query, start)
+	return row
+}
+
+// QueryRowxContext runs a single-row query under the query timeout
+func (c instrumentedConn) QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row {
+	ctx = rowsContext(ctx)
+
+	start := time.Now()
+	row := c.DBTX.QueryRowxContext(ctx, query, args...)
+	logSlowQuery(ctx, query, start)
+	return row
+}
+
+// rowsContext applies the query timeout to a query whose rows are read after it returns.
+// The context can't be cancelled before the caller is done with the rows, so it is
+// released when the timeout expires.
+func rowsContext(ctx context.Context) context.Context {
+	if queryTimeout <= 0 {
+		return ctx
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, queryTimeout)
+	_ = cancel
+	return ctx
+}
+
+// logSlowQuery logs a query that took longer than the slow query threshold. For queries
+// returning rows, the time is until the first rows are available.
+func logSlowQuery(ctx context.Context, query string, start time.Time) {
+	duration := time.Since(start)
+	if slowQueryThreshold <= 0 || duration < slowQueryThreshold {
+		return
+	}
+
+	logger.WarnContext(ctx, "Slow query",
+		zap.Duration("duration", duration),
+		zap.String("query", normalizeSQL(query)),
+	)
+}
+
+// normalizeSQL collapses whitespace and replaces literals with ?, so the same query looks the
+// same in every log line and no values leak into logs
+func normalizeSQL(query string) string {
+	query = sqlLiteral.ReplaceAllStringFunc(query, func(literal string) string {
+		if strings.HasPrefix(literal, "$") {
+			return literal
+		}
+		return "?"
+	})
+	return strings.Join(strings.Fields(query), " ")
+}
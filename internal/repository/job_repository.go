@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// JobRepository defines methods for job repository
+type JobRepository interface {
+	Enqueue(ctx context.Context, jobType string, payload []byte, maxAttempts int, runAt time.Time) (string, error)
+	Claim(ctx context.Context, lockTimeout time.Duration) (*model.Job, error)
+	Complete(ctx context.Context, id string) error
+	Retry(ctx context.Context, id string, runAt time.Time, lastError string) error
+	Bury(ctx context.Context, id string, lastError string) error
+	ListDead(ctx context.Context) ([]model.Job, error)
+	Requeue(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string) error
+}
+
+// jobRepository is the implementation of JobRepository
+type jobRepository struct {
+	db *sqlx.DB
+}
+
+// NewJobRepository creates a new JobRepository
+func NewJobRepository(db *sqlx.DB) JobRepository {
+	return &jobRepository{db: db}
+}
+
+// jobColumns is the column list matching scanJob
+const jobColumns = `id, type, payload, status, attempts, max_attempts, last_error, run_at, created_at, updated_at`
+
+// Enqueue adds a job to the queue
+func (r *jobRepository) Enqueue(ctx context.Context, jobType string, payload []byte, maxAttempts int, runAt time.Time) (string, error) {
+	query := `INSERT INTO jobs (id, type, payload, max_attempts, run_at)
+			  VALUES ($1, $2, $3, $4, $5)
+			  RETURNING id`
+
+	var id string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, newID(), jobType, payload, maxAttempts, runAt).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// Claim locks the next due job for this worker and counts the attempt.
+// Running jobs locked longer than lockTimeout are assumed abandoned and claimed again.
+// Returns nil when no job is due.
+func (r *jobRepository) Claim(ctx context.Context, lockTimeout time.Duration) (*model.Job, error) {
+	query := `UPDATE jobs
+			  SET status = 'running', attempts = attempts + 1, locked_at = NOW(), updated_at = NOW()
+			  WHERE id = (
+			      SELECT id FROM jobs
+			      WHERE (status = 'pending' AND run_at <= NOW())
+			         OR (status = 'running' AND locked_at < $1)
+			      ORDER BY run_at
+			      LIMIT 1
+			      FOR UPDATE SKIP LOCKED
+			  )
+			  RETURNING ` + jobColumns
+
+	job, err := scanJob(conn(ctx, r.db).QueryRowContext(ctx, query, time.Now().Add(-lockTimeout)))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Complete removes a finished job
+func (r *jobRepository) Complete(ctx context.Context, id string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM jobs WHERE id = $1`, id)
+	return err
+}
+
+// Retry schedules a failed job to run again
+func (r *jobRepository) Retry(ctx context.Context, id string, runAt time.Time, lastError string) error {
+	query := `UPDATE jobs
+			  SET status = 'pending', run_at = $2, last_error = $3, locked_at = NULL, updated_at = NOW()
+			  WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, runAt, lastError)
+	return err
+}
+
+// Bury moves a job to the dead-letter list
+func (r *jobRepository) Bury(ctx context.Context, id string, lastError string) error {
+	query := `UPDATE jobs
+			  SET status = 'dead', last_error = $2, locked_at = NULL, updated_at = NOW()
+			  WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, lastError)
+	return err
+}
+
+// ListDead lists dead-letter jobs, most recent first
+func (r *jobRepository) ListDead(ctx context.Context) ([]model.Job, error) {
+	query := `SELECT ` + jobColumns + `
+			  FROM jobs
+			  WHERE status = 'dead'
+			  ORDER BY updated_at DESC`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []model.Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// Requeue gives a dead job a fresh set of attempts
+func (r *jobRepository) Requeue(ctx context.Context, id string) error {
+	query := `UPDATE jobs
+			  SET status = 'pending', attempts = 0, run_at = NOW(), updated_at = NOW()
+			  WHERE id = $1 AND status = 'dead'`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New("job not found")
+	}
+
+	return nil
+}
+
+// Delete deletes a dead job
+func (r *jobRepository) Delete(ctx context.Context, id string) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM jobs WHERE id = $1 AND status = 'dead'`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New("job not found")
+	}
+
+	return nil
+}
+
+// scanJob scans a job row selected with jobColumns
+func scanJob(row rowScanner) (*model.Job, error) {
+	var job model.Job
+	var payload []byte
+	var lastError sql.NullString
+
+	err := row.Scan(
+		&job.ID,
+		&job.Type,
+		&payload,
+		&job.Status,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&lastError,
+		&job.RunAt,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	job.Payload = payload
+	if lastError.Valid {
+		job.LastError = lastError.String
+	}
+
+	return &job, nil
+}
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// LinkRepository defines methods for link repository
+type LinkRepository interface {
+	Create(ctx context.Context, link *model.LinkCreate, userID string) (string, error)
+	Update(ctx context.Context, id string, link *model.LinkUpdate) error
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (*model.Link, error)
+	List(ctx context.Context, page, perPage int, onlyPublished bool, tag string) ([]model.Link, int, error)
+}
+
+// linkRepository is the implementation of LinkRepository
+type linkRepository struct {
+	db *sqlx.DB
+}
+
+// NewLinkRepository creates a new LinkRepository
+func NewLinkRepository(db *sqlx.DB) LinkRepository {
+	return &linkRepository{db: db}
+}
+
+// linkColumns is the column list matching scanLink
+const linkColumns = `id, url, title, commentary, tags, is_published, user_id, created_at, updated_at, published_at`
+
+// Create creates a new link
+func (r *linkRepository) Create(ctx context.Context, linkCreate *model.LinkCreate, userID string) (string, error) {
+	query := `INSERT INTO links (id, url, title, commentary, tags, is_published, user_id, published_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			  RETURNING id`
+
+	tags, err := linkTags(linkCreate.Tags)
+	if err != nil {
+		return "", err
+	}
+
+	var publishedAt sql.NullTime
+	if linkCreate.IsPublished {
+		publishedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	}
+
+	var id string
+	err = conn(ctx, r.db).QueryRowContext(
+		ctx, query,
+		newID(),
+		linkCreate.URL,
+		linkCreate.Title,
+		linkCreate.Commentary,
+		tags,
+		linkCreate.IsPublished,
+		userID,
+		publishedAt,
+	).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// Update updates a link
+func (r *linkRepository) Update(ctx context.Context, id string, linkUpdate *model.LinkUpdate) error {
+	// Keep the original published_at when a link is republished
+	query := `UPDATE links
+			  SET url = $2, title = $3, commentary = $4, tags = $5, is_published = $6, updated_at = $7,
+			      published_at = CASE WHEN $6 AND published_at IS NULL THEN $7 ELSE published_at END
+			  WHERE id = $1`
+
+	tags, err := linkTags(linkUpdate.Tags)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, r.db).ExecContext(
+		ctx, query,
+		id,
+		linkUpdate.URL,
+		linkUpdate.Title,
+		linkUpdate.Commentary,
+		tags,
+		linkUpdate.IsPublished,
+		time.Now(),
+	)
+	return err
+}
+
+// Delete deletes a link
+func (r *linkRepository) Delete(ctx context.Context, id string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM links WHERE id = $1`, id)
+	return err
+}
+
+// GetByID gets a link by ID
+func (r *linkRepository) GetByID(ctx context.Context, id string) (*model.Link, error) {
+	query := `SELECT ` + linkColumns + ` FROM links WHERE id = $1`
+
+	link, err := scanLink(readConn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("link not found")
+		}
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// List lists links newest first with pagination, optionally only those with a tag
+func (r *linkRepository) List(ctx context.Context, page, perPage int, onlyPublished bool, tag string) ([]model.Link, int, error) {
+	offset := (page - 1) * perPage
+
+	var conditions []string
+	var args []interface{}
+	if onlyPublished {
+		conditions = append(conditions, `is_published = true`)
+	}
+	if tag != "" {
+		// Matches the expression index on the lowercased tags document
+		args = append(args, strings.ToLower(tag))
+		conditions = append(conditions, fmt.Sprintf(`LOWER(tags::text)::jsonb ? $%d`, len(args)))
+	}
+	where := whereClause(conditions)
+
+	// Count total
+	var total int
+	err := readConn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM links`+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get links
+	query := `SELECT ` + linkColumns + `
+			  FROM links` + where + `
+			  ORDER BY COALESCE(published_at, created_at) DESC, id DESC` +
+		fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query, append(args, perPage, offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	links := []model.Link{}
+	for rows.Next() {
+		link, err := scanLink(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		links = append(links, *link)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return links, total, nil
+}
+
+// scanLink scans a link row selected with linkColumns
+func scanLink(row rowScanner) (*model.Link, error) {
+	var link model.Link
+	var tagsJSON []byte
+	var userID sql.NullString
+	var publishedAt sql.NullTime
+
+	err := row.Scan(
+		&link.ID,
+		&link.URL,
+		&link.Title,
+		&link.Commentary,
+		&tagsJSON,
+		&link.IsPublished,
+		&userID,
+		&link.CreatedAt,
+		&link.UpdatedAt,
+		&publishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(tagsJSON, &link.Tags); err != nil {
+		return nil, err
+	}
+	if userID.Valid {
+		link.UserID = userID.String
+	}
+	if publishedAt.Valid {
+		link.PublishedAt = publishedAt.Time
+	}
+
+	return &link, nil
+}
+
+// linkTags encodes tags for the tags column, trimmed and without duplicates
+func linkTags(tags []string) ([]byte, error) {
+	cleaned := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		cleaned = append(cleaned, tag)
+	}
+
+	return json.Marshal(cleaned)
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// LoginAttemptRepository defines methods for login attempt repository
+type LoginAttemptRepository interface {
+	Save(ctx context.Context, attempt *model.LoginAttempt) error
+	ListActive(ctx context.Context, failedSince time.Time) ([]model.LoginAttempt, error)
+	ListBlocked(ctx context.Context) ([]model.LoginAttempt, error)
+	Delete(ctx context.Context, id string) (*model.LoginAttempt, error)
+	DeleteByKey(ctx context.Context, ip, username string) error
+	DeleteExpired(ctx context.Context, failedBefore time.Time) error
+}
+
+// loginAttemptRepository is the implementation of LoginAttemptRepository
+type loginAttemptRepository struct {
+	db *sqlx.DB
+}
+
+// NewLoginAttemptRepository creates a new LoginAttemptRepository
+func NewLoginAttemptRepository(db *sqlx.DB) LoginAttemptRepository {
+	return &loginAttemptRepository{db: db}
+}
+
+// loginAttemptColumns is the column list matching scanLoginAttempt
+const loginAttemptColumns = `id, ip, username, failed_attempts, last_failed_at, blocked_until`
+
+// Save creates or replaces the attempt for its IP and username
+func (r *loginAttemptRepository) Save(ctx context.Context, attempt *model.LoginAttempt) error {
+	query := `INSERT INTO login_attempts (id, ip, username, failed_attempts, last_failed_at, blocked_until)
+			  VALUES ($1, $2, $3, $4, $5, $6)
+			  ON CONFLICT (ip, username) DO UPDATE
+			  SET failed_attempts = EXCLUDED.failed_attempts, last_failed_at = EXCLUDED.last_failed_at,
+			      blocked_until = EXCLUDED.blocked_until, updated_at = NOW()`
+
+	var blockedUntil sql.NullTime
+	if !attempt.BlockedUntil.IsZero() {
+		blockedUntil = sql.NullTime{Time: attempt.BlockedUntil, Valid: true}
+	}
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, newID(), attempt.IP, attempt.Username, attempt.FailedAttempts, attempt.LastFailedAt, blockedUntil)
+	return err
+}
+
+// ListActive lists attempts that are still blocked or failed since the given time
+func (r *loginAttemptRepository) ListActive(ctx context.Context, failedSince time.Time) ([]model.LoginAttempt, error) {
+	query := `SELECT ` + loginAttemptColumns + `
+			  FROM login_attempts
+			  WHERE blocked_until > NOW() OR last_failed_at > $1`
+
+	return r.queryLoginAttempts(ctx, query, failedSince)
+}
+
+// ListBlocked lists the attempts that are currently blocked, longest block first
+func (r *loginAttemptRepository) ListBlocked(ctx context.Context) ([]model.LoginAttempt, error) {
+	query := `SELECT ` + loginAttemptColumns + `
+			  FROM login_attempts
+			  WHERE blocked_until > NOW()
+			  ORDER BY blocked_until DESC`
+
+	return r.queryLoginAttempts(ctx, query)
+}
+
+// Delete removes an attempt by ID and returns it; returns nil when it doesn't exist
+func (r *loginAttemptRepository) Delete(ctx context.Context, id string) (*model.LoginAttempt, error) {
+	query := `DELETE FROM login_attempts WHERE id = $1 RETURNING ` + loginAttemptColumns
+
+	attempt, err := scanLoginAttempt(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return attempt, nil
+}
+
+// DeleteByKey removes the attempt for an IP and username
+func (r *loginAttemptRepository) DeleteByKey(ctx context.Context, ip, username string) error {
+	query := `DELETE FROM login_attempts WHERE ip = $1 AND username = $2`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, ip, username)
+	return err
+}
+
+// DeleteExpired removes attempts that are no longer blocked and last failed before the given time
+func (r *loginAttemptRepository) DeleteExpired(ctx context.Context, failedBefore time.Time) error {
+	query := `DELETE FROM login_attempts
+			  WHERE (blocked_until IS NULL OR blocked_until < NOW()) AND last_failed_at < $1`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, failedBefore)
+	return err
+}
+
+// queryLoginAttempts runs a query returning a list of login attempts
+func (r *loginAttemptRepository) queryLoginAttempts(ctx context.Context, query string, args ...interface{}) ([]model.LoginAttempt, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attempts []model.LoginAttempt
+	for rows.Next() {
+		attempt, err := scanLoginAttempt(rows)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, *attempt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return attempts, nil
+}
+
+// scanLoginAttempt scans a login attempt row selected with loginAttemptColumns
+func scanLoginAttempt(row rowScanner) (*model.LoginAttempt, error) {
+	var attempt model.LoginAttempt
+	var blockedUntil sql.NullTime
+
+	err := row.Scan(
+		&attempt.ID,
+		&attempt.IP,
+		&attempt.Username,
+		&attempt.FailedAttempts,
+		&attempt.LastFailedAt,
+		&blockedUntil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if blockedUntil.Valid {
+		attempt.BlockedUntil = blockedUntil.Time
+	}
+
+	return &attempt, nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// LoginDeviceRepository defines methods for login device repository
+type LoginDeviceRepository interface {
+	Get(ctx context.Context, userID, fingerprint string) (*model.LoginDevice, error)
+	Create(ctx context.Context, device *model.LoginDevice) (string, error)
+	Touch(ctx context.Context, id, ip, location string) error
+	SetConfirmToken(ctx context.Context, id, token string) error
+	GetByConfirmToken(ctx context.Context, token string) (*model.LoginDevice, error)
+	Confirm(ctx context.Context, id string) error
+}
+
+// loginDeviceRepository is the implementation of LoginDeviceRepository
+type loginDeviceRepository struct {
+	db *sqlx.DB
+}
+
+// NewLoginDeviceRepository creates a new LoginDeviceRepository
+func NewLoginDeviceRepository(db *sqlx.DB) LoginDeviceRepository {
+	return &loginDeviceRepository{db: db}
+}
+
+// loginDeviceColumns is the column list matching scanLoginDevice
+const loginDeviceColumns = `id, user_id, fingerprint, user_agent, last_ip, location, confirm_token, confirmation_sent_at, confirmed_at, last_seen_at, created_at`
+
+// Get gets a user's device by fingerprint; returns nil when the device is unknown
+func (r *loginDeviceRepository) Get(ctx context.Context, userID, fingerprint string) (*model.LoginDevice, error) {
+	query := `SELECT ` + loginDeviceColumns + `
+			  FROM login_devices
+			  WHERE user_id = $1 AND fingerprint = $2`
+
+	device, err := scanLoginDevice(conn(ctx, r.db).QueryRowContext(ctx, query, userID, fingerprint))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return device, nil
+}
+
+// Create records a device; it is stored as confirmed when ConfirmedAt is set
+func (r *loginDeviceRepository) Create(ctx context.Context, device *model.LoginDevice) (string, error) {
+	query := `INSERT INTO login_devices (id, user_id, fingerprint, user_agent, last_ip, location, confirmed_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7)
+			  RETURNING id`
+
+	var confirmedAt sql.NullTime
+	if !device.ConfirmedAt.IsZero() {
+		confirmedAt = sql.NullTime{Time: device.ConfirmedAt, Valid: true}
+	}
+
+	var id string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query,
+		newID(),
+		device.UserID, device.Fingerprint, device.UserAgent, device.LastIP, device.Location, confirmedAt,
+	).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// Touch records another login from a known device
+func (r *loginDeviceRepository) Touch(ctx context.Context, id, ip, location string) error {
+	query := `UPDATE login_devices SET last_ip = $2, location = $3, last_seen_at = NOW() WHERE id = $1`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, ip, location)
+	return err
+}
+
+// SetConfirmToken replaces the device's confirmation token and restarts its expiry
+func (r *loginDeviceRepository) SetConfirmToken(ctx context.Context, id, token string) error {
+	query := `UPDATE login_devices SET confirm_token = $2, confirmation_sent_at = NOW() WHERE id = $1`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, token)
+	return err
+}
+
+// GetByConfirmToken gets a device by its confirmation token
+func (r *loginDeviceRepository) GetByConfirmToken(ctx context.Context, token string) (*model.LoginDevice, error) {
+	query := `SELECT ` + loginDeviceColumns + `
+			  FROM login_devices
+			  WHERE confirm_token = $1`
+
+	device, err := scanLoginDevice(conn(ctx, r.db).QueryRowContext(ctx, query, token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("login device not found")
+		}
+		return nil, err
+	}
+
+	return device, nil
+}
+
+// Confirm marks a device as approved and clears its token
+func (r *loginDeviceRepository) Confirm(ctx context.Context, id string) error {
+	query := `UPDATE login_devices SET confirmed_at = NOW(), confirm_token = NULL WHERE id = $1`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	return err
+}
+
+// scanLoginDevice scans a login device row selected with loginDeviceColumns
+func scanLoginDevice(row rowScanner) (*model.LoginDevice, error) {
+	var device model.LoginDevice
+	var confirmToken sql.NullString
+	var confirmationSentAt, confirmedAt sql.NullTime
+
+	err := row.Scan(
+		&device.ID,
+		&device.UserID,
+		&device.Fingerprint,
+		&device.UserAgent,
+		&device.LastIP,
+		&device.Location,
+		&confirmToken,
+		&confirmationSentAt,
+		&confirmedAt,
+		&device.LastSeenAt,
+		&device.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if confirmToken.Valid {
+		device.ConfirmToken = confirmToken.String
+	}
+	if confirmationSentAt.Valid {
+		device.ConfirmationSentAt = confirmationSentAt.Time
+	}
+	if confirmedAt.Valid {
+		device.ConfirmedAt = confirmedAt.Time
+	}
+
+	return &device, nil
+}
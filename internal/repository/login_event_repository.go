@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// LoginEventRepository defines methods for login event repository
+type LoginEventRepository interface {
+	Create(ctx context.Context, event *model.LoginEvent) error
+	ListByUser(ctx context.Context, userID string, page, perPage int) ([]model.LoginEvent, int, error)
+}
+
+// loginEventRepository is the implementation of LoginEventRepository
+type loginEventRepository struct {
+	db *sqlx.DB
+}
+
+// NewLoginEventRepository creates a new LoginEventRepository
+func NewLoginEventRepository(db *sqlx.DB) LoginEventRepository {
+	return &loginEventRepository{db: db}
+}
+
+// loginEventColumns is the column list matching scanLoginEvent
+const loginEventColumns = `id, user_id, username, ip, user_agent, location, success, reason, created_at`
+
+// Create records a login event; an empty UserID is stored as NULL
+func (r *loginEventRepository) Create(ctx context.Context, event *model.LoginEvent) error {
+	query := `INSERT INTO login_events (id, user_id, username, ip, user_agent, location, success, reason)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	var userID sql.NullString
+	if event.UserID != "" {
+		userID = sql.NullString{String: event.UserID, Valid: true}
+	}
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query,
+		newID(),
+		userID,
+		event.Username,
+		event.IP,
+		event.UserAgent,
+		event.Location,
+		event.Success,
+		event.Reason,
+	)
+	return err
+}
+
+// ListByUser lists a user's login events with pagination, newest first
+func (r *loginEventRepository) ListByUser(ctx context.Context, userID string, page, perPage int) ([]model.LoginEvent, int, error) {
+	offset := (page - 1) * perPage
+
+	// Count total
+	var total int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM login_events WHERE user_id = $1`, userID).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + loginEventColumns + `
+			  FROM login_events
+			  WHERE user_id = $1
+			  ORDER BY created_at DESC
+			  LIMIT $2 OFFSET $3`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, userID, perPage, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	events := []model.LoginEvent{}
+	for rows.Next() {
+		event, err := scanLoginEvent(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		events = append(events, *event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return events, total, nil
+}
+
+// scanLoginEvent scans a login event row selected with loginEventColumns
+func scanLoginEvent(row rowScanner) (*model.LoginEvent, error) {
+	var event model.LoginEvent
+	var userID sql.NullString
+
+	err := row.Scan(
+		&event.ID,
+		&userID,
+		&event.Username,
+		&event.IP,
+		&event.UserAgent,
+		&event.Location,
+		&event.Success,
+		&event.Reason,
+		&event.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID.Valid {
+		event.UserID = userID.String
+	}
+
+	return &event, nil
+}
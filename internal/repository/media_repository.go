@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// MediaRepository defines methods for media repository
+type MediaRepository interface {
+	Create(ctx context.Context, media *model.Media) error
+	GetByID(ctx context.Context, id string) (*model.Media, error)
+	MarkReady(ctx context.Context, id string, size int64) error
+	SetProcessed(ctx context.Context, media *model.Media) error
+	List(ctx context.Context, page, perPage int) ([]model.Media, int, error)
+}
+
+// mediaRepository is the implementation of MediaRepository
+type mediaRepository struct {
+	db *sqlx.DB
+}
+
+// NewMediaRepository creates a new MediaRepository
+func NewMediaRepository(db *sqlx.DB) MediaRepository {
+	return &mediaRepository{db: db}
+}
+
+// mediaColumns is the column list matching scanMedia
+const mediaColumns = `id, object_key, filename, content_type, size, width, height, status, variants, user_id, created_at, updated_at`
+
+// mediaVariantRow is how a variant is stored in the variants column
+type mediaVariantRow struct {
+	ContentType string `json:"content_type"`
+	Key         string `json:"key"`
+	Size        int64  `json:"size"`
+}
+
+// Create inserts a pending media record and fills in its ID and timestamps
+func (r *mediaRepository) Create(ctx context.Context, media *model.Media) error {
+	query := `INSERT INTO media (id, object_key, filename, content_type, size, status, user_id)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7)
+			  RETURNING id, created_at, updated_at`
+
+	return conn(ctx, r.db).QueryRowContext(ctx, query,
+		newID(),
+		media.Key,
+		media.Filename,
+		media.ContentType,
+		media.Size,
+		media.Status,
+		nullString(media.UserID),
+	).Scan(&media.ID, &media.CreatedAt, &media.UpdatedAt)
+}
+
+// GetByID gets a media record by ID
+func (r *mediaRepository) GetByID(ctx context.Context, id string) (*model.Media, error) {
+	query := `SELECT ` + mediaColumns + ` FROM media WHERE id = $1`
+
+	media, err := scanMedia(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("media not found")
+		}
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// MarkReady marks an uploaded file as ready with its stored size
+func (r *mediaRepository) MarkReady(ctx context.Context, id string, size int64) error {
+	query := `UPDATE media SET status = $2, size = $3, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, model.MediaReady, size)
+	return err
+}
+
+// SetProcessed stores the processed file's size, dimensions and variants
+func (r *mediaRepository) SetProcessed(ctx context.Context, media *model.Media) error {
+	rows := make([]mediaVariantRow, 0, len(media.Variants))
+	for _, variant := range media.Variants {
+		rows = append(rows, mediaVariantRow{ContentType: variant.ContentType, Key: variant.Key, Size: variant.Size})
+	}
+
+	variantsJSON, err := json.Marshal(rows)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE media
+			  SET size = $2, width = $3, height = $4, variants = $5, updated_at = CURRENT_TIMESTAMP
+			  WHERE id = $1`
+	_, err = conn(ctx, r.db).ExecContext(ctx, query, media.ID, media.Size, media.Width, media.Height, variantsJSON)
+	return err
+}
+
+// List lists ready media with pagination, newest first
+func (r *mediaRepository) List(ctx context.Context, page, perPage int) ([]model.Media, int, error) {
+	offset := (page - 1) * perPage
+
+	var total int
+	err := readConn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM media WHERE status = $1`, model.MediaReady).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + mediaColumns + `
+			  FROM media
+			  WHERE status = $1
+			  ORDER BY created_at DESC
+			  LIMIT $2 OFFSET $3`
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query, model.MediaReady, perPage, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var media []model.Media
+	for rows.Next() {
+		item, err := scanMedia(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		media = append(media, *item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return media, total, nil
+}
+
+// scanMedia scans a media row selected with mediaColumns
+func scanMedia(row rowScanner) (*model.Media, error) {
+	var media model.Media
+	var userID sql.NullString
+	var variantsJSON []byte
+
+	err := row.Scan(
+		&media.ID,
+		&media.Key,
+		&media.Filename,
+		&media.ContentType,
+		&media.Size,
+		&media.Width,
+		&media.Height,
+		&media.Status,
+		&variantsJSON,
+		&userID,
+		&media.CreatedAt,
+		&media.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID.Valid {
+		media.UserID = userID.String
+	}
+
+	var variants []mediaVariantRow
+	if err := json.Unmarshal(variantsJSON, &variants); err != nil {
+		return nil, err
+	}
+	media.Variants = make([]model.MediaVariant, 0, len(variants))
+	for _, variant := range variants {
+		media.Variants = append(media.Variants, model.MediaVariant{ContentType: variant.ContentType, Key: variant.Key, Size: variant.Size})
+	}
+
+	return &media, nil
+}
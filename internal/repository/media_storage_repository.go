@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// MediaStorageRepository manages media objects in the S3 bucket
+type MediaStorageRepository struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewMediaStorageRepository creates a new media storage repository; it returns nil when no bucket is configured
+func NewMediaStorageRepository(cfg config.Config) (*MediaStorageRepository, error) {
+	if cfg.MediaS3Bucket == "" {
+		return nil, nil
+	}
+
+	client, err := minio.New(cfg.MediaS3Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.MediaS3AccessKey, cfg.MediaS3SecretKey, ""),
+		Secure: cfg.MediaS3UseSSL,
+		Region: cfg.MediaS3Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MediaStorageRepository{
+		client: client,
+		bucket: cfg.MediaS3Bucket,
+	}, nil
+}
+
+// PresignUpload returns the URL and form fields of a browser upload. The policy pins the key
+// and content type and caps the size, so storage rejects anything else.
+func (r *MediaStorageRepository) PresignUpload(ctx context.Context, key, contentType string, maxSize int64, expiresAt time.Time) (string, map[string]string, error) {
+	policy := minio.NewPostPolicy()
+	if err := policy.SetBucket(r.bucket); err != nil {
+		return "", nil, err
+	}
+	if err := policy.SetKey(key); err != nil {
+		return "", nil, err
+	}
+	if err := policy.SetContentType(contentType); err != nil {
+		return "", nil, err
+	}
+	if err := policy.SetContentLengthRange(1, maxSize); err != nil {
+		return "", nil, err
+	}
+	if err := policy.SetExpires(expiresAt); err != nil {
+		return "", nil, err
+	}
+
+	url, fields, err := r.client.PresignedPostPolicy(ctx, policy)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return url.String(), fields, nil
+}
+
+// Stat returns the size of a stored object
+func (r *MediaStorageRepository) Stat(ctx context.Context, key string) (int64, error) {
+	info, err := r.client.StatObject(ctx, r.bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return 0, err
+	}
+	return info.Size, nil
+}
+
+// Get reads a stored object, refusing objects larger than maxSize
+func (r *MediaStorageRepository) Get(ctx context.Context, key string, maxSize int64) ([]byte, error) {
+	object, err := r.client.GetObject(ctx, r.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer object.Close()
+
+	data, err := io.ReadAll(io.LimitReader(object, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("object %s is larger than %d bytes", key, maxSize)
+	}
+
+	return data, nil
+}
+
+// Put stores an object, replacing any object with the same key
+func (r *MediaStorageRepository) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	_, err := r.client.PutObject(ctx, r.bucket, key, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	return err
+}
+
+// Ping checks that the bucket is reachable with the configured credentials
+func (r *MediaStorageRepository) Ping(ctx context.Context) error {
+	exists, err := r.client.BucketExists(ctx, r.bucket)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("bucket %s does not exist", r.bucket)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// OEmbedCacheRepository defines methods for oEmbed cache repository
+type OEmbedCacheRepository interface {
+	Get(ctx context.Context, url string, maxAge time.Duration) (*model.OEmbed, error)
+	Save(ctx context.Context, url string, embed *model.OEmbed) error
+}
+
+// oEmbedCacheRepository is the implementation of OEmbedCacheRepository
+type oEmbedCacheRepository struct {
+	db *sqlx.DB
+}
+
+// NewOEmbedCacheRepository creates a new OEmbedCacheRepository
+func NewOEmbedCacheRepository(db *sqlx.DB) OEmbedCacheRepository {
+	return &oEmbedCacheRepository{db: db}
+}
+
+// Get returns a cached response younger than maxAge, or nil
+func (r *oEmbedCacheRepository) Get(ctx context.Context, url string, maxAge time.Duration) (*model.OEmbed, error) {
+	query := `SELECT response FROM oembed_cache WHERE url = $1 AND fetched_at > $2`
+
+	var response []byte
+	err := readConn(ctx, r.db).QueryRowContext(ctx, query, url, time.Now().Add(-maxAge)).Scan(&response)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var embed model.OEmbed
+	if err := json.Unmarshal(response, &embed); err != nil {
+		return nil, err
+	}
+
+	return &embed, nil
+}
+
+// Save stores a response, replacing an expired one
+func (r *oEmbedCacheRepository) Save(ctx context.Context, url string, embed *model.OEmbed) error {
+	response, err := json.Marshal(embed)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO oembed_cache (url, response, fetched_at)
+			  VALUES ($1, $2, CURRENT_TIMESTAMP)
+			  ON CONFLICT (url) DO UPDATE
+			  SET response = EXCLUDED.response, fetched_at = EXCLUDED.fetched_at`
+
+	_, err = conn(ctx, r.db).ExecContext(ctx, query, url, response)
+	return err
+}
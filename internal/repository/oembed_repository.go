@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"go.uber.org/zap"
+)
+
+// maxOEmbedResponse bounds provider responses read into memory
+const maxOEmbedResponse = 1 << 20
+
+// OEmbedRepository fetches embed data from oEmbed providers and GitHub
+type OEmbedRepository struct {
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewOEmbedRepository creates a new oEmbed repository
+func NewOEmbedRepository(logger *zap.Logger) *OEmbedRepository {
+	return &OEmbedRepository{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+// Fetch asks a provider's oEmbed endpoint about a URL; params are added to the query
+func (r *OEmbedRepository) Fetch(ctx context.Context, endpoint, target string, params url.Values) (*model.OEmbed, error) {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("url", target)
+	params.Set("format", "json")
+
+	var embed model.OEmbed
+	if err := r.getJSON(ctx, endpoint+"?"+params.Encode(), &embed); err != nil {
+		r.logger.Error("Failed to fetch oEmbed", zap.String("url", target), zap.Error(err))
+		return nil, err
+	}
+
+	return &embed, nil
+}
+
+// FetchGist fetches a public gist from the GitHub API
+func (r *OEmbedRepository) FetchGist(ctx context.Context, id string) (*model.Gist, error) {
+	var gist model.Gist
+	if err := r.getJSON(ctx, githubAPIURL+"/gists/"+url.PathEscape(id), &gist); err != nil {
+		r.logger.Error("Failed to fetch gist", zap.String("id", id), zap.Error(err))
+		return nil, err
+	}
+
+	return &gist, nil
+}
+
+// getJSON decodes a JSON response into out
+func (r *OEmbedRepository) getJSON(ctx context.Context, rawURL string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("provider returned status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, maxOEmbedResponse)).Decode(out)
+}
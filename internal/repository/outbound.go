@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+)
+
+// ErrCircuitOpen is returned without calling a service that keeps failing, until its cooldown ends
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// maxRetryWait is the longest wait before a retry
+const maxRetryWait = 30 * time.Second
+
+// Circuit breaker states
+const (
+	circuitClosed   = "closed"
+	circuitOpen     = "open"
+	circuitHalfOpen = "half_open"
+)
+
+// circuitBreaker stops calls to a service after consecutive failures. Once the cooldown has
+// passed, a single trial call decides whether it closes again.
+type circuitBreaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	state     string
+	failures  int
+	openUntil time.Time
+	stats     model.OutboundStats
+}
+
+// breakers holds every circuit breaker by name, for the admin stats
+var breakers = struct {
+	sync.Mutex
+	byName map[string]*circuitBreaker
+}{byName: map[string]*circuitBreaker{}}
+
+// breakerFor returns the shared circuit breaker of a service, creating it on first use
+func breakerFor(name string, cfg config.Config) *circuitBreaker {
+	breakers.Lock()
+	defer breakers.Unlock()
+
+	if breaker, ok := breakers.byName[name]; ok {
+		return breaker
+	}
+
+	breaker := &circuitBreaker{
+		name:      name,
+		threshold: cfg.OutboundBreakerThreshold,
+		cooldown:  cfg.OutboundBreakerCooldown,
+		state:     circuitClosed,
+	}
+	breakers.byName[name] = breaker
+	return breaker
+}
+
+// OutboundStats returns the state and counters of every outbound service, sorted by name
+func OutboundStats() []model.OutboundStats {
+	breakers.Lock()
+	list := make([]*circuitBreaker, 0, len(breakers.byName))
+	for _, breaker := range breakers.byName {
+		list = append(list, breaker)
+	}
+	breakers.Unlock()
+
+	stats := make([]model.OutboundStats, 0, len(list))
+	for _, breaker := range list {
+		breaker.mu.Lock()
+		snapshot := breaker.stats
+		snapshot.Name = breaker.name
+		snapshot.State = breaker.state
+		breaker.mu.Unlock()
+		stats = append(stats, snapshot)
+	}
+
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Name < stats[j].Name })
+	return stats
+}
+
+// allow reports whether a call may go out, moving an open breaker to half-open after its cooldown
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if time.Now().Before(b.openUntil) {
+			b.stats.ShortCircuits++
+			return false
+		}
+		b.state = circuitHalfOpen
+		return true
+	case circuitHalfOpen:
+		// A trial call is already out
+		b.stats.ShortCircuits++
+		return false
+	}
+	return true
+}
+
+// record updates the breaker with the outcome of a call
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.stats.Requests++
+	if err == nil {
+		b.state = circuitClosed
+		b.failures = 0
+		return
+	}
+
+	b.stats.Failures++
+	b.stats.LastError = err.Error()
+	now := time.Now()
+	b.stats.LastFailureAt = &now
+
+	b.failures++
+	if b.state == circuitHalfOpen || (b.threshold > 0 && b.failures >= b.threshold) {
+		b.state = circuitOpen
+		b.openUntil = now.Add(b.cooldown)
+	}
+}
+
+// outboundClient sends HTTP requests to one service with retries and a circuit breaker
+type outboundClient struct {
+	httpClient *http.Client
+	breaker    *circuitBreaker
+	attempts   int
+	backoff    time.Duration
+}
+
+// newOutboundClient creates a client for the named service
+func newOutboundClient(name string, httpClient *http.Client, cfg config.Config) *outboundClient {
+	attempts := cfg.OutboundRetryAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	return &outboundClient{
+		httpClient: httpClient,
+		breaker:    breakerFor(name, cfg),
+		attempts:   attempts,
+		backoff:    cfg.OutboundRetryBackoff,
+	}
+}
+
+// do sends the request built by newRequest, retrying network errors, 429 and 5xx responses
+// with exponential backoff. newRequest is called per attempt so the body can be re-read.
+// Other responses are returned as they are; the caller closes the body.
+func (c *outboundClient) do(ctx context.Context, newRequest func(ctx context.Context) (*http.Request, error)) (*http.Response, error) {
+	req, err := newRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	backoff := c.backoff
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 {
+			if req, err = newRequest(ctx); err != nil {
+				c.breaker.record(err)
+				return nil, err
+			}
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err == nil && resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+			c.breaker.record(nil)
+			return resp, nil
+		}
+
+		wait := backoff
+		if err == nil {
+			wait = max(wait, retryAfter(resp))
+			resp.Body.Close()
+			err = fmt.Errorf("%s returned status %d", c.breaker.name, resp.StatusCode)
+		}
+
+		// A long Retry-After is better waited out by the caller, e.g. a job retry
+		if attempt >= c.attempts || wait > maxRetryWait {
+			c.breaker.record(err)
+			return nil, err
+		}
+
+		c.breaker.mu.Lock()
+		c.breaker.stats.Retries++
+		c.breaker.mu.Unlock()
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			c.breaker.record(err)
+			return nil, ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// retryAfter reads a Retry-After header given in seconds
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
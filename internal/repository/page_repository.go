@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"github.com/jmoiron/sqlx"
+)
+
+// PageRepository defines methods for page repository
+type PageRepository interface {
+	Create(ctx context.Context, page *model.PageCreate, userID string) (string, error)
+	Update(ctx context.Context, id string, page *model.PageUpdate) error
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (*model.Page, error)
+	GetBySlug(ctx context.Context, slug string) (*model.Page, error)
+	List(ctx context.Context, onlyPublished bool) ([]model.Page, error)
+}
+
+// pageRepository is the implementation of PageRepository
+type pageRepository struct {
+	db *sqlx.DB
+}
+
+// NewPageRepository creates a new PageRepository
+func NewPageRepository(db *sqlx.DB) PageRepository {
+	return &pageRepository{db: db}
+}
+
+// pageColumns is the column list matching scanPage
+const pageColumns = `id, title, slug, content, is_published, user_id, created_at, updated_at, published_at`
+
+// Create creates a new page
+func (r *pageRepository) Create(ctx context.Context, pageCreate *model.PageCreate, userID string) (string, error) {
+	query := `INSERT INTO pages (id, title, slug, content, is_published, user_id, published_at)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7)
+			  RETURNING id`
+
+	var publishedAt sql.NullTime
+	if pageCreate.IsPublished {
+		publishedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	}
+
+	var id string
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx, query,
+		newID(),
+		pageCreate.Title,
+		pageSlug(pageCreate.Slug, pageCreate.Title),
+		pageCreate.Content,
+		pageCreate.IsPublished,
+		userID,
+		publishedAt,
+	).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// Update updates a page
+func (r *pageRepository) Update(ctx context.Context, id string, pageUpdate *model.PageUpdate) error {
+	// Keep the original published_at when a page is republished
+	query := `UPDATE pages
+			  SET title = $2, slug = $3, content = $4, is_published = $5, updated_at = $6,
+			      published_at = CASE WHEN $5 AND published_at IS NULL THEN $6 ELSE published_at END
+			  WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx, query,
+		id,
+		pageUpdate.Title,
+		pageSlug(pageUpdate.Slug, pageUpdate.Title),
+		pageUpdate.Content,
+		pageUpdate.IsPublished,
+		time.Now(),
+	)
+	return err
+}
+
+// Delete deletes a page
+func (r *pageRepository) Delete(ctx context.Context, id string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM pages WHERE id = $1`, id)
+	return err
+}
+
+// GetByID gets a page by ID
+func (r *pageRepository) GetByID(ctx context.Context, id string) (*model.Page, error) {
+	query := `SELECT ` + pageColumns + ` FROM pages WHERE id = $1`
+	return r.getOne(ctx, query, id)
+}
+
+// GetBySlug gets a page by slug
+func (r *pageRepository) GetBySlug(ctx context.Context, slug string) (*model.Page, error) {
+	query := `SELECT ` + pageColumns + ` FROM pages WHERE slug = $1`
+	return r.getOne(ctx, query, slug)
+}
+
+// List lists pages ordered by title
+func (r *pageRepository) List(ctx context.Context, onlyPublished bool) ([]model.Page, error) {
+	query := `SELECT ` + pageColumns + ` FROM pages`
+	if onlyPublished {
+		query += ` WHERE is_published = true`
+	}
+	query += ` ORDER BY title ASC`
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pages := []model.Page{}
+	for rows.Next() {
+		page, err := scanPage(rows)
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, *page)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pages, nil
+}
+
+// getOne runs a query expected to return a single page
+func (r *pageRepository) getOne(ctx context.Context, query string, args ...interface{}) (*model.Page, error) {
+	page, err := scanPage(readConn(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("page not found")
+		}
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// scanPage scans a page row selected with pageColumns
+func scanPage(row rowScanner) (*model.Page, error) {
+	var page model.Page
+	var publishedAt sql.NullTime
+
+	err := row.Scan(
+		&page.ID,
+		&page.Title,
+		&page.Slug,
+		&page.Content,
+		&page.IsPublished,
+		&page.UserID,
+		&page.CreatedAt,
+		&page.UpdatedAt,
+		&publishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if publishedAt.Valid {
+		page.PublishedAt = publishedAt.Time
+	}
+
+	return &page, nil
+}
+
+// pageSlug uses the explicit slug when given, falling back to the title
+func pageSlug(slug, title string) string {
+	if slug != "" {
+		return util.GenerateSlug(slug)
+	}
+	return util.GenerateSlug(title)
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// PortfolioImageRepository defines methods for portfolio gallery repository
+type PortfolioImageRepository interface {
+	ListByPortfolio(ctx context.Context, portfolioID string) ([]model.PortfolioImage, error)
+	Add(ctx context.Context, portfolioID, mediaID, alt string) (string, error)
+	SetPositions(ctx context.Context, portfolioID string, imageIDs []string) error
+	Delete(ctx context.Context, portfolioID, id string) (bool, error)
+}
+
+// portfolioImageRepository is the implementation of PortfolioImageRepository
+type portfolioImageRepository struct {
+	db *sqlx.DB
+}
+
+// NewPortfolioImageRepository creates a new PortfolioImageRepository
+func NewPortfolioImageRepository(db *sqlx.DB) PortfolioImageRepository {
+	return &portfolioImageRepository{db: db}
+}
+
+// ListByPortfolio lists a portfolio's gallery in order, with the media file behind each image
+func (r *portfolioImageRepository) ListByPortfolio(ctx context.Context, portfolioID string) ([]model.PortfolioImage, error) {
+	query := `SELECT i.id, i.media_id, m.object_key, i.alt, m.width, m.height, m.variants
+			  FROM portfolio_images i
+			  JOIN media m ON m.id = i.media_id
+			  WHERE i.portfolio_id = $1
+			  ORDER BY i.position, i.created_at`
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	images := []model.PortfolioImage{}
+	for rows.Next() {
+		var image model.PortfolioImage
+		var variantsJSON []byte
+		err := rows.Scan(&image.ID, &image.MediaID, &image.Key, &image.Alt, &image.Width, &image.Height, &variantsJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		var variants []mediaVariantRow
+		if err := json.Unmarshal(variantsJSON, &variants); err != nil {
+			return nil, err
+		}
+		image.Variants = make([]model.MediaVariant, 0, len(variants))
+		for _, variant := range variants {
+			image.Variants = append(image.Variants, model.MediaVariant{ContentType: variant.ContentType, Key: variant.Key, Size: variant.Size})
+		}
+
+		images = append(images, image)
+	}
+
+	return images, rows.Err()
+}
+
+// Add appends a media file to the end of a portfolio's gallery
+func (r *portfolioImageRepository) Add(ctx context.Context, portfolioID, mediaID, alt string) (string, error) {
+	query := `INSERT INTO portfolio_images (id, portfolio_id, media_id, alt, position)
+			  VALUES ($1, $2, $3, $4, (SELECT COALESCE(MAX(position) + 1, 0) FROM portfolio_images WHERE portfolio_id = $2))
+			  RETURNING id`
+
+	var id string
+	if err := conn(ctx, r.db).QueryRowContext(ctx, query, newID(), portfolioID, mediaID, alt).Scan(&id); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// SetPositions orders a portfolio's gallery as listed in imageIDs
+func (r *portfolioImageRepository) SetPositions(ctx context.Context, portfolioID string, imageIDs []string) error {
+	query := `UPDATE portfolio_images i
+			  SET position = o.position - 1
+			  FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, position)
+			  WHERE i.id = o.id AND i.portfolio_id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, portfolioID, imageIDs)
+	return err
+}
+
+// Delete removes an image from a portfolio's gallery and reports whether it existed.
+// The media file stays in the library.
+func (r *portfolioImageRepository) Delete(ctx context.Context, portfolioID, id string) (bool, error) {
+	query := `DELETE FROM portfolio_images WHERE portfolio_id = $1 AND id = $2`
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, query, portfolioID, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
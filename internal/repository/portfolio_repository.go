@@ -5,6 +5,9 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/budhilaw/personal-website-backend/internal/model"
@@ -19,7 +22,10 @@ type PortfolioRepository interface {
 	Delete(ctx context.Context, id string) error
 	GetByID(ctx context.Context, id string) (*model.Portfolio, error)
 	GetBySlug(ctx context.Context, slug string) (*model.Portfolio, error)
-	List(ctx context.Context, page, perPage int, onlyPublished bool) ([]model.Portfolio, int, error)
+	List(ctx context.Context, page, perPage int, onlyPublished bool, filter model.PortfolioFilter) ([]model.Portfolio, int, error)
+	ListAfter(ctx context.Context, after *model.Cursor, limit int, onlyPublished bool, filter model.PortfolioFilter) ([]model.Portfolio, error)
+	ListFields(ctx context.Context, fields []string, page, perPage int, onlyPublished bool, filter model.PortfolioFilter) ([]model.PartialItem, int, error)
+	ListFieldsAfter(ctx context.Context, fields []string, after *model.Cursor, limit int, onlyPublished bool, filter model.PortfolioFilter) ([]model.PartialItem, error)
 	GetByAuthor(ctx context.Context, userID string, page, perPage int) ([]model.Portfolio, int, error)
 }
 
@@ -33,10 +39,13 @@ func NewPortfolioRepository(db *sqlx.DB) PortfolioRepository {
 	return &portfolioRepository{db: db}
 }
 
+// portfolioColumns is the column list matching scanPortfolio
+const portfolioColumns = `id, title, slug, description, content, image, project_url, github_url, technologies, category, role, duration, metrics, is_published, user_id, created_at, updated_at, ` + seoColumns
+
 // Create creates a new portfolio
 func (r *portfolioRepository) Create(ctx context.Context, portfolioCreate *model.PortfolioCreate, userID string) (string, error) {
-	query := `INSERT INTO portfolios (title, slug, description, image, project_url, github_url, technologies, is_published, user_id) 
-			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) 
+	query := `INSERT INTO portfolios (id, title, slug, description, image, project_url, github_url, technologies, category, is_published, user_id, content, role, duration, metrics, meta_title, meta_description, canonical_url, og_image) 
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19) 
 			  RETURNING id`
 
 	slug := util.GenerateSlug(portfolioCreate.Title)
@@ -51,9 +60,13 @@ func (r *portfolioRepository) Create(ctx context.Context, portfolioCreate *model
 		}
 	}
 
-	var id string
-	err = r.db.QueryRowContext(
-		ctx, query,
+	caseStudy, err := caseStudyArgs(portfolioCreate.Content, portfolioCreate.Role, portfolioCreate.Duration, portfolioCreate.Metrics)
+	if err != nil {
+		return "", err
+	}
+
+	params := []interface{}{
+		newID(),
 		portfolioCreate.Title,
 		slug,
 		portfolioCreate.Description,
@@ -61,9 +74,15 @@ func (r *portfolioRepository) Create(ctx context.Context, portfolioCreate *model
 		portfolioCreate.ProjectURL,
 		portfolioCreate.GithubURL,
 		technologiesJSON,
+		nullString(portfolioCreate.Category),
 		portfolioCreate.IsPublished,
 		userID,
-	).Scan(&id)
+	}
+	params = append(params, caseStudy...)
+	params = append(params, seoArgs(portfolioCreate.SEOMeta)...)
+
+	var id string
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, params...).Scan(&id)
 	if err != nil {
 		return "", err
 	}
@@ -71,10 +90,12 @@ func (r *portfolioRepository) Create(ctx context.Context, portfolioCreate *model
 	return id, nil
 }
 
-// Update updates a portfolio
+// Update updates a portfolio, keeping the previous slug in history when it changes
 func (r *portfolioRepository) Update(ctx context.Context, id string, portfolioUpdate *model.PortfolioUpdate) error {
 	query := `UPDATE portfolios 
-			  SET title = $2, slug = $3, description = $4, image = $5, project_url = $6, github_url = $7, technologies = $8, is_published = $9, updated_at = $10
+			  SET title = $2, slug = $3, description = $4, image = $5, project_url = $6, github_url = $7, technologies = $8, category = $9, is_published = $10, updated_at = $11,
+			      content = $12, role = $13, duration = $14, metrics = $15,
+			      meta_title = $16, meta_description = $17, canonical_url = $18, og_image = $19
 			  WHERE id = $1`
 
 	// Convert technologies slice to JSON
@@ -87,52 +108,72 @@ func (r *portfolioRepository) Update(ctx context.Context, id string, portfolioUp
 		}
 	}
 
-	_, err = r.db.ExecContext(
-		ctx, query,
-		id,
-		portfolioUpdate.Title,
-		util.GenerateSlug(portfolioUpdate.Title),
-		portfolioUpdate.Description,
-		portfolioUpdate.Image,
-		portfolioUpdate.ProjectURL,
-		portfolioUpdate.GithubURL,
-		technologiesJSON,
-		portfolioUpdate.IsPublished,
-		time.Now(),
-	)
-	return err
+	caseStudy, err := caseStudyArgs(portfolioUpdate.Content, portfolioUpdate.Role, portfolioUpdate.Duration, portfolioUpdate.Metrics)
+	if err != nil {
+		return err
+	}
+
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		var currentSlug string
+		err := tx.QueryRowContext(ctx, "SELECT slug FROM portfolios WHERE id = $1 FOR UPDATE", id).Scan(&currentSlug)
+		if err != nil {
+			return err
+		}
+
+		slug := util.GenerateSlug(portfolioUpdate.Title)
+
+		params := []interface{}{
+			id,
+			portfolioUpdate.Title,
+			slug,
+			portfolioUpdate.Description,
+			portfolioUpdate.Image,
+			portfolioUpdate.ProjectURL,
+			portfolioUpdate.GithubURL,
+			technologiesJSON,
+			nullString(portfolioUpdate.Category),
+			portfolioUpdate.IsPublished,
+			time.Now(),
+		}
+		params = append(params, caseStudy...)
+		params = append(params, seoArgs(portfolioUpdate.SEOMeta)...)
+
+		_, err = tx.ExecContext(ctx, query, params...)
+		if err != nil {
+			return err
+		}
+
+		return recordSlugChange(ctx, tx, slugEntityPortfolio, id, currentSlug, slug)
+	})
+}
+
+// caseStudyArgs returns the query arguments for the case-study columns, in column order
+func caseStudyArgs(content, role, duration string, metrics []model.PortfolioMetric) ([]interface{}, error) {
+	if metrics == nil {
+		metrics = []model.PortfolioMetric{}
+	}
+	metricsJSON, err := json.Marshal(metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return []interface{}{content, nullString(role), nullString(duration), metricsJSON}, nil
 }
 
 // Delete deletes a portfolio
 func (r *portfolioRepository) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM portfolios WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id)
 	return err
 }
 
 // GetByID gets a portfolio by ID
 func (r *portfolioRepository) GetByID(ctx context.Context, id string) (*model.Portfolio, error) {
-	query := `SELECT id, title, slug, description, image, project_url, github_url, technologies, is_published, user_id, created_at, updated_at 
+	query := `SELECT ` + portfolioColumns + ` 
 			  FROM portfolios 
 			  WHERE id = $1`
 
-	var portfolio model.Portfolio
-	var technologiesJSON sql.NullString
-
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&portfolio.ID,
-		&portfolio.Title,
-		&portfolio.Slug,
-		&portfolio.Description,
-		&portfolio.Image,
-		&portfolio.ProjectURL,
-		&portfolio.GithubURL,
-		&technologiesJSON,
-		&portfolio.IsPublished,
-		&portfolio.UserID,
-		&portfolio.CreatedAt,
-		&portfolio.UpdatedAt,
-	)
+	portfolio, err := scanPortfolio(readConn(ctx, r.db).QueryRowContext(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New("portfolio not found")
@@ -140,36 +181,25 @@ func (r *portfolioRepository) GetByID(ctx context.Context, id string) (*model.Po
 		return nil, err
 	}
 
-	if technologiesJSON.Valid {
-		portfolio.Technologies = json.RawMessage(technologiesJSON.String)
-	}
-
-	return &portfolio, nil
+	return portfolio, nil
 }
 
-// GetBySlug gets a portfolio by slug
+// GetBySlug gets a portfolio by slug, falling back to previous slugs.
+// The returned portfolio always carries its current slug.
 func (r *portfolioRepository) GetBySlug(ctx context.Context, slug string) (*model.Portfolio, error) {
-	query := `SELECT id, title, slug, description, image, project_url, github_url, technologies, is_published, user_id, created_at, updated_at 
+	query := `SELECT ` + portfolioColumns + ` 
 			  FROM portfolios 
 			  WHERE slug = $1`
 
-	var portfolio model.Portfolio
-	var technologiesJSON sql.NullString
+	portfolio, err := scanPortfolio(readConn(ctx, r.db).QueryRowContext(ctx, query, slug))
+	if err == nil {
+		return portfolio, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
 
-	err := r.db.QueryRowContext(ctx, query, slug).Scan(
-		&portfolio.ID,
-		&portfolio.Title,
-		&portfolio.Slug,
-		&portfolio.Description,
-		&portfolio.Image,
-		&portfolio.ProjectURL,
-		&portfolio.GithubURL,
-		&technologiesJSON,
-		&portfolio.IsPublished,
-		&portfolio.UserID,
-		&portfolio.CreatedAt,
-		&portfolio.UpdatedAt,
-	)
+	id, err := resolveSlugHistory(ctx, readConn(ctx, r.db), slugEntityPortfolio, slug)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, errors.New("portfolio not found")
@@ -177,78 +207,181 @@ func (r *portfolioRepository) GetBySlug(ctx context.Context, slug string) (*mode
 		return nil, err
 	}
 
-	if technologiesJSON.Valid {
-		portfolio.Technologies = json.RawMessage(technologiesJSON.String)
-	}
-
-	return &portfolio, nil
+	return r.GetByID(ctx, id)
 }
 
-// List lists portfolios with pagination
-func (r *portfolioRepository) List(ctx context.Context, page, perPage int, onlyPublished bool) ([]model.Portfolio, int, error) {
+// List lists portfolios with pagination, optionally filtered by technology and category
+func (r *portfolioRepository) List(ctx context.Context, page, perPage int, onlyPublished bool, filter model.PortfolioFilter) ([]model.Portfolio, int, error) {
 	offset := (page - 1) * perPage
 
-	// Count total
-	countQuery := `SELECT COUNT(*) FROM portfolios`
-	if onlyPublished {
-		countQuery += ` WHERE is_published = true`
+	order, err := orderClause(portfolioSortColumns, filter.ListOptions)
+	if err != nil {
+		return nil, 0, err
 	}
 
+	conditions, args := portfolioConditions(onlyPublished, filter)
+	where := whereClause(conditions)
+
+	// Count total
 	var total int
-	err := r.db.QueryRowContext(ctx, countQuery).Scan(&total)
+	err = readConn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM portfolios`+where, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Get portfolios
-	query := `SELECT id, title, slug, description, image, project_url, github_url, technologies, is_published, user_id, created_at, updated_at 
-			  FROM portfolios`
-	if onlyPublished {
-		query += ` WHERE is_published = true`
+	query := `SELECT ` + portfolioColumns + ` 
+			  FROM portfolios` + where + order +
+		fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+
+	portfolios, err := r.queryPortfolios(ctx, query, append(args, perPage, offset)...)
+	if err != nil {
+		return nil, 0, err
 	}
-	query += ` ORDER BY created_at DESC LIMIT $1 OFFSET $2`
 
-	rows, err := r.db.QueryContext(ctx, query, perPage, offset)
+	return portfolios, total, nil
+}
+
+// ListAfter lists portfolios older than the cursor, newest first; a nil cursor starts at the newest
+func (r *portfolioRepository) ListAfter(ctx context.Context, after *model.Cursor, limit int, onlyPublished bool, filter model.PortfolioFilter) ([]model.Portfolio, error) {
+	conditions, args := portfolioConditions(onlyPublished, filter)
+	if after != nil {
+		args = append(args, after.CreatedAt, after.ID)
+		conditions = append(conditions, fmt.Sprintf(`(created_at, id) < ($%d, $%d)`, len(args)-1, len(args)))
+	}
+
+	query := `SELECT ` + portfolioColumns + `
+			  FROM portfolios` + whereClause(conditions) +
+		fmt.Sprintf(` ORDER BY created_at DESC, id DESC LIMIT $%d`, len(args)+1)
+
+	return r.queryPortfolios(ctx, query, append(args, limit)...)
+}
+
+// portfolioFields maps the fields selectable through sparse fieldsets to SQL expressions
+var portfolioFields = map[string]string{
+	"id":               "id",
+	"title":            "title",
+	"slug":             "slug",
+	"description":      "description",
+	"content":          "content",
+	"image":            "image",
+	"project_url":      "project_url",
+	"github_url":       "github_url",
+	"technologies":     "technologies",
+	"category":         "category",
+	"role":             "role",
+	"duration":         "duration",
+	"metrics":          "metrics",
+	"is_published":     "is_published",
+	"created_at":       "created_at",
+	"updated_at":       "updated_at",
+	"meta_title":       "COALESCE(meta_title, '')",
+	"meta_description": "COALESCE(meta_description, '')",
+	"canonical_url":    "COALESCE(canonical_url, '')",
+	"og_image":         "COALESCE(og_image, '')",
+	"views":            "views",
+}
+
+// ListFields lists portfolios like List, selecting only the requested fields
+func (r *portfolioRepository) ListFields(ctx context.Context, fields []string, page, perPage int, onlyPublished bool, filter model.PortfolioFilter) ([]model.PartialItem, int, error) {
+	offset := (page - 1) * perPage
+
+	columns, err := fieldList(portfolioFields, fields)
 	if err != nil {
 		return nil, 0, err
 	}
-	defer rows.Close()
 
-	var portfolios []model.Portfolio
-	for rows.Next() {
-		var portfolio model.Portfolio
-		var technologiesJSON sql.NullString
-
-		err := rows.Scan(
-			&portfolio.ID,
-			&portfolio.Title,
-			&portfolio.Slug,
-			&portfolio.Description,
-			&portfolio.Image,
-			&portfolio.ProjectURL,
-			&portfolio.GithubURL,
-			&technologiesJSON,
-			&portfolio.IsPublished,
-			&portfolio.UserID,
-			&portfolio.CreatedAt,
-			&portfolio.UpdatedAt,
-		)
-		if err != nil {
-			return nil, 0, err
-		}
+	order, err := orderClause(portfolioSortColumns, filter.ListOptions)
+	if err != nil {
+		return nil, 0, err
+	}
 
-		if technologiesJSON.Valid {
-			portfolio.Technologies = json.RawMessage(technologiesJSON.String)
-		}
+	conditions, args := portfolioConditions(onlyPublished, filter)
+	where := whereClause(conditions)
 
-		portfolios = append(portfolios, portfolio)
+	var total int
+	err = readConn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM portfolios`+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	if err := rows.Err(); err != nil {
+	query := `SELECT ` + columns + `
+			  FROM portfolios` + where + order +
+		fmt.Sprintf(` LIMIT $%d OFFSET $%d`, len(args)+1, len(args)+2)
+
+	items, err := queryFields(ctx, readConn(ctx, r.db), query, append(args, perPage, offset)...)
+	if err != nil {
 		return nil, 0, err
 	}
 
-	return portfolios, total, nil
+	return items, total, nil
+}
+
+// ListFieldsAfter lists portfolios like ListAfter, selecting only the requested fields.
+// created_at is always selected for the next cursor.
+func (r *portfolioRepository) ListFieldsAfter(ctx context.Context, fields []string, after *model.Cursor, limit int, onlyPublished bool, filter model.PortfolioFilter) ([]model.PartialItem, error) {
+	columns, err := fieldList(portfolioFields, append(slices.Clip(fields), "created_at"))
+	if err != nil {
+		return nil, err
+	}
+
+	conditions, args := portfolioConditions(onlyPublished, filter)
+	if after != nil {
+		args = append(args, after.CreatedAt, after.ID)
+		conditions = append(conditions, fmt.Sprintf(`(created_at, id) < ($%d, $%d)`, len(args)-1, len(args)))
+	}
+
+	query := `SELECT ` + columns + `
+			  FROM portfolios` + whereClause(conditions) +
+		fmt.Sprintf(` ORDER BY created_at DESC, id DESC LIMIT $%d`, len(args)+1)
+
+	return queryFields(ctx, readConn(ctx, r.db), query, append(args, limit)...)
+}
+
+// portfolioSortColumns maps the accepted sort keys to SQL expressions.
+// Portfolios have no publish date, so published_at falls back to created_at.
+var portfolioSortColumns = map[string]string{
+	"created_at":   "created_at",
+	"published_at": "created_at",
+	"title":        "LOWER(title)",
+	"views":        "views",
+}
+
+// portfolioConditions builds the WHERE conditions for a portfolio listing
+func portfolioConditions(onlyPublished bool, filter model.PortfolioFilter) ([]string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	if onlyPublished {
+		conditions = append(conditions, `is_published = true`)
+	}
+	if filter.Tech != "" {
+		// Matches the expression index on the lowercased technologies document
+		args = append(args, strings.ToLower(filter.Tech))
+		conditions = append(conditions, fmt.Sprintf(`LOWER(technologies::text)::jsonb ? $%d`, len(args)))
+	}
+	if filter.Category != "" {
+		args = append(args, filter.Category)
+		conditions = append(conditions, fmt.Sprintf(`LOWER(category) = LOWER($%d)`, len(args)))
+	}
+	if !filter.PublishedAfter.IsZero() {
+		// Portfolios are public from creation, which stands in for a publish date
+		args = append(args, filter.PublishedAfter)
+		conditions = append(conditions, fmt.Sprintf(`created_at > $%d`, len(args)))
+	}
+	if filter.Author != "" {
+		args = append(args, filter.Author)
+		conditions = append(conditions, fmt.Sprintf(`user_id = (SELECT id FROM users WHERE username = $%d)`, len(args)))
+	}
+	if filter.AuthorID != "" {
+		args = append(args, filter.AuthorID)
+		conditions = append(conditions, fmt.Sprintf(`user_id = $%d`, len(args)))
+	}
+	if filter.Search != "" {
+		args = append(args, likePattern(filter.Search))
+		conditions = append(conditions, fmt.Sprintf(`(title ILIKE $%[1]d OR description ILIKE $%[1]d)`, len(args)))
+	}
+
+	return conditions, args
 }
 
 // GetByAuthor gets portfolios by author ID with pagination
@@ -258,57 +391,99 @@ func (r *portfolioRepository) GetByAuthor(ctx context.Context, userID string, pa
 	// Count total
 	countQuery := `SELECT COUNT(*) FROM portfolios WHERE user_id = $1`
 	var total int
-	err := r.db.QueryRowContext(ctx, countQuery, userID).Scan(&total)
+	err := readConn(ctx, r.db).QueryRowContext(ctx, countQuery, userID).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Get portfolios
-	query := `SELECT id, title, slug, description, image, project_url, github_url, technologies, is_published, user_id, created_at, updated_at 
+	query := `SELECT ` + portfolioColumns + ` 
 			  FROM portfolios 
 			  WHERE user_id = $1 
 			  ORDER BY created_at DESC 
 			  LIMIT $2 OFFSET $3`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, perPage, offset)
+	portfolios, err := r.queryPortfolios(ctx, query, userID, perPage, offset)
 	if err != nil {
 		return nil, 0, err
 	}
+
+	return portfolios, total, nil
+}
+
+// queryPortfolios runs a query returning a list of portfolios
+func (r *portfolioRepository) queryPortfolios(ctx context.Context, query string, args ...interface{}) ([]model.Portfolio, error) {
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
 	var portfolios []model.Portfolio
 	for rows.Next() {
-		var portfolio model.Portfolio
-		var technologiesJSON sql.NullString
-
-		err := rows.Scan(
-			&portfolio.ID,
-			&portfolio.Title,
-			&portfolio.Slug,
-			&portfolio.Description,
-			&portfolio.Image,
-			&portfolio.ProjectURL,
-			&portfolio.GithubURL,
-			&technologiesJSON,
-			&portfolio.IsPublished,
-			&portfolio.UserID,
-			&portfolio.CreatedAt,
-			&portfolio.UpdatedAt,
-		)
+		portfolio, err := scanPortfolio(rows)
 		if err != nil {
-			return nil, 0, err
+			return nil, err
 		}
+		portfolios = append(portfolios, *portfolio)
+	}
 
-		if technologiesJSON.Valid {
-			portfolio.Technologies = json.RawMessage(technologiesJSON.String)
-		}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return portfolios, nil
+}
+
+// scanPortfolio scans a portfolio row selected with portfolioColumns
+func scanPortfolio(row rowScanner) (*model.Portfolio, error) {
+	var portfolio model.Portfolio
+	var technologiesJSON sql.NullString
+	var category sql.NullString
+	var role sql.NullString
+	var duration sql.NullString
+	var metricsJSON []byte
+
+	dest := []interface{}{
+		&portfolio.ID,
+		&portfolio.Title,
+		&portfolio.Slug,
+		&portfolio.Description,
+		&portfolio.Content,
+		&portfolio.Image,
+		&portfolio.ProjectURL,
+		&portfolio.GithubURL,
+		&technologiesJSON,
+		&category,
+		&role,
+		&duration,
+		&metricsJSON,
+		&portfolio.IsPublished,
+		&portfolio.UserID,
+		&portfolio.CreatedAt,
+		&portfolio.UpdatedAt,
+	}
 
-		portfolios = append(portfolios, portfolio)
+	err := row.Scan(append(dest, seoDest(&portfolio.SEOMeta)...)...)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, 0, err
+	if technologiesJSON.Valid {
+		portfolio.Technologies = json.RawMessage(technologiesJSON.String)
+	}
+	if category.Valid {
+		portfolio.Category = category.String
+	}
+	if role.Valid {
+		portfolio.Role = role.String
+	}
+	if duration.Valid {
+		portfolio.Duration = duration.String
+	}
+	if err := json.Unmarshal(metricsJSON, &portfolio.Metrics); err != nil {
+		return nil, err
 	}
 
-	return portfolios, total, nil
+	return &portfolio, nil
 }
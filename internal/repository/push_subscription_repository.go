@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// PushSubscriptionRepository defines methods for Web Push subscription repository
+type PushSubscriptionRepository interface {
+	Upsert(ctx context.Context, subscription *model.PushSubscribeRequest) (string, error)
+	GetByID(ctx context.Context, id string) (*model.PushSubscription, error)
+	ListIDs(ctx context.Context) ([]string, error)
+	Delete(ctx context.Context, id string) error
+	DeleteByEndpoint(ctx context.Context, endpoint string) error
+}
+
+// pushSubscriptionRepository is the implementation of PushSubscriptionRepository
+type pushSubscriptionRepository struct {
+	db *sqlx.DB
+}
+
+// NewPushSubscriptionRepository creates a new PushSubscriptionRepository
+func NewPushSubscriptionRepository(db *sqlx.DB) PushSubscriptionRepository {
+	return &pushSubscriptionRepository{db: db}
+}
+
+// Upsert stores a subscription; subscribing the same endpoint again refreshes its keys
+func (r *pushSubscriptionRepository) Upsert(ctx context.Context, subscription *model.PushSubscribeRequest) (string, error) {
+	query := `INSERT INTO push_subscriptions (id, endpoint, p256dh, auth)
+			  VALUES ($1, $2, $3, $4)
+			  ON CONFLICT (endpoint) DO UPDATE
+			  SET p256dh = EXCLUDED.p256dh, auth = EXCLUDED.auth, updated_at = NOW()
+			  RETURNING id`
+
+	var id string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, newID(), subscription.Endpoint, subscription.Keys.P256dh, subscription.Keys.Auth).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// GetByID gets a subscription by ID
+func (r *pushSubscriptionRepository) GetByID(ctx context.Context, id string) (*model.PushSubscription, error) {
+	query := `SELECT id, endpoint, p256dh, auth, created_at
+			  FROM push_subscriptions
+			  WHERE id = $1`
+
+	var subscription model.PushSubscription
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
+		&subscription.ID,
+		&subscription.Endpoint,
+		&subscription.P256dh,
+		&subscription.Auth,
+		&subscription.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// ListIDs lists the IDs of every subscription
+func (r *pushSubscriptionRepository) ListIDs(ctx context.Context) ([]string, error) {
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, `SELECT id FROM push_subscriptions ORDER BY created_at`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// Delete deletes a subscription by ID
+func (r *pushSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM push_subscriptions WHERE id = $1`, id)
+	return err
+}
+
+// DeleteByEndpoint deletes the subscription of a push endpoint
+func (r *pushSubscriptionRepository) DeleteByEndpoint(ctx context.Context, endpoint string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM push_subscriptions WHERE endpoint = $1`, endpoint)
+	return err
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+)
+
+// ErrUnknownField is returned when a sparse fieldset asks for a field outside the whitelist
+var ErrUnknownField = errors.New("unknown field")
+
+// likePattern builds an ILIKE pattern matching s anywhere, escaping LIKE wildcards
+func likePattern(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+	return "%" + s + "%"
+}
+
+// whereClause joins conditions into a WHERE clause, or returns an empty string
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return ` WHERE ` + strings.Join(conditions, ` AND `)
+}
+
+// orderClause builds an ORDER BY clause from a whitelist of sort columns,
+// newest first by default. The id tiebreak keeps pages stable.
+func orderClause(columns map[string]string, opts model.ListOptions) (string, error) {
+	column := "created_at"
+	if opts.Sort != "" {
+		var ok bool
+		column, ok = columns[opts.Sort]
+		if !ok {
+			return "", fmt.Errorf("unknown sort column: %s", opts.Sort)
+		}
+	}
+
+	direction := "DESC"
+	if opts.Order == model.SortAsc {
+		direction = "ASC"
+	}
+
+	return ` ORDER BY ` + column + ` ` + direction + ` NULLS LAST, id ` + direction, nil
+}
+
+// IsSortKey reports whether article and portfolio listings accept a sort key
+func IsSortKey(sort string) bool {
+	_, article := articleSortColumns[sort]
+	_, portfolio := portfolioSortColumns[sort]
+	return article && portfolio
+}
+
+// fieldList builds a SELECT list for a sparse fieldset from a whitelist of field expressions,
+// each aliased to its field name. id is always selected, first.
+func fieldList(columns map[string]string, fields []string) (string, error) {
+	selected := []string{"id"}
+	list := []string{columns["id"] + ` AS "id"`}
+	for _, field := range fields {
+		expr, ok := columns[field]
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrUnknownField, field)
+		}
+		if slices.Contains(selected, field) {
+			continue
+		}
+		selected = append(selected, field)
+		list = append(list, expr+` AS "`+field+`"`)
+	}
+	return strings.Join(list, ", "), nil
+}
+
+// queryFields runs a query selected with fieldList and returns the rows keyed by field name.
+// JSON columns are passed through as they are stored.
+func queryFields(ctx context.Context, db DBTX, query string, args ...interface{}) ([]model.PartialItem, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	items := []model.PartialItem{}
+	for rows.Next() {
+		values := make([]interface{}, len(names))
+		dest := make([]interface{}, len(names))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		item := make(model.PartialItem, len(names))
+		for i, name := range names {
+			if raw, ok := values[i].([]byte); ok {
+				item[name] = json.RawMessage(raw)
+			} else {
+				item[name] = values[i]
+			}
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// RedirectRepository defines methods for redirect repository
+type RedirectRepository interface {
+	Create(ctx context.Context, redirect *model.RedirectCreate) (string, error)
+	Update(ctx context.Context, id string, redirect *model.RedirectUpdate) error
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (*model.Redirect, error)
+	List(ctx context.Context) ([]model.Redirect, error)
+	Resolve(ctx context.Context, code string) (*model.Redirect, error)
+}
+
+// redirectRepository is the implementation of RedirectRepository
+type redirectRepository struct {
+	db *sqlx.DB
+}
+
+// NewRedirectRepository creates a new RedirectRepository
+func NewRedirectRepository(db *sqlx.DB) RedirectRepository {
+	return &redirectRepository{db: db}
+}
+
+// redirectColumns is the column list matching scanRedirect
+const redirectColumns = `id, code, target_url, permanent, clicks, last_clicked_at, created_at, updated_at`
+
+// Create creates a new redirect
+func (r *redirectRepository) Create(ctx context.Context, redirect *model.RedirectCreate) (string, error) {
+	query := `INSERT INTO redirects (id, code, target_url, permanent)
+			  VALUES ($1, $2, $3, $4)
+			  RETURNING id`
+
+	var id string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, newID(), redirect.Code, redirect.TargetURL, redirect.Permanent).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// Update updates a redirect
+func (r *redirectRepository) Update(ctx context.Context, id string, redirect *model.RedirectUpdate) error {
+	query := `UPDATE redirects
+			  SET code = $2, target_url = $3, permanent = $4, updated_at = $5
+			  WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, redirect.Code, redirect.TargetURL, redirect.Permanent, time.Now())
+	return err
+}
+
+// Delete deletes a redirect
+func (r *redirectRepository) Delete(ctx context.Context, id string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM redirects WHERE id = $1`, id)
+	return err
+}
+
+// GetByID gets a redirect by ID
+func (r *redirectRepository) GetByID(ctx context.Context, id string) (*model.Redirect, error) {
+	query := `SELECT ` + redirectColumns + ` FROM redirects WHERE id = $1`
+
+	redirect, err := scanRedirect(conn(ctx, r.db).QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("redirect not found")
+		}
+		return nil, err
+	}
+
+	return redirect, nil
+}
+
+// List lists all redirects, most clicked first
+func (r *redirectRepository) List(ctx context.Context) ([]model.Redirect, error) {
+	query := `SELECT ` + redirectColumns + ` FROM redirects ORDER BY clicks DESC, created_at DESC`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	redirects := []model.Redirect{}
+	for rows.Next() {
+		redirect, err := scanRedirect(rows)
+		if err != nil {
+			return nil, err
+		}
+		redirects = append(redirects, *redirect)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return redirects, nil
+}
+
+// Resolve looks up a redirect by code and counts the click in the same statement
+func (r *redirectRepository) Resolve(ctx context.Context, code string) (*model.Redirect, error) {
+	query := `UPDATE redirects
+			  SET clicks = clicks + 1, last_clicked_at = $2
+			  WHERE code = $1
+			  RETURNING ` + redirectColumns
+
+	redirect, err := scanRedirect(conn(ctx, r.db).QueryRowContext(ctx, query, code, time.Now()))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("redirect not found")
+		}
+		return nil, err
+	}
+
+	return redirect, nil
+}
+
+// scanRedirect scans a redirect row selected with redirectColumns
+func scanRedirect(row rowScanner) (*model.Redirect, error) {
+	var redirect model.Redirect
+	var lastClickedAt sql.NullTime
+
+	err := row.Scan(
+		&redirect.ID,
+		&redirect.Code,
+		&redirect.TargetURL,
+		&redirect.Permanent,
+		&redirect.Clicks,
+		&lastClickedAt,
+		&redirect.CreatedAt,
+		&redirect.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	redirect.LastClickedAt = timePtr(lastClickedAt)
+
+	return &redirect, nil
+}
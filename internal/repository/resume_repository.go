@@ -0,0 +1,448 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// ResumeRepository defines methods for resume repository
+type ResumeRepository interface {
+	ListExperiences(ctx context.Context) ([]model.Experience, error)
+	CreateExperience(ctx context.Context, experience *model.ExperienceCreate) (string, error)
+	UpdateExperience(ctx context.Context, id string, experience *model.ExperienceUpdate) error
+	DeleteExperience(ctx context.Context, id string) error
+
+	ListEducations(ctx context.Context) ([]model.Education, error)
+	CreateEducation(ctx context.Context, education *model.EducationCreate) (string, error)
+	UpdateEducation(ctx context.Context, id string, education *model.EducationUpdate) error
+	DeleteEducation(ctx context.Context, id string) error
+
+	ListCertifications(ctx context.Context) ([]model.Certification, error)
+	CreateCertification(ctx context.Context, certification *model.CertificationCreate) (string, error)
+	UpdateCertification(ctx context.Context, id string, certification *model.CertificationUpdate) error
+	DeleteCertification(ctx context.Context, id string) error
+
+	ListSkills(ctx context.Context) ([]model.Skill, error)
+	CreateSkill(ctx context.Context, skill *model.SkillCreate) (string, error)
+	UpdateSkill(ctx context.Context, id string, skill *model.SkillUpdate) error
+	DeleteSkill(ctx context.Context, id string) error
+}
+
+// resumeRepository is the implementation of ResumeRepository
+type resumeRepository struct {
+	db *sqlx.DB
+}
+
+// NewResumeRepository creates a new ResumeRepository
+func NewResumeRepository(db *sqlx.DB) ResumeRepository {
+	return &resumeRepository{db: db}
+}
+
+// ListExperiences lists work experiences, most recent first within the same sort order
+func (r *resumeRepository) ListExperiences(ctx context.Context) ([]model.Experience, error) {
+	query := `SELECT id, company, position, location, description, start_date, end_date, sort_order, created_at, updated_at
+			  FROM experiences
+			  ORDER BY sort_order ASC, start_date DESC`
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	experiences := []model.Experience{}
+	for rows.Next() {
+		var experience model.Experience
+		var location, description sql.NullString
+		var endDate sql.NullTime
+
+		err := rows.Scan(
+			&experience.ID,
+			&experience.Company,
+			&experience.Position,
+			&location,
+			&description,
+			&experience.StartDate.Time,
+			&endDate,
+			&experience.SortOrder,
+			&experience.CreatedAt,
+			&experience.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		experience.Location = location.String
+		experience.Description = description.String
+		experience.EndDate = datePtr(endDate)
+
+		experiences = append(experiences, experience)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return experiences, nil
+}
+
+// CreateExperience creates a work experience
+func (r *resumeRepository) CreateExperience(ctx context.Context, experience *model.ExperienceCreate) (string, error) {
+	query := `INSERT INTO experiences (id, company, position, location, description, start_date, end_date, sort_order)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			  RETURNING id`
+
+	var id string
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx, query,
+		newID(),
+		experience.Company,
+		experience.Position,
+		nullString(experience.Location),
+		nullString(experience.Description),
+		experience.StartDate.Time,
+		nullDate(experience.EndDate),
+		experience.SortOrder,
+	).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// UpdateExperience updates a work experience
+func (r *resumeRepository) UpdateExperience(ctx context.Context, id string, experience *model.ExperienceUpdate) error {
+	query := `UPDATE experiences
+			  SET company = $2, position = $3, location = $4, description = $5, start_date = $6, end_date = $7, sort_order = $8, updated_at = $9
+			  WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx, query,
+		id,
+		experience.Company,
+		experience.Position,
+		nullString(experience.Location),
+		nullString(experience.Description),
+		experience.StartDate.Time,
+		nullDate(experience.EndDate),
+		experience.SortOrder,
+		time.Now(),
+	)
+	return err
+}
+
+// DeleteExperience deletes a work experience
+func (r *resumeRepository) DeleteExperience(ctx context.Context, id string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM experiences WHERE id = $1`, id)
+	return err
+}
+
+// ListEducations lists education entries, most recent first within the same sort order
+func (r *resumeRepository) ListEducations(ctx context.Context) ([]model.Education, error) {
+	query := `SELECT id, institution, degree, field_of_study, description, start_date, end_date, sort_order, created_at, updated_at
+			  FROM educations
+			  ORDER BY sort_order ASC, start_date DESC`
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	educations := []model.Education{}
+	for rows.Next() {
+		var education model.Education
+		var fieldOfStudy, description sql.NullString
+		var endDate sql.NullTime
+
+		err := rows.Scan(
+			&education.ID,
+			&education.Institution,
+			&education.Degree,
+			&fieldOfStudy,
+			&description,
+			&education.StartDate.Time,
+			&endDate,
+			&education.SortOrder,
+			&education.CreatedAt,
+			&education.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		education.FieldOfStudy = fieldOfStudy.String
+		education.Description = description.String
+		education.EndDate = datePtr(endDate)
+
+		educations = append(educations, education)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return educations, nil
+}
+
+// CreateEducation creates an education entry
+func (r *resumeRepository) CreateEducation(ctx context.Context, education *model.EducationCreate) (string, error) {
+	query := `INSERT INTO educations (id, institution, degree, field_of_study, description, start_date, end_date, sort_order)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			  RETURNING id`
+
+	var id string
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx, query,
+		newID(),
+		education.Institution,
+		education.Degree,
+		nullString(education.FieldOfStudy),
+		nullString(education.Description),
+		education.StartDate.Time,
+		nullDate(education.EndDate),
+		education.SortOrder,
+	).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// UpdateEducation updates an education entry
+func (r *resumeRepository) UpdateEducation(ctx context.Context, id string, education *model.EducationUpdate) error {
+	query := `UPDATE educations
+			  SET institution = $2, degree = $3, field_of_study = $4, description = $5, start_date = $6, end_date = $7, sort_order = $8, updated_at = $9
+			  WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx, query,
+		id,
+		education.Institution,
+		education.Degree,
+		nullString(education.FieldOfStudy),
+		nullString(education.Description),
+		education.StartDate.Time,
+		nullDate(education.EndDate),
+		education.SortOrder,
+		time.Now(),
+	)
+	return err
+}
+
+// DeleteEducation deletes an education entry
+func (r *resumeRepository) DeleteEducation(ctx context.Context, id string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM educations WHERE id = $1`, id)
+	return err
+}
+
+// ListCertifications lists certifications, most recent first within the same sort order
+func (r *resumeRepository) ListCertifications(ctx context.Context) ([]model.Certification, error) {
+	query := `SELECT id, name, issuer, issued_at, expires_at, credential_id, credential_url, sort_order, created_at, updated_at
+			  FROM certifications
+			  ORDER BY sort_order ASC, issued_at DESC`
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	certifications := []model.Certification{}
+	for rows.Next() {
+		var certification model.Certification
+		var expiresAt sql.NullTime
+		var credentialID, credentialURL sql.NullString
+
+		err := rows.Scan(
+			&certification.ID,
+			&certification.Name,
+			&certification.Issuer,
+			&certification.IssuedAt.Time,
+			&expiresAt,
+			&credentialID,
+			&credentialURL,
+			&certification.SortOrder,
+			&certification.CreatedAt,
+			&certification.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		certification.ExpiresAt = datePtr(expiresAt)
+		certification.CredentialID = credentialID.String
+		certification.CredentialURL = credentialURL.String
+
+		certifications = append(certifications, certification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return certifications, nil
+}
+
+// CreateCertification creates a certification
+func (r *resumeRepository) CreateCertification(ctx context.Context, certification *model.CertificationCreate) (string, error) {
+	query := `INSERT INTO certifications (id, name, issuer, issued_at, expires_at, credential_id, credential_url, sort_order)
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			  RETURNING id`
+
+	var id string
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx, query,
+		newID(),
+		certification.Name,
+		certification.Issuer,
+		certification.IssuedAt.Time,
+		nullDate(certification.ExpiresAt),
+		nullString(certification.CredentialID),
+		nullString(certification.CredentialURL),
+		certification.SortOrder,
+	).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// UpdateCertification updates a certification
+func (r *resumeRepository) UpdateCertification(ctx context.Context, id string, certification *model.CertificationUpdate) error {
+	query := `UPDATE certifications
+			  SET name = $2, issuer = $3, issued_at = $4, expires_at = $5, credential_id = $6, credential_url = $7, sort_order = $8, updated_at = $9
+			  WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx, query,
+		id,
+		certification.Name,
+		certification.Issuer,
+		certification.IssuedAt.Time,
+		nullDate(certification.ExpiresAt),
+		nullString(certification.CredentialID),
+		nullString(certification.CredentialURL),
+		certification.SortOrder,
+		time.Now(),
+	)
+	return err
+}
+
+// DeleteCertification deletes a certification
+func (r *resumeRepository) DeleteCertification(ctx context.Context, id string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM certifications WHERE id = $1`, id)
+	return err
+}
+
+// ListSkills lists skills grouped by category
+func (r *resumeRepository) ListSkills(ctx context.Context) ([]model.Skill, error) {
+	query := `SELECT id, name, category, level, sort_order, created_at, updated_at
+			  FROM skills
+			  ORDER BY category ASC NULLS LAST, sort_order ASC, name ASC`
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skills := []model.Skill{}
+	for rows.Next() {
+		var skill model.Skill
+		var category sql.NullString
+
+		err := rows.Scan(
+			&skill.ID,
+			&skill.Name,
+			&category,
+			&skill.Level,
+			&skill.SortOrder,
+			&skill.CreatedAt,
+			&skill.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		skill.Category = category.String
+
+		skills = append(skills, skill)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return skills, nil
+}
+
+// CreateSkill creates a skill
+func (r *resumeRepository) CreateSkill(ctx context.Context, skill *model.SkillCreate) (string, error) {
+	query := `INSERT INTO skills (id, name, category, level, sort_order)
+			  VALUES ($1, $2, $3, $4, $5)
+			  RETURNING id`
+
+	var id string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, newID(), skill.Name, nullString(skill.Category), skill.Level, skill.SortOrder).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// UpdateSkill updates a skill
+func (r *resumeRepository) UpdateSkill(ctx context.Context, id string, skill *model.SkillUpdate) error {
+	query := `UPDATE skills
+			  SET name = $2, category = $3, level = $4, sort_order = $5, updated_at = $6
+			  WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, skill.Name, nullString(skill.Category), skill.Level, skill.SortOrder, time.Now())
+	return err
+}
+
+// DeleteSkill deletes a skill
+func (r *resumeRepository) DeleteSkill(ctx context.Context, id string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM skills WHERE id = $1`, id)
+	return err
+}
+
+// nullTime converts a nil time to NULL
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+// timePtr converts a nullable time to a pointer
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// nullDate converts a nil date to NULL
+func nullDate(d *model.Date) sql.NullTime {
+	if d == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: d.Time, Valid: true}
+}
+
+// datePtr converts a nullable date to a pointer
+func datePtr(t sql.NullTime) *model.Date {
+	if !t.Valid {
+		return nil
+	}
+	return &model.Date{Time: t.Time}
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+)
+
+// RevalidationRepository calls the frontend's on-demand revalidation endpoint
+type RevalidationRepository struct {
+	url    string
+	secret string
+	client *outboundClient
+}
+
+// NewRevalidationRepository creates a new revalidation repository
+func NewRevalidationRepository(cfg config.Config) *RevalidationRepository {
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+
+	return &RevalidationRepository{
+		url:    cfg.RevalidateURL,
+		secret: cfg.RevalidateSecret,
+		client: newOutboundClient("revalidate", httpClient, cfg),
+	}
+}
+
+// Revalidate asks the frontend to regenerate the given paths. The secret is sent as a
+// bearer token and the paths as {"paths": [...]}.
+func (r *RevalidationRepository) Revalidate(ctx context.Context, paths []string) error {
+	body, err := json.Marshal(map[string][]string{"paths": paths})
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.client.do(ctx, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+r.secret)
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("revalidation returned status %d", resp.StatusCode)
+	}
+	return nil
+}
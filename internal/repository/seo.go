@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"github.com/budhilaw/personal-website-backend/internal/model"
+)
+
+// seoColumns selects the SEO metadata columns, matching seoDest
+const seoColumns = `COALESCE(meta_title, ''), COALESCE(meta_description, ''), COALESCE(canonical_url, ''), COALESCE(og_image, '')`
+
+// seoDest returns scan destinations for seoColumns
+func seoDest(seo *model.SEOMeta) []interface{} {
+	return []interface{}{&seo.MetaTitle, &seo.MetaDescription, &seo.CanonicalURL, &seo.OGImage}
+}
+
+// seoArgs returns the SEO metadata as query arguments, storing empty values as NULL
+func seoArgs(seo model.SEOMeta) []interface{} {
+	return []interface{}{
+		nullString(seo.MetaTitle),
+		nullString(seo.MetaDescription),
+		nullString(seo.CanonicalURL),
+		nullString(seo.OGImage),
+	}
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"github.com/jmoiron/sqlx"
+)
+
+// SeriesRepository defines methods for series repository
+type SeriesRepository interface {
+	Create(ctx context.Context, series *model.SeriesCreate, userID string) (string, error)
+	Update(ctx context.Context, id string, series *model.SeriesUpdate) error
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (*model.Series, error)
+	GetBySlug(ctx context.Context, slug string) (*model.Series, error)
+	List(ctx context.Context) ([]model.Series, error)
+}
+
+// seriesRepository is the implementation of SeriesRepository
+type seriesRepository struct {
+	db *sqlx.DB
+}
+
+// NewSeriesRepository creates a new SeriesRepository
+func NewSeriesRepository(db *sqlx.DB) SeriesRepository {
+	return &seriesRepository{db: db}
+}
+
+const seriesColumns = `id, title, slug, description, user_id, created_at, updated_at`
+
+// Create creates a new series
+func (r *seriesRepository) Create(ctx context.Context, series *model.SeriesCreate, userID string) (string, error) {
+	query := `INSERT INTO series (id, title, slug, description, user_id)
+			  VALUES ($1, $2, $3, $4, $5)
+			  RETURNING id`
+
+	var id string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, newID(), series.Title, util.GenerateSlug(series.Title), series.Description, userID).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// Update updates a series
+func (r *seriesRepository) Update(ctx context.Context, id string, series *model.SeriesUpdate) error {
+	query := `UPDATE series
+			  SET title = $2, slug = $3, description = $4, updated_at = $5
+			  WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, series.Title, util.GenerateSlug(series.Title), series.Description, time.Now())
+	return err
+}
+
+// Delete deletes a series, detaching its articles
+func (r *seriesRepository) Delete(ctx context.Context, id string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM series WHERE id = $1`, id)
+	return err
+}
+
+// GetByID gets a series by ID
+func (r *seriesRepository) GetByID(ctx context.Context, id string) (*model.Series, error) {
+	query := `SELECT ` + seriesColumns + ` FROM series WHERE id = $1`
+	return r.getOne(ctx, query, id)
+}
+
+// GetBySlug gets a series by slug
+func (r *seriesRepository) GetBySlug(ctx context.Context, slug string) (*model.Series, error) {
+	query := `SELECT ` + seriesColumns + ` FROM series WHERE slug = $1`
+	return r.getOne(ctx, query, slug)
+}
+
+// List lists all series
+func (r *seriesRepository) List(ctx context.Context) ([]model.Series, error) {
+	query := `SELECT ` + seriesColumns + ` FROM series ORDER BY created_at DESC`
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []model.Series
+	for rows.Next() {
+		series, err := scanSeries(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, *series)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// getOne runs a query expected to return a single series
+func (r *seriesRepository) getOne(ctx context.Context, query string, args ...interface{}) (*model.Series, error) {
+	series, err := scanSeries(readConn(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("series not found")
+		}
+		return nil, err
+	}
+
+	return series, nil
+}
+
+// scanSeries scans a series row selected with seriesColumns
+func scanSeries(row rowScanner) (*model.Series, error) {
+	var series model.Series
+	var description sql.NullString
+
+	err := row.Scan(
+		&series.ID,
+		&series.Title,
+		&series.Slug,
+		&description,
+		&series.UserID,
+		&series.CreatedAt,
+		&series.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if description.Valid {
+		series.Description = description.String
+	}
+
+	return &series, nil
+}
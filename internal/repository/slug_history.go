@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+)
+
+// Entity types tracked in slug_history
+const (
+	slugEntityArticle   = "article"
+	slugEntityPortfolio = "portfolio"
+)
+
+// recordSlugChange keeps the previous slug so old links keep resolving.
+// The new slug is dropped from history since it is live again.
+func recordSlugChange(ctx context.Context, tx DBTX, entityType, entityID, oldSlug, newSlug string) error {
+	if oldSlug == newSlug {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx,
+		`DELETE FROM slug_history WHERE entity_type = $1 AND slug = $2`,
+		entityType, newSlug,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO slug_history (id, entity_type, entity_id, slug)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (entity_type, slug) DO UPDATE SET entity_id = EXCLUDED.entity_id, created_at = CURRENT_TIMESTAMP`,
+		newID(), entityType, entityID, oldSlug,
+	)
+	return err
+}
+
+// resolveSlugHistory returns the ID of the entity that previously used slug
+func resolveSlugHistory(ctx context.Context, db DBTX, entityType, slug string) (string, error) {
+	var entityID string
+	err := db.QueryRowContext(ctx,
+		`SELECT entity_id FROM slug_history WHERE entity_type = $1 AND slug = $2`,
+		entityType, slug,
+	).Scan(&entityID)
+	if err != nil {
+		return "", err
+	}
+
+	return entityID, nil
+}
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"go.uber.org/zap"
+)
+
+const (
+	spotifyTokenURL = "https://accounts.spotify.com/api/token"
+	spotifyAPIURL   = "https://api.spotify.com/v1"
+	// maxSpotifyResponse bounds Spotify responses read into memory
+	maxSpotifyResponse = 1 << 20
+)
+
+// errSpotifyUnauthorized means the access token was rejected and should be refreshed
+var errSpotifyUnauthorized = errors.New("spotify access token rejected")
+
+// SpotifyRepository reads the player state of the configured Spotify account
+type SpotifyRepository struct {
+	clientID     string
+	clientSecret string
+	refreshToken string
+	httpClient   *http.Client
+	logger       *zap.Logger
+
+	mutex       sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// NewSpotifyRepository creates a new Spotify repository
+func NewSpotifyRepository(cfg config.Config, logger *zap.Logger) *SpotifyRepository {
+	return &SpotifyRepository{
+		clientID:     cfg.SpotifyClientID,
+		clientSecret: cfg.SpotifyClientSecret,
+		refreshToken: cfg.SpotifyRefreshToken,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+// spotifyCurrentlyPlaying is the part of the currently-playing response we use
+type spotifyCurrentlyPlaying struct {
+	IsPlaying  bool `json:"is_playing"`
+	ProgressMs int  `json:"progress_ms"`
+	Item       *struct {
+		Name         string `json:"name"`
+		DurationMs   int    `json:"duration_ms"`
+		ExternalURLs struct {
+			Spotify string `json:"spotify"`
+		} `json:"external_urls"`
+		Album struct {
+			Name   string `json:"name"`
+			Images []struct {
+				URL string `json:"url"`
+			} `json:"images"`
+		} `json:"album"`
+		Artists []struct {
+			Name string `json:"name"`
+		} `json:"artists"`
+	} `json:"item"`
+}
+
+// CurrentlyPlaying returns the track playing right now; IsPlaying is false when nothing
+// is playing or the player is on something other than a track, like a podcast episode
+func (r *SpotifyRepository) CurrentlyPlaying(ctx context.Context) (*model.NowPlaying, error) {
+	playing, err := r.currentlyPlaying(ctx)
+	if errors.Is(err, errSpotifyUnauthorized) {
+		// The token may have been revoked before it expired, try once more with a fresh one
+		r.mutex.Lock()
+		r.accessToken = ""
+		r.mutex.Unlock()
+		playing, err = r.currentlyPlaying(ctx)
+	}
+	if err != nil {
+		r.logger.Error("Failed to fetch Spotify currently playing", zap.Error(err))
+		return nil, err
+	}
+
+	nowPlaying := &model.NowPlaying{UpdatedAt: time.Now()}
+	if playing == nil || playing.Item == nil {
+		return nowPlaying, nil
+	}
+
+	nowPlaying.IsPlaying = playing.IsPlaying
+	nowPlaying.Title = playing.Item.Name
+	nowPlaying.Album = playing.Item.Album.Name
+	nowPlaying.SongURL = playing.Item.ExternalURLs.Spotify
+	nowPlaying.ProgressMs = playing.ProgressMs
+	nowPlaying.DurationMs = playing.Item.DurationMs
+	for _, artist := range playing.Item.Artists {
+		nowPlaying.Artists = append(nowPlaying.Artists, artist.Name)
+	}
+	// Images are sorted widest first
+	if len(playing.Item.Album.Images) > 0 {
+		nowPlaying.AlbumImageURL = playing.Item.Album.Images[0].URL
+	}
+
+	return nowPlaying, nil
+}
+
+// currentlyPlaying calls the currently-playing endpoint; nil means nothing is playing
+func (r *SpotifyRepository) currentlyPlaying(ctx context.Context) (*spotifyCurrentlyPlaying, error) {
+	token, err := r.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, spotifyAPIURL+"/me/player/currently-playing", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNoContent:
+		return nil, nil
+	case http.StatusUnauthorized:
+		return nil, errSpotifyUnauthorized
+	default:
+		return nil, fmt.Errorf("spotify API returned status %d", resp.StatusCode)
+	}
+
+	var playing spotifyCurrentlyPlaying
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxSpotifyResponse)).Decode(&playing); err != nil {
+		return nil, err
+	}
+	return &playing, nil
+}
+
+// token returns a valid access token, exchanging the refresh token when the current one is about to expire
+func (r *SpotifyRepository) token(ctx context.Context) (string, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.accessToken != "" && time.Now().Add(time.Minute).Before(r.expiresAt) {
+		return r.accessToken, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", r.refreshToken)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, spotifyTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(r.clientID, r.clientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("spotify token endpoint returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		AccessToken  string `json:"access_token"`
+		ExpiresIn    int    `json:"expires_in"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxSpotifyResponse)).Decode(&body); err != nil {
+		return "", err
+	}
+	if body.AccessToken == "" {
+		return "", errors.New("spotify token endpoint returned no access token")
+	}
+
+	r.accessToken = body.AccessToken
+	r.expiresAt = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	// Spotify may rotate the refresh token; the new one replaces ours until restart
+	if body.RefreshToken != "" {
+		r.refreshToken = body.RefreshToken
+	}
+
+	return r.accessToken, nil
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// ErrSubscriberNotFound is returned when no subscriber matches the lookup
+var ErrSubscriberNotFound = errors.New("subscriber not found")
+
+// SubscriberRepository defines methods for newsletter subscriber repository
+type SubscriberRepository interface {
+	Create(ctx context.Context, email, confirmToken, unsubscribeToken string) (string, error)
+	GetByEmail(ctx context.Context, email string) (*model.Subscriber, error)
+	GetByConfirmToken(ctx context.Context, token string) (*model.Subscriber, error)
+	GetByUnsubscribeToken(ctx context.Context, token string) (*model.Subscriber, error)
+	ResetPending(ctx context.Context, id, confirmToken string) error
+	Confirm(ctx context.Context, id string) error
+	Unsubscribe(ctx context.Context, id string) error
+	List(ctx context.Context, page, perPage int, status string) ([]model.Subscriber, int, error)
+	ListAll(ctx context.Context, status string) ([]model.Subscriber, error)
+}
+
+// subscriberRepository is the implementation of SubscriberRepository
+type subscriberRepository struct {
+	db *sqlx.DB
+}
+
+// NewSubscriberRepository creates a new SubscriberRepository
+func NewSubscriberRepository(db *sqlx.DB) SubscriberRepository {
+	return &subscriberRepository{db: db}
+}
+
+const subscriberColumns = `id, email, status, confirm_token, unsubscribe_token, confirmation_sent_at, confirmed_at, unsubscribed_at, created_at, updated_at`
+
+// Create creates a new pending subscriber
+func (r *subscriberRepository) Create(ctx context.Context, email, confirmToken, unsubscribeToken string) (string, error) {
+	query := `INSERT INTO subscribers (id, email, status, confirm_token, unsubscribe_token, confirmation_sent_at) 
+			  VALUES ($1, $2, $3, $4, $5, $6) 
+			  RETURNING id`
+
+	var id string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, newID(), email, model.SubscriberPending, confirmToken, unsubscribeToken, time.Now()).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// GetByEmail gets a subscriber by email
+func (r *subscriberRepository) GetByEmail(ctx context.Context, email string) (*model.Subscriber, error) {
+	query := `SELECT ` + subscriberColumns + ` FROM subscribers WHERE email = $1`
+	return r.getOne(ctx, query, email)
+}
+
+// GetByConfirmToken gets a subscriber by confirmation token
+func (r *subscriberRepository) GetByConfirmToken(ctx context.Context, token string) (*model.Subscriber, error) {
+	query := `SELECT ` + subscriberColumns + ` FROM subscribers WHERE confirm_token = $1`
+	return r.getOne(ctx, query, token)
+}
+
+// GetByUnsubscribeToken gets a subscriber by unsubscribe token
+func (r *subscriberRepository) GetByUnsubscribeToken(ctx context.Context, token string) (*model.Subscriber, error) {
+	query := `SELECT ` + subscriberColumns + ` FROM subscribers WHERE unsubscribe_token = $1`
+	return r.getOne(ctx, query, token)
+}
+
+// ResetPending puts a subscriber back into pending state with a fresh confirmation token
+func (r *subscriberRepository) ResetPending(ctx context.Context, id, confirmToken string) error {
+	query := `UPDATE subscribers 
+			  SET status = $2, confirm_token = $3, confirmation_sent_at = $4, unsubscribed_at = NULL, updated_at = $4
+			  WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, model.SubscriberPending, confirmToken, time.Now())
+	return err
+}
+
+// Confirm marks a subscriber as confirmed and clears the confirmation token
+func (r *subscriberRepository) Confirm(ctx context.Context, id string) error {
+	query := `UPDATE subscribers 
+			  SET status = $2, confirm_token = NULL, confirmed_at = $3, updated_at = $3
+			  WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, model.SubscriberConfirmed, time.Now())
+	return err
+}
+
+// Unsubscribe marks a subscriber as unsubscribed
+func (r *subscriberRepository) Unsubscribe(ctx context.Context, id string) error {
+	query := `UPDATE subscribers 
+			  SET status = $2, confirm_token = NULL, unsubscribed_at = $3, updated_at = $3
+			  WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, model.SubscriberUnsubscribed, time.Now())
+	return err
+}
+
+// List lists subscribers with pagination, optionally filtered by status
+func (r *subscriberRepository) List(ctx context.Context, page, perPage int, status string) ([]model.Subscriber, int, error) {
+	offset := (page - 1) * perPage
+
+	// Count total
+	var total int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM subscribers WHERE ($1 = '' OR status = $1)`, status).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + subscriberColumns + ` 
+			  FROM subscribers 
+			  WHERE ($1 = '' OR status = $1) 
+			  ORDER BY created_at DESC 
+			  LIMIT $2 OFFSET $3`
+
+	subscribers, err := r.getMany(ctx, query, status, perPage, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return subscribers, total, nil
+}
+
+// ListAll lists every subscriber, optionally filtered by status
+func (r *subscriberRepository) ListAll(ctx context.Context, status string) ([]model.Subscriber, error) {
+	query := `SELECT ` + subscriberColumns + ` 
+			  FROM subscribers 
+			  WHERE ($1 = '' OR status = $1) 
+			  ORDER BY created_at ASC`
+
+	return r.getMany(ctx, query, status)
+}
+
+// getOne runs a query expected to return a single subscriber
+func (r *subscriberRepository) getOne(ctx context.Context, query string, args ...interface{}) (*model.Subscriber, error) {
+	subscriber, err := scanSubscriber(conn(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSubscriberNotFound
+		}
+		return nil, err
+	}
+
+	return subscriber, nil
+}
+
+// getMany runs a query returning a list of subscribers
+func (r *subscriberRepository) getMany(ctx context.Context, query string, args ...interface{}) ([]model.Subscriber, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscribers []model.Subscriber
+	for rows.Next() {
+		subscriber, err := scanSubscriber(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscribers = append(subscribers, *subscriber)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subscribers, nil
+}
+
+// scanSubscriber scans a subscriber row
+func scanSubscriber(row rowScanner) (*model.Subscriber, error) {
+	var subscriber model.Subscriber
+	var confirmToken sql.NullString
+	var confirmationSentAt, confirmedAt, unsubscribedAt sql.NullTime
+
+	err := row.Scan(
+		&subscriber.ID,
+		&subscriber.Email,
+		&subscriber.Status,
+		&confirmToken,
+		&subscriber.UnsubscribeToken,
+		&confirmationSentAt,
+		&confirmedAt,
+		&unsubscribedAt,
+		&subscriber.CreatedAt,
+		&subscriber.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set the nullable fields
+	if confirmToken.Valid {
+		subscriber.ConfirmToken = confirmToken.String
+	}
+	if confirmationSentAt.Valid {
+		subscriber.ConfirmationSentAt = confirmationSentAt.Time
+	}
+	if confirmedAt.Valid {
+		subscriber.ConfirmedAt = confirmedAt.Time
+	}
+	if unsubscribedAt.Valid {
+		subscriber.UnsubscribedAt = unsubscribedAt.Time
+	}
+
+	return &subscriber, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// TagRepository defines methods for tag repository
+type TagRepository interface {
+	Upsert(ctx context.Context, name, slug string) (*model.Tag, error)
+	SetArticleTags(ctx context.Context, articleID string, tagIDs []string) error
+	ListByArticle(ctx context.Context, articleID string) ([]model.Tag, error)
+}
+
+// tagRepository is the implementation of TagRepository
+type tagRepository struct {
+	db *sqlx.DB
+}
+
+// NewTagRepository creates a new TagRepository
+func NewTagRepository(db *sqlx.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+// tagColumns is the column list matching scanTag
+const tagColumns = `id, name, slug, created_at`
+
+// Upsert gets the tag with the slug, creating it with the name if there is none
+func (r *tagRepository) Upsert(ctx context.Context, name, slug string) (*model.Tag, error) {
+	// The no-op update makes RETURNING include an existing row
+	query := `INSERT INTO tags (id, name, slug)
+			  VALUES ($1, $2, $3)
+			  ON CONFLICT (slug) DO UPDATE SET slug = EXCLUDED.slug
+			  RETURNING ` + tagColumns
+
+	return scanTag(conn(ctx, r.db).QueryRowContext(ctx, query, newID(), name, slug))
+}
+
+// SetArticleTags replaces the tags of an article
+func (r *tagRepository) SetArticleTags(ctx context.Context, articleID string, tagIDs []string) error {
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM article_tags WHERE article_id = $1`, articleID); err != nil {
+			return err
+		}
+
+		for _, tagID := range tagIDs {
+			_, err := tx.ExecContext(ctx,
+				`INSERT INTO article_tags (article_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+				articleID, tagID,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListByArticle lists the tags of an article by name
+func (r *tagRepository) ListByArticle(ctx context.Context, articleID string) ([]model.Tag, error) {
+	query := `SELECT t.id, t.name, t.slug, t.created_at
+			  FROM tags t
+			  JOIN article_tags a ON a.tag_id = t.id
+			  WHERE a.article_id = $1
+			  ORDER BY t.name`
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query, articleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := []model.Tag{}
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, *tag)
+	}
+
+	return tags, rows.Err()
+}
+
+// scanTag scans a tag row selected with tagColumns
+func scanTag(row rowScanner) (*model.Tag, error) {
+	var tag model.Tag
+	if err := row.Scan(&tag.ID, &tag.Name, &tag.Slug, &tag.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
@@ -2,7 +2,9 @@ package repository
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -13,11 +15,11 @@ import (
 
 // TelegramRepository handles API calls to Telegram
 type TelegramRepository struct {
-	botToken   string
-	chatID     string
-	topicID    int
-	httpClient *http.Client
-	logger     *zap.Logger
+	botToken string
+	chatID   string
+	topicID  int
+	client   *outboundClient
+	logger   *zap.Logger
 }
 
 // NewTelegramRepository creates a new Telegram repository
@@ -27,11 +29,11 @@ func NewTelegramRepository(cfg config.Config, logger *zap.Logger) *TelegramRepos
 	}
 
 	return &TelegramRepository{
-		botToken:   cfg.TelegramBotToken,
-		chatID:     cfg.TelegramChatID,
-		topicID:    cfg.TelegramTopicID,
-		httpClient: httpClient,
-		logger:     logger,
+		botToken: cfg.TelegramBotToken,
+		chatID:   cfg.TelegramChatID,
+		topicID:  cfg.TelegramTopicID,
+		client:   newOutboundClient("telegram", httpClient, cfg),
+		logger:   logger,
 	}
 }
 
@@ -39,7 +41,7 @@ func NewTelegramRepository(cfg config.Config, logger *zap.Logger) *TelegramRepos
 type TelegramMessage struct {
 	ChatID              string `json:"chat_id"`
 	Text                string `json:"text"`
-	ParseMode           string `json:"parse_mode"`
+	ParseMode           string `json:"parse_mode,omitempty"`
 	DisableNotification bool   `json:"disable_notification"`
 	MessageThreadID     int    `json:"message_thread_id,omitempty"`
 }
@@ -60,13 +62,35 @@ func (r *TelegramRepository) SendMessage(message string, disableNotification boo
 		msg.MessageThreadID = r.topicID
 	}
 
+	return r.send(url, msg)
+}
+
+// SendReply sends a plain text message to a chat, such as the answer to a bot command
+func (r *TelegramRepository) SendReply(chatID, message string) error {
+	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", r.botToken)
+
+	return r.send(url, TelegramMessage{
+		ChatID: chatID,
+		Text:   message,
+	})
+}
+
+// send posts a message to the Telegram API
+func (r *TelegramRepository) send(url string, msg TelegramMessage) error {
 	jsonData, err := json.Marshal(msg)
 	if err != nil {
 		r.logger.Error("Failed to marshal Telegram message", zap.Error(err))
 		return err
 	}
 
-	resp, err := r.httpClient.Post(url, "application/json", bytes.NewBuffer(jsonData))
+	resp, err := r.client.do(context.Background(), func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
 	if err != nil {
 		r.logger.Error("Failed to send Telegram message", zap.Error(err))
 		return err
@@ -83,3 +107,26 @@ func (r *TelegramRepository) SendMessage(message string, disableNotification boo
 	r.logger.Debug("Telegram message sent successfully")
 	return nil
 }
+
+// Ping checks that the Telegram API accepts the bot token. It skips the retries and
+// circuit breaker so a health check neither waits on backoff nor trips the breaker.
+func (r *TelegramRepository) Ping(ctx context.Context) error {
+	url := fmt.Sprintf("https://api.telegram.org/bot%s/getMe", r.botToken)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.client.httpClient.Do(req)
+	if err != nil {
+		// The error contains the URL and with it the bot token
+		return errors.New("telegram API is unreachable")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("telegram API returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// TranslationRepository defines methods for translation repository
+type TranslationRepository interface {
+	ListArticle(ctx context.Context, articleID string) ([]model.Translation, error)
+	ListArticles(ctx context.Context, articleIDs []string) (map[string][]model.Translation, error)
+	GetArticle(ctx context.Context, articleID, locale string) (*model.Translation, error)
+	UpsertArticle(ctx context.Context, articleID, locale string, translation *model.TranslationUpsert) error
+	DeleteArticle(ctx context.Context, articleID, locale string) error
+
+	ListPage(ctx context.Context, pageID string) ([]model.Translation, error)
+	GetPage(ctx context.Context, pageID, locale string) (*model.Translation, error)
+	UpsertPage(ctx context.Context, pageID, locale string, translation *model.TranslationUpsert) error
+	DeletePage(ctx context.Context, pageID, locale string) error
+}
+
+// translationRepository is the implementation of TranslationRepository
+type translationRepository struct {
+	db *sqlx.DB
+}
+
+// NewTranslationRepository creates a new TranslationRepository
+func NewTranslationRepository(db *sqlx.DB) TranslationRepository {
+	return &translationRepository{db: db}
+}
+
+// ListArticle lists all translations of an article
+func (r *translationRepository) ListArticle(ctx context.Context, articleID string) ([]model.Translation, error) {
+	query := `SELECT locale, title, content, COALESCE(excerpt, ''), created_at, updated_at
+			  FROM article_translations
+			  WHERE article_id = $1
+			  ORDER BY locale`
+
+	return r.queryTranslations(ctx, query, articleID)
+}
+
+// ListArticles lists the translations of several articles in one query, keyed by article ID
+func (r *translationRepository) ListArticles(ctx context.Context, articleIDs []string) (map[string][]model.Translation, error) {
+	translations := make(map[string][]model.Translation, len(articleIDs))
+	if len(articleIDs) == 0 {
+		return translations, nil
+	}
+
+	placeholders := make([]string, len(articleIDs))
+	args := make([]interface{}, len(articleIDs))
+	for i, id := range articleIDs {
+		args[i] = id
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+
+	query := `SELECT article_id, locale, title, content, COALESCE(excerpt, ''), created_at, updated_at
+			  FROM article_translations
+			  WHERE article_id IN (` + strings.Join(placeholders, ", ") + `)
+			  ORDER BY article_id, locale`
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var articleID string
+		var translation model.Translation
+		err := rows.Scan(
+			&articleID,
+			&translation.Locale,
+			&translation.Title,
+			&translation.Content,
+			&translation.Excerpt,
+			&translation.CreatedAt,
+			&translation.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		translations[articleID] = append(translations[articleID], translation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return translations, nil
+}
+
+// GetArticle gets an article translation
+func (r *translationRepository) GetArticle(ctx context.Context, articleID, locale string) (*model.Translation, error) {
+	query := `SELECT locale, title, content, COALESCE(excerpt, ''), created_at, updated_at
+			  FROM article_translations
+			  WHERE article_id = $1 AND locale = $2`
+
+	return r.getTranslation(ctx, query, articleID, locale)
+}
+
+// UpsertArticle creates or replaces an article translation
+func (r *translationRepository) UpsertArticle(ctx context.Context, articleID, locale string, translation *model.TranslationUpsert) error {
+	query := `INSERT INTO article_translations (article_id, locale, title, content, excerpt)
+			  VALUES ($1, $2, $3, $4, $5)
+			  ON CONFLICT (article_id, locale) DO UPDATE
+			  SET title = EXCLUDED.title, content = EXCLUDED.content, excerpt = EXCLUDED.excerpt, updated_at = $6`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, articleID, locale, translation.Title, translation.Content, nullString(translation.Excerpt), time.Now())
+	return err
+}
+
+// DeleteArticle deletes an article translation
+func (r *translationRepository) DeleteArticle(ctx context.Context, articleID, locale string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM article_translations WHERE article_id = $1 AND locale = $2`, articleID, locale)
+	return err
+}
+
+// ListPage lists all translations of a page
+func (r *translationRepository) ListPage(ctx context.Context, pageID string) ([]model.Translation, error) {
+	query := `SELECT locale, title, content, '', created_at, updated_at
+			  FROM page_translations
+			  WHERE page_id = $1
+			  ORDER BY locale`
+
+	return r.queryTranslations(ctx, query, pageID)
+}
+
+// GetPage gets a page translation
+func (r *translationRepository) GetPage(ctx context.Context, pageID, locale string) (*model.Translation, error) {
+	query := `SELECT locale, title, content, '', created_at, updated_at
+			  FROM page_translations
+			  WHERE page_id = $1 AND locale = $2`
+
+	return r.getTranslation(ctx, query, pageID, locale)
+}
+
+// UpsertPage creates or replaces a page translation
+func (r *translationRepository) UpsertPage(ctx context.Context, pageID, locale string, translation *model.TranslationUpsert) error {
+	query := `INSERT INTO page_translations (page_id, locale, title, content)
+			  VALUES ($1, $2, $3, $4)
+			  ON CONFLICT (page_id, locale) DO UPDATE
+			  SET title = EXCLUDED.title, content = EXCLUDED.content, updated_at = $5`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, pageID, locale, translation.Title, translation.Content, time.Now())
+	return err
+}
+
+// DeletePage deletes a page translation
+func (r *translationRepository) DeletePage(ctx context.Context, pageID, locale string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM page_translations WHERE page_id = $1 AND locale = $2`, pageID, locale)
+	return err
+}
+
+// queryTranslations runs a query returning a list of translations
+func (r *translationRepository) queryTranslations(ctx context.Context, query string, args ...interface{}) ([]model.Translation, error) {
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	translations := []model.Translation{}
+	for rows.Next() {
+		translation, err := scanTranslation(rows)
+		if err != nil {
+			return nil, err
+		}
+		translations = append(translations, *translation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return translations, nil
+}
+
+// getTranslation runs a query expected to return a single translation
+func (r *translationRepository) getTranslation(ctx context.Context, query string, args ...interface{}) (*model.Translation, error) {
+	translation, err := scanTranslation(readConn(ctx, r.db).QueryRowContext(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("translation not found")
+		}
+		return nil, err
+	}
+
+	return translation, nil
+}
+
+// scanTranslation scans a translation row
+func scanTranslation(row rowScanner) (*model.Translation, error) {
+	var translation model.Translation
+
+	err := row.Scan(
+		&translation.Locale,
+		&translation.Title,
+		&translation.Content,
+		&translation.Excerpt,
+		&translation.CreatedAt,
+		&translation.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &translation, nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// DBTX is the query interface shared by *sqlx.DB and *sqlx.Tx
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row
+}
+
+// TxManager runs several repository calls atomically
+type TxManager interface {
+	// WithinTx runs fn in a transaction that repository calls made with the ctx passed to fn join.
+	// It commits when fn returns nil and rolls back otherwise; nested calls join the outer transaction.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// txKey is the context key of the ambient transaction
+type txKey struct{}
+
+// txManager is the implementation of TxManager
+type txManager struct {
+	db *sqlx.DB
+}
+
+// NewTxManager creates a new TxManager
+func NewTxManager(db *sqlx.DB) TxManager {
+	return &txManager{db: db}
+}
+
+// WithinTx runs fn in a transaction
+func (m *txManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return beginTx(ctx, m.db, func(tx *sqlx.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// ReadReplicaKey is the request context key holding the read-replica pool.
+// Routes whose reads may lag behind the primary set it; see middleware.ReadReplica.
+type ReadReplicaKey struct{}
+
+// conn returns the ambient transaction, or db outside of one
+func conn(ctx context.Context, db *sqlx.DB) DBTX {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return instrument(tx)
+	}
+	return instrument(db)
+}
+
+// readConn is conn for read-only queries: outside a transaction it prefers the
+// read replica attached to the context, if any
+func readConn(ctx context.Context, db *sqlx.DB) DBTX {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return instrument(tx)
+	}
+	if replica, ok := ctx.Value(ReadReplicaKey{}).(*sqlx.DB); ok && replica != nil {
+		return instrument(replica)
+	}
+	return instrument(db)
+}
+
+// withTx runs fn in the ambient transaction, or in a new one committed when fn succeeds
+func withTx(ctx context.Context, db *sqlx.DB, fn func(tx DBTX) error) error {
+	return beginTx(ctx, db, func(tx *sqlx.Tx) error {
+		return fn(instrument(tx))
+	})
+}
+
+// beginTx is withTx handing fn the bare transaction
+func beginTx(ctx context.Context, db *sqlx.DB, fn func(tx *sqlx.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(*sqlx.Tx); ok {
+		return fn(tx)
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -12,6 +12,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// ErrUserNotFound is returned when no user matches the lookup
+var ErrUserNotFound = errors.New("user not found")
+
 // UserRepository defines methods for user repository
 type UserRepository interface {
 	GetByID(ctx context.Context, id string) (*model.User, error)
@@ -20,6 +23,10 @@ type UserRepository interface {
 	UpdateProfile(ctx context.Context, id string, user *model.ProfileUpdate) error
 	UpdateAvatar(ctx context.Context, id string, avatar string) error
 	UpdatePassword(ctx context.Context, id string, password string) error
+	List(ctx context.Context, page, perPage int) ([]model.User, int, error)
+	Create(ctx context.Context, user *model.UserCreate, password string) (string, error)
+	UpdateRole(ctx context.Context, id string, role string) error
+	SetActive(ctx context.Context, id string, active bool) error
 }
 
 // userRepository is the implementation of UserRepository
@@ -34,14 +41,14 @@ func NewUserRepository(db *sqlx.DB) UserRepository {
 
 // GetByID gets a user by ID
 func (r *userRepository) GetByID(ctx context.Context, id string) (*model.User, error) {
-	query := `SELECT id, username, password, email, first_name, last_name, avatar, bio, is_admin, created_at, updated_at 
+	query := `SELECT id, username, password, email, first_name, last_name, avatar, bio, is_admin, role, is_active, created_at, updated_at, deletion_requested_at 
 			  FROM users 
 			  WHERE id = $1`
 
 	var user model.User
 	var lastName, avatar, bio sql.NullString
 
-	err := r.db.QueryRowxContext(ctx, query, id).Scan(
+	err := conn(ctx, r.db).QueryRowxContext(ctx, query, id).Scan(
 		&user.ID,
 		&user.Username,
 		&user.Password,
@@ -51,12 +58,15 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*model.User, e
 		&avatar,
 		&bio,
 		&user.IsAdmin,
+		&user.Role,
+		&user.IsActive,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.DeletionRequestedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("user not found")
+			return nil, ErrUserNotFound
 		}
 		logger.ErrorContext(ctx, "Failed to get user by ID", zap.Error(err), zap.String("id", id))
 		return nil, err
@@ -78,14 +88,14 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*model.User, e
 
 // GetByUsername gets a user by username
 func (r *userRepository) GetByUsername(ctx context.Context, username string) (*model.User, error) {
-	query := `SELECT id, username, password, email, first_name, last_name, avatar, bio, is_admin, created_at, updated_at 
+	query := `SELECT id, username, password, email, first_name, last_name, avatar, bio, is_admin, role, is_active, created_at, updated_at, deletion_requested_at 
 			  FROM users 
 			  WHERE username = $1`
 
 	var user model.User
 	var lastName, avatar, bio sql.NullString
 
-	err := r.db.QueryRowxContext(ctx, query, username).Scan(
+	err := conn(ctx, r.db).QueryRowxContext(ctx, query, username).Scan(
 		&user.ID,
 		&user.Username,
 		&user.Password,
@@ -95,12 +105,15 @@ func (r *userRepository) GetByUsername(ctx context.Context, username string) (*m
 		&avatar,
 		&bio,
 		&user.IsAdmin,
+		&user.Role,
+		&user.IsActive,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.DeletionRequestedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("user not found")
+			return nil, ErrUserNotFound
 		}
 		logger.ErrorContext(ctx, "Failed to get user by username", zap.Error(err), zap.String("username", username))
 		return nil, err
@@ -122,14 +135,14 @@ func (r *userRepository) GetByUsername(ctx context.Context, username string) (*m
 
 // GetByEmail gets a user by email
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*model.User, error) {
-	query := `SELECT id, username, password, email, first_name, last_name, avatar, bio, is_admin, created_at, updated_at 
+	query := `SELECT id, username, password, email, first_name, last_name, avatar, bio, is_admin, role, is_active, created_at, updated_at, deletion_requested_at 
 			  FROM users 
 			  WHERE email = $1`
 
 	var user model.User
 	var lastName, avatar, bio sql.NullString
 
-	err := r.db.QueryRowxContext(ctx, query, email).Scan(
+	err := conn(ctx, r.db).QueryRowxContext(ctx, query, email).Scan(
 		&user.ID,
 		&user.Username,
 		&user.Password,
@@ -139,12 +152,15 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*model.U
 		&avatar,
 		&bio,
 		&user.IsAdmin,
+		&user.Role,
+		&user.IsActive,
 		&user.CreatedAt,
 		&user.UpdatedAt,
+		&user.DeletionRequestedAt,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("user not found")
+			return nil, ErrUserNotFound
 		}
 		logger.ErrorContext(ctx, "Failed to get user by email", zap.Error(err), zap.String("email", email))
 		return nil, err
@@ -170,7 +186,7 @@ func (r *userRepository) UpdateProfile(ctx context.Context, id string, profile *
 			  SET first_name = $2, last_name = $3, email = $4, bio = $5, updated_at = $6
 			  WHERE id = $1`
 
-	_, err := r.db.ExecContext(ctx, query, id, profile.FirstName, profile.LastName, profile.Email, profile.Bio, time.Now())
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, profile.FirstName, profile.LastName, profile.Email, profile.Bio, time.Now())
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to update profile", zap.Error(err), zap.String("id", id))
 	}
@@ -183,7 +199,7 @@ func (r *userRepository) UpdateAvatar(ctx context.Context, id string, avatar str
 			  SET avatar = $2, updated_at = $3
 			  WHERE id = $1`
 
-	_, err := r.db.ExecContext(ctx, query, id, avatar, time.Now())
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, avatar, time.Now())
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to update avatar", zap.Error(err), zap.String("id", id))
 	}
@@ -196,9 +212,138 @@ func (r *userRepository) UpdatePassword(ctx context.Context, id string, password
 			  SET password = $2, updated_at = $3
 			  WHERE id = $1`
 
-	_, err := r.db.ExecContext(ctx, query, id, password, time.Now())
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, password, time.Now())
 	if err != nil {
 		logger.ErrorContext(ctx, "Failed to update password", zap.Error(err), zap.String("id", id))
 	}
 	return err
 }
+
+// List lists users with pagination
+func (r *userRepository) List(ctx context.Context, page, perPage int) ([]model.User, int, error) {
+	offset := (page - 1) * perPage
+
+	// Count total
+	var total int
+	err := conn(ctx, r.db).QueryRowContext(ctx, `SELECT COUNT(*) FROM users`).Scan(&total)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to count users", zap.Error(err))
+		return nil, 0, err
+	}
+
+	// Get users
+	query := `SELECT id, username, password, email, first_name, last_name, avatar, bio, is_admin, role, is_active, created_at, updated_at, deletion_requested_at 
+			  FROM users 
+			  ORDER BY created_at ASC 
+			  LIMIT $1 OFFSET $2`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, perPage, offset)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to list users", zap.Error(err))
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var users []model.User
+	for rows.Next() {
+		var user model.User
+		var lastName, avatar, bio sql.NullString
+
+		err := rows.Scan(
+			&user.ID,
+			&user.Username,
+			&user.Password,
+			&user.Email,
+			&user.FirstName,
+			&lastName,
+			&avatar,
+			&bio,
+			&user.IsAdmin,
+			&user.Role,
+			&user.IsActive,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+			&user.DeletionRequestedAt,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// Set the nullable fields
+		if lastName.Valid {
+			user.LastName = lastName.String
+		}
+		if avatar.Valid {
+			user.Avatar = avatar.String
+		}
+		if bio.Valid {
+			user.Bio = bio.String
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// Create creates a new user with an already hashed password
+func (r *userRepository) Create(ctx context.Context, user *model.UserCreate, password string) (string, error) {
+	query := `INSERT INTO users (id, username, password, email, first_name, last_name, is_admin, role) 
+			  VALUES ($1, $2, $3, $4, $5, $6, $7, $8) 
+			  RETURNING id`
+
+	isAdmin := user.Role == model.RoleAdmin || user.Role == model.RoleOwner
+
+	var id string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query,
+		newID(),
+		user.Username,
+		password,
+		user.Email,
+		user.FirstName,
+		user.LastName,
+		isAdmin,
+		user.Role,
+	).Scan(&id)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to create user", zap.Error(err), zap.String("username", user.Username))
+		return "", err
+	}
+
+	return id, nil
+}
+
+// UpdateRole updates the user role and keeps the admin flag in sync
+func (r *userRepository) UpdateRole(ctx context.Context, id string, role string) error {
+	query := `UPDATE users 
+			  SET role = $2, is_admin = $3, updated_at = $4
+			  WHERE id = $1`
+
+	isAdmin := role == model.RoleAdmin || role == model.RoleOwner
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, role, isAdmin, time.Now())
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to update role", zap.Error(err), zap.String("id", id))
+	}
+	return err
+}
+
+// SetActive activates or deactivates a user. Activating also cancels a pending account deletion.
+func (r *userRepository) SetActive(ctx context.Context, id string, active bool) error {
+	query := `UPDATE users 
+			  SET is_active = $2, updated_at = $3,
+				  deletion_requested_at = CASE WHEN $2 THEN NULL ELSE deletion_requested_at END,
+				  deletion_content = CASE WHEN $2 THEN NULL ELSE deletion_content END,
+				  deletion_reassign_to = CASE WHEN $2 THEN NULL ELSE deletion_reassign_to END
+			  WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, active, time.Now())
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to update active state", zap.Error(err), zap.String("id", id))
+	}
+	return err
+}
@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/jmoiron/sqlx"
+)
+
+// UsesRepository defines methods for uses page repository
+type UsesRepository interface {
+	ListCategories(ctx context.Context) ([]model.UsesCategory, error)
+	CreateCategory(ctx context.Context, category *model.UsesCategoryCreate) (string, error)
+	UpdateCategory(ctx context.Context, id string, category *model.UsesCategoryUpdate) error
+	DeleteCategory(ctx context.Context, id string) error
+
+	ListItems(ctx context.Context) ([]model.UsesItem, error)
+	CreateItem(ctx context.Context, item *model.UsesItemCreate) (string, error)
+	UpdateItem(ctx context.Context, id string, item *model.UsesItemUpdate) error
+	DeleteItem(ctx context.Context, id string) error
+}
+
+// usesRepository is the implementation of UsesRepository
+type usesRepository struct {
+	db *sqlx.DB
+}
+
+// NewUsesRepository creates a new UsesRepository
+func NewUsesRepository(db *sqlx.DB) UsesRepository {
+	return &usesRepository{db: db}
+}
+
+// ListCategories lists uses categories in display order, without their items
+func (r *usesRepository) ListCategories(ctx context.Context) ([]model.UsesCategory, error) {
+	query := `SELECT id, name, description, sort_order, created_at, updated_at
+			  FROM uses_categories
+			  ORDER BY sort_order ASC, name ASC`
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []model.UsesCategory{}
+	for rows.Next() {
+		var category model.UsesCategory
+		var description sql.NullString
+
+		err := rows.Scan(
+			&category.ID,
+			&category.Name,
+			&description,
+			&category.SortOrder,
+			&category.CreatedAt,
+			&category.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		category.Description = description.String
+		category.Items = []model.UsesItem{}
+
+		categories = append(categories, category)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+// CreateCategory creates a uses category
+func (r *usesRepository) CreateCategory(ctx context.Context, category *model.UsesCategoryCreate) (string, error) {
+	query := `INSERT INTO uses_categories (id, name, description, sort_order)
+			  VALUES ($1, $2, $3, $4)
+			  RETURNING id`
+
+	var id string
+	err := conn(ctx, r.db).QueryRowContext(ctx, query, newID(), category.Name, nullString(category.Description), category.SortOrder).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// UpdateCategory updates a uses category
+func (r *usesRepository) UpdateCategory(ctx context.Context, id string, category *model.UsesCategoryUpdate) error {
+	query := `UPDATE uses_categories
+			  SET name = $2, description = $3, sort_order = $4, updated_at = $5
+			  WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(ctx, query, id, category.Name, nullString(category.Description), category.SortOrder, time.Now())
+	return err
+}
+
+// DeleteCategory deletes a uses category along with its items
+func (r *usesRepository) DeleteCategory(ctx context.Context, id string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM uses_categories WHERE id = $1`, id)
+	return err
+}
+
+// ListItems lists every uses item in display order within its category
+func (r *usesRepository) ListItems(ctx context.Context) ([]model.UsesItem, error) {
+	query := `SELECT id, category_id, name, description, url, sort_order, created_at, updated_at
+			  FROM uses_items
+			  ORDER BY sort_order ASC, name ASC`
+
+	rows, err := readConn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []model.UsesItem{}
+	for rows.Next() {
+		var item model.UsesItem
+		var description, url sql.NullString
+
+		err := rows.Scan(
+			&item.ID,
+			&item.CategoryID,
+			&item.Name,
+			&description,
+			&url,
+			&item.SortOrder,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		item.Description = description.String
+		item.URL = url.String
+
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// CreateItem creates a uses item
+func (r *usesRepository) CreateItem(ctx context.Context, item *model.UsesItemCreate) (string, error) {
+	query := `INSERT INTO uses_items (id, category_id, name, description, url, sort_order)
+			  VALUES ($1, $2, $3, $4, $5, $6)
+			  RETURNING id`
+
+	var id string
+	err := conn(ctx, r.db).QueryRowContext(
+		ctx, query,
+		newID(),
+		item.CategoryID,
+		item.Name,
+		nullString(item.Description),
+		nullString(item.URL),
+		item.SortOrder,
+	).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// UpdateItem updates a uses item, which may move it to another category
+func (r *usesRepository) UpdateItem(ctx context.Context, id string, item *model.UsesItemUpdate) error {
+	query := `UPDATE uses_items
+			  SET category_id = $2, name = $3, description = $4, url = $5, sort_order = $6, updated_at = $7
+			  WHERE id = $1`
+
+	_, err := conn(ctx, r.db).ExecContext(
+		ctx, query,
+		id,
+		item.CategoryID,
+		item.Name,
+		nullString(item.Description),
+		nullString(item.URL),
+		item.SortOrder,
+		time.Now(),
+	)
+	return err
+}
+
+// DeleteItem deletes a uses item
+func (r *usesRepository) DeleteItem(ctx context.Context, id string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `DELETE FROM uses_items WHERE id = $1`, id)
+	return err
+}
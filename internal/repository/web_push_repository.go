@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/SherClockHolmes/webpush-go"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"go.uber.org/zap"
+)
+
+// webPushTTL is how long a push service keeps a notification for an offline browser
+const webPushTTL = 24 * 60 * 60
+
+// ErrPushSubscriptionGone is returned when the push service no longer knows a subscription,
+// usually because the browser unsubscribed or the user revoked the permission
+var ErrPushSubscriptionGone = errors.New("push subscription expired")
+
+// WebPushRepository sends Web Push notifications signed with the site's VAPID key
+type WebPushRepository struct {
+	privateKey string
+	publicKey  string
+	subject    string
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewWebPushRepository creates a new Web Push repository. subject is the contact URL
+// (mailto: or https:) push services reach out to about misbehaving senders.
+func NewWebPushRepository(privateKey, publicKey, subject string, logger *zap.Logger) *WebPushRepository {
+	return &WebPushRepository{
+		privateKey: privateKey,
+		publicKey:  publicKey,
+		subject:    subject,
+		// Endpoints come from the browser, so they could point at internal services
+		httpClient: newPublicClient(10 * time.Second),
+		logger:     logger,
+	}
+}
+
+// PublicKey returns the VAPID public key browsers subscribe with
+func (r *WebPushRepository) PublicKey() string {
+	return r.publicKey
+}
+
+// Send encrypts the payload for the subscription and posts it to its push service
+func (r *WebPushRepository) Send(ctx context.Context, subscription *model.PushSubscription, payload []byte) error {
+	resp, err := webpush.SendNotificationWithContext(ctx, payload, &webpush.Subscription{
+		Endpoint: subscription.Endpoint,
+		Keys: webpush.Keys{
+			P256dh: subscription.P256dh,
+			Auth:   subscription.Auth,
+		},
+	}, &webpush.Options{
+		HTTPClient:      r.httpClient,
+		Subscriber:      r.subject,
+		TTL:             webPushTTL,
+		Urgency:         webpush.UrgencyNormal,
+		VAPIDPublicKey:  r.publicKey,
+		VAPIDPrivateKey: r.privateKey,
+	})
+	if err != nil {
+		r.logger.Error("Failed to send push notification", zap.Error(err))
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		return ErrPushSubscriptionGone
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		r.logger.Error("Push service returned non-success status",
+			zap.Int("status_code", resp.StatusCode),
+			zap.String("status", resp.Status))
+		return fmt.Errorf("push service returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"go.uber.org/zap"
+)
+
+// WebhookRepository handles JSON POSTs to incoming webhooks (Discord, Slack, ...)
+type WebhookRepository struct {
+	httpClient *http.Client
+	cfg        config.Config
+	logger     *zap.Logger
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(cfg config.Config, logger *zap.Logger) *WebhookRepository {
+	return &WebhookRepository{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// PostJSON posts the payload as JSON to the given URL
+func (r *WebhookRepository) PostJSON(ctx context.Context, url string, payload interface{}) error {
+	jsonData, err := json.Marshal(payload)
+	if err != nil {
+		r.logger.Error("Failed to marshal webhook payload", zap.Error(err))
+		return err
+	}
+
+	parsed, err := neturl.Parse(url)
+	if err != nil {
+		return err
+	}
+
+	// Each webhook host gets its own circuit breaker, so a Discord outage doesn't stop Slack
+	client := newOutboundClient("webhook "+parsed.Host, r.httpClient, r.cfg)
+	resp, err := client.do(ctx, func(ctx context.Context) (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(jsonData))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		r.logger.Error("Failed to send webhook", zap.Error(err))
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		r.logger.Error("Webhook returned non-success status",
+			zap.Int("status_code", resp.StatusCode),
+			zap.String("status", resp.Status))
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -4,90 +4,488 @@ import (
 	"github.com/budhilaw/personal-website-backend/config"
 	"github.com/budhilaw/personal-website-backend/internal/controller"
 	"github.com/budhilaw/personal-website-backend/internal/middleware"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/pprof"
+	"github.com/jmoiron/sqlx"
 )
 
+// Controllers holds the HTTP handlers mounted by the router
+type Controllers struct {
+	Auth           *controller.AuthController
+	Account        *controller.AccountController
+	Article        *controller.ArticleController
+	Portfolio      *controller.PortfolioController
+	PortfolioImage *controller.PortfolioImageController
+	User           *controller.UserController
+	Newsletter     *controller.NewsletterController
+	Campaign       *controller.CampaignController
+	Series         *controller.SeriesController
+	Resume         *controller.ResumeController
+	Uses           *controller.UsesController
+	Page           *controller.PageController
+	Link           *controller.LinkController
+	Analytics      *controller.AnalyticsController
+	Redirect       *controller.RedirectController
+	OGImage        *controller.OGImageController
+	Translation    *controller.TranslationController
+	Job            *controller.JobController
+	Scheduler      *controller.SchedulerController
+	Backup         *controller.BackupController
+	Security       *controller.SecurityController
+	ActivityPub    *controller.ActivityPubController
+	Syndication    *controller.SyndicationController
+	ContentImport  *controller.ContentImportController
+	Media          *controller.MediaController
+	OEmbed         *controller.OEmbedController
+	NowPlaying     *controller.NowPlayingController
+	GitHubActivity *controller.GitHubActivityController
+	Preview        *controller.PreviewController
+	Revision       *controller.RevisionController
+	Email          *controller.EmailController
+	Telegram       *controller.TelegramController
+	Push           *controller.PushController
+	Events         *controller.EventsController
+	System         *controller.SystemController
+	WellKnown      *controller.WellKnownController
+	Revalidation   *controller.RevalidationController
+	APIKey         *controller.APIKeyController
+	Health         *controller.HealthController
+	ContentAudit   *controller.ContentAuditController
+	Form           *controller.FormController
+}
+
 // SetupRoutes sets up the API routes
 func SetupRoutes(
 	app *fiber.App,
-	authController *controller.AuthController,
-	articleController *controller.ArticleController,
-	portfolioController *controller.PortfolioController,
+	controllers Controllers,
+	rateLimitStorage fiber.Storage,
+	idempotencyRepo repository.IdempotencyRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	userRepo repository.UserRepository,
+	replica *sqlx.DB,
 	cfg config.Config,
 ) {
-	// API v1 group
-	v1 := app.Group("/api/v1")
+	// Probes for the orchestrator and uptime dashboards, kept out of the rate limits
+	app.Get("/healthz", controllers.Health.Live)
+	app.Get("/readyz", controllers.Health.Ready)
+
+	// Short links live outside the API so they stay short
+	app.Get("/go/:code", middleware.RateLimiter(middleware.PublicRateLimitRule(cfg), rateLimitStorage), controllers.Redirect.Follow)
+
+	// Social card images referenced from og:image tags
+	app.Get("/og/:slug.png", middleware.RateLimiter(middleware.PublicRateLimitRule(cfg), rateLimitStorage), controllers.OGImage.GetArticleCard)
+
+	// Crawler rules and the security contact, served here so the frontend doesn't have to
+	app.Get("/robots.txt", middleware.RateLimiter(middleware.PublicRateLimitRule(cfg), rateLimitStorage), controllers.WellKnown.Robots)
+	app.Get("/.well-known/security.txt", middleware.RateLimiter(middleware.PublicRateLimitRule(cfg), rateLimitStorage), controllers.WellKnown.SecurityTxt)
+
+	// Fediverse discovery and federation
+	if cfg.ActivityPubEnabled {
+		setupActivityPubRoutes(app, controllers, rateLimitStorage, cfg)
+	}
+
+	// API v1 keeps its original response bodies, announcing its deprecation once scheduled
+	v1 := app.Group("/api/v1", middleware.APIVersion("v1"), middleware.Deprecation(cfg))
+
+	// Content repository push webhook, authenticated by its signature
+	if cfg.ContentImportWebhookSecret != "" {
+		v1.Post("/webhooks/content-import", middleware.RateLimiter(middleware.PublicRateLimitRule(cfg), rateLimitStorage), controllers.ContentImport.Webhook)
+	}
 
+	// Bounce reports from the mail provider, authenticated by a shared secret
+	if cfg.EmailBounceWebhookSecret != "" {
+		v1.Post("/webhooks/email-bounce", middleware.RateLimiter(middleware.PublicRateLimitRule(cfg), rateLimitStorage), controllers.Email.Bounce)
+	}
+
+	// Telegram bot commands, authenticated by the webhook secret token
+	if cfg.TelegramWebhookSecret != "" {
+		v1.Post("/webhooks/telegram", middleware.RateLimiter(middleware.PublicRateLimitRule(cfg), rateLimitStorage), controllers.Telegram.Webhook)
+	}
+
+	setupAPIRoutes(v1, controllers, rateLimitStorage, idempotencyRepo, apiKeyRepo, userRepo, replica, cfg)
+
+	// Go profiles for debugging production latency (owner/admin only), behind the admin middleware
+	if cfg.PprofEnabled {
+		v1.Use("/admin/debug/pprof", middleware.RequireRole(model.RoleOwner, model.RoleAdmin), pprof.New(pprof.Config{
+			Prefix: "/api/v1/admin",
+		}))
+	}
+
+	// API v2 serves the same routes with every JSON response in a data/meta envelope
+	v2 := app.Group("/api/v2", middleware.APIVersion("v2"), middleware.Envelope())
+	setupAPIRoutes(v2, controllers, rateLimitStorage, idempotencyRepo, apiKeyRepo, userRepo, replica, cfg)
+}
+
+// setupAPIRoutes sets up the public, admin and auth routes of an API version
+func setupAPIRoutes(
+	api fiber.Router,
+	controllers Controllers,
+	rateLimitStorage fiber.Storage,
+	idempotencyRepo repository.IdempotencyRepository,
+	apiKeyRepo repository.APIKeyRepository,
+	userRepo repository.UserRepository,
+	replica *sqlx.DB,
+	cfg config.Config,
+) {
 	// Public routes
-	public := v1.Group("/public")
-	setupPublicRoutes(public, articleController, portfolioController)
+	public := api.Group("/public")
+	public.Use(middleware.APIKey(apiKeyRepo, cfg))
+	public.Use(middleware.RateLimiter(middleware.PublicRateLimitRule(cfg), rateLimitStorage))
+	public.Use(middleware.Locale(cfg))
+	public.Use(middleware.ETag())
+	public.Use(middleware.ReadReplica(replica))
+	setupPublicRoutes(public, controllers, rateLimitStorage, cfg)
 
 	// Admin routes (protected)
-	admin := v1.Group("/admin")
-	admin.Use(middleware.Protected(cfg))
+	admin := api.Group("/admin")
+	admin.Use(middleware.RateLimiter(middleware.AdminRateLimitRule(cfg), rateLimitStorage))
+	admin.Use(middleware.Protected(cfg, userRepo))
 	admin.Use(middleware.AdminOnly())
-	setupAdminRoutes(admin, authController, articleController, portfolioController)
+	admin.Use(middleware.CacheControl(middleware.NoStoreCachePolicy()))
+	setupAdminRoutes(admin, controllers, middleware.Idempotency(idempotencyRepo, cfg))
 
 	// Auth routes
-	auth := v1.Group("/auth")
-	setupAuthRoutes(auth, authController, cfg)
+	auth := api.Group("/auth")
+	auth.Use(middleware.CacheControl(middleware.NoStoreCachePolicy()))
+	setupAuthRoutes(auth, controllers, rateLimitStorage, cfg)
 }
 
 // setupPublicRoutes sets up public routes
 func setupPublicRoutes(
 	router fiber.Router,
-	articleController *controller.ArticleController,
-	portfolioController *controller.PortfolioController,
+	controllers Controllers,
+	rateLimitStorage fiber.Storage,
+	cfg config.Config,
 ) {
+	listCache := middleware.CacheControl(middleware.ListCachePolicy(cfg))
+	detailCache := middleware.CacheControl(middleware.DetailCachePolicy(cfg))
+
 	// Articles
 	articles := router.Group("/articles")
-	articles.Get("/", articleController.ListArticles)
-	articles.Get("/:id", articleController.GetArticle)
-	articles.Get("/slug/:slug", articleController.GetArticleBySlug)
+	articles.Get("/", listCache, controllers.Article.ListArticles)
+	articles.Get("/archive", listCache, controllers.Article.GetArchive)
+	// Popularity comes from daily rollups, so it can be cached as long as a detail page
+	articles.Get("/popular", detailCache, controllers.Article.ListPopularArticles)
+	articles.Get("/recently-updated", listCache, controllers.Article.ListRecentlyUpdatedArticles)
+	articles.Get("/featured", listCache, controllers.Article.ListFeaturedArticles)
+	articles.Get("/archive/:year/:month", listCache, controllers.Article.GetArchiveMonth)
+	articles.Get("/:id", detailCache, controllers.Article.GetArticle)
+	articles.Get("/slug/:slug", detailCache, controllers.Article.GetArticleBySlug)
+	articles.Post("/:slug/unlock",
+		middleware.RateLimiter(middleware.UnlockRateLimitRule(cfg), rateLimitStorage),
+		middleware.CacheControl(middleware.NoStoreCachePolicy()),
+		controllers.Article.UnlockArticle)
 
 	// Portfolios
 	portfolios := router.Group("/portfolios")
-	portfolios.Get("/", portfolioController.ListPortfolios)
-	portfolios.Get("/:id", portfolioController.GetPortfolio)
-	portfolios.Get("/slug/:slug", portfolioController.GetPortfolioBySlug)
+	portfolios.Get("/", listCache, controllers.Portfolio.ListPortfolios)
+	portfolios.Get("/:id", detailCache, controllers.Portfolio.GetPortfolio)
+	portfolios.Get("/slug/:slug", detailCache, controllers.Portfolio.GetPortfolioBySlug)
+
+	// Draft previews shared through signed links
+	router.Get("/preview/:token", middleware.CacheControl(middleware.NoStoreCachePolicy()), controllers.Preview.GetPreview)
+
+	// Series
+	router.Get("/series/:slug", detailCache, controllers.Series.GetSeriesBySlug)
+
+	// Links
+	links := router.Group("/links")
+	links.Get("/", listCache, controllers.Link.ListLinks)
+	links.Get("/rss", listCache, controllers.Link.GetLinkFeed)
+
+	// Resume
+	router.Get("/resume", detailCache, controllers.Resume.GetResume)
+
+	// Uses
+	router.Get("/uses", detailCache, controllers.Uses.GetUses)
+
+	// Pages
+	router.Get("/pages/:slug", detailCache, controllers.Page.GetPageBySlug)
+
+	// oEmbed proxy for article embeds
+	router.Get("/oembed", detailCache, controllers.OEmbed.GetOEmbed)
+
+	// Spotify "now playing" widget, cached for as long as the track is refreshed
+	if cfg.SpotifyRefreshToken != "" {
+		nowPlayingCache := middleware.CacheControl(middleware.CachePolicy{MaxAge: cfg.SpotifyRefreshInterval})
+		router.Get("/now-playing", nowPlayingCache, controllers.NowPlaying.GetNowPlaying)
+	}
+
+	// GitHub activity feed, cached for as long as the server keeps it
+	if cfg.GitHubActivityUsername != "" {
+		githubCache := middleware.CacheControl(middleware.CachePolicy{MaxAge: cfg.GitHubActivityCacheTTL})
+		router.Get("/github/activity", githubCache, controllers.GitHubActivity.GetActivity)
+	}
+
+	// Analytics
+	router.Post("/analytics/pageview", controllers.Analytics.TrackPageview)
+
+	// Form tokens for spam protection of public forms
+	router.Get("/forms/token", middleware.CacheControl(middleware.NoStoreCachePolicy()), controllers.Form.GetToken)
+
+	// Newsletter
+	newsletter := router.Group("/newsletter")
+	newsletter.Use(middleware.CacheControl(middleware.NoStoreCachePolicy()))
+	newsletter.Post("/subscribe",
+		middleware.FormProtection(cfg, middleware.FormRouteNewsletter, rateLimitStorage),
+		middleware.Captcha(cfg, middleware.FormRouteNewsletter),
+		controllers.Newsletter.Subscribe)
+	newsletter.Get("/confirm/:token", controllers.Newsletter.Confirm)
+	newsletter.Get("/unsubscribe/:token", controllers.Newsletter.Unsubscribe)
+	newsletter.Get("/open/:token", controllers.Campaign.TrackOpen)
+
+	// Web Push subscriptions for new article notifications
+	if cfg.PushEnabled {
+		push := router.Group("/push")
+		push.Use(middleware.CacheControl(middleware.NoStoreCachePolicy()))
+		push.Get("/public-key", controllers.Push.GetPublicKey)
+		push.Post("/subscribe", controllers.Push.Subscribe)
+		push.Post("/unsubscribe", controllers.Push.Unsubscribe)
+	}
 }
 
 // setupAdminRoutes sets up admin routes
 func setupAdminRoutes(
 	router fiber.Router,
-	authController *controller.AuthController,
-	articleController *controller.ArticleController,
-	portfolioController *controller.PortfolioController,
+	controllers Controllers,
+	idempotency fiber.Handler,
 ) {
 	// Profile
 	profile := router.Group("/profile")
-	profile.Get("/", authController.GetProfile)
-	profile.Put("/", authController.UpdateProfile)
-	profile.Put("/avatar", authController.UpdateAvatar)
-	profile.Put("/password", authController.UpdatePassword)
+	profile.Get("/", controllers.Auth.GetProfile)
+	profile.Put("/", controllers.Auth.UpdateProfile)
+	profile.Put("/avatar", controllers.Auth.UpdateAvatar)
+	profile.Put("/password", controllers.Auth.UpdatePassword)
+	profile.Get("/logins", controllers.Auth.ListLogins)
+	profile.Get("/export", controllers.Account.ExportAccount)
+	profile.Delete("/", controllers.Account.DeleteAccount)
+
+	// API keys for public API clients, each limited by its tier
+	apiKeys := router.Group("/api-keys")
+	apiKeys.Get("/", controllers.APIKey.ListAPIKeys)
+	apiKeys.Post("/", controllers.APIKey.CreateAPIKey)
+	apiKeys.Delete("/:id", controllers.APIKey.RevokeAPIKey)
+	apiKeys.Get("/:id/usage", controllers.APIKey.GetAPIKeyUsage)
 
 	// Articles
 	articles := router.Group("/articles")
-	articles.Get("/", articleController.ListAdminArticles)
-	articles.Post("/", articleController.CreateArticle)
-	articles.Put("/:id", articleController.UpdateArticle)
-	articles.Delete("/:id", articleController.DeleteArticle)
-	articles.Get("/:id", articleController.GetArticle)
+	articles.Get("/", controllers.Article.ListAdminArticles)
+	articles.Post("/", idempotency, controllers.Article.CreateArticle)
+	articles.Put("/:id", controllers.Article.UpdateArticle)
+	articles.Delete("/:id", controllers.Article.DeleteArticle)
+	articles.Get("/:id", controllers.Article.GetAdminArticle)
+	articles.Get("/:id/translations", controllers.Translation.ListArticleTranslations)
+	articles.Put("/:id/translations/:locale", controllers.Translation.UpsertArticleTranslation)
+	articles.Delete("/:id/translations/:locale", controllers.Translation.DeleteArticleTranslation)
+	articles.Get("/:id/syndications", controllers.Syndication.ListSyndications)
+	articles.Post("/:id/syndicate/:platform", controllers.Syndication.Syndicate)
+	articles.Post("/:id/transition", controllers.Article.TransitionArticle)
+	articles.Put("/:id/featured", controllers.Article.SetArticleFeatured)
+	articles.Put("/:id/password", controllers.Article.SetArticlePassword)
+	articles.Delete("/:id/password", controllers.Article.RemoveArticlePassword)
+	articles.Post("/:id/duplicate", controllers.Article.DuplicateArticle)
+	articles.Post("/:id/preview-link", controllers.Preview.CreatePreviewLink)
+	articles.Get("/:id/revisions", controllers.Revision.ListRevisions)
+	articles.Get("/:id/revisions/diff", controllers.Revision.DiffRevisions)
 
 	// Portfolios
 	portfolios := router.Group("/portfolios")
-	portfolios.Get("/", portfolioController.ListAdminPortfolios)
-	portfolios.Post("/", portfolioController.CreatePortfolio)
-	portfolios.Put("/:id", portfolioController.UpdatePortfolio)
-	portfolios.Delete("/:id", portfolioController.DeletePortfolio)
-	portfolios.Get("/:id", portfolioController.GetPortfolio)
+	portfolios.Get("/", controllers.Portfolio.ListAdminPortfolios)
+	portfolios.Post("/", idempotency, controllers.Portfolio.CreatePortfolio)
+	portfolios.Put("/:id", controllers.Portfolio.UpdatePortfolio)
+	portfolios.Delete("/:id", controllers.Portfolio.DeletePortfolio)
+	portfolios.Get("/:id", controllers.Portfolio.GetPortfolio)
+	portfolios.Post("/:id/images", controllers.PortfolioImage.AddImage)
+	portfolios.Put("/:id/images/order", controllers.PortfolioImage.ReorderImages)
+	portfolios.Delete("/:id/images/:imageId", controllers.PortfolioImage.DeleteImage)
+
+	// Series
+	series := router.Group("/series")
+	series.Get("/", controllers.Series.ListSeries)
+	series.Post("/", controllers.Series.CreateSeries)
+	series.Put("/:id", controllers.Series.UpdateSeries)
+	series.Delete("/:id", controllers.Series.DeleteSeries)
+	series.Get("/:id", controllers.Series.GetSeries)
+
+	// Resume
+	resume := router.Group("/resume")
+	resume.Get("/", controllers.Resume.GetResume)
+	resume.Post("/experiences", controllers.Resume.CreateExperience)
+	resume.Put("/experiences/:id", controllers.Resume.UpdateExperience)
+	resume.Delete("/experiences/:id", controllers.Resume.DeleteExperience)
+	resume.Post("/educations", controllers.Resume.CreateEducation)
+	resume.Put("/educations/:id", controllers.Resume.UpdateEducation)
+	resume.Delete("/educations/:id", controllers.Resume.DeleteEducation)
+	resume.Post("/certifications", controllers.Resume.CreateCertification)
+	resume.Put("/certifications/:id", controllers.Resume.UpdateCertification)
+	resume.Delete("/certifications/:id", controllers.Resume.DeleteCertification)
+	resume.Post("/skills", controllers.Resume.CreateSkill)
+	resume.Put("/skills/:id", controllers.Resume.UpdateSkill)
+	resume.Delete("/skills/:id", controllers.Resume.DeleteSkill)
+
+	// Uses
+	uses := router.Group("/uses")
+	uses.Get("/", controllers.Uses.GetUses)
+	uses.Post("/categories", controllers.Uses.CreateCategory)
+	uses.Put("/categories/:id", controllers.Uses.UpdateCategory)
+	uses.Delete("/categories/:id", controllers.Uses.DeleteCategory)
+	uses.Post("/items", controllers.Uses.CreateItem)
+	uses.Put("/items/:id", controllers.Uses.UpdateItem)
+	uses.Delete("/items/:id", controllers.Uses.DeleteItem)
+
+	// Links
+	links := router.Group("/links")
+	links.Get("/", controllers.Link.ListAdminLinks)
+	links.Post("/", controllers.Link.CreateLink)
+	links.Put("/:id", controllers.Link.UpdateLink)
+	links.Delete("/:id", controllers.Link.DeleteLink)
+	links.Get("/:id", controllers.Link.GetLink)
+
+	// Pages
+	pages := router.Group("/pages")
+	pages.Get("/", controllers.Page.ListPages)
+	pages.Post("/", controllers.Page.CreatePage)
+	pages.Put("/:id", controllers.Page.UpdatePage)
+	pages.Delete("/:id", controllers.Page.DeletePage)
+	pages.Get("/:id", controllers.Page.GetPage)
+	pages.Get("/:id/translations", controllers.Translation.ListPageTranslations)
+	pages.Put("/:id/translations/:locale", controllers.Translation.UpsertPageTranslation)
+	pages.Delete("/:id/translations/:locale", controllers.Translation.DeletePageTranslation)
+
+	// Analytics
+	analytics := router.Group("/analytics")
+	analytics.Get("/summary", controllers.Analytics.GetSummary)
+	analytics.Get("/paths", controllers.Analytics.GetTopPaths)
+	analytics.Get("/referrers", controllers.Analytics.GetTopReferrers)
+	analytics.Get("/countries", controllers.Analytics.GetTopCountries)
+	analytics.Get("/search", controllers.Analytics.GetSearches)
+
+	// Media library
+	media := router.Group("/media")
+	media.Get("/", controllers.Media.ListMedia)
+	media.Post("/presign", controllers.Media.PresignUpload)
+	media.Post("/:id/confirm", controllers.Media.ConfirmUpload)
+
+	// Short links
+	redirects := router.Group("/redirects")
+	redirects.Get("/", controllers.Redirect.ListRedirects)
+	redirects.Post("/", controllers.Redirect.CreateRedirect)
+	redirects.Put("/:id", controllers.Redirect.UpdateRedirect)
+	redirects.Delete("/:id", controllers.Redirect.DeleteRedirect)
+	redirects.Get("/:id", controllers.Redirect.GetRedirect)
+
+	// Users (owner/admin only)
+	users := router.Group("/users")
+	users.Use(middleware.RequireRole(model.RoleOwner, model.RoleAdmin))
+	users.Get("/", controllers.User.ListUsers)
+	users.Post("/", controllers.User.CreateUser)
+	users.Get("/:id", controllers.User.GetUser)
+	users.Put("/:id/role", controllers.User.UpdateUserRole)
+	users.Put("/:id/deactivate", controllers.User.DeactivateUser)
+	users.Put("/:id/activate", controllers.User.ActivateUser)
+
+	// Live dashboard events over Server-Sent Events (owner/admin only)
+	router.Get("/events", middleware.RequireRole(model.RoleOwner, model.RoleAdmin), controllers.Events.Stream)
+
+	// Background jobs (owner/admin only)
+	jobs := router.Group("/jobs")
+	jobs.Use(middleware.RequireRole(model.RoleOwner, model.RoleAdmin))
+	jobs.Get("/dead", controllers.Job.ListDeadJobs)
+	jobs.Post("/:id/retry", controllers.Job.RetryJob)
+	jobs.Delete("/:id", controllers.Job.DeleteJob)
+
+	// Regenerate frontend pages right away (owner/admin only)
+	router.Post("/revalidate", middleware.RequireRole(model.RoleOwner, model.RoleAdmin), controllers.Revalidation.Revalidate)
+
+	// Email deliveries (owner/admin only)
+	emails := router.Group("/emails")
+	emails.Use(middleware.RequireRole(model.RoleOwner, model.RoleAdmin))
+	emails.Get("/", controllers.Email.ListEmails)
+
+	// Article and portfolio change history (owner/admin only)
+	audit := router.Group("/audit")
+	audit.Use(middleware.RequireRole(model.RoleOwner, model.RoleAdmin))
+	audit.Get("/", controllers.ContentAudit.ListAuditEntries)
+	audit.Get("/:id", controllers.ContentAudit.GetAuditEntry)
+
+	// Scheduled tasks (owner/admin only)
+	scheduler := router.Group("/scheduler")
+	scheduler.Use(middleware.RequireRole(model.RoleOwner, model.RoleAdmin))
+	scheduler.Get("/", controllers.Scheduler.ListTasks)
+
+	// Runtime stats and log levels (owner/admin only)
+	system := router.Group("/system")
+	system.Use(middleware.RequireRole(model.RoleOwner, model.RoleAdmin))
+	system.Get("/stats", controllers.System.GetStats)
+	system.Get("/log-levels", controllers.System.ListLogLevels)
+	system.Put("/log-levels/:name", controllers.System.SetLogLevel)
+	system.Delete("/log-levels/:name", controllers.System.ResetLogLevel)
+
+	// Database backups (owner/admin only)
+	backups := router.Group("/backups")
+	backups.Use(middleware.RequireRole(model.RoleOwner, model.RoleAdmin))
+	backups.Get("/", controllers.Backup.ListBackups)
+	backups.Post("/", controllers.Backup.CreateBackup)
+
+	// Markdown content sync (owner/admin only)
+	contentImport := router.Group("/import")
+	contentImport.Use(middleware.RequireRole(model.RoleOwner, model.RoleAdmin))
+	contentImport.Post("/git", controllers.ContentImport.Import)
+
+	// Login blocks (owner/admin only)
+	security := router.Group("/security")
+	security.Use(middleware.RequireRole(model.RoleOwner, model.RoleAdmin))
+	security.Get("/blocked", controllers.Security.ListBlocked)
+	security.Delete("/blocked/:id", controllers.Security.Unblock)
+
+	// Newsletter
+	newsletter := router.Group("/newsletter")
+	newsletter.Get("/subscribers", controllers.Newsletter.ListSubscribers)
+	newsletter.Get("/subscribers/export", controllers.Newsletter.ExportSubscribers)
+
+	// Newsletter campaigns (owner/admin only)
+	campaigns := newsletter.Group("/campaigns")
+	campaigns.Use(middleware.RequireRole(model.RoleOwner, model.RoleAdmin))
+	campaigns.Get("/", controllers.Campaign.ListCampaigns)
+	campaigns.Post("/", controllers.Campaign.CreateCampaign)
+	campaigns.Post("/from-article/:id", controllers.Campaign.CreateCampaignFromArticle)
+	campaigns.Get("/:id", controllers.Campaign.GetCampaign)
+	campaigns.Put("/:id", controllers.Campaign.UpdateCampaign)
+	campaigns.Delete("/:id", controllers.Campaign.DeleteCampaign)
+	campaigns.Post("/:id/send", controllers.Campaign.SendCampaign)
+}
+
+// setupActivityPubRoutes sets up the WebFinger and ActivityPub routes
+func setupActivityPubRoutes(
+	app *fiber.App,
+	controllers Controllers,
+	rateLimitStorage fiber.Storage,
+	cfg config.Config,
+) {
+	rateLimit := middleware.RateLimiter(middleware.PublicRateLimitRule(cfg), rateLimitStorage)
+
+	app.Get("/.well-known/webfinger", rateLimit, controllers.ActivityPub.WebFinger)
+
+	ap := app.Group("/ap")
+	ap.Use(rateLimit)
+	ap.Get("/actor", controllers.ActivityPub.GetActor)
+	ap.Get("/outbox", controllers.ActivityPub.GetOutbox)
+	ap.Get("/followers", controllers.ActivityPub.GetFollowers)
+	ap.Get("/articles/:id", controllers.ActivityPub.GetArticle)
+	ap.Post("/inbox", controllers.ActivityPub.Inbox)
 }
 
 // setupAuthRoutes sets up authentication routes
 func setupAuthRoutes(
 	router fiber.Router,
-	authController *controller.AuthController,
+	controllers Controllers,
+	rateLimitStorage fiber.Storage,
 	cfg config.Config,
 ) {
-	router.Post("/login", authController.Login)
-} 
\ No newline at end of file
+	// Stricter limit on login to slow down credential stuffing
+	router.Post("/login", middleware.RateLimiter(middleware.AuthRateLimitRule(cfg), rateLimitStorage), controllers.Auth.Login)
+	router.Get("/confirm-device/:token", middleware.RateLimiter(middleware.AuthRateLimitRule(cfg), rateLimitStorage), controllers.Auth.GetDeviceConfirmation)
+	router.Post("/confirm-device/:token", middleware.RateLimiter(middleware.AuthRateLimitRule(cfg), rateLimitStorage), controllers.Auth.ConfirmDevice)
+}
@@ -0,0 +1,81 @@
+package router
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/controller"
+	"github.com/budhilaw/personal-website-backend/internal/middleware"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/internal/service"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+// stubUserRepo returns the same user for any ID
+type stubUserRepo struct {
+	repository.UserRepository
+	user *model.User
+}
+
+func (r *stubUserRepo) GetByID(ctx context.Context, id string) (*model.User, error) {
+	return r.user, nil
+}
+
+// stubAuditService lists no audit entries
+type stubAuditService struct {
+	service.ContentAuditService
+}
+
+func (s *stubAuditService) List(ctx context.Context, filter model.ContentAuditFilter, page, perPage int) (*model.ContentAuditList, error) {
+	return &model.ContentAuditList{Entries: []model.ContentAuditEntry{}, Page: page, PerPage: perPage}, nil
+}
+
+func TestAuditRoutesRequireAdminAuth(t *testing.T) {
+	logger.InitLogger(false)
+
+	cfg := config.Config{JWTSecret: "test-secret", JWTExpiration: time.Hour}
+	owner := &model.User{ID: "owner-id", Username: "owner", IsAdmin: true, IsActive: true, Role: model.RoleOwner}
+
+	app := fiber.New()
+	SetupRoutes(app, Controllers{
+		ContentAudit: controller.NewContentAuditController(&stubAuditService{}),
+	}, middleware.NewRateLimitStorage(cfg), nil, nil, &stubUserRepo{user: owner}, nil, cfg)
+
+	token, err := middleware.GenerateToken(owner.ID, owner.Username, owner.IsAdmin, owner.Role, cfg)
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		token  string
+		status int
+	}{
+		{"owner", "/api/v1/admin/audit", token, fiber.StatusOK},
+		{"anonymous", "/api/v1/admin/audit", "", fiber.StatusUnauthorized},
+		{"not public", "/api/v1/public/audit", token, fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set(fiber.HeaderAuthorization, "Bearer "+tt.token)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request: %v", err)
+			}
+			if resp.StatusCode != tt.status {
+				t.Errorf("GET %s: status %d, want %d", tt.path, resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
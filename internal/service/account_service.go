@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"go.uber.org/zap"
+)
+
+// Account deletion errors
+var (
+	ErrIncorrectPassword     = errors.New("password is incorrect")
+	ErrOwnerDeletion         = errors.New("the owner account can't be deleted")
+	ErrInvalidReassignTarget = errors.New("content can only be reassigned to another active user")
+)
+
+// AccountService defines methods for users exporting and deleting their own account
+type AccountService interface {
+	Export(ctx context.Context, userID string) (*model.AccountExport, error)
+	RequestDeletion(ctx context.Context, userID string, deletion *model.AccountDeletion) (*model.AccountDeletionScheduled, error)
+	PurgeDeleted(ctx context.Context) error
+}
+
+// accountService is the implementation of AccountService
+type accountService struct {
+	accountRepo repository.AccountRepository
+	userRepo    repository.UserRepository
+	cfg         config.Config
+}
+
+// NewAccountService creates a new AccountService
+func NewAccountService(accountRepo repository.AccountRepository, userRepo repository.UserRepository, cfg config.Config) AccountService {
+	return &accountService{
+		accountRepo: accountRepo,
+		userRepo:    userRepo,
+		cfg:         cfg,
+	}
+}
+
+// Export collects the user's profile, content and login history
+func (s *accountService) Export(ctx context.Context, userID string) (*model.AccountExport, error) {
+	ctx = logger.WithContextFields(ctx, logger.RequestLogger(userID, "EXPORT_ACCOUNT", ""))
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &model.AccountExport{
+		ExportedAt: time.Now(),
+		Profile:    *user,
+	}
+	if err := s.accountRepo.Export(ctx, userID, export); err != nil {
+		logger.ErrorContext(ctx, "Failed to export account", zap.Error(err))
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Account exported")
+	return export, nil
+}
+
+// RequestDeletion deactivates the account and schedules it to be deleted once
+// ACCOUNT_DELETION_GRACE_PERIOD has passed. Until then, an owner or admin can cancel the
+// deletion by reactivating the account.
+func (s *accountService) RequestDeletion(ctx context.Context, userID string, deletion *model.AccountDeletion) (*model.AccountDeletionScheduled, error) {
+	ctx = logger.WithContextFields(ctx, logger.RequestLogger(userID, "DELETE_ACCOUNT", ""))
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nobody could manage the site, or cancel the deletion, without its owner
+	if user.Role == model.RoleOwner {
+		return nil, ErrOwnerDeletion
+	}
+
+	valid, err := util.VerifyPassword(deletion.Password, user.Password)
+	if err != nil {
+		logger.ErrorContext(ctx, "Password verification error", zap.Error(err))
+		return nil, err
+	}
+	if !valid {
+		return nil, ErrIncorrectPassword
+	}
+
+	reassignTo := ""
+	if deletion.Content == model.DeletionContentReassign {
+		target, err := s.userRepo.GetByID(ctx, deletion.ReassignTo)
+		if err != nil || target.ID == userID || !target.IsActive {
+			return nil, ErrInvalidReassignTarget
+		}
+		reassignTo = target.ID
+	}
+
+	requestedAt, err := s.accountRepo.ScheduleDeletion(ctx, userID, deletion.Content, reassignTo)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to schedule account deletion", zap.Error(err))
+		return nil, err
+	}
+
+	scheduled := &model.AccountDeletionScheduled{
+		DeleteAfter: requestedAt.Add(s.cfg.AccountDeletionGracePeriod),
+		Content:     deletion.Content,
+		ReassignTo:  reassignTo,
+	}
+	logger.InfoContext(ctx, "Account deletion scheduled",
+		zap.Time("delete_after", scheduled.DeleteAfter),
+		zap.String("content", deletion.Content),
+	)
+	return scheduled, nil
+}
+
+// PurgeDeleted deletes the accounts whose grace period has passed
+func (s *accountService) PurgeDeleted(ctx context.Context) error {
+	deletions, err := s.accountRepo.ListDueDeletions(ctx, time.Now().Add(-s.cfg.AccountDeletionGracePeriod))
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, deletion := range deletions {
+		if err := s.accountRepo.Purge(ctx, deletion); err != nil {
+			logger.ErrorContext(ctx, "Failed to delete account", zap.Error(err), zap.String("user_id", deletion.UserID))
+			errs = append(errs, err)
+			continue
+		}
+		logger.InfoContext(ctx, "Account deleted", zap.String("user_id", deletion.UserID), zap.String("content", deletion.Content))
+	}
+
+	return errors.Join(errs...)
+}
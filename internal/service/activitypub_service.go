@@ -0,0 +1,445 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/jobs"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"github.com/go-fed/httpsig"
+	"go.uber.org/zap"
+)
+
+// JobDeliverActivity is the job type posting an activity to one remote inbox
+const JobDeliverActivity = "activitypub.deliver"
+
+const (
+	activityStreamsContext = "https://www.w3.org/ns/activitystreams"
+	securityContext        = "https://w3id.org/security/v1"
+	publicCollection       = "https://www.w3.org/ns/activitystreams#Public"
+	// outboxSize is the number of recent articles listed in the outbox
+	outboxSize = 20
+	// maxSignatureAge rejects replayed inbox requests
+	maxSignatureAge = time.Hour
+)
+
+var (
+	ErrUnknownResource  = errors.New("unknown webfinger resource")
+	ErrInvalidSignature = errors.New("invalid http signature")
+	ErrInvalidActivity  = errors.New("invalid activity")
+)
+
+// deliveryJob is the payload of a JobDeliverActivity job
+type deliveryJob struct {
+	Inbox    string          `json:"inbox"`
+	Activity json.RawMessage `json:"activity"`
+}
+
+// ActivityPubService defines methods for ActivityPub service
+type ActivityPubService interface {
+	WebFinger(resource string) (map[string]interface{}, error)
+	Actor() map[string]interface{}
+	Outbox(ctx context.Context) (map[string]interface{}, error)
+	Followers(ctx context.Context) (map[string]interface{}, error)
+	ArticleObject(ctx context.Context, id string) (map[string]interface{}, error)
+	HandleInbox(ctx context.Context, req *http.Request, body []byte) error
+	PublishArticle(ctx context.Context, id string)
+	HandleDeliveryJob(ctx context.Context, payload json.RawMessage) error
+}
+
+// activityPubService is the implementation of ActivityPubService
+type activityPubService struct {
+	followerRepo repository.ActivityPubFollowerRepository
+	articleRepo  repository.ArticleRepository
+	apRepo       *repository.ActivityPubRepository
+	queue        jobs.Enqueuer
+	cfg          config.Config
+	publicKeyPEM string
+	baseURL      string
+	actorID      string
+	handle       string
+}
+
+// NewActivityPubService creates a new ActivityPubService
+func NewActivityPubService(
+	followerRepo repository.ActivityPubFollowerRepository,
+	articleRepo repository.ArticleRepository,
+	apRepo *repository.ActivityPubRepository,
+	queue jobs.Enqueuer,
+	publicKeyPEM string,
+	cfg config.Config,
+) ActivityPubService {
+	baseURL := strings.TrimRight(cfg.APIURL, "/") + "/ap"
+
+	return &activityPubService{
+		followerRepo: followerRepo,
+		articleRepo:  articleRepo,
+		apRepo:       apRepo,
+		queue:        queue,
+		cfg:          cfg,
+		publicKeyPEM: publicKeyPEM,
+		baseURL:      baseURL,
+		actorID:      ActivityPubActorID(cfg),
+		handle:       "acct:" + cfg.ActivityPubUsername + "@" + ActivityPubDomain(cfg),
+	}
+}
+
+// ActivityPubActorID returns the URL identifying the blog actor
+func ActivityPubActorID(cfg config.Config) string {
+	return strings.TrimRight(cfg.APIURL, "/") + "/ap/actor"
+}
+
+// ActivityPubDomain returns the domain of the blog's fediverse handle, defaulting to the API host
+func ActivityPubDomain(cfg config.Config) string {
+	if cfg.ActivityPubDomain != "" {
+		return cfg.ActivityPubDomain
+	}
+	if u, err := url.Parse(cfg.APIURL); err == nil {
+		return u.Host
+	}
+	return ""
+}
+
+// WebFinger resolves the blog handle or actor URL to the actor document
+func (s *activityPubService) WebFinger(resource string) (map[string]interface{}, error) {
+	if !strings.EqualFold(resource, s.handle) && resource != s.actorID {
+		return nil, ErrUnknownResource
+	}
+
+	return map[string]interface{}{
+		"subject": s.handle,
+		"aliases": []string{s.actorID},
+		"links": []map[string]string{
+			{"rel": "self", "type": repository.ActivityPubContentType, "href": s.actorID},
+			{"rel": "http://webfinger.net/rel/profile-page", "type": "text/html", "href": s.cfg.FrontendURL},
+		},
+	}, nil
+}
+
+// Actor returns the blog actor document
+func (s *activityPubService) Actor() map[string]interface{} {
+	name := s.cfg.OGSiteName
+	if name == "" {
+		name = s.cfg.AppName
+	}
+
+	return map[string]interface{}{
+		"@context":          []string{activityStreamsContext, securityContext},
+		"id":                s.actorID,
+		"type":              "Service",
+		"preferredUsername": s.cfg.ActivityPubUsername,
+		"name":              name,
+		"url":               s.cfg.FrontendURL,
+		"inbox":             s.baseURL + "/inbox",
+		"outbox":            s.baseURL + "/outbox",
+		"followers":         s.baseURL + "/followers",
+		"publicKey": map[string]string{
+			"id":           s.actorID + "#main-key",
+			"owner":        s.actorID,
+			"publicKeyPem": s.publicKeyPEM,
+		},
+	}
+}
+
+// Outbox lists Create activities for the most recently published articles
+func (s *activityPubService) Outbox(ctx context.Context) (map[string]interface{}, error) {
+	articles, total, err := s.articleRepo.List(ctx, 1, outboxSize, true, model.ListOptions{Sort: "published_at"})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]map[string]interface{}, 0, len(articles))
+	for i := range articles {
+		items = append(items, s.createActivity(&articles[i]))
+	}
+
+	return map[string]interface{}{
+		"@context":     activityStreamsContext,
+		"id":           s.baseURL + "/outbox",
+		"type":         "OrderedCollection",
+		"totalItems":   total,
+		"orderedItems": items,
+	}, nil
+}
+
+// Followers returns the follower count; the accounts themselves are not listed
+func (s *activityPubService) Followers(ctx context.Context) (map[string]interface{}, error) {
+	total, err := s.followerRepo.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"@context":   activityStreamsContext,
+		"id":         s.baseURL + "/followers",
+		"type":       "OrderedCollection",
+		"totalItems": total,
+	}, nil
+}
+
+// ArticleObject returns the ActivityPub object of a published article
+func (s *activityPubService) ArticleObject(ctx context.Context, id string) (map[string]interface{}, error) {
+	article, err := s.articleRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !article.IsPublished {
+		return nil, errors.New("article not found")
+	}
+
+	object := s.articleObject(article)
+	object["@context"] = activityStreamsContext
+	return object, nil
+}
+
+// HandleInbox verifies the signature of an incoming activity and processes follows and unfollows
+func (s *activityPubService) HandleInbox(ctx context.Context, req *http.Request, body []byte) error {
+	var activity model.Activity
+	if err := json.Unmarshal(body, &activity); err != nil || activity.Actor == "" {
+		return ErrInvalidActivity
+	}
+
+	actor, err := s.verifySignature(ctx, req, body, activity.Actor)
+	if err != nil {
+		logger.WarnContext(ctx, "Rejected inbox activity", zap.String("actor", activity.Actor), zap.Error(err))
+		return ErrInvalidSignature
+	}
+
+	switch activity.Type {
+	case "Follow":
+		if objectID(activity.Object) != s.actorID {
+			return ErrInvalidActivity
+		}
+		return s.follow(ctx, actor, body)
+	case "Undo":
+		var inner model.Activity
+		if err := json.Unmarshal(activity.Object, &inner); err != nil {
+			return ErrInvalidActivity
+		}
+		if inner.Type == "Follow" {
+			return s.followerRepo.DeleteByActor(ctx, actor.ID)
+		}
+	case "Delete":
+		// An account deleting itself
+		if objectID(activity.Object) == actor.ID {
+			return s.followerRepo.DeleteByActor(ctx, actor.ID)
+		}
+	}
+
+	// Other activities (likes, boosts, replies) are accepted and ignored
+	return nil
+}
+
+// follow stores the follower and queues the Accept reply
+func (s *activityPubService) follow(ctx context.Context, actor *model.RemoteActor, followActivity []byte) error {
+	err := s.followerRepo.Save(ctx, &model.ActivityPubFollower{
+		ActorID:     actor.ID,
+		Inbox:       actor.Inbox,
+		SharedInbox: actor.Endpoints.SharedInbox,
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.InfoContext(ctx, "New fediverse follower", zap.String("actor", actor.ID))
+
+	id, _ := generateActivityID()
+	accept, err := json.Marshal(map[string]interface{}{
+		"@context": activityStreamsContext,
+		"id":       s.baseURL + "/activities/" + id,
+		"type":     "Accept",
+		"actor":    s.actorID,
+		"object":   json.RawMessage(followActivity),
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.queue.Enqueue(ctx, JobDeliverActivity, deliveryJob{Inbox: actor.Inbox, Activity: accept})
+}
+
+// PublishArticle queues a Create activity for the article to every follower inbox
+func (s *activityPubService) PublishArticle(ctx context.Context, id string) {
+	if !s.cfg.ActivityPubEnabled {
+		return
+	}
+
+	article, err := s.articleRepo.GetByID(ctx, id)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to load article for federation", zap.Error(err), zap.String("id", id))
+		return
+	}
+
+	inboxes, err := s.followerRepo.ListInboxes(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to list fediverse followers", zap.Error(err))
+		return
+	}
+
+	create := s.createActivity(article)
+	create["@context"] = activityStreamsContext
+	activity, err := json.Marshal(create)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to encode article activity", zap.Error(err))
+		return
+	}
+
+	for _, inbox := range inboxes {
+		if err := s.queue.Enqueue(ctx, JobDeliverActivity, deliveryJob{Inbox: inbox, Activity: activity}); err != nil {
+			logger.ErrorContext(ctx, "Failed to queue activity delivery", zap.Error(err), zap.String("inbox", inbox))
+		}
+	}
+}
+
+// HandleDeliveryJob posts a queued activity; errors make the queue retry it
+func (s *activityPubService) HandleDeliveryJob(ctx context.Context, payload json.RawMessage) error {
+	var job deliveryJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+
+	return s.apRepo.Deliver(ctx, job.Inbox, job.Activity)
+}
+
+// verifySignature checks the request's HTTP signature against the key of the activity's actor
+func (s *activityPubService) verifySignature(ctx context.Context, req *http.Request, body []byte, actorID string) (*model.RemoteActor, error) {
+	verifier, err := httpsig.NewVerifier(req)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := http.ParseTime(req.Header.Get("Date"))
+	if err != nil || time.Since(date).Abs() > maxSignatureAge {
+		return nil, errors.New("missing or stale Date header")
+	}
+
+	// The digest only binds the body if it is one of the signed headers
+	if !signsDigest(req.Header.Get("Signature")) {
+		return nil, errors.New("signature does not cover the digest")
+	}
+
+	digest := sha256.Sum256(body)
+	if req.Header.Get("Digest") != "SHA-256="+base64.StdEncoding.EncodeToString(digest[:]) {
+		return nil, errors.New("digest does not match body")
+	}
+
+	actor, err := s.apRepo.FetchActor(ctx, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if actor.ID != actorID || actor.PublicKey.Owner != actorID || actor.PublicKey.ID != verifier.KeyId() {
+		return nil, errors.New("signing key does not belong to the actor")
+	}
+
+	block, _ := pem.Decode([]byte(actor.PublicKey.PublicKeyPem))
+	if block == nil {
+		return nil, errors.New("actor has no usable public key")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifier.Verify(publicKey, httpsig.RSA_SHA256); err != nil {
+		return nil, err
+	}
+
+	return actor, nil
+}
+
+// signsDigest reports whether a Signature header lists digest among its signed headers
+func signsDigest(signature string) bool {
+	for _, param := range strings.Split(signature, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if ok && name == "headers" {
+			for _, header := range strings.Fields(strings.Trim(value, `"`)) {
+				if strings.EqualFold(header, "digest") {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// createActivity wraps an article in a Create activity addressed to the public and followers
+func (s *activityPubService) createActivity(article *model.Article) map[string]interface{} {
+	object := s.articleObject(article)
+	return map[string]interface{}{
+		"id":        object["id"].(string) + "/activity",
+		"type":      "Create",
+		"actor":     s.actorID,
+		"published": object["published"],
+		"to":        object["to"],
+		"cc":        object["cc"],
+		"object":    object,
+	}
+}
+
+// articleObject renders an article as an ActivityPub Article linking back to the site
+func (s *activityPubService) articleObject(article *model.Article) map[string]interface{} {
+	link := s.cfg.ArticleURL(article.Slug)
+	if article.CanonicalURL != "" {
+		link = article.CanonicalURL
+	}
+
+	var content bytes.Buffer
+	if article.Excerpt != "" {
+		content.WriteString("<p>" + html.EscapeString(article.Excerpt) + "</p>")
+	}
+	content.WriteString(`<p><a href="` + html.EscapeString(link) + `">` + html.EscapeString(link) + `</a></p>`)
+
+	published := article.PublishedAt
+	if published.IsZero() {
+		published = article.CreatedAt
+	}
+
+	return map[string]interface{}{
+		"id":           s.baseURL + "/articles/" + article.ID,
+		"type":         "Article",
+		"attributedTo": s.actorID,
+		"name":         article.Title,
+		"summary":      article.Excerpt,
+		"content":      content.String(),
+		"url":          link,
+		"published":    published.UTC().Format(time.RFC3339),
+		"to":           []string{publicCollection},
+		"cc":           []string{s.baseURL + "/followers"},
+	}
+}
+
+// objectID reads an activity object that is either a bare ID or an embedded object
+func objectID(object json.RawMessage) string {
+	var id string
+	if err := json.Unmarshal(object, &id); err == nil {
+		return id
+	}
+
+	var embedded struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(object, &embedded); err == nil {
+		return embedded.ID
+	}
+	return ""
+}
+
+// generateActivityID returns a random identifier for outgoing activities
+func generateActivityID() (string, error) {
+	return util.GenerateRandomToken(16)
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/events"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"go.uber.org/zap"
+	"golang.org/x/net/publicsuffix"
+)
+
+const (
+	// maxAnalyticsFieldLength matches the rollup column sizes
+	maxAnalyticsFieldLength = 255
+	// maxSearchQueryLength matches the search rollup column size
+	maxSearchQueryLength = 100
+	// maxAnalyticsRange bounds reporting queries to roughly a year
+	maxAnalyticsRange = 366 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidPageview  = errors.New("invalid pageview")
+	ErrInvalidDateRange = errors.New("invalid date range")
+	ErrUnknownDimension = errors.New("unknown analytics dimension")
+)
+
+// AnalyticsService defines methods for analytics service
+type AnalyticsService interface {
+	RecordPageview(ctx context.Context, req *model.PageviewRequest, ip, userAgent, country string) error
+	Summary(ctx context.Context, from, to time.Time) (*model.AnalyticsSummary, error)
+	Top(ctx context.Context, dimension string, from, to time.Time, limit int) ([]model.AnalyticsBreakdown, error)
+	Referrers(ctx context.Context, from, to time.Time, limit int) (*model.ReferrerReport, error)
+	RecordSearch(ctx context.Context, query string, results int)
+	Searches(ctx context.Context, from, to time.Time, limit int) (*model.SearchReport, error)
+}
+
+// analyticsService is the implementation of AnalyticsService
+type analyticsService struct {
+	analyticsRepo  repository.AnalyticsRepository
+	enabled        bool
+	salt           []byte
+	siteHost       string
+	events         *events.Hub
+	spikeThreshold int
+
+	// Pageviews counted on this instance in the current minute, for spike detection
+	mutex       sync.Mutex
+	minute      time.Time
+	minuteViews int
+}
+
+// NewAnalyticsService creates a new AnalyticsService
+func NewAnalyticsService(analyticsRepo repository.AnalyticsRepository, hub *events.Hub, cfg config.Config) AnalyticsService {
+	salt := cfg.AnalyticsSalt
+	if salt == "" {
+		salt = cfg.JWTSecret
+	}
+
+	var siteHost string
+	if u, err := url.Parse(cfg.FrontendURL); err == nil {
+		siteHost = strings.ToLower(u.Hostname())
+	}
+
+	return &analyticsService{
+		analyticsRepo:  analyticsRepo,
+		enabled:        cfg.AnalyticsEnabled,
+		salt:           []byte(salt),
+		siteHost:       siteHost,
+		events:         hub,
+		spikeThreshold: cfg.AnalyticsSpikeThreshold,
+	}
+}
+
+// RecordPageview records an anonymous pageview; no IP or user agent is stored
+func (s *analyticsService) RecordPageview(ctx context.Context, req *model.PageviewRequest, ip, userAgent, country string) error {
+	if !s.enabled {
+		return nil
+	}
+
+	path := normalizePath(req.Path)
+	if path == "" {
+		return ErrInvalidPageview
+	}
+
+	s.countPageview()
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+
+	return s.analyticsRepo.RecordPageview(
+		ctx,
+		day,
+		s.visitorHash(day, ip, userAgent),
+		path,
+		s.referrerDomain(req.Referrer),
+		normalizeCountry(country),
+	)
+}
+
+// countPageview counts a pageview in the current minute and publishes a spike event the
+// first time the minute reaches ANALYTICS_SPIKE_THRESHOLD
+func (s *analyticsService) countPageview() {
+	if s.spikeThreshold <= 0 {
+		return
+	}
+
+	s.mutex.Lock()
+	minute := time.Now().UTC().Truncate(time.Minute)
+	if !minute.Equal(s.minute) {
+		s.minute = minute
+		s.minuteViews = 0
+	}
+	s.minuteViews++
+	spike := s.minuteViews == s.spikeThreshold
+	s.mutex.Unlock()
+
+	if spike {
+		s.events.Publish(events.TypePageviewSpike, map[string]interface{}{
+			"minute": minute,
+			"views":  s.spikeThreshold,
+		})
+	}
+}
+
+// Summary returns totals and daily stats for the range
+func (s *analyticsService) Summary(ctx context.Context, from, to time.Time) (*model.AnalyticsSummary, error) {
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	daily, err := s.analyticsRepo.Daily(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &model.AnalyticsSummary{
+		From:  from.Format("2006-01-02"),
+		To:    to.Format("2006-01-02"),
+		Daily: daily,
+	}
+	for _, day := range daily {
+		summary.Views += day.Views
+		summary.Visitors += day.Visitors
+	}
+
+	return summary, nil
+}
+
+// Top returns the most viewed paths, referrers or countries for the range
+func (s *analyticsService) Top(ctx context.Context, dimension string, from, to time.Time, limit int) ([]model.AnalyticsBreakdown, error) {
+	if !repository.IsAnalyticsDimension(dimension) {
+		return nil, ErrUnknownDimension
+	}
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	return s.analyticsRepo.TopBy(ctx, dimension, from, to, limit)
+}
+
+// Referrers returns the referring domains with the most views for the range, and the views
+// without an external referrer
+func (s *analyticsService) Referrers(ctx context.Context, from, to time.Time, limit int) (*model.ReferrerReport, error) {
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	domains, direct, err := s.analyticsRepo.TopReferrers(ctx, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ReferrerReport{
+		From:   from.Format("2006-01-02"),
+		To:     to.Format("2006-01-02"),
+		Direct: direct,
+		Items:  domains,
+	}, nil
+}
+
+// RecordSearch records a normalized search query and its result count. Nothing about the
+// visitor is stored, and failures are only logged so searches never fail because of analytics.
+func (s *analyticsService) RecordSearch(ctx context.Context, query string, results int) {
+	if !s.enabled {
+		return
+	}
+
+	query = normalizeSearchQuery(query)
+	if query == "" {
+		return
+	}
+
+	day := time.Now().UTC().Truncate(24 * time.Hour)
+	if err := s.analyticsRepo.RecordSearch(ctx, day, query, results); err != nil {
+		logger.ErrorContext(ctx, "Failed to record search", zap.Error(err))
+	}
+}
+
+// Searches returns the top and zero-result search queries for the range
+func (s *analyticsService) Searches(ctx context.Context, from, to time.Time, limit int) (*model.SearchReport, error) {
+	if err := validateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	top, err := s.analyticsRepo.TopSearches(ctx, from, to, false, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	zeroResults, err := s.analyticsRepo.TopSearches(ctx, from, to, true, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SearchReport{
+		From:        from.Format("2006-01-02"),
+		To:          to.Format("2006-01-02"),
+		Top:         top,
+		ZeroResults: zeroResults,
+	}, nil
+}
+
+// visitorHash derives a visitor identifier that rotates daily,
+// so visitors can be counted without being tracked across days
+func (s *analyticsService) visitorHash(day time.Time, ip, userAgent string) string {
+	mac := hmac.New(sha256.New, s.salt)
+	mac.Write([]byte(day.Format("2006-01-02") + "|" + ip + "|" + userAgent))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// referrerDomain scrubs a referrer down to its registrable domain, like facebook.com for
+// https://l.facebook.com/l.php?u=..., so no page, query or subdomain of the referring site is
+// stored. Internal navigation, IP addresses and non-web referrers like android-app:// are dropped.
+func (s *analyticsService) referrerDomain(referrer string) string {
+	u, err := url.Parse(strings.TrimSpace(referrer))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ""
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if net.ParseIP(host) != nil || strings.TrimPrefix(host, "www.") == strings.TrimPrefix(s.siteHost, "www.") {
+		return ""
+	}
+
+	// Hosts without a public suffix, like localhost, are kept whole
+	domain, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		domain = host
+	}
+
+	return truncate(domain, maxAnalyticsFieldLength)
+}
+
+// normalizePath strips query strings and fragments from a path
+func normalizePath(path string) string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	if !strings.HasPrefix(path, "/") {
+		return ""
+	}
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	return truncate(path, maxAnalyticsFieldLength)
+}
+
+// normalizeSearchQuery lowercases a query and collapses whitespace so variants group together
+func normalizeSearchQuery(query string) string {
+	query = strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	return strings.TrimSpace(truncate(query, maxSearchQueryLength))
+}
+
+// normalizeCountry accepts ISO 3166-1 alpha-2 codes only
+func normalizeCountry(country string) string {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if len(country) != 2 || country == "XX" {
+		return ""
+	}
+	return country
+}
+
+// validateRange checks the reporting range is ordered and bounded
+func validateRange(from, to time.Time) error {
+	if to.Before(from) || to.Sub(from) > maxAnalyticsRange {
+		return ErrInvalidDateRange
+	}
+	return nil
+}
+
+// truncate shortens s to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+)
+
+// API key format: a recognisable prefix followed by 32 random bytes, hex encoded
+const (
+	apiKeyPrefix       = "pwk_"
+	apiKeyBytes        = 32
+	apiKeyDisplayChars = 12
+)
+
+// apiKeyUsageDays is how many days of daily request counts are kept and reported
+const apiKeyUsageDays = 30
+
+var (
+	ErrInvalidAPIKeyTier = errors.New("unknown API key tier")
+	ErrAPIKeyNotFound    = errors.New("API key not found")
+)
+
+// APIKeyService defines methods for managing API keys and reporting their usage
+type APIKeyService interface {
+	Create(ctx context.Context, userID string, key *model.APIKeyCreate) (*model.APIKeyCreated, error)
+	List(ctx context.Context, userID string) ([]model.APIKey, error)
+	Revoke(ctx context.Context, userID, id string) error
+	Usage(ctx context.Context, userID, id string) (*model.APIKeyUsage, error)
+	PurgeUsage(ctx context.Context) error
+}
+
+// apiKeyService is the implementation of APIKeyService
+type apiKeyService struct {
+	apiKeyRepo repository.APIKeyRepository
+	cfg        config.Config
+}
+
+// NewAPIKeyService creates a new APIKeyService
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository, cfg config.Config) APIKeyService {
+	return &apiKeyService{
+		apiKeyRepo: apiKeyRepo,
+		cfg:        cfg,
+	}
+}
+
+// Create generates a new API key for the user; the secret is only returned here
+func (s *apiKeyService) Create(ctx context.Context, userID string, key *model.APIKeyCreate) (*model.APIKeyCreated, error) {
+	tiers := s.cfg.APIKeyTierList()
+	tier := strings.ToLower(strings.TrimSpace(key.Tier))
+	if tier == "" && len(tiers) > 0 {
+		tier = tiers[0].Name
+	}
+	if _, ok := s.tierLimit(tier); !ok {
+		return nil, ErrInvalidAPIKeyTier
+	}
+
+	token, err := util.GenerateRandomToken(apiKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+	secret := apiKeyPrefix + token
+
+	created, err := s.apiKeyRepo.Create(ctx, userID, key.Name, secret[:apiKeyDisplayChars], util.HashToken(secret), tier)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.APIKeyCreated{APIKey: *created, Key: secret}, nil
+}
+
+// List lists the user's API keys, revoked ones included
+func (s *apiKeyService) List(ctx context.Context, userID string) ([]model.APIKey, error) {
+	return s.apiKeyRepo.ListByUser(ctx, userID)
+}
+
+// Revoke revokes one of the user's API keys
+func (s *apiKeyService) Revoke(ctx context.Context, userID, id string) error {
+	if _, err := s.ownedKey(ctx, userID, id); err != nil {
+		return err
+	}
+	return s.apiKeyRepo.Revoke(ctx, id)
+}
+
+// Usage reports how much of its tier's limit one of the user's API keys has used in the
+// current window, with its daily request counts
+func (s *apiKeyService) Usage(ctx context.Context, userID, id string) (*model.APIKeyUsage, error) {
+	key, err := s.ownedKey(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	used, err := s.apiKeyRepo.CountSince(ctx, id, now.Add(-s.cfg.APIKeyRateWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	today := now.UTC().Truncate(24 * time.Hour)
+	days, err := s.apiKeyRepo.DailyUsage(ctx, id, today.AddDate(0, 0, -(apiKeyUsageDays-1)))
+	if err != nil {
+		return nil, err
+	}
+
+	// A tier removed from the configuration has no limit of its own anymore
+	limit, _ := s.tierLimit(key.Tier)
+
+	return &model.APIKeyUsage{
+		KeyID:     key.ID,
+		Tier:      key.Tier,
+		Limit:     limit,
+		Window:    s.cfg.APIKeyRateWindow.String(),
+		Used:      used,
+		Remaining: max(limit-used, 0),
+		Days:      days,
+	}, nil
+}
+
+// PurgeUsage deletes request counts older than the reported history and the rate limit window
+func (s *apiKeyService) PurgeUsage(ctx context.Context) error {
+	keep := max(apiKeyUsageDays*24*time.Hour, s.cfg.APIKeyRateWindow)
+	return s.apiKeyRepo.DeleteUsageBefore(ctx, time.Now().Add(-keep))
+}
+
+// ownedKey gets an API key, reporting keys of other users as not found
+func (s *apiKeyService) ownedKey(ctx context.Context, userID, id string) (*model.APIKey, error) {
+	key, err := s.apiKeyRepo.GetByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && key.UserID != userID) {
+		return nil, ErrAPIKeyNotFound
+	}
+	return key, err
+}
+
+// tierLimit returns the request limit of a configured tier
+func (s *apiKeyService) tierLimit(tier string) (int, bool) {
+	for _, t := range s.cfg.APIKeyTierList() {
+		if t.Name == tier {
+			return t.Max, true
+		}
+	}
+	return 0, false
+}
@@ -2,51 +2,630 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
 
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/cache"
+	"github.com/budhilaw/personal-website-backend/internal/jobs"
 	"github.com/budhilaw/personal-website-backend/internal/model"
 	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
 )
 
+var (
+	ErrInvalidArchiveMonth  = errors.New("invalid archive month")
+	ErrInvalidTransition    = errors.New("article can't move to this status from its current one")
+	ErrTransitionNotAllowed = errors.New("not allowed to move this article to this status")
+	ErrInvalidSchedule      = errors.New("scheduled time must be in the future")
+	ErrTooManyFeatured      = errors.New("maximum number of featured articles reached")
+	ErrArticleNotProtected  = errors.New("article is not password protected")
+	ErrWrongArticlePassword = errors.New("wrong article password")
+)
+
+// articleAccessAudience keeps article access tokens from being accepted anywhere else that shares the format
+const articleAccessAudience = "article-access"
+
+// articleAccessClaims are the claims of an article access token
+type articleAccessClaims struct {
+	// Password fingerprints the password the token was issued for, so changing it revokes the token
+	Password string `json:"pwd"`
+	jwt.RegisteredClaims
+}
+
+// JobArticlePublished is the job type running the side effects of publishing an article.
+// It is queued in the same transaction that publishes the article, so the jobs table acts
+// as an outbox: the side effects run exactly when the publish commits, even across a crash.
+const JobArticlePublished = "article.published"
+
+// articleCache names the published articles cache in invalidations
+const articleCache = "articles"
+
+// articlePublishedJob is the payload of a JobArticlePublished job
+type articlePublishedJob struct {
+	ArticleID string `json:"article_id"`
+}
+
+// articleTransitions lists the statuses an article can move to from each status
+var articleTransitions = map[string][]string{
+	model.ArticleStatusDraft:     {model.ArticleStatusInReview, model.ArticleStatusScheduled, model.ArticleStatusPublished, model.ArticleStatusArchived},
+	model.ArticleStatusInReview:  {model.ArticleStatusDraft, model.ArticleStatusScheduled, model.ArticleStatusPublished},
+	model.ArticleStatusScheduled: {model.ArticleStatusDraft, model.ArticleStatusPublished},
+	model.ArticleStatusPublished: {model.ArticleStatusDraft, model.ArticleStatusArchived},
+	model.ArticleStatusArchived:  {model.ArticleStatusDraft, model.ArticleStatusPublished},
+}
+
 // ArticleService defines methods for article service
 type ArticleService interface {
-	Create(ctx context.Context, article *model.ArticleCreate, userID string) (string, error)
-	Update(ctx context.Context, id string, article *model.ArticleUpdate) error
+	Create(ctx context.Context, article *model.ArticleCreate, userID, role string) (string, error)
+	Update(ctx context.Context, id string, article *model.ArticleUpdate, actorID, actorRole string) error
+	Duplicate(ctx context.Context, id, userID string) (string, error)
+	Transition(ctx context.Context, id string, transition *model.ArticleTransition, actorID, actorRole string) (*model.Article, error)
+	PublishScheduled(ctx context.Context) error
+	SetFeatured(ctx context.Context, id string, featured bool) (*model.Article, error)
+	SetPassword(ctx context.Context, id, password string) (*model.Article, error)
+	Unlock(ctx context.Context, slug, password string) (*model.ArticleAccess, error)
+	Redact(article *model.ArticleResponse, token string)
 	Delete(ctx context.Context, id string) error
 	GetByID(ctx context.Context, id string) (*model.Article, error)
 	GetBySlug(ctx context.Context, slug string) (*model.Article, error)
-	List(ctx context.Context, page, perPage int, onlyPublished bool) ([]model.Article, int, error)
+	List(ctx context.Context, page, perPage int, onlyPublished bool, opts model.ListOptions) ([]model.Article, int, error)
+	ListAfter(ctx context.Context, cursor string, limit int, onlyPublished bool, opts model.ListOptions) ([]model.Article, string, error)
+	ListFields(ctx context.Context, fields []string, page, perPage int, onlyPublished bool, opts model.ListOptions) ([]model.PartialItem, int, error)
+	ListFieldsAfter(ctx context.Context, fields []string, cursor string, limit int, onlyPublished bool, opts model.ListOptions) ([]model.PartialItem, string, error)
 	GetByAuthor(ctx context.Context, userID string, page, perPage int) ([]model.Article, int, error)
 	GetArticleWithAuthor(ctx context.Context, id string) (*model.ArticleResponse, error)
 	GetBySlugWithAuthor(ctx context.Context, slug string) (*model.ArticleResponse, error)
+	Archive(ctx context.Context) ([]model.ArchiveMonth, error)
+	ListByMonth(ctx context.Context, year, month int) ([]model.Article, error)
+	ListPopular(ctx context.Context, days, limit int) ([]model.Article, error)
+	ListRecentlyUpdated(ctx context.Context, limit int) ([]model.Article, error)
+	ListFeatured(ctx context.Context) ([]model.Article, error)
+
+	HandlePublishedJob(ctx context.Context, payload json.RawMessage) error
 }
 
 // articleService is the implementation of ArticleService
 type articleService struct {
-	articleRepo repository.ArticleRepository
-	userRepo    repository.UserRepository
+	articleRepo         repository.ArticleRepository
+	userRepo            repository.UserRepository
+	seriesRepo          repository.SeriesRepository
+	syndicationRepo     repository.ArticleSyndicationRepository
+	discussionRepo      repository.ArticleDiscussionRepository
+	tagRepo             repository.TagRepository
+	txManager           repository.TxManager
+	notificationService *NotificationService
+	activityPubService  ActivityPubService
+	pushService         PushService
+	revalidation        RevalidationService
+	audit               ContentAuditService
+	queue               jobs.Enqueuer
+	markdown            *util.MarkdownRenderer
+	cfg                 config.Config
+	location            *time.Location
+
+	// Published articles by slug; nil while the content cache is disabled
+	invalidator *cache.Invalidator
+	bySlug      *cache.Store[*model.ArticleResponse]
+}
+
+// NewArticleService creates a new ArticleService; invalidator is nil while the content cache is disabled
+func NewArticleService(articleRepo repository.ArticleRepository, userRepo repository.UserRepository, seriesRepo repository.SeriesRepository, syndicationRepo repository.ArticleSyndicationRepository, discussionRepo repository.ArticleDiscussionRepository, tagRepo repository.TagRepository, txManager repository.TxManager, notificationService *NotificationService, activityPubService ActivityPubService, pushService PushService, revalidation RevalidationService, audit ContentAuditService, queue jobs.Enqueuer, markdown *util.MarkdownRenderer, invalidator *cache.Invalidator, cfg config.Config) ArticleService {
+	service := &articleService{
+		articleRepo:         articleRepo,
+		userRepo:            userRepo,
+		seriesRepo:          seriesRepo,
+		syndicationRepo:     syndicationRepo,
+		discussionRepo:      discussionRepo,
+		tagRepo:             tagRepo,
+		txManager:           txManager,
+		notificationService: notificationService,
+		activityPubService:  activityPubService,
+		pushService:         pushService,
+		revalidation:        revalidation,
+		audit:               audit,
+		queue:               queue,
+		markdown:            markdown,
+		cfg:                 cfg,
+		location:            cfg.Location(),
+		invalidator:         invalidator,
+	}
+
+	if invalidator != nil {
+		service.bySlug = cache.NewStore[*model.ArticleResponse](cfg.ContentCacheTTL)
+		invalidator.Register(articleCache, service.bySlug)
+	}
+
+	return service
+}
+
+// Create creates a new article as a draft, or published when the author may publish without review
+func (s *articleService) Create(ctx context.Context, article *model.ArticleCreate, userID, role string) (string, error) {
+	if article.IsPublished && !canTransition(userID, role, &model.Article{UserID: userID, Status: model.ArticleStatusDraft}, model.ArticleStatusPublished) {
+		return "", ErrTransitionNotAllowed
+	}
+
+	var id string
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		id, err = s.articleRepo.Create(ctx, article, userID)
+		if err != nil {
+			return err
+		}
+		if err := s.recordChange(ctx, id, nil); err != nil {
+			return err
+		}
+
+		if article.IsPublished {
+			if err := s.queuePublished(ctx, id); err != nil {
+				return err
+			}
+			return s.revalidateArticle(ctx, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// Update updates an article; a change of published state is checked like a status transition
+func (s *articleService) Update(ctx context.Context, id string, article *model.ArticleUpdate, actorID, actorRole string) error {
+	var wasPublished bool
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.articleRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		wasPublished = current.IsPublished
+
+		if next := article.NextStatus(current.Status); next != current.Status {
+			if err := checkTransition(actorID, actorRole, current, next); err != nil {
+				return err
+			}
+		}
+
+		if err := s.articleRepo.Update(ctx, id, article); err != nil {
+			return err
+		}
+		if err := s.recordChange(ctx, id, current); err != nil {
+			return err
+		}
+
+		// Only notify on the transition from draft to published
+		if !wasPublished && article.IsPublished {
+			if err := s.queuePublished(ctx, id); err != nil {
+				return err
+			}
+		}
+		if wasPublished || article.IsPublished {
+			return s.revalidateArticle(ctx, id, current.Slug)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Duplicate copies an article into a new draft owned by userID, to use an existing post as a template
+func (s *articleService) Duplicate(ctx context.Context, id, userID string) (string, error) {
+	var copyID string
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		source, err := s.articleRepo.GetByID(ctx, id)
+		if err != nil {
+			return ErrContentNotFound
+		}
+
+		slug, err := s.copySlug(ctx, source.Slug)
+		if err != nil {
+			return err
+		}
+
+		article := &model.ArticleCreate{
+			Title:         source.Title,
+			Slug:          slug,
+			Content:       source.Content,
+			Excerpt:       source.Excerpt,
+			FeaturedImage: source.FeaturedImage,
+			SEOMeta:       source.SEOMeta,
+		}
+		// The copy isn't the original, so it shouldn't claim its canonical URL
+		article.CanonicalURL = ""
+
+		copyID, err = s.articleRepo.Create(ctx, article, userID)
+		if err != nil {
+			return err
+		}
+		return s.recordChange(ctx, copyID, nil)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return copyID, nil
+}
+
+// copySlug returns slug with a -copy suffix, numbered when earlier copies exist
+func (s *articleService) copySlug(ctx context.Context, slug string) (string, error) {
+	base := slug + "-copy"
+	candidate := base
+	for i := 2; ; i++ {
+		exists, err := s.articleRepo.SlugExists(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+// Transition moves an article to another workflow status
+func (s *articleService) Transition(ctx context.Context, id string, transition *model.ArticleTransition, actorID, actorRole string) (*model.Article, error) {
+	scheduledAt := transition.ScheduledAt.In(s.location)
+	if transition.Status == model.ArticleStatusScheduled && !scheduledAt.After(time.Now()) {
+		return nil, ErrInvalidSchedule
+	}
+
+	var wasPublished bool
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.articleRepo.GetByID(ctx, id)
+		if err != nil {
+			return ErrContentNotFound
+		}
+		wasPublished = current.IsPublished
+
+		if err := checkTransition(actorID, actorRole, current, transition.Status); err != nil {
+			return err
+		}
+
+		if err := s.articleRepo.SetStatus(ctx, id, transition.Status, scheduledAt); err != nil {
+			return err
+		}
+		if err := s.recordChange(ctx, id, current); err != nil {
+			return err
+		}
+
+		if !wasPublished && transition.Status == model.ArticleStatusPublished {
+			if err := s.queuePublished(ctx, id); err != nil {
+				return err
+			}
+		}
+		if wasPublished || transition.Status == model.ArticleStatusPublished {
+			return s.revalidateArticle(ctx, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.articleRepo.GetByID(ctx, id)
+}
+
+// PublishScheduled publishes the scheduled articles that are due
+func (s *articleService) PublishScheduled(ctx context.Context) error {
+	var ids []string
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		due, err := s.articleRepo.ListDue(ctx, now)
+		if err != nil {
+			return err
+		}
+		ids, err = s.articleRepo.PublishDue(ctx, now)
+		if err != nil {
+			return err
+		}
+
+		for _, article := range due {
+			if err := s.recordChange(ctx, article.ID, &article); err != nil {
+				return err
+			}
+		}
+		for _, id := range ids {
+			if err := s.queuePublished(ctx, id); err != nil {
+				return err
+			}
+			if err := s.revalidateArticle(ctx, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		logger.InfoContext(ctx, "Scheduled article published", zap.String("id", id))
+	}
+
+	return nil
 }
 
-// NewArticleService creates a new ArticleService
-func NewArticleService(articleRepo repository.ArticleRepository, userRepo repository.UserRepository) ArticleService {
-	return &articleService{
-		articleRepo: articleRepo,
-		userRepo:    userRepo,
+// SetFeatured pins a published article to the featured list, up to FEATURED_ARTICLES_MAX
+// at a time, or unpins it
+func (s *articleService) SetFeatured(ctx context.Context, id string, featured bool) (*model.Article, error) {
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.articleRepo.GetByID(ctx, id)
+		if err != nil {
+			return ErrContentNotFound
+		}
+		// Unpinning is always allowed, and pinning again keeps the article's place
+		if !featured || current.IsFeatured {
+			if err := s.articleRepo.SetFeatured(ctx, id, featured); err != nil {
+				return err
+			}
+			if err := s.recordChange(ctx, id, current); err != nil {
+				return err
+			}
+			if current.IsPublished {
+				return s.articleChanged(ctx, current.Slug)
+			}
+			return nil
+		}
+
+		if !current.IsPublished {
+			return ErrArticleNotPublished
+		}
+		count, err := s.articleRepo.CountFeatured(ctx)
+		if err != nil {
+			return err
+		}
+		if count >= s.cfg.FeaturedArticlesMax {
+			return ErrTooManyFeatured
+		}
+
+		if err := s.articleRepo.SetFeatured(ctx, id, true); err != nil {
+			return err
+		}
+		if err := s.recordChange(ctx, id, current); err != nil {
+			return err
+		}
+		return s.articleChanged(ctx, current.Slug)
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	return s.articleRepo.GetByID(ctx, id)
 }
 
-// Create creates a new article
-func (s *articleService) Create(ctx context.Context, article *model.ArticleCreate, userID string) (string, error) {
-	return s.articleRepo.Create(ctx, article, userID)
+// SetPassword protects an article with a password, or makes it public again when password is
+// empty. Changing the password revokes the access tokens issued for the previous one.
+func (s *articleService) SetPassword(ctx context.Context, id, password string) (*model.Article, error) {
+	var passwordHash string
+	if password != "" {
+		hash, err := util.HashPassword(password)
+		if err != nil {
+			return nil, err
+		}
+		passwordHash = hash
+	}
+
+	err := s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.articleRepo.GetByID(ctx, id)
+		if err != nil {
+			return ErrContentNotFound
+		}
+
+		if err := s.articleRepo.SetPassword(ctx, id, passwordHash); err != nil {
+			return err
+		}
+		if err := s.recordChange(ctx, id, current); err != nil {
+			return err
+		}
+		if current.IsPublished {
+			return s.articleChanged(ctx, current.Slug)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.articleRepo.GetByID(ctx, id)
 }
 
-// Update updates an article
-func (s *articleService) Update(ctx context.Context, id string, article *model.ArticleUpdate) error {
-	return s.articleRepo.Update(ctx, id, article)
+// Unlock checks the password of a published, password-protected article and issues a token
+// for its content that expires after ARTICLE_ACCESS_TOKEN_EXPIRY
+func (s *articleService) Unlock(ctx context.Context, slug, password string) (*model.ArticleAccess, error) {
+	article, err := s.articleRepo.GetBySlug(ctx, slug)
+	if err != nil || !article.IsPublished {
+		return nil, ErrContentNotFound
+	}
+	if !article.IsProtected {
+		return nil, ErrArticleNotProtected
+	}
+
+	ok, err := util.VerifyPassword(password, article.PasswordHash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrWrongArticlePassword
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.cfg.ArticleAccessTokenExpiry)
+	claims := articleAccessClaims{
+		Password: passwordFingerprint(article.PasswordHash),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   article.ID,
+			Audience:  jwt.ClaimStrings{articleAccessAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.cfg.JWTSecret))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ArticleAccess{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Redact leaves out the content of a password-protected article unless token is an access
+// token issued by Unlock for its current password
+func (s *articleService) Redact(article *model.ArticleResponse, token string) {
+	if !article.IsProtected || s.canAccess(article, token) {
+		return
+	}
+
+	article.Content = ""
+	article.ContentHTML = ""
+	article.TOC = []model.TOCEntry{}
+	article.Locked = true
+}
+
+// canAccess reports whether token is a valid access token for a protected article
+func (s *articleService) canAccess(article *model.ArticleResponse, token string) bool {
+	if token == "" {
+		return false
+	}
+
+	var claims articleAccessClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(s.cfg.JWTSecret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(articleAccessAudience),
+		jwt.WithExpirationRequired(),
+	)
+	return err == nil && claims.Subject == article.ID && claims.Password == passwordFingerprint(article.PasswordHash)
+}
+
+// passwordFingerprint identifies a password hash in access tokens without revealing it
+func passwordFingerprint(passwordHash string) string {
+	return util.HashToken(passwordHash)[:16]
+}
+
+// checkTransition checks that an article can move to a status and that the actor may move it
+func checkTransition(actorID, actorRole string, article *model.Article, to string) error {
+	// Rescheduling is the only transition to the same status
+	rescheduling := article.Status == model.ArticleStatusScheduled && to == model.ArticleStatusScheduled
+	if !rescheduling && !slices.Contains(articleTransitions[article.Status], to) {
+		return ErrInvalidTransition
+	}
+	if !canTransition(actorID, actorRole, article, to) {
+		return ErrTransitionNotAllowed
+	}
+	return nil
+}
+
+// canTransition reports whether an actor may move an article to a status. Owners may make
+// any transition. Admins submit, withdraw and archive their own articles, and publish,
+// schedule or send back articles in review written by someone else.
+func canTransition(actorID, actorRole string, article *model.Article, to string) bool {
+	if actorRole == model.RoleOwner {
+		return true
+	}
+
+	isAuthor := article.UserID == actorID
+	switch to {
+	case model.ArticleStatusInReview, model.ArticleStatusArchived:
+		return isAuthor
+	case model.ArticleStatusDraft:
+		return isAuthor || article.Status == model.ArticleStatusInReview
+	case model.ArticleStatusScheduled, model.ArticleStatusPublished:
+		return !isAuthor && (article.Status == model.ArticleStatusInReview || article.Status == model.ArticleStatusScheduled)
+	}
+	return false
+}
+
+// queuePublished queues the side effects of publishing an article in the caller's transaction
+func (s *articleService) queuePublished(ctx context.Context, id string) error {
+	return s.queue.Enqueue(ctx, JobArticlePublished, articlePublishedJob{ArticleID: id})
+}
+
+// revalidateArticle queues revalidation of an article's pages, and of the pages under its
+// previous slugs
+func (s *articleService) revalidateArticle(ctx context.Context, id string, previousSlugs ...string) error {
+	article, err := s.articleRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.articleChanged(ctx, append(previousSlugs, article.Slug)...)
+}
+
+// recordChange adds an article's state after a change to the audit trail, next to its state
+// before, which is nil for new articles
+func (s *articleService) recordChange(ctx context.Context, id string, before *model.Article) error {
+	after, err := s.articleRepo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.audit.Record(ctx, model.AuditEntityArticle, id, before, after)
+}
+
+// articleChanged drops a published article from every instance's cache and queues revalidation
+// of its pages; slugs are the article's current and previous slugs
+func (s *articleService) articleChanged(ctx context.Context, slugs ...string) error {
+	if s.invalidator != nil {
+		if err := s.invalidator.Invalidate(ctx, articleCache, slugs...); err != nil {
+			return err
+		}
+	}
+	return s.revalidation.ArticleChanged(ctx, slugs...)
+}
+
+// HandlePublishedJob sends the article published notification, federates the article and
+// notifies push subscribers. Only loading the article is retried: the rest queue their own
+// jobs, which would be duplicated by a retry.
+func (s *articleService) HandlePublishedJob(ctx context.Context, payload json.RawMessage) error {
+	var job articlePublishedJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+
+	article, err := s.GetArticleWithAuthor(ctx, job.ArticleID)
+	if err != nil {
+		return err
+	}
+
+	s.notificationService.SendArticlePublished(article.Title, article.Slug, article.Author.Username)
+	s.activityPubService.PublishArticle(ctx, job.ArticleID)
+	s.pushService.PublishArticle(ctx, job.ArticleID)
+	return nil
 }
 
 // Delete deletes an article
 func (s *articleService) Delete(ctx context.Context, id string) error {
-	return s.articleRepo.Delete(ctx, id)
+	return s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		current, err := s.articleRepo.GetByID(ctx, id)
+		if err != nil {
+			// Deleting an unknown article stays a no-op
+			return s.articleRepo.Delete(ctx, id)
+		}
+
+		if err := s.articleRepo.Delete(ctx, id); err != nil {
+			return err
+		}
+		if err := s.audit.Record(ctx, model.AuditEntityArticle, id, current, nil); err != nil {
+			return err
+		}
+
+		if current.IsPublished {
+			return s.articleChanged(ctx, current.Slug)
+		}
+		return nil
+	})
 }
 
 // GetByID gets an article by ID
@@ -60,8 +639,71 @@ func (s *articleService) GetBySlug(ctx context.Context, slug string) (*model.Art
 }
 
 // List lists articles with pagination
-func (s *articleService) List(ctx context.Context, page, perPage int, onlyPublished bool) ([]model.Article, int, error) {
-	return s.articleRepo.List(ctx, page, perPage, onlyPublished)
+func (s *articleService) List(ctx context.Context, page, perPage int, onlyPublished bool, opts model.ListOptions) ([]model.Article, int, error) {
+	if err := validateListOptions(opts); err != nil {
+		return nil, 0, err
+	}
+
+	return s.articleRepo.List(ctx, page, perPage, onlyPublished, opts)
+}
+
+// ListAfter lists articles after the cursor and returns the cursor of the next page, empty on the last page
+func (s *articleService) ListAfter(ctx context.Context, cursor string, limit int, onlyPublished bool, opts model.ListOptions) ([]model.Article, string, error) {
+	if err := validateCursorOptions(opts); err != nil {
+		return nil, "", err
+	}
+
+	after, err := parseCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Fetch one extra article to know whether another page follows
+	articles, err := s.articleRepo.ListAfter(ctx, after, limit+1, onlyPublished, opts)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(articles) <= limit {
+		return articles, "", nil
+	}
+
+	articles = articles[:limit]
+	last := articles[limit-1]
+	return articles, util.EncodeCursor(last.CreatedAt, last.ID), nil
+}
+
+// ListFields lists articles with only the requested fields, skipping authors and rendering
+func (s *articleService) ListFields(ctx context.Context, fields []string, page, perPage int, onlyPublished bool, opts model.ListOptions) ([]model.PartialItem, int, error) {
+	if err := validateListOptions(opts); err != nil {
+		return nil, 0, err
+	}
+
+	items, total, err := s.articleRepo.ListFields(ctx, fields, page, perPage, onlyPublished, opts)
+	if err != nil {
+		return nil, 0, fieldsError(err)
+	}
+	return items, total, nil
+}
+
+// ListFieldsAfter lists articles after the cursor with only the requested fields
+func (s *articleService) ListFieldsAfter(ctx context.Context, fields []string, cursor string, limit int, onlyPublished bool, opts model.ListOptions) ([]model.PartialItem, string, error) {
+	if err := validateCursorOptions(opts); err != nil {
+		return nil, "", err
+	}
+
+	after, err := parseCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Fetch one extra article to know whether another page follows
+	items, err := s.articleRepo.ListFieldsAfter(ctx, fields, after, limit+1, onlyPublished, opts)
+	if err != nil {
+		return nil, "", fieldsError(err)
+	}
+
+	items, next := partialCursorPage(items, limit, fields)
+	return items, next, nil
 }
 
 // GetByAuthor gets articles by author ID with pagination
@@ -69,6 +711,37 @@ func (s *articleService) GetByAuthor(ctx context.Context, userID string, page, p
 	return s.articleRepo.GetByAuthor(ctx, userID, page, perPage)
 }
 
+// Archive returns the number of published articles per month of the site timezone
+func (s *articleService) Archive(ctx context.Context) ([]model.ArchiveMonth, error) {
+	return s.articleRepo.Archive(ctx, s.location)
+}
+
+// ListByMonth lists the articles published in a calendar month of the site timezone
+func (s *articleService) ListByMonth(ctx context.Context, year, month int) ([]model.Article, error) {
+	if year < 1 || year > 9999 || month < 1 || month > 12 {
+		return nil, ErrInvalidArchiveMonth
+	}
+
+	from := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, s.location)
+	return s.articleRepo.ListPublishedBetween(ctx, from, from.AddDate(0, 1, 0))
+}
+
+// ListPopular lists the most viewed published articles over the last days
+func (s *articleService) ListPopular(ctx context.Context, days, limit int) ([]model.Article, error) {
+	since := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -(days - 1))
+	return s.articleRepo.ListPopular(ctx, since, limit)
+}
+
+// ListRecentlyUpdated lists published articles by their latest edit
+func (s *articleService) ListRecentlyUpdated(ctx context.Context, limit int) ([]model.Article, error) {
+	return s.articleRepo.ListRecentlyUpdated(ctx, limit)
+}
+
+// ListFeatured lists the published featured articles, most recently featured first
+func (s *articleService) ListFeatured(ctx context.Context) ([]model.Article, error) {
+	return s.articleRepo.ListFeatured(ctx)
+}
+
 // GetArticleWithAuthor gets an article with author information
 func (s *articleService) GetArticleWithAuthor(ctx context.Context, id string) (*model.ArticleResponse, error) {
 	article, err := s.articleRepo.GetByID(ctx, id)
@@ -76,40 +749,98 @@ func (s *articleService) GetArticleWithAuthor(ctx context.Context, id string) (*
 		return nil, err
 	}
 
-	author, err := s.userRepo.GetByID(ctx, article.UserID)
+	response, err := s.toResponse(ctx, article)
 	if err != nil {
 		return nil, err
 	}
 
-	response := &model.ArticleResponse{
-		ID:            article.ID,
-		Title:         article.Title,
-		Slug:          article.Slug,
-		Content:       article.Content,
-		Excerpt:       article.Excerpt,
-		FeaturedImage: article.FeaturedImage,
-		IsPublished:   article.IsPublished,
-		CreatedAt:     article.CreatedAt,
-		UpdatedAt:     article.UpdatedAt,
-		PublishedAt:   article.PublishedAt,
-	}
-
-	response.Author.ID = author.ID
-	response.Author.Username = author.Username
-	response.Author.FirstName = author.FirstName
-	response.Author.LastName = author.LastName
-	response.Author.Avatar = author.Avatar
-
+	s.attachSyndications(ctx, response)
+	s.attachDiscussion(ctx, response)
+	s.attachTags(ctx, response)
 	return response, nil
 }
 
-// GetBySlugWithAuthor gets an article by slug with author information
+// GetBySlugWithAuthor gets an article by slug with author information. Published articles are
+// served from the content cache when it is enabled; callers get their own copy to modify.
 func (s *articleService) GetBySlugWithAuthor(ctx context.Context, slug string) (*model.ArticleResponse, error) {
+	if s.bySlug != nil {
+		if cached, ok := s.bySlug.Get(slug); ok {
+			response := *cached
+			return &response, nil
+		}
+	}
+
 	article, err := s.articleRepo.GetBySlug(ctx, slug)
 	if err != nil {
 		return nil, err
 	}
 
+	response, err := s.toResponse(ctx, article)
+	if err != nil {
+		return nil, err
+	}
+
+	// Found through slug history, let the frontend redirect to the canonical slug
+	if article.Slug != slug {
+		response.RedirectedFrom = slug
+	}
+
+	s.attachSyndications(ctx, response)
+	s.attachDiscussion(ctx, response)
+	s.attachTags(ctx, response)
+
+	// Invalidations name the current slug, so only cache published articles found by it
+	if s.bySlug != nil && article.IsPublished && response.RedirectedFrom == "" {
+		cached := *response
+		s.bySlug.Set(slug, &cached)
+	}
+	return response, nil
+}
+
+// attachSyndications adds the article's live cross-posted copies to the response
+func (s *articleService) attachSyndications(ctx context.Context, response *model.ArticleResponse) {
+	syndications, err := s.syndicationRepo.ListByArticle(ctx, response.ID)
+	if err != nil {
+		// Cross-post links are supplementary, don't fail the article
+		logger.ErrorContext(ctx, "Failed to load article syndications", zap.Error(err), zap.String("id", response.ID))
+		return
+	}
+
+	for _, syndication := range syndications {
+		if syndication.Status == model.SyndicationPublished {
+			response.Syndications = append(response.Syndications, syndication)
+		}
+	}
+}
+
+// attachTags adds the article's tags to the response
+func (s *articleService) attachTags(ctx context.Context, response *model.ArticleResponse) {
+	tags, err := s.tagRepo.ListByArticle(ctx, response.ID)
+	if err != nil {
+		// Tags are supplementary, don't fail the article
+		logger.ErrorContext(ctx, "Failed to load article tags", zap.Error(err), zap.String("id", response.ID))
+		return
+	}
+	response.Tags = tags
+}
+
+// attachDiscussion adds the article's synced giscus discussion to the response
+func (s *articleService) attachDiscussion(ctx context.Context, response *model.ArticleResponse) {
+	if s.cfg.GiscusRepo == "" {
+		return
+	}
+
+	discussion, err := s.discussionRepo.GetByArticle(ctx, response.ID)
+	if err != nil {
+		// Comments are supplementary, don't fail the article
+		logger.ErrorContext(ctx, "Failed to load article discussion", zap.Error(err), zap.String("id", response.ID))
+		return
+	}
+	response.Discussion = discussion
+}
+
+// toResponse builds an article response with author and series information
+func (s *articleService) toResponse(ctx context.Context, article *model.Article) (*model.ArticleResponse, error) {
 	author, err := s.userRepo.GetByID(ctx, article.UserID)
 	if err != nil {
 		return nil, err
@@ -123,9 +854,21 @@ func (s *articleService) GetBySlugWithAuthor(ctx context.Context, slug string) (
 		Excerpt:       article.Excerpt,
 		FeaturedImage: article.FeaturedImage,
 		IsPublished:   article.IsPublished,
+		IsFeatured:    article.IsFeatured,
+		Status:        article.Status,
+		SEOMeta:       article.SEOMeta,
+		TOC:           article.TOC,
+		IsProtected:   article.IsProtected,
+		PasswordHash:  article.PasswordHash,
 		CreatedAt:     article.CreatedAt,
 		UpdatedAt:     article.UpdatedAt,
 		PublishedAt:   article.PublishedAt,
+		ScheduledAt:   article.ScheduledAt,
+	}
+
+	response.ContentHTML, err = s.markdown.Render(article.Content)
+	if err != nil {
+		return nil, err
 	}
 
 	response.Author.ID = author.ID
@@ -134,5 +877,62 @@ func (s *articleService) GetBySlugWithAuthor(ctx context.Context, slug string) (
 	response.Author.LastName = author.LastName
 	response.Author.Avatar = author.Avatar
 
+	if article.SeriesID != "" {
+		series, err := s.seriesInfo(ctx, article)
+		if err != nil {
+			// Series navigation is supplementary, don't fail the article
+			logger.ErrorContext(ctx, "Failed to load article series", zap.Error(err), zap.String("id", article.ID))
+		} else {
+			response.Series = series
+		}
+	}
+
 	return response, nil
 }
+
+// seriesInfo resolves the article's position and neighbours within its series
+func (s *articleService) seriesInfo(ctx context.Context, article *model.Article) (*model.ArticleSeries, error) {
+	series, err := s.seriesRepo.GetByID(ctx, article.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Previous/next links only point at published parts
+	parts, err := s.articleRepo.ListBySeries(ctx, series.ID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &model.ArticleSeries{
+		ID:    series.ID,
+		Title: series.Title,
+		Slug:  series.Slug,
+		Total: len(parts),
+	}
+
+	for i, part := range parts {
+		if part.ID != article.ID {
+			continue
+		}
+
+		info.Part = i + 1
+		if i > 0 {
+			info.Previous = toArticleLink(parts[i-1])
+		}
+		if i < len(parts)-1 {
+			info.Next = toArticleLink(parts[i+1])
+		}
+		break
+	}
+
+	return info, nil
+}
+
+// toArticleLink converts an article to a lightweight link
+func toArticleLink(article model.Article) *model.ArticleLink {
+	return &model.ArticleLink{
+		ID:    article.ID,
+		Title: article.Title,
+		Slug:  article.Slug,
+	}
+}
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/budhilaw/personal-website-backend/config"
 	"github.com/budhilaw/personal-website-backend/internal/middleware"
@@ -10,93 +12,165 @@ import (
 	"github.com/budhilaw/personal-website-backend/internal/repository"
 	"github.com/budhilaw/personal-website-backend/pkg/logger"
 	"github.com/budhilaw/personal-website-backend/pkg/util"
-	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
 
+// deviceConfirmationTTL is how long a new device confirmation link stays valid
+const deviceConfirmationTTL = 24 * time.Hour
+
+// deviceConfirmationCooldown stops repeated logins from resending the confirmation email
+const deviceConfirmationCooldown = 5 * time.Minute
+
+var (
+	ErrDeviceConfirmationRequired = errors.New("new device must be confirmed by email")
+	ErrInvalidDeviceToken         = errors.New("invalid or expired token")
+)
+
 // AuthService defines methods for authentication service
 type AuthService interface {
-	Login(ctx context.Context, username, password string, c *fiber.Ctx) (*model.LoginResponse, error)
+	Login(ctx context.Context, username, password, ip, userAgent string) (*model.LoginResponse, error)
+	GetDeviceConfirmation(ctx context.Context, token string) (*model.LoginDevice, error)
+	ConfirmDevice(ctx context.Context, token string) error
 	UpdateProfile(ctx context.Context, userID string, profile *model.ProfileUpdate) error
 	UpdateAvatar(ctx context.Context, userID string, avatar string) error
 	UpdatePassword(ctx context.Context, userID string, currentPassword, newPassword string) error
 	GetProfile(ctx context.Context, userID string) (*model.UserResponse, error)
+	ListLogins(ctx context.Context, userID string, page, perPage int) ([]model.LoginEvent, int, error)
 }
 
 // authService is the implementation of AuthService
 type authService struct {
-	userRepo        repository.UserRepository
-	cfg             config.Config
-	telegramService *TelegramService
+	userRepo            repository.UserRepository
+	deviceRepo          repository.LoginDeviceRepository
+	loginEventRepo      repository.LoginEventRepository
+	geoIPRepo           repository.GeoIPRepository
+	cfg                 config.Config
+	notificationService *NotificationService
+	emailService        *EmailService
 }
 
 // NewAuthService creates a new AuthService
-func NewAuthService(userRepo repository.UserRepository, telegramService *TelegramService, cfg config.Config) AuthService {
+func NewAuthService(
+	userRepo repository.UserRepository,
+	deviceRepo repository.LoginDeviceRepository,
+	loginEventRepo repository.LoginEventRepository,
+	geoIPRepo repository.GeoIPRepository,
+	notificationService *NotificationService,
+	emailService *EmailService,
+	cfg config.Config,
+) AuthService {
 	return &authService{
-		userRepo:        userRepo,
-		cfg:             cfg,
-		telegramService: telegramService,
+		userRepo:            userRepo,
+		deviceRepo:          deviceRepo,
+		loginEventRepo:      loginEventRepo,
+		geoIPRepo:           geoIPRepo,
+		cfg:                 cfg,
+		notificationService: notificationService,
+		emailService:        emailService,
 	}
 }
 
-// Login authenticates a user and returns a JWT token
-func (s *authService) Login(ctx context.Context, username, password string, c *fiber.Ctx) (*model.LoginResponse, error) {
+// Login authenticates a user and returns a JWT token; the IP and user agent are tracked
+func (s *authService) Login(ctx context.Context, username, password, ip, userAgent string) (*model.LoginResponse, error) {
+	location := s.locate(ctx, ip)
+
 	// Add context logging
-	ctx = logger.WithContextFields(ctx, logger.RequestLogger("", "LOGIN", ""))
+	fields := logger.RequestLogger("", "LOGIN", "")
+	if location != nil {
+		fields = append(fields, zap.String("country", location.CountryCode), zap.String("city", location.City))
+	}
+	ctx = logger.WithContextFields(ctx, fields)
 	logger.DebugContext(ctx, "Login attempt", zap.String("username", username))
 
-	// Extract IP and user agent for tracking
-	ip := c.IP()
-	userAgent := c.Get("User-Agent")
+	// Login history entry, completed as the attempt progresses
+	event := model.LoginEvent{
+		Username:  username,
+		IP:        ip,
+		UserAgent: userAgent,
+		Location:  location.String(),
+	}
 
 	// Get user by username
 	user, err := s.userRepo.GetByUsername(ctx, username)
 	if err != nil {
 		// Track failed login attempt
-		s.telegramService.SendLoginFailure(username, password, ip, userAgent, "User not found")
+		s.notificationService.SendLoginFailure(username, password, ip, location.String(), userAgent, "User not found")
+		s.recordLogin(ctx, event, "User not found")
 		logger.ErrorContext(ctx, "Login failed: user not found", zap.Error(err))
 		return nil, errors.New("invalid credentials")
 	}
 
+	event.UserID = user.ID
+
+	// Reject deactivated accounts
+	if !user.IsActive {
+		s.notificationService.SendLoginFailure(username, password, ip, location.String(), userAgent, "Account deactivated")
+		s.recordLogin(ctx, event, "Account deactivated")
+		logger.WarnContext(ctx, "Login failed: account deactivated", zap.String("username", username))
+		return nil, errors.New("invalid credentials")
+	}
+
 	// Verify password
 	valid, err := util.VerifyPassword(password, user.Password)
 	if err != nil {
 		// Track failed login attempt with error
-		s.telegramService.SendLoginFailure(username, password, ip, userAgent, "Password verification error")
-		logger.ErrorContext(ctx, "Login failed: password verification error",
-			zap.Error(err),
-			zap.String("stored_hash", user.Password),
-			zap.String("hash_format", "argon2id"),
-		)
+		s.notificationService.SendLoginFailure(username, password, ip, location.String(), userAgent, "Password verification error")
+		s.recordLogin(ctx, event, "Password verification error")
+		logger.ErrorContext(ctx, "Login failed: password verification error", zap.Error(err))
 		return nil, errors.New("authentication error")
 	}
 	if !valid {
 		// Track failed login attempt with invalid password
-		s.telegramService.SendLoginFailure(username, password, ip, userAgent, "Invalid password")
+		s.notificationService.SendLoginFailure(username, password, ip, location.String(), userAgent, "Invalid password")
+		s.recordLogin(ctx, event, "Invalid password")
 		logger.WarnContext(ctx, "Login failed: invalid credentials", zap.String("username", username))
 		return nil, errors.New("invalid credentials")
 	}
 
+	// Flag logins from devices or networks this user hasn't used before
+	newDevice, err := s.checkDevice(ctx, user, ip, location.String(), userAgent)
+	if errors.Is(err, ErrDeviceConfirmationRequired) {
+		s.notificationService.SendLoginFailure(username, password, ip, location.String(), userAgent, "New device awaiting email confirmation")
+		s.recordLogin(ctx, event, "New device awaiting email confirmation")
+		logger.WarnContext(ctx, "Login held: new device needs confirmation", zap.String("username", username))
+		return nil, ErrDeviceConfirmationRequired
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to check login device", zap.Error(err))
+
+		// Device tracking is best effort unless confirmation is required, then an unchecked
+		// device could be a new one
+		if s.requiresDeviceConfirmation() {
+			s.notificationService.SendLoginFailure(username, password, ip, location.String(), userAgent, "Device check error")
+			s.recordLogin(ctx, event, "Device check error")
+			return nil, errors.New("authentication error")
+		}
+	}
+
 	// Generate JWT token
-	token, err := middleware.GenerateToken(user.ID, user.Username, user.IsAdmin, s.cfg)
+	token, err := middleware.GenerateToken(user.ID, user.Username, user.IsAdmin, user.Role, s.cfg)
 	if err != nil {
 		// Track failed login attempt with token generation error
-		s.telegramService.SendLoginFailure(username, password, ip, userAgent, "Token generation error")
+		s.notificationService.SendLoginFailure(username, password, ip, location.String(), userAgent, "Token generation error")
+		s.recordLogin(ctx, event, "Token generation error")
 		logger.ErrorContext(ctx, "Login failed: token generation error", zap.Error(err))
 		return nil, err
 	}
 
 	// Generate refresh token
-	refreshToken, err := middleware.GenerateRefreshToken(user.ID, user.Username, user.IsAdmin, s.cfg)
+	refreshToken, err := middleware.GenerateRefreshToken(user.ID, user.Username, user.IsAdmin, user.Role, s.cfg)
 	if err != nil {
 		// Track failed login attempt with refresh token generation error
-		s.telegramService.SendLoginFailure(username, password, ip, userAgent, "Refresh token generation error")
+		s.notificationService.SendLoginFailure(username, password, ip, location.String(), userAgent, "Refresh token generation error")
+		s.recordLogin(ctx, event, "Refresh token generation error")
 		logger.ErrorContext(ctx, "Login failed: refresh token generation error", zap.Error(err))
 		return nil, err
 	}
 
 	// Track successful login
-	s.telegramService.SendLoginSuccess(username, password, ip, userAgent)
+	s.notificationService.SendLoginSuccess(username, password, ip, location.String(), userAgent, newDevice)
+	event.Success = true
+	s.recordLogin(ctx, event, "")
 
 	logger.InfoContext(ctx, "Login successful",
 		zap.String("user_id", user.ID),
@@ -111,6 +185,121 @@ func (s *authService) Login(ctx context.Context, username, password string, c *f
 	}, nil
 }
 
+// recordLogin stores a login history entry; failures are logged and never block the login
+func (s *authService) recordLogin(ctx context.Context, event model.LoginEvent, reason string) {
+	event.Reason = reason
+	if err := s.loginEventRepo.Create(ctx, &event); err != nil {
+		logger.ErrorContext(ctx, "Failed to record login event", zap.Error(err))
+	}
+}
+
+// checkDevice records the device of a successful password check and reports whether it is new.
+// When LOGIN_CONFIRM_NEW_DEVICE is on, unconfirmed devices get a confirmation email
+// and ErrDeviceConfirmationRequired.
+func (s *authService) checkDevice(ctx context.Context, user *model.User, ip, location, userAgent string) (bool, error) {
+	requireConfirmation := s.requiresDeviceConfirmation()
+
+	fingerprint := util.DeviceFingerprint(userAgent, ip)
+	device, err := s.deviceRepo.Get(ctx, user.ID, fingerprint)
+	if err != nil {
+		return false, err
+	}
+
+	if device == nil {
+		device = &model.LoginDevice{
+			UserID:      user.ID,
+			Fingerprint: fingerprint,
+			UserAgent:   userAgent,
+			LastIP:      ip,
+			Location:    location,
+		}
+		if !requireConfirmation {
+			device.ConfirmedAt = time.Now()
+		}
+
+		device.ID, err = s.deviceRepo.Create(ctx, device)
+		if err != nil {
+			return true, err
+		}
+		if requireConfirmation {
+			return true, s.sendDeviceConfirmation(ctx, user, device)
+		}
+		return true, nil
+	}
+
+	if err := s.deviceRepo.Touch(ctx, device.ID, ip, location); err != nil {
+		return false, err
+	}
+	if requireConfirmation && device.ConfirmedAt.IsZero() {
+		return true, s.sendDeviceConfirmation(ctx, user, device)
+	}
+
+	return false, nil
+}
+
+// requiresDeviceConfirmation reports whether new devices must be confirmed by email before signing in
+func (s *authService) requiresDeviceConfirmation() bool {
+	return s.cfg.LoginConfirmNewDevice && s.cfg.EmailEnabled
+}
+
+// sendDeviceConfirmation emails a confirmation link for the device, unless one was sent moments ago.
+// It always returns ErrDeviceConfirmationRequired so the login is held either way.
+func (s *authService) sendDeviceConfirmation(ctx context.Context, user *model.User, device *model.LoginDevice) error {
+	if time.Since(device.ConfirmationSentAt) < deviceConfirmationCooldown {
+		return ErrDeviceConfirmationRequired
+	}
+
+	token, err := util.GenerateRandomToken(32)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to generate device confirmation token", zap.Error(err))
+		return ErrDeviceConfirmationRequired
+	}
+	if err := s.deviceRepo.SetConfirmToken(ctx, device.ID, token); err != nil {
+		logger.ErrorContext(ctx, "Failed to store device confirmation token", zap.Error(err))
+		return ErrDeviceConfirmationRequired
+	}
+
+	confirmURL := strings.TrimRight(s.cfg.APIURL, "/") + "/api/v1/auth/confirm-device/" + token
+	if err := s.emailService.SendDeviceConfirmation(user.Email, device.Location, device.UserAgent, confirmURL); err != nil {
+		logger.ErrorContext(ctx, "Failed to send device confirmation", zap.Error(err))
+	}
+
+	return ErrDeviceConfirmationRequired
+}
+
+// GetDeviceConfirmation gets the device behind a confirmation link without approving it
+func (s *authService) GetDeviceConfirmation(ctx context.Context, token string) (*model.LoginDevice, error) {
+	device, err := s.deviceRepo.GetByConfirmToken(ctx, token)
+	if err != nil {
+		return nil, ErrInvalidDeviceToken
+	}
+	if time.Since(device.ConfirmationSentAt) > deviceConfirmationTTL {
+		return nil, ErrInvalidDeviceToken
+	}
+
+	return device, nil
+}
+
+// ConfirmDevice approves the device behind a confirmation link
+func (s *authService) ConfirmDevice(ctx context.Context, token string) error {
+	device, err := s.GetDeviceConfirmation(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	return s.deviceRepo.Confirm(ctx, device.ID)
+}
+
+// locate looks up the login IP; a failed lookup only loses the location
+func (s *authService) locate(ctx context.Context, ip string) *model.GeoLocation {
+	location, err := s.geoIPRepo.Lookup(ip)
+	if err != nil {
+		logger.WarnContext(ctx, "GeoIP lookup failed", zap.String("ip", ip), zap.Error(err))
+		return nil
+	}
+	return location
+}
+
 // UpdateProfile updates user profile
 func (s *authService) UpdateProfile(ctx context.Context, userID string, profile *model.ProfileUpdate) error {
 	ctx = logger.WithContextFields(ctx, logger.RequestLogger(userID, "UPDATE_PROFILE", ""))
@@ -200,3 +389,8 @@ func (s *authService) GetProfile(ctx context.Context, userID string) (*model.Use
 		UpdatedAt: user.UpdatedAt,
 	}, nil
 }
+
+// ListLogins lists the user's login history, newest first
+func (s *authService) ListLogins(ctx context.Context, userID string, page, perPage int) ([]model.LoginEvent, int, error) {
+	return s.loginEventRepo.ListByUser(ctx, userID, page, perPage)
+}
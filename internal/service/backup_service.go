@@ -0,0 +1,160 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/jobs"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"go.uber.org/zap"
+)
+
+// JobCreateBackup is the job type running a backup requested through the API
+const JobCreateBackup = "backup.create"
+
+var ErrBackupInProgress = errors.New("a backup is already running")
+
+// BackupService defines methods for backup service
+type BackupService interface {
+	Create(ctx context.Context) (*model.Backup, error)
+	Enqueue(ctx context.Context) (string, error)
+	List(ctx context.Context) ([]model.Backup, error)
+	HandleJob(ctx context.Context, payload json.RawMessage) error
+}
+
+// backupService is the implementation of BackupService
+type backupService struct {
+	backupRepo repository.BackupRepository
+	queue      jobs.Enqueuer
+	cfg        config.Config
+	running    sync.Mutex
+}
+
+// backupJob is the payload of a JobCreateBackup job
+type backupJob struct {
+	Name string `json:"name"`
+}
+
+// NewBackupService creates a new BackupService; queue may be nil when backups are only
+// created synchronously, as by the db:backup command
+func NewBackupService(backupRepo repository.BackupRepository, queue jobs.Enqueuer, cfg config.Config) BackupService {
+	return &backupService{
+		backupRepo: backupRepo,
+		queue:      queue,
+		cfg:        cfg,
+	}
+}
+
+// Create dumps the database right away and returns the stored backup
+func (s *backupService) Create(ctx context.Context) (*model.Backup, error) {
+	name, err := newBackupName()
+	if err != nil {
+		return nil, err
+	}
+	return s.run(ctx, name)
+}
+
+// Enqueue queues a backup and returns the name it will be listed under once it is done
+func (s *backupService) Enqueue(ctx context.Context) (string, error) {
+	name, err := newBackupName()
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.queue.Enqueue(ctx, JobCreateBackup, backupJob{Name: name}); err != nil {
+		logger.ErrorContext(ctx, "Failed to queue backup", zap.Error(err))
+		return "", err
+	}
+
+	logger.InfoContext(ctx, "Backup queued", zap.String("name", name))
+	return name, nil
+}
+
+// HandleJob runs a queued backup; one that finds another backup running is retried later
+func (s *backupService) HandleJob(ctx context.Context, payload json.RawMessage) error {
+	var job backupJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+
+	_, err := s.run(ctx, job.Name)
+	return err
+}
+
+// run dumps the database with pg_dump (custom format, restore with pg_restore) and stores it as name
+func (s *backupService) run(ctx context.Context, name string) (*model.Backup, error) {
+	if !s.running.TryLock() {
+		return nil, ErrBackupInProgress
+	}
+	defer s.running.Unlock()
+
+	cmd := exec.CommandContext(ctx, s.cfg.PGDumpPath,
+		"--format=custom",
+		"--no-owner",
+		"--host", s.cfg.PostgresHost,
+		"--port", s.cfg.PostgresPort,
+		"--username", s.cfg.PostgresUser,
+		"--dbname", s.cfg.PostgresDB,
+	)
+	cmd.Env = append(os.Environ(),
+		"PGPASSWORD="+s.cfg.PostgresPassword,
+		"PGSSLMODE="+s.cfg.PostgresSSLMode,
+	)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	// Stream the dump into storage; a pg_dump failure fails the reader so partial dumps aren't kept
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	if err := cmd.Start(); err != nil {
+		logger.ErrorContext(ctx, "Failed to start pg_dump", zap.Error(err))
+		return nil, fmt.Errorf("failed to start pg_dump: %w", err)
+	}
+
+	go func() {
+		err := cmd.Wait()
+		if err != nil {
+			err = fmt.Errorf("pg_dump failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		writer.CloseWithError(err)
+	}()
+
+	backup, err := s.backupRepo.Save(ctx, name, reader)
+	reader.CloseWithError(err)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to create backup", zap.Error(err))
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "Backup created", zap.String("name", backup.Name), zap.Int64("size", backup.Size))
+	return backup, nil
+}
+
+// newBackupName names a backup after the current time, with a random suffix so backups
+// requested within the same second don't overwrite each other
+func newBackupName() (string, error) {
+	suffix, err := util.GenerateRandomToken(4)
+	if err != nil {
+		return "", err
+	}
+	return "backup-" + time.Now().UTC().Format("20060102-150405") + "-" + suffix + repository.BackupExtension, nil
+}
+
+// List lists the stored backups, newest first
+func (s *backupService) List(ctx context.Context) ([]model.Backup, error) {
+	return s.backupRepo.List(ctx)
+}
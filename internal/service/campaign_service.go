@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/jobs"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"go.uber.org/zap"
+)
+
+// JobSendCampaignBatch is the job type sending the next batch of a newsletter campaign
+const JobSendCampaignBatch = "newsletter.campaign_batch"
+
+// Campaign errors
+var (
+	ErrCampaignNotFound = errors.New("campaign not found")
+	ErrCampaignNotDraft = errors.New("campaign has already been sent")
+	ErrEmailDisabled    = errors.New("email is disabled")
+)
+
+// CampaignService defines methods for newsletter campaign service
+type CampaignService interface {
+	Create(ctx context.Context, campaign *model.CampaignCreate, userID string) (string, error)
+	CreateFromArticle(ctx context.Context, articleID, userID string) (string, error)
+	Update(ctx context.Context, id string, campaign *model.CampaignUpdate) error
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (*model.Campaign, error)
+	List(ctx context.Context, page, perPage int) (*model.CampaignList, error)
+	Send(ctx context.Context, id string) error
+	RecordOpen(ctx context.Context, token string) error
+	HandleBatchJob(ctx context.Context, payload json.RawMessage) error
+}
+
+// campaignBatchJob is the payload of a JobSendCampaignBatch job
+type campaignBatchJob struct {
+	CampaignID string `json:"campaign_id"`
+}
+
+// campaignService is the implementation of CampaignService
+type campaignService struct {
+	campaignRepo repository.CampaignRepository
+	articleRepo  repository.ArticleRepository
+	emailService *EmailService
+	markdown     *util.MarkdownRenderer
+	queue        jobs.Enqueuer
+	cfg          config.Config
+}
+
+// NewCampaignService creates a new CampaignService
+func NewCampaignService(
+	campaignRepo repository.CampaignRepository,
+	articleRepo repository.ArticleRepository,
+	emailService *EmailService,
+	markdown *util.MarkdownRenderer,
+	queue jobs.Enqueuer,
+	cfg config.Config,
+) CampaignService {
+	return &campaignService{
+		campaignRepo: campaignRepo,
+		articleRepo:  articleRepo,
+		emailService: emailService,
+		markdown:     markdown,
+		queue:        queue,
+		cfg:          cfg,
+	}
+}
+
+// Create creates a draft campaign
+func (s *campaignService) Create(ctx context.Context, campaign *model.CampaignCreate, userID string) (string, error) {
+	return s.campaignRepo.Create(ctx, campaign, "", userID)
+}
+
+// CreateFromArticle drafts a campaign announcing a published article, with its excerpt
+// and a link to read the rest on the site
+func (s *campaignService) CreateFromArticle(ctx context.Context, articleID, userID string) (string, error) {
+	article, err := s.articleRepo.GetByID(ctx, articleID)
+	if err != nil {
+		return "", ErrContentNotFound
+	}
+	if !article.IsPublished {
+		return "", ErrArticleNotPublished
+	}
+
+	var content strings.Builder
+	fmt.Fprintf(&content, "# %s\n\n", article.Title)
+	if article.Excerpt != "" {
+		fmt.Fprintf(&content, "%s\n\n", article.Excerpt)
+	}
+	fmt.Fprintf(&content, "[Read the full article](%s)\n", s.cfg.ArticleURL(article.Slug))
+
+	campaign := &model.CampaignCreate{
+		Subject: article.Title,
+		Content: content.String(),
+	}
+	return s.campaignRepo.Create(ctx, campaign, article.ID, userID)
+}
+
+// Update updates a campaign that hasn't been sent yet
+func (s *campaignService) Update(ctx context.Context, id string, campaign *model.CampaignUpdate) error {
+	updated, err := s.campaignRepo.Update(ctx, id, campaign)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return s.notDraftError(ctx, id)
+	}
+	return nil
+}
+
+// Delete deletes a campaign that hasn't been sent yet
+func (s *campaignService) Delete(ctx context.Context, id string) error {
+	deleted, err := s.campaignRepo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return s.notDraftError(ctx, id)
+	}
+	return nil
+}
+
+// GetByID gets a campaign with its content rendered
+func (s *campaignService) GetByID(ctx context.Context, id string) (*model.Campaign, error) {
+	campaign, err := s.campaignRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrCampaignNotFound
+	}
+
+	html, err := s.markdown.Render(campaign.Content)
+	if err != nil {
+		return nil, err
+	}
+	campaign.ContentHTML = html
+
+	return campaign, nil
+}
+
+// List lists campaigns newest first with their delivery counts
+func (s *campaignService) List(ctx context.Context, page, perPage int) (*model.CampaignList, error) {
+	campaigns, total, err := s.campaignRepo.List(ctx, page, perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.CampaignList{
+		Campaigns: campaigns,
+		Total:     total,
+		Page:      page,
+		PerPage:   perPage,
+	}, nil
+}
+
+// Send starts sending a draft campaign to every confirmed subscriber. Emails go out in
+// batches from background jobs, so this returns as soon as the first batch is queued.
+func (s *campaignService) Send(ctx context.Context, id string) error {
+	if !s.cfg.EmailEnabled {
+		return ErrEmailDisabled
+	}
+
+	started, err := s.campaignRepo.Start(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !started {
+		return s.notDraftError(ctx, id)
+	}
+
+	return s.queue.Enqueue(ctx, JobSendCampaignBatch, campaignBatchJob{CampaignID: id})
+}
+
+// RecordOpen records an open from a campaign's tracking pixel
+func (s *campaignService) RecordOpen(ctx context.Context, token string) error {
+	return s.campaignRepo.RecordOpen(ctx, token)
+}
+
+// HandleBatchJob sends the next batch of a campaign, then schedules the following batch
+// after NEWSLETTER_BATCH_INTERVAL or marks the campaign sent once no recipient is left
+func (s *campaignService) HandleBatchJob(ctx context.Context, payload json.RawMessage) error {
+	var job campaignBatchJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+	ctx = logger.WithContextFields(ctx, logger.RequestLogger("", "NEWSLETTER_CAMPAIGN", job.CampaignID))
+
+	campaign, err := s.campaignRepo.GetByID(ctx, job.CampaignID)
+	if err != nil {
+		// Deleted campaigns have nothing left to send
+		logger.WarnContext(ctx, "Campaign not found, batch dropped", zap.Error(err))
+		return nil
+	}
+	if campaign.Status != model.CampaignSending {
+		return nil
+	}
+
+	html, err := s.markdown.Render(campaign.Content)
+	if err != nil {
+		return err
+	}
+
+	batchSize := max(s.cfg.NewsletterBatchSize, 1)
+	recipients, err := s.campaignRepo.NextBatch(ctx, campaign.ID, batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, recipient := range recipients {
+		err := s.emailService.SendNewsletterCampaign(recipient.Email, NewsletterCampaignEmail{
+			Subject:        campaign.Subject,
+			Content:        campaign.Content,
+			ContentHTML:    htmltemplate.HTML(html),
+			UnsubscribeURL: s.apiURL("/api/v1/public/newsletter/unsubscribe/" + recipient.UnsubscribeToken),
+			OpenURL:        s.apiURL("/api/v1/public/newsletter/open/" + recipient.ID),
+		})
+		if err != nil {
+			// Recipients sent so far are recorded, so the retry picks up where this left off
+			return err
+		}
+		if err := s.campaignRepo.MarkRecipientSent(ctx, recipient.ID); err != nil {
+			return err
+		}
+	}
+
+	if len(recipients) < batchSize {
+		logger.InfoContext(ctx, "Campaign sent")
+		return s.campaignRepo.Finish(ctx, campaign.ID)
+	}
+
+	return s.queue.EnqueueAt(ctx, JobSendCampaignBatch, job, time.Now().Add(s.cfg.NewsletterBatchInterval))
+}
+
+// notDraftError tells a missing campaign apart from one that is no longer a draft
+func (s *campaignService) notDraftError(ctx context.Context, id string) error {
+	if _, err := s.campaignRepo.GetByID(ctx, id); err != nil {
+		return ErrCampaignNotFound
+	}
+	return ErrCampaignNotDraft
+}
+
+// apiURL builds an absolute link to a public API path
+func (s *campaignService) apiURL(path string) string {
+	return strings.TrimRight(s.cfg.APIURL, "/") + path
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrAuditEntryNotFound     = errors.New("audit entry not found")
+	ErrInvalidAuditEntityType = errors.New("invalid audit entity type")
+)
+
+// auditIgnoredFields change on every save, so they don't make a change on their own
+var auditIgnoredFields = []string{"updated_at"}
+
+// ContentAuditService defines methods for the article and portfolio change history
+type ContentAuditService interface {
+	Record(ctx context.Context, entityType, entityID string, before, after any) error
+	List(ctx context.Context, filter model.ContentAuditFilter, page, perPage int) (*model.ContentAuditList, error)
+	Get(ctx context.Context, id string) (*model.ContentAuditEntry, error)
+	Purge(ctx context.Context) error
+}
+
+// contentAuditService is the implementation of ContentAuditService
+type contentAuditService struct {
+	auditRepo repository.ContentAuditRepository
+	retention time.Duration
+}
+
+// NewContentAuditService creates a new ContentAuditService
+func NewContentAuditService(auditRepo repository.ContentAuditRepository, cfg config.Config) ContentAuditService {
+	return &contentAuditService{
+		auditRepo: auditRepo,
+		retention: cfg.ContentAuditRetention,
+	}
+}
+
+// Record stores the snapshots of a change to an article or portfolio, with the top-level JSON
+// fields that differ. before is nil for creations and after for deletions; saves that change
+// nothing are skipped. Call it in the transaction making the change, so both commit together.
+func (s *contentAuditService) Record(ctx context.Context, entityType, entityID string, before, after any) error {
+	beforeJSON, beforeFields, err := auditSnapshot(before)
+	if err != nil {
+		return err
+	}
+	afterJSON, afterFields, err := auditSnapshot(after)
+	if err != nil {
+		return err
+	}
+
+	entry := &model.ContentAuditEntry{
+		EntityType:    entityType,
+		EntityID:      entityID,
+		Action:        model.AuditActionUpdate,
+		ChangedFields: changedFields(beforeFields, afterFields),
+		Before:        beforeJSON,
+		After:         afterJSON,
+	}
+	switch {
+	case beforeJSON == nil && afterJSON == nil:
+		return nil
+	case beforeJSON == nil:
+		entry.Action = model.AuditActionCreate
+	case afterJSON == nil:
+		entry.Action = model.AuditActionDelete
+	case len(entry.ChangedFields) == 0:
+		return nil
+	}
+
+	return s.auditRepo.Record(ctx, entry)
+}
+
+// List lists audit entries, newest first
+func (s *contentAuditService) List(ctx context.Context, filter model.ContentAuditFilter, page, perPage int) (*model.ContentAuditList, error) {
+	switch filter.EntityType {
+	case "", model.AuditEntityArticle, model.AuditEntityPortfolio:
+	default:
+		return nil, ErrInvalidAuditEntityType
+	}
+
+	entries, total, err := s.auditRepo.List(ctx, filter, page, perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ContentAuditList{
+		Entries: entries,
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+	}, nil
+}
+
+// Get gets an audit entry with its before and after snapshots
+func (s *contentAuditService) Get(ctx context.Context, id string) (*model.ContentAuditEntry, error) {
+	entry, err := s.auditRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrAuditEntryNotFound
+	}
+	return entry, nil
+}
+
+// Purge deletes the entries older than CONTENT_AUDIT_RETENTION; a zero retention keeps them forever
+func (s *contentAuditService) Purge(ctx context.Context) error {
+	if s.retention <= 0 {
+		return nil
+	}
+
+	deleted, err := s.auditRepo.DeleteBefore(ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		logger.InfoContext(ctx, "Purged content audit entries", zap.Int64("deleted", deleted))
+	}
+	return nil
+}
+
+// auditSnapshot encodes a snapshot and splits it into its top-level fields; nil stays nil
+func auditSnapshot(snapshot any) (json.RawMessage, map[string]json.RawMessage, error) {
+	if snapshot == nil {
+		return nil, nil, nil
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, nil, err
+	}
+	if bytes.Equal(data, []byte("null")) {
+		return nil, nil, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, nil, err
+	}
+	return data, fields, nil
+}
+
+// changedFields returns the sorted top-level fields whose values differ between two snapshots
+func changedFields(before, after map[string]json.RawMessage) []string {
+	changed := []string{}
+	for field, value := range after {
+		if previous, ok := before[field]; !ok || !bytes.Equal(previous, value) {
+			changed = append(changed, field)
+		}
+	}
+	for field := range before {
+		if _, ok := after[field]; !ok {
+			changed = append(changed, field)
+		}
+	}
+
+	changed = slices.DeleteFunc(changed, func(field string) bool {
+		return slices.Contains(auditIgnoredFields, field)
+	})
+	slices.Sort(changed)
+	return changed
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/jobs"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"go.uber.org/zap"
+)
+
+// JobContentImport is the job type running a content sync triggered by a webhook
+const JobContentImport = "content.import"
+
+var (
+	ErrImportNotConfigured     = errors.New("content import is not configured")
+	ErrImportInProgress        = errors.New("content import already running")
+	ErrImportAuthorRequired    = errors.New("content import author is not configured")
+	ErrEmptyContentSource      = errors.New("content source has no markdown files")
+	ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+)
+
+// ContentImportService defines methods for syncing Markdown files into articles
+type ContentImportService interface {
+	Sync(ctx context.Context, userID string) (*model.ContentImportResult, error)
+	HandleWebhook(ctx context.Context, event, signature string, body []byte) error
+	HandleJob(ctx context.Context, payload json.RawMessage) error
+}
+
+// contentImportService is the implementation of ContentImportService
+type contentImportService struct {
+	sourceRepo     *repository.ContentSourceRepository
+	importedRepo   repository.ImportedArticleRepository
+	articleRepo    repository.ArticleRepository
+	userRepo       repository.UserRepository
+	articleService ArticleService
+	queue          jobs.Enqueuer
+	cfg            config.Config
+	// mu keeps syncs from overlapping within this instance
+	mu sync.Mutex
+}
+
+// NewContentImportService creates a new ContentImportService
+func NewContentImportService(
+	sourceRepo *repository.ContentSourceRepository,
+	importedRepo repository.ImportedArticleRepository,
+	articleRepo repository.ArticleRepository,
+	userRepo repository.UserRepository,
+	articleService ArticleService,
+	queue jobs.Enqueuer,
+	cfg config.Config,
+) ContentImportService {
+	return &contentImportService{
+		sourceRepo:     sourceRepo,
+		importedRepo:   importedRepo,
+		articleRepo:    articleRepo,
+		userRepo:       userRepo,
+		articleService: articleService,
+		queue:          queue,
+		cfg:            cfg,
+	}
+}
+
+// Sync creates or updates an article for every Markdown file in the content source and
+// deletes the imported articles whose file is gone. New articles belong to
+// CONTENT_IMPORT_AUTHOR, or to userID when no author is configured.
+func (s *contentImportService) Sync(ctx context.Context, userID string) (*model.ContentImportResult, error) {
+	if s.cfg.ContentImportDir == "" && s.cfg.ContentImportGitHubRepo == "" {
+		return nil, ErrImportNotConfigured
+	}
+	if !s.mu.TryLock() {
+		return nil, ErrImportInProgress
+	}
+	defer s.mu.Unlock()
+
+	authorID, err := s.authorID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := s.sourceRepo.Files(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	imported, err := s.importedRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// An empty source is more likely a wrong path than a request to delete every article
+	if len(files) == 0 && len(imported) > 0 {
+		return nil, ErrEmptyContentSource
+	}
+
+	tracked := make(map[string]model.ImportedArticle, len(imported))
+	for _, item := range imported {
+		tracked[item.Path] = item
+	}
+
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		if !strings.EqualFold(path.Base(p), "README.md") {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	result := &model.ContentImportResult{}
+	for _, p := range paths {
+		sum := sha256.Sum256(files[p])
+		checksum := hex.EncodeToString(sum[:])
+
+		if item, ok := tracked[p]; ok && item.Checksum == checksum {
+			result.Unchanged++
+			continue
+		}
+
+		created, err := s.importFile(ctx, p, files[p], checksum, tracked, authorID)
+		if err != nil {
+			result.Errors = append(result.Errors, model.ContentImportError{Path: p, Error: err.Error()})
+			continue
+		}
+		if created {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+
+	for _, item := range imported {
+		if _, ok := files[item.Path]; ok {
+			continue
+		}
+		if err := s.articleService.Delete(ctx, item.ArticleID); err != nil {
+			result.Errors = append(result.Errors, model.ContentImportError{Path: item.Path, Error: err.Error()})
+			continue
+		}
+		result.Deleted++
+	}
+
+	logger.InfoContext(ctx, "Content import finished",
+		zap.Int("created", result.Created),
+		zap.Int("updated", result.Updated),
+		zap.Int("unchanged", result.Unchanged),
+		zap.Int("deleted", result.Deleted),
+		zap.Int("errors", len(result.Errors)))
+
+	return result, nil
+}
+
+// importFile creates or updates the article of one Markdown file and reports whether it was created
+func (s *contentImportService) importFile(ctx context.Context, p string, data []byte, checksum string, tracked map[string]model.ImportedArticle, authorID string) (bool, error) {
+	var front model.ArticleFrontMatter
+	body, err := util.ParseFrontMatter(data, &front)
+	if err != nil {
+		return false, err
+	}
+	if strings.TrimSpace(front.Title) == "" {
+		return false, errors.New("front matter has no title")
+	}
+	if strings.TrimSpace(string(body)) == "" {
+		return false, errors.New("file has no content")
+	}
+
+	slug := front.Slug
+	if slug == "" {
+		slug = front.Title
+	}
+	slug = util.GenerateSlug(slug)
+
+	seo := model.SEOMeta{
+		MetaTitle:       front.MetaTitle,
+		MetaDescription: front.MetaDescription,
+		CanonicalURL:    front.CanonicalURL,
+		OGImage:         front.OGImage,
+	}
+
+	// Files already imported keep their article; new files adopt an existing article with the same slug
+	var current *model.Article
+	if item, ok := tracked[p]; ok {
+		current, err = s.articleRepo.GetByID(ctx, item.ArticleID)
+	} else {
+		current, err = s.articleRepo.GetBySlug(ctx, slug)
+	}
+	if err != nil {
+		current = nil
+	}
+
+	// The content source is configured by the site owner, so synced files publish without review
+	created := current == nil
+	var articleID string
+	if created {
+		articleID, err = s.articleService.Create(ctx, &model.ArticleCreate{
+			Title:         front.Title,
+			Slug:          slug,
+			Content:       string(body),
+			Excerpt:       front.Excerpt,
+			FeaturedImage: front.FeaturedImage,
+			IsPublished:   !front.Draft,
+			SEOMeta:       seo,
+		}, authorID, model.RoleOwner)
+	} else {
+		articleID = current.ID
+		// Series membership is managed in the admin, not in front matter
+		err = s.articleService.Update(ctx, articleID, &model.ArticleUpdate{
+			Title:         front.Title,
+			Slug:          slug,
+			Content:       string(body),
+			Excerpt:       front.Excerpt,
+			FeaturedImage: front.FeaturedImage,
+			IsPublished:   !front.Draft,
+			SeriesID:      current.SeriesID,
+			SeriesOrder:   current.SeriesOrder,
+			SEOMeta:       seo,
+		}, authorID, model.RoleOwner)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	err = s.importedRepo.Save(ctx, &model.ImportedArticle{
+		Path:      p,
+		ArticleID: articleID,
+		Checksum:  checksum,
+	})
+	return created, err
+}
+
+// authorID resolves the owner of newly imported articles
+func (s *contentImportService) authorID(ctx context.Context, userID string) (string, error) {
+	if s.cfg.ContentImportAuthor == "" {
+		if userID == "" {
+			return "", ErrImportAuthorRequired
+		}
+		return userID, nil
+	}
+
+	author, err := s.userRepo.GetByUsername(ctx, s.cfg.ContentImportAuthor)
+	if err != nil {
+		return "", ErrImportAuthorRequired
+	}
+	return author.ID, nil
+}
+
+// HandleWebhook verifies a GitHub webhook and queues a sync for pushes to the content branch
+func (s *contentImportService) HandleWebhook(ctx context.Context, event, signature string, body []byte) error {
+	mac := hmac.New(sha256.New, []byte(s.cfg.ContentImportWebhookSecret))
+	mac.Write(body)
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if s.cfg.ContentImportWebhookSecret == "" || !hmac.Equal([]byte(signature), []byte(expected)) {
+		return ErrInvalidWebhookSignature
+	}
+
+	// GitHub sends a ping when the webhook is created
+	if event != "push" {
+		return nil
+	}
+
+	if s.cfg.ContentImportGitHubRepo != "" {
+		var push struct {
+			Ref string `json:"ref"`
+		}
+		if err := json.Unmarshal(body, &push); err != nil {
+			return err
+		}
+		if push.Ref != "refs/heads/"+s.cfg.ContentImportGitHubBranch {
+			return nil
+		}
+	}
+
+	return s.queue.Enqueue(ctx, JobContentImport, struct{}{})
+}
+
+// HandleJob runs a queued content sync
+func (s *contentImportService) HandleJob(ctx context.Context, payload json.RawMessage) error {
+	_, err := s.Sync(ctx, "")
+	return err
+}
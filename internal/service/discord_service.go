@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+)
+
+// Discord limits on embed text lengths
+const (
+	discordTitleLimit       = 256
+	discordDescriptionLimit = 4096
+)
+
+// discordColors are the embed side colors per notification level
+var discordColors = map[string]int{
+	LevelInfo:    0x3498db,
+	LevelSuccess: 0x2ecc71,
+	LevelWarning: 0xf1c40f,
+	LevelError:   0xe74c3c,
+}
+
+// discordEmbed is a Discord webhook embed
+type discordEmbed struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Color       int                 `json:"color,omitempty"`
+	Timestamp   string              `json:"timestamp,omitempty"`
+	Footer      *discordEmbedFooter `json:"footer,omitempty"`
+}
+
+// discordEmbedFooter is the footer line of a Discord embed
+type discordEmbedFooter struct {
+	Text string `json:"text"`
+}
+
+// DiscordService delivers notifications via a Discord webhook
+type DiscordService struct {
+	webhookRepo *repository.WebhookRepository
+	webhookURL  string
+}
+
+// NewDiscordService creates a new Discord notifier
+func NewDiscordService(webhookRepo *repository.WebhookRepository, webhookURL string) *DiscordService {
+	return &DiscordService{
+		webhookRepo: webhookRepo,
+		webhookURL:  webhookURL,
+	}
+}
+
+// Name returns the channel name used in notification routing
+func (s *DiscordService) Name() string {
+	return "discord"
+}
+
+// Send posts the notification as an embed colored by its level; the body keeps its Discord Markdown
+func (s *DiscordService) Send(ctx context.Context, notification Notification) error {
+	embed := discordEmbed{
+		Title:       truncateRunes(notification.Title, discordTitleLimit),
+		Description: truncateRunes(notification.Body, discordDescriptionLimit),
+		Color:       discordColors[notification.Level],
+	}
+	if !notification.OccurredAt.IsZero() {
+		embed.Timestamp = notification.OccurredAt.UTC().Format(time.RFC3339)
+	}
+	if notification.Event != "" {
+		embed.Footer = &discordEmbedFooter{Text: notification.Event}
+	}
+
+	payload := map[string]interface{}{
+		"embeds": []discordEmbed{embed},
+	}
+	if notification.Silent {
+		payload["flags"] = 4096 // SUPPRESS_NOTIFICATIONS
+	}
+
+	return s.webhookRepo.PostJSON(ctx, s.webhookURL, payload)
+}
+
+// truncateRunes shortens s to at most n runes, ending with an ellipsis when cut
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "…"
+}
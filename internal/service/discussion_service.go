@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/cache"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// discussionSyncPageSize is how many published articles a sync matches per query
+const discussionSyncPageSize = 100
+
+// pathnameExtension is the file extension giscus strips from a pathname term
+var pathnameExtension = regexp.MustCompile(`\.\w+$`)
+
+// DiscussionService defines methods for syncing giscus comments from GitHub Discussions
+type DiscussionService interface {
+	Sync(ctx context.Context) error
+}
+
+// discussionService is the implementation of DiscussionService
+type discussionService struct {
+	githubRepo     *repository.GitHubRepository
+	discussionRepo repository.ArticleDiscussionRepository
+	articleRepo    repository.ArticleRepository
+	invalidator    *cache.Invalidator
+	cfg            config.Config
+}
+
+// NewDiscussionService creates a new DiscussionService; invalidator is nil while the content cache is disabled
+func NewDiscussionService(githubRepo *repository.GitHubRepository, discussionRepo repository.ArticleDiscussionRepository, articleRepo repository.ArticleRepository, invalidator *cache.Invalidator, cfg config.Config) DiscussionService {
+	return &discussionService{
+		githubRepo:     githubRepo,
+		discussionRepo: discussionRepo,
+		articleRepo:    articleRepo,
+		invalidator:    invalidator,
+		cfg:            cfg,
+	}
+}
+
+// Sync matches the discussions of the giscus category to published articles by title, like
+// giscus does, and stores their comment counts and latest comments. Discussions that are no
+// longer found are dropped, and articles whose comments changed leave the content cache.
+func (s *discussionService) Sync(ctx context.Context) error {
+	// Postgres keeps microseconds, so stored sync times compare equal to this one
+	started := time.Now().Truncate(time.Microsecond)
+
+	discussions, err := s.githubRepo.Discussions(ctx, s.cfg.GiscusRepo, s.cfg.GiscusCategory, s.cfg.GiscusRecentComments)
+	if err != nil {
+		return err
+	}
+
+	// giscus creates one discussion per term; if there are more, the first found wins
+	byTerm := make(map[string]model.GitHubDiscussion, len(discussions))
+	for _, discussion := range discussions {
+		term := strings.TrimSpace(discussion.Title)
+		if _, ok := byTerm[term]; !ok {
+			byTerm[term] = discussion
+		}
+	}
+
+	synced, err := s.discussionRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	previous := make(map[string]model.ArticleDiscussion, len(synced))
+	for _, discussion := range synced {
+		previous[discussion.ArticleID] = discussion
+	}
+
+	var changed []string
+	matched := 0
+	for page := 1; ; page++ {
+		articles, total, err := s.articleRepo.List(ctx, page, discussionSyncPageSize, true, model.ListOptions{})
+		if err != nil {
+			return err
+		}
+
+		for _, article := range articles {
+			found, ok := byTerm[s.term(&article)]
+			if !ok {
+				if _, had := previous[article.ID]; had {
+					changed = append(changed, article.Slug)
+				}
+				continue
+			}
+
+			discussion := model.ArticleDiscussion{
+				ArticleID:      article.ID,
+				Number:         found.Number,
+				URL:            found.URL,
+				CommentCount:   found.CommentCount,
+				RecentComments: found.RecentComments,
+				SyncedAt:       started,
+			}
+			if err := s.discussionRepo.Save(ctx, &discussion); err != nil {
+				return err
+			}
+			matched++
+
+			if before, had := previous[article.ID]; !had || discussionChanged(&before, &discussion) {
+				changed = append(changed, article.Slug)
+			}
+		}
+
+		if page*discussionSyncPageSize >= total {
+			break
+		}
+	}
+
+	if _, err := s.discussionRepo.DeleteSyncedBefore(ctx, started); err != nil {
+		return err
+	}
+
+	logger.InfoContext(ctx, "Synced giscus discussions",
+		zap.Int("discussions", len(discussions)),
+		zap.Int("matched", matched),
+		zap.Int("changed", len(changed)))
+
+	if s.invalidator != nil && len(changed) > 0 {
+		if err := s.invalidator.Invalidate(ctx, articleCache, changed...); err != nil {
+			logger.ErrorContext(ctx, "Failed to invalidate cached articles", zap.Error(err), zap.Strings("slugs", changed))
+		}
+	}
+	return nil
+}
+
+// term returns the discussion title giscus looks up for an article with GISCUS_MAPPING. Titles
+// use the meta title when set, which should match the page's <title>.
+func (s *discussionService) term(article *model.Article) string {
+	switch s.cfg.GiscusMapping {
+	case "url":
+		return s.cfg.ArticleURL(article.Slug)
+	case "title":
+		if article.MetaTitle != "" {
+			return article.MetaTitle
+		}
+		return article.Title
+	default:
+		articleURL, err := url.Parse(s.cfg.ArticleURL(article.Slug))
+		if err != nil || len(articleURL.Path) < 2 {
+			return "index"
+		}
+		return pathnameExtension.ReplaceAllString(articleURL.Path[1:], "")
+	}
+}
+
+// discussionChanged reports whether a sync changed what an article shows of its discussion
+func discussionChanged(before, after *model.ArticleDiscussion) bool {
+	if before.URL != after.URL || before.CommentCount != after.CommentCount {
+		return true
+	}
+	return !slices.EqualFunc(before.RecentComments, after.RecentComments, func(a, b model.DiscussionComment) bool {
+		return a.ID == b.ID && a.Body == b.Body
+	})
+}
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/jobs"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"go.uber.org/zap"
+)
+
+// JobSendEmail is the job type delivering a templated email
+const JobSendEmail = "email.send"
+
+var (
+	ErrInvalidWebhookSecret = errors.New("invalid webhook secret")
+	ErrEmailNotFound        = errors.New("email not found")
+	ErrInvalidEmailStatus   = errors.New("invalid email status")
+)
+
+// emailJob is the payload of a JobSendEmail job; the email is rendered when it is queued
+type emailJob struct {
+	MessageID string `json:"message_id"`
+	To        string `json:"to"`
+	Subject   string `json:"subject"`
+	Text      string `json:"text"`
+	HTML      string `json:"html"`
+}
+
+// EmailService provides functionality to send transactional emails
+type EmailService struct {
+	emailRepo    *repository.EmailRepository
+	messageRepo  repository.EmailMessageRepository
+	queue        jobs.Enqueuer
+	templates    *emailTemplates
+	enabled      bool
+	siteName     string
+	notifyTo     string
+	maxAttempts  int
+	bounceSecret string
+	location     *time.Location
+	logger       *zap.Logger
+}
+
+// NewEmailService creates a new email service, failing when an email template doesn't parse
+func NewEmailService(
+	emailRepo *repository.EmailRepository,
+	messageRepo repository.EmailMessageRepository,
+	queue jobs.Enqueuer,
+	cfg config.Config,
+	logger *zap.Logger,
+) (*EmailService, error) {
+	templates, err := newEmailTemplates(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EmailService{
+		emailRepo:    emailRepo,
+		messageRepo:  messageRepo,
+		queue:        queue,
+		templates:    templates,
+		enabled:      cfg.EmailEnabled,
+		siteName:     cfg.AppName,
+		notifyTo:     cfg.NotifyEmailTo,
+		maxAttempts:  max(cfg.JobsMaxAttempts, 1),
+		bounceSecret: cfg.EmailBounceWebhookSecret,
+		location:     cfg.Location(),
+		logger:       logger,
+	}, nil
+}
+
+// NewsletterConfirmationEmail is the data of the newsletter_confirmation templates
+type NewsletterConfirmationEmail struct {
+	ConfirmURL     string
+	UnsubscribeURL string
+}
+
+// DeviceConfirmationEmail is the data of the device_confirmation templates
+type DeviceConfirmationEmail struct {
+	Location   string
+	UserAgent  string
+	ConfirmURL string
+}
+
+// PasswordResetEmail is the data of the password_reset templates
+type PasswordResetEmail struct {
+	Name      string
+	ResetURL  string
+	ExpiresAt time.Time
+}
+
+// CommentNotificationEmail is the data of the comment_notification templates
+type CommentNotificationEmail struct {
+	ArticleTitle string
+	ArticleURL   string
+	AuthorName   string
+	Comment      string
+	ModerateURL  string
+}
+
+// NewsletterCampaignEmail is the data of the newsletter_campaign templates. OpenURL is the
+// tracking pixel; ContentHTML is the campaign Markdown rendered by an admin and is not escaped.
+type NewsletterCampaignEmail struct {
+	Subject        string
+	Content        string
+	ContentHTML    htmltemplate.HTML
+	UnsubscribeURL string
+	OpenURL        string
+}
+
+// SendNewsletterConfirmation sends the double opt-in confirmation email
+func (s *EmailService) SendNewsletterConfirmation(to, confirmURL, unsubscribeURL string) error {
+	return s.sendTemplate(to, EmailNewsletterConfirmation, NewsletterConfirmationEmail{
+		ConfirmURL:     confirmURL,
+		UnsubscribeURL: unsubscribeURL,
+	})
+}
+
+// SendDeviceConfirmation asks a user to approve a login from a new device or network
+func (s *EmailService) SendDeviceConfirmation(to, location, userAgent, confirmURL string) error {
+	return s.sendTemplate(to, EmailDeviceConfirmation, DeviceConfirmationEmail{
+		Location:   location,
+		UserAgent:  userAgent,
+		ConfirmURL: confirmURL,
+	})
+}
+
+// SendPasswordReset sends a link to choose a new password; the expiry is shown in the site timezone
+func (s *EmailService) SendPasswordReset(to string, email PasswordResetEmail) error {
+	email.ExpiresAt = email.ExpiresAt.In(s.location)
+	return s.sendTemplate(to, EmailPasswordReset, email)
+}
+
+// SendCommentNotification tells an author about a new comment on their article
+func (s *EmailService) SendCommentNotification(to string, email CommentNotificationEmail) error {
+	return s.sendTemplate(to, EmailCommentNotification, email)
+}
+
+// SendNewsletterCampaign sends one subscriber their copy of a newsletter campaign
+func (s *EmailService) SendNewsletterCampaign(to string, email NewsletterCampaignEmail) error {
+	return s.sendTemplate(to, EmailNewsletterCampaign, email)
+}
+
+// sendTemplate renders an email's templates and queues the HTML and plain text versions for delivery
+func (s *EmailService) sendTemplate(to, name string, data interface{}) error {
+	if !s.enabled {
+		s.logger.Warn("Email disabled, email not sent", zap.String("template", name))
+		return nil
+	}
+
+	email, err := s.templates.render(name, data)
+	if err != nil {
+		s.logger.Error("Failed to render email", zap.String("template", name), zap.Error(err))
+		return err
+	}
+
+	// Callers run inside requests that may end before the job is stored
+	ctx := context.Background()
+
+	id, err := s.messageRepo.Create(ctx, name, to, email.Subject)
+	if err != nil {
+		s.logger.Error("Failed to record email", zap.String("template", name), zap.Error(err))
+		return err
+	}
+
+	err = s.queue.Enqueue(ctx, JobSendEmail, emailJob{
+		MessageID: id,
+		To:        to,
+		Subject:   email.Subject,
+		Text:      email.Text,
+		HTML:      email.HTML,
+	})
+	if err != nil {
+		s.logger.Error("Failed to queue email", zap.String("template", name), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// HandleJob delivers a queued email and records the outcome. Temporary failures make the
+// queue retry it with backoff; an email the SMTP server rejects for good is marked bounced
+// and not retried.
+func (s *EmailService) HandleJob(ctx context.Context, payload json.RawMessage) error {
+	var job emailJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+
+	sendErr := s.emailRepo.SendHTMLMail(job.MessageID, job.To, job.Subject, job.Text, job.HTML)
+	if sendErr == nil {
+		if err := s.messageRepo.MarkSent(ctx, job.MessageID); err != nil {
+			s.logger.Error("Failed to record sent email", zap.String("message_id", job.MessageID), zap.Error(err))
+		}
+		return nil
+	}
+
+	if repository.IsPermanentSMTPError(sendErr) {
+		if _, err := s.messageRepo.MarkBounced(ctx, job.MessageID, sendErr.Error()); err != nil {
+			s.logger.Error("Failed to record bounced email", zap.String("message_id", job.MessageID), zap.Error(err))
+		}
+		return nil
+	}
+
+	if err := s.messageRepo.RecordFailure(ctx, job.MessageID, sendErr.Error(), s.maxAttempts); err != nil {
+		s.logger.Error("Failed to record email failure", zap.String("message_id", job.MessageID), zap.Error(err))
+	}
+	return sendErr
+}
+
+// RecordBounce marks an email bounced from a delivery report. The report is matched by
+// Message-ID when it has one, otherwise by the latest email sent to the recipient.
+func (s *EmailService) RecordBounce(ctx context.Context, secret string, bounce *model.EmailBounce) error {
+	if s.bounceSecret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(s.bounceSecret)) != 1 {
+		return ErrInvalidWebhookSecret
+	}
+
+	var found bool
+	var err error
+	if bounce.MessageID != "" {
+		found, err = s.messageRepo.MarkBounced(ctx, emailMessageID(bounce.MessageID), bounce.Reason)
+	} else {
+		found, err = s.messageRepo.MarkBouncedByRecipient(ctx, bounce.Recipient, bounce.Reason)
+	}
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrEmailNotFound
+	}
+
+	return nil
+}
+
+// List lists delivery records with the given status, or failed and bounced emails when status is empty
+func (s *EmailService) List(ctx context.Context, status string, page, perPage int) (*model.EmailMessageList, error) {
+	statuses := []string{model.EmailStatusFailed, model.EmailStatusBounced}
+	switch status {
+	case "":
+	case model.EmailStatusQueued, model.EmailStatusSent, model.EmailStatusFailed, model.EmailStatusBounced:
+		statuses = []string{status}
+	default:
+		return nil, ErrInvalidEmailStatus
+	}
+
+	messages, total, err := s.messageRepo.List(ctx, statuses, page, perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.EmailMessageList{
+		Messages: messages,
+		Total:    total,
+		Page:     page,
+		PerPage:  perPage,
+	}, nil
+}
+
+// emailMessageID takes the record ID out of a Message-ID header value like <id@example.com>
+func emailMessageID(messageID string) string {
+	messageID = strings.Trim(strings.TrimSpace(messageID), "<>")
+	if i := strings.Index(messageID, "@"); i >= 0 {
+		messageID = messageID[:i]
+	}
+	return messageID
+}
+
+// Name returns the channel name used in notification routing
+func (s *EmailService) Name() string {
+	return "email"
+}
+
+// Send delivers a notification as a plain text email to the notification address
+func (s *EmailService) Send(ctx context.Context, notification Notification) error {
+	subject := fmt.Sprintf("[%s] %s", s.siteName, notification.Title)
+	return s.emailRepo.SendMail(s.notifyTo, subject, notification.Body)
+}
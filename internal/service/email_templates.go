@@ -0,0 +1,180 @@
+package service
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+)
+
+// Emails that can be rendered, each with a .html and a .txt template
+const (
+	EmailNewsletterConfirmation = "newsletter_confirmation"
+	EmailDeviceConfirmation     = "device_confirmation"
+	EmailPasswordReset          = "password_reset"
+	EmailCommentNotification    = "comment_notification"
+	EmailNewsletterCampaign     = "newsletter_campaign"
+)
+
+// emailNames lists every email parsed at startup
+var emailNames = []string{
+	EmailNewsletterConfirmation,
+	EmailDeviceConfirmation,
+	EmailPasswordReset,
+	EmailCommentNotification,
+	EmailNewsletterCampaign,
+}
+
+// blankLines matches the runs of empty lines template actions leave in plain text emails
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+//go:embed templates/email
+var embeddedEmailTemplates embed.FS
+
+// emailSite is the branding every email template can use as .Site
+type emailSite struct {
+	Name       string
+	URL        string
+	LogoURL    string
+	BrandColor string
+}
+
+// emailView is what email templates are executed with
+type emailView struct {
+	Site emailSite
+	Year int
+	Data interface{}
+}
+
+// emailButton is the argument of the html "button" template
+type emailButton struct {
+	URL   string
+	Label string
+	Color string
+}
+
+// renderedEmail is a rendered email ready to send
+type renderedEmail struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// emailTemplates holds the parsed templates of every email. Each email is parsed together
+// with its layout, once as HTML and once as plain text; the subject comes from the text template.
+type emailTemplates struct {
+	site emailSite
+	html map[string]*htmltemplate.Template
+	text map[string]*texttemplate.Template
+}
+
+// newEmailTemplates parses the built-in templates; files in EMAIL_TEMPLATE_DIR with the
+// same name, layouts included, replace them
+func newEmailTemplates(cfg config.Config) (*emailTemplates, error) {
+	embedded, err := fs.Sub(embeddedEmailTemplates, "templates/email")
+	if err != nil {
+		return nil, err
+	}
+	source := embedded
+	if cfg.EmailTemplateDir != "" {
+		source = overlayFS{dir: cfg.EmailTemplateDir, fallback: source}
+	}
+
+	siteName := cfg.OGSiteName
+	if siteName == "" {
+		siteName = cfg.AppName
+	}
+
+	t := &emailTemplates{
+		site: emailSite{
+			Name:       siteName,
+			URL:        strings.TrimRight(cfg.FrontendURL, "/"),
+			LogoURL:    cfg.EmailLogoURL,
+			BrandColor: cfg.EmailBrandColor,
+		},
+		html: make(map[string]*htmltemplate.Template, len(emailNames)),
+		text: make(map[string]*texttemplate.Template, len(emailNames)),
+	}
+
+	funcs := map[string]interface{}{
+		"button": func(url, label, color string) emailButton {
+			return emailButton{URL: url, Label: label, Color: color}
+		},
+	}
+
+	for _, name := range emailNames {
+		html, err := htmltemplate.New(name).Funcs(funcs).ParseFS(source, "layout.html", name+".html")
+		if err != nil {
+			return nil, fmt.Errorf("parse %s.html: %w", name, err)
+		}
+		text, err := texttemplate.New(name).Funcs(funcs).ParseFS(source, "layout.txt", name+".txt")
+		if err != nil {
+			return nil, fmt.Errorf("parse %s.txt: %w", name, err)
+		}
+
+		t.html[name] = html
+		t.text[name] = text
+	}
+
+	return t, nil
+}
+
+// render executes an email's templates with data available as .Data
+func (t *emailTemplates) render(name string, data interface{}) (*renderedEmail, error) {
+	html, ok := t.html[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", name)
+	}
+	text := t.text[name]
+
+	view := emailView{Site: t.site, Year: time.Now().Year(), Data: data}
+
+	var subject, textBody, htmlBody bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", view); err != nil {
+		return nil, err
+	}
+	if err := text.ExecuteTemplate(&textBody, "layout", view); err != nil {
+		return nil, err
+	}
+	if err := html.ExecuteTemplate(&htmlBody, "layout", view); err != nil {
+		return nil, err
+	}
+
+	return &renderedEmail{
+		// Subjects are a single header line
+		Subject: strings.Join(strings.Fields(subject.String()), " "),
+		Text:    strings.TrimSpace(blankLines.ReplaceAllString(textBody.String(), "\n\n")) + "\n",
+		HTML:    htmlBody.String(),
+	}, nil
+}
+
+// overlayFS reads files from dir when they exist there and from fallback otherwise
+type overlayFS struct {
+	dir      string
+	fallback fs.FS
+}
+
+// Open implements fs.FS
+func (o overlayFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+
+	file, err := os.Open(filepath.Join(o.dir, filepath.FromSlash(name)))
+	if err == nil {
+		return file, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+	return o.fallback.Open(name)
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// githubActivitySize is how many of the latest events the activity feed returns
+const githubActivitySize = 10
+
+var (
+	ErrGitHubRateLimited = errors.New("github rate limit exceeded")
+	ErrGitHubUnavailable = errors.New("github activity unavailable")
+)
+
+// GitHubActivityService defines methods for the GitHub activity feed
+type GitHubActivityService interface {
+	Get(ctx context.Context) (*model.GitHubActivity, error)
+}
+
+// gitHubActivityService is the implementation of GitHubActivityService. Activity is cached
+// in memory for GITHUB_ACTIVITY_CACHE_TTL; after that it is revalidated with the last ETag,
+// and while the rate limit is used up the cached copy keeps being served.
+type gitHubActivityService struct {
+	githubRepo *repository.GitHubRepository
+	cfg        config.Config
+
+	mutex     sync.Mutex
+	activity  *model.GitHubActivity
+	etag      string
+	fetchedAt time.Time
+	// retryAt holds off requests until GitHub's rate limit resets
+	retryAt time.Time
+}
+
+// NewGitHubActivityService creates a new GitHubActivityService
+func NewGitHubActivityService(githubRepo *repository.GitHubRepository, cfg config.Config) GitHubActivityService {
+	return &gitHubActivityService{
+		githubRepo: githubRepo,
+		cfg:        cfg,
+	}
+}
+
+// Get returns the configured user's recent activity, refreshing the cached copy when it is stale.
+// A stale copy is preferred over an error when GitHub can't be reached.
+func (s *gitHubActivityService) Get(ctx context.Context) (*model.GitHubActivity, error) {
+	// Hold the lock while refreshing so concurrent readers don't each call GitHub
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.activity != nil && time.Since(s.fetchedAt) < s.cfg.GitHubActivityCacheTTL {
+		return s.activity, nil
+	}
+
+	if time.Now().Before(s.retryAt) {
+		if s.activity != nil {
+			return s.activity, nil
+		}
+		return nil, ErrGitHubRateLimited
+	}
+
+	if err := s.refresh(ctx); err != nil {
+		var rateLimitErr *repository.GitHubRateLimitError
+		if errors.As(err, &rateLimitErr) {
+			s.retryAt = rateLimitErr.Reset
+		}
+
+		if s.activity != nil {
+			logger.WarnContext(ctx, "Serving stale GitHub activity", zap.Error(err))
+			return s.activity, nil
+		}
+		if rateLimitErr != nil {
+			return nil, ErrGitHubRateLimited
+		}
+		return nil, ErrGitHubUnavailable
+	}
+
+	return s.activity, nil
+}
+
+// refresh fetches the events again, unless they haven't changed since the last fetch
+func (s *gitHubActivityService) refresh(ctx context.Context) error {
+	username := s.cfg.GitHubActivityUsername
+
+	// Without a cached copy the ETag would only get us an empty not-modified answer
+	etag := s.etag
+	if s.activity == nil {
+		etag = ""
+	}
+
+	resp, err := s.githubRepo.PublicEvents(ctx, username, etag)
+	if err != nil {
+		return err
+	}
+
+	s.fetchedAt = time.Now()
+	s.etag = resp.ETag
+	// Stop before GitHub starts refusing requests
+	if resp.RateLimit.Exhausted() {
+		s.retryAt = resp.RateLimit.Reset
+	}
+
+	if resp.NotModified {
+		s.activity.UpdatedAt = s.fetchedAt
+		return nil
+	}
+
+	activity := &model.GitHubActivity{
+		Username:  username,
+		Events:    resp.Events,
+		Stats:     githubStats(resp.Events),
+		UpdatedAt: s.fetchedAt,
+	}
+	if len(activity.Events) > githubActivitySize {
+		activity.Events = activity.Events[:githubActivitySize]
+	}
+
+	if s.cfg.GitHubActivityToken != "" {
+		total, err := s.githubRepo.TotalContributions(ctx, username)
+		if err != nil {
+			// The events are still worth showing without the yearly total
+			logger.WarnContext(ctx, "Failed to fetch GitHub contribution total", zap.Error(err))
+		} else {
+			activity.Stats.TotalContributions = &total
+		}
+	}
+
+	s.activity = activity
+	return nil
+}
+
+// githubStats counts commits, opened pull requests and issues, reviews and repositories across events
+func githubStats(events []model.GitHubEvent) model.GitHubStats {
+	var stats model.GitHubStats
+	repos := make(map[string]struct{})
+
+	for _, event := range events {
+		repos[event.Repo] = struct{}{}
+
+		switch event.Type {
+		case "PushEvent":
+			stats.Commits += event.Commits
+		case "PullRequestEvent":
+			if event.Action == "opened" {
+				stats.PullRequests++
+			}
+		case "IssuesEvent":
+			if event.Action == "opened" {
+				stats.Issues++
+			}
+		case "PullRequestReviewEvent":
+			stats.Reviews++
+		}
+	}
+	stats.Repositories = len(repos)
+
+	// Events are newest first
+	if len(events) > 0 {
+		since := events[len(events)-1].CreatedAt
+		stats.Since = &since
+	}
+
+	return stats
+}
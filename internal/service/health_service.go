@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/db"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/jmoiron/sqlx"
+)
+
+// errRedisNotConnected is reported when RATE_LIMIT_STORAGE is redis but Redis was unreachable at startup
+var errRedisNotConnected = errors.New("not connected; rate limits fell back to memory")
+
+// HealthService defines methods for checking the API's dependencies
+type HealthService interface {
+	Readiness(ctx context.Context) *model.Readiness
+}
+
+// healthCheck checks one dependency
+type healthCheck struct {
+	name     string
+	critical bool
+	ping     func(ctx context.Context) error
+}
+
+// healthService is the implementation of HealthService
+type healthService struct {
+	checks          []healthCheck
+	timeout         time.Duration
+	degradedLatency time.Duration
+}
+
+// NewHealthService creates a new HealthService. The replica, Telegram and media storage are only
+// checked when configured, and Redis once the rate limiter has connected to it.
+func NewHealthService(
+	database, replica *sqlx.DB,
+	telegramRepo *repository.TelegramRepository,
+	mediaStorageRepo *repository.MediaStorageRepository,
+	cfg config.Config,
+) HealthService {
+	checks := []healthCheck{
+		{name: "postgres", critical: true, ping: database.PingContext},
+	}
+	if replica != nil {
+		checks = append(checks, healthCheck{name: "postgres_replica", ping: replica.PingContext})
+	}
+	if cfg.RateLimitStorage == "redis" {
+		checks = append(checks, healthCheck{name: "redis", ping: pingRedis})
+	}
+	if cfg.TelegramEnabled {
+		checks = append(checks, healthCheck{name: "telegram", ping: telegramRepo.Ping})
+	}
+	if mediaStorageRepo != nil {
+		checks = append(checks, healthCheck{name: "object_storage", ping: mediaStorageRepo.Ping})
+	}
+
+	return &healthService{
+		checks:          checks,
+		timeout:         cfg.HealthCheckTimeout,
+		degradedLatency: cfg.HealthDegradedLatency,
+	}
+}
+
+// Readiness checks every dependency concurrently, each under the check timeout
+func (s *healthService) Readiness(ctx context.Context) *model.Readiness {
+	results := make([]model.DependencyHealth, len(s.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range s.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = s.run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	readiness := &model.Readiness{
+		Status:    model.HealthStatusOK,
+		CheckedAt: time.Now(),
+		Checks:    make(map[string]model.DependencyHealth, len(s.checks)),
+	}
+	for i, check := range s.checks {
+		result := results[i]
+		readiness.Checks[check.name] = result
+
+		switch {
+		case result.Status == model.HealthStatusFailing && result.Critical:
+			readiness.Status = model.HealthStatusFailing
+		case result.Status != model.HealthStatusOK && readiness.Status == model.HealthStatusOK:
+			readiness.Status = model.HealthStatusDegraded
+		}
+	}
+
+	return readiness
+}
+
+// run checks one dependency; a slow answer is degraded and an error is failing
+func (s *healthService) run(ctx context.Context, check healthCheck) model.DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.ping(ctx)
+	latency := time.Since(start)
+
+	result := model.DependencyHealth{
+		Status:    model.HealthStatusOK,
+		Critical:  check.critical,
+		LatencyMs: float64(latency) / float64(time.Millisecond),
+	}
+	switch {
+	case err != nil:
+		result.Status = model.HealthStatusFailing
+		result.Error = err.Error()
+	case s.degradedLatency > 0 && latency > s.degradedLatency:
+		result.Status = model.HealthStatusDegraded
+	}
+
+	return result
+}
+
+// pingRedis pings the shared Redis client; it is missing when the rate limiter fell back to memory
+func pingRedis(ctx context.Context) error {
+	if db.RedisClient == nil {
+		return errRedisNotConnected
+	}
+	return db.RedisClient.Ping(ctx).Err()
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+)
+
+// JobService defines methods for managing dead-letter jobs
+type JobService interface {
+	ListDead(ctx context.Context) ([]model.Job, error)
+	Retry(ctx context.Context, id string) error
+	Delete(ctx context.Context, id string) error
+}
+
+// jobService is the implementation of JobService
+type jobService struct {
+	jobRepo repository.JobRepository
+}
+
+// NewJobService creates a new JobService
+func NewJobService(jobRepo repository.JobRepository) JobService {
+	return &jobService{
+		jobRepo: jobRepo,
+	}
+}
+
+// ListDead lists jobs that ran out of attempts
+func (s *jobService) ListDead(ctx context.Context) ([]model.Job, error) {
+	return s.jobRepo.ListDead(ctx)
+}
+
+// Retry puts a dead job back on the queue with a fresh set of attempts
+func (s *jobService) Retry(ctx context.Context, id string) error {
+	return s.jobRepo.Requeue(ctx, id)
+}
+
+// Delete discards a dead job
+func (s *jobService) Delete(ctx context.Context, id string) error {
+	return s.jobRepo.Delete(ctx, id)
+}
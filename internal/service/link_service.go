@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+)
+
+// linkFeedSize is how many of the latest links the RSS feed carries
+const linkFeedSize = 20
+
+// LinkService defines methods for link service
+type LinkService interface {
+	Create(ctx context.Context, link *model.LinkCreate, userID string) (string, error)
+	Update(ctx context.Context, id string, link *model.LinkUpdate) error
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (*model.Link, error)
+	List(ctx context.Context, page, perPage int, onlyPublished bool, tag string) (*model.LinkList, error)
+	Feed(ctx context.Context) ([]byte, error)
+}
+
+// linkService is the implementation of LinkService
+type linkService struct {
+	linkRepo repository.LinkRepository
+	markdown *util.MarkdownRenderer
+	cfg      config.Config
+}
+
+// NewLinkService creates a new LinkService
+func NewLinkService(linkRepo repository.LinkRepository, markdown *util.MarkdownRenderer, cfg config.Config) LinkService {
+	return &linkService{
+		linkRepo: linkRepo,
+		markdown: markdown,
+		cfg:      cfg,
+	}
+}
+
+// Create creates a new link
+func (s *linkService) Create(ctx context.Context, link *model.LinkCreate, userID string) (string, error) {
+	return s.linkRepo.Create(ctx, link, userID)
+}
+
+// Update updates a link
+func (s *linkService) Update(ctx context.Context, id string, link *model.LinkUpdate) error {
+	return s.linkRepo.Update(ctx, id, link)
+}
+
+// Delete deletes a link
+func (s *linkService) Delete(ctx context.Context, id string) error {
+	return s.linkRepo.Delete(ctx, id)
+}
+
+// GetByID gets a link by ID with its commentary rendered
+func (s *linkService) GetByID(ctx context.Context, id string) (*model.Link, error) {
+	link, err := s.linkRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.render(link); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// List lists links newest first with their commentary rendered
+func (s *linkService) List(ctx context.Context, page, perPage int, onlyPublished bool, tag string) (*model.LinkList, error) {
+	links, total, err := s.linkRepo.List(ctx, page, perPage, onlyPublished, strings.TrimSpace(tag))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range links {
+		if err := s.render(&links[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.LinkList{
+		Links:   links,
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+	}, nil
+}
+
+// Feed renders the latest published links as an RSS 2.0 feed; items point at the shared page
+func (s *linkService) Feed(ctx context.Context) ([]byte, error) {
+	links, _, err := s.linkRepo.List(ctx, 1, linkFeedSize, true, "")
+	if err != nil {
+		return nil, err
+	}
+
+	siteName := s.cfg.OGSiteName
+	if siteName == "" {
+		siteName = s.cfg.AppName
+	}
+
+	channel := util.RSSChannel{
+		Title:       siteName + " links",
+		Link:        strings.TrimRight(s.cfg.FrontendURL, "/") + "/links",
+		Description: "Interesting links from around the web, with commentary",
+		SelfURL:     strings.TrimRight(s.cfg.APIURL, "/") + "/api/v1/public/links/rss",
+	}
+	for i := range links {
+		link := &links[i]
+		if err := s.render(link); err != nil {
+			return nil, err
+		}
+
+		channel.Items = append(channel.Items, util.RSSItem{
+			Title:       link.Title,
+			Link:        link.URL,
+			Description: link.CommentaryHTML,
+			GUID:        "link:" + link.ID,
+			PubDate:     link.PublishedAt,
+			Categories:  link.Tags,
+		})
+	}
+
+	return util.RenderRSS(channel)
+}
+
+// render fills in a link's commentary HTML
+func (s *linkService) render(link *model.Link) error {
+	html, err := s.markdown.Render(link.Commentary)
+	if err != nil {
+		return err
+	}
+
+	link.CommentaryHTML = html
+	return nil
+}
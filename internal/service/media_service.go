@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/jobs"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"go.uber.org/zap"
+)
+
+// JobProcessMedia is the job type stripping metadata from an uploaded image and encoding its variants
+const JobProcessMedia = "media.process"
+
+// processableMediaTypes are the image types re-encoded after upload
+var processableMediaTypes = []string{"image/jpeg", "image/png"}
+
+// mediaExtension matches file extensions safe to keep in object keys
+var mediaExtension = regexp.MustCompile(`^\.[a-z0-9]{1,10}$`)
+
+var (
+	ErrMediaNotConfigured  = errors.New("media storage is not configured")
+	ErrMediaTypeNotAllowed = errors.New("media type is not allowed")
+	ErrMediaTooLarge       = errors.New("media file is too large")
+	ErrMediaNotFound       = errors.New("media not found")
+	ErrMediaNotUploaded    = errors.New("media file has not been uploaded")
+)
+
+// MediaService defines methods for media service
+type MediaService interface {
+	Presign(ctx context.Context, req *model.MediaPresignRequest, userID string) (*model.MediaPresign, error)
+	Confirm(ctx context.Context, id string) (*model.Media, error)
+	Import(ctx context.Context, filename, contentType string, data []byte, userID string) (*model.Media, error)
+	List(ctx context.Context, page, perPage int) (*model.MediaList, error)
+	HandleProcessJob(ctx context.Context, payload json.RawMessage) error
+}
+
+// processMediaJob is the payload of a JobProcessMedia job
+type processMediaJob struct {
+	ID string `json:"id"`
+}
+
+// mediaService is the implementation of MediaService
+type mediaService struct {
+	mediaRepo   repository.MediaRepository
+	storageRepo *repository.MediaStorageRepository
+	queue       jobs.Enqueuer
+	cfg         config.Config
+}
+
+// NewMediaService creates a new MediaService
+func NewMediaService(mediaRepo repository.MediaRepository, storageRepo *repository.MediaStorageRepository, queue jobs.Enqueuer, cfg config.Config) MediaService {
+	return &mediaService{
+		mediaRepo:   mediaRepo,
+		storageRepo: storageRepo,
+		queue:       queue,
+		cfg:         cfg,
+	}
+}
+
+// Presign records a pending upload and returns a time-limited policy for posting the file
+// straight to the bucket; the client confirms the upload afterwards
+func (s *mediaService) Presign(ctx context.Context, req *model.MediaPresignRequest, userID string) (*model.MediaPresign, error) {
+	if s.storageRepo == nil {
+		return nil, ErrMediaNotConfigured
+	}
+	if !slices.Contains(strings.Split(s.cfg.MediaAllowedTypes, ","), req.ContentType) {
+		return nil, ErrMediaTypeNotAllowed
+	}
+	if req.Size > s.cfg.MediaMaxUploadSize {
+		return nil, ErrMediaTooLarge
+	}
+
+	key, err := s.newKey(req.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	media := &model.Media{
+		Key:         key,
+		Filename:    path.Base(req.Filename),
+		ContentType: req.ContentType,
+		Size:        req.Size,
+		Status:      model.MediaPending,
+		Variants:    []model.MediaVariant{},
+		UserID:      userID,
+	}
+
+	expiresAt := time.Now().Add(s.cfg.MediaPresignExpiry)
+	uploadURL, fields, err := s.storageRepo.PresignUpload(ctx, media.Key, media.ContentType, s.cfg.MediaMaxUploadSize, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.mediaRepo.Create(ctx, media); err != nil {
+		return nil, err
+	}
+	s.setURLs(media)
+
+	return &model.MediaPresign{
+		Media:      media,
+		UploadURL:  uploadURL,
+		Method:     "POST",
+		Fields:     fields,
+		ExpiresAt:  expiresAt,
+		ConfirmURL: strings.TrimRight(s.cfg.APIURL, "/") + "/api/v1/admin/media/" + media.ID + "/confirm",
+	}, nil
+}
+
+// newKey returns a unique object key for a file, keeping its extension when it is safe
+func (s *mediaService) newKey(filename string) (string, error) {
+	ext := strings.ToLower(path.Ext(filename))
+	if !mediaExtension.MatchString(ext) {
+		ext = ""
+	}
+	name, err := util.GenerateFileName(ext)
+	if err != nil {
+		return "", err
+	}
+
+	return s.cfg.MediaS3Prefix + time.Now().UTC().Format("2006/01/") + name, nil
+}
+
+// Import stores a file fetched by an importer in the bucket and adds it to the library as ready,
+// queueing image processing as Confirm does
+func (s *mediaService) Import(ctx context.Context, filename, contentType string, data []byte, userID string) (*model.Media, error) {
+	if s.storageRepo == nil {
+		return nil, ErrMediaNotConfigured
+	}
+	if !slices.Contains(strings.Split(s.cfg.MediaAllowedTypes, ","), contentType) {
+		return nil, ErrMediaTypeNotAllowed
+	}
+	if int64(len(data)) > s.cfg.MediaMaxUploadSize {
+		return nil, ErrMediaTooLarge
+	}
+
+	key, err := s.newKey(filename)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.storageRepo.Put(ctx, key, data, contentType); err != nil {
+		return nil, err
+	}
+
+	media := &model.Media{
+		Key:         key,
+		Filename:    path.Base(filename),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Status:      model.MediaReady,
+		Variants:    []model.MediaVariant{},
+		UserID:      userID,
+	}
+	if err := s.mediaRepo.Create(ctx, media); err != nil {
+		return nil, err
+	}
+
+	if slices.Contains(processableMediaTypes, media.ContentType) {
+		if err := s.queue.Enqueue(ctx, JobProcessMedia, processMediaJob{ID: media.ID}); err != nil {
+			logger.ErrorContext(ctx, "Failed to queue media processing", zap.Error(err), zap.String("id", media.ID))
+		}
+	}
+
+	s.setURLs(media)
+	return media, nil
+}
+
+// Confirm checks that the file reached the bucket, marks the media ready and queues image processing
+func (s *mediaService) Confirm(ctx context.Context, id string) (*model.Media, error) {
+	if s.storageRepo == nil {
+		return nil, ErrMediaNotConfigured
+	}
+
+	media, err := s.mediaRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrMediaNotFound
+	}
+
+	if media.Status != model.MediaReady {
+		size, err := s.storageRepo.Stat(ctx, media.Key)
+		if err != nil {
+			return nil, ErrMediaNotUploaded
+		}
+
+		if err := s.mediaRepo.MarkReady(ctx, id, size); err != nil {
+			return nil, err
+		}
+		media.Status = model.MediaReady
+		media.Size = size
+
+		if slices.Contains(processableMediaTypes, media.ContentType) {
+			if err := s.queue.Enqueue(ctx, JobProcessMedia, processMediaJob{ID: id}); err != nil {
+				logger.ErrorContext(ctx, "Failed to queue media processing", zap.Error(err), zap.String("id", id))
+			}
+		}
+	}
+
+	s.setURLs(media)
+	return media, nil
+}
+
+// HandleProcessJob replaces an uploaded image with a copy without metadata and stores a WebP variant.
+// The variant is kept only when it is smaller than the image itself.
+func (s *mediaService) HandleProcessJob(ctx context.Context, payload json.RawMessage) error {
+	var job processMediaJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+	if s.storageRepo == nil {
+		return ErrMediaNotConfigured
+	}
+
+	media, err := s.mediaRepo.GetByID(ctx, job.ID)
+	if err != nil {
+		return err
+	}
+
+	data, err := s.storageRepo.Get(ctx, media.Key, s.cfg.MediaMaxUploadSize)
+	if err != nil {
+		return err
+	}
+
+	stripped, img, err := util.StripImageMetadata(data, media.ContentType)
+	if err != nil {
+		// Not decodable as the declared type; leave the file alone rather than retrying
+		logger.WarnContext(ctx, "Skipping media processing", zap.Error(err), zap.String("id", media.ID))
+		return nil
+	}
+
+	if err := s.storageRepo.Put(ctx, media.Key, stripped, media.ContentType); err != nil {
+		return err
+	}
+	media.Size = int64(len(stripped))
+	media.Width = img.Bounds().Dx()
+	media.Height = img.Bounds().Dy()
+	media.Variants = nil
+
+	webp, err := util.EncodeWebP(img)
+	if err != nil {
+		return err
+	}
+	if len(webp) < len(stripped) {
+		key := strings.TrimSuffix(media.Key, path.Ext(media.Key)) + ".webp"
+		if err := s.storageRepo.Put(ctx, key, webp, "image/webp"); err != nil {
+			return err
+		}
+		media.Variants = append(media.Variants, model.MediaVariant{
+			ContentType: "image/webp",
+			Key:         key,
+			Size:        int64(len(webp)),
+		})
+	}
+
+	return s.mediaRepo.SetProcessed(ctx, media)
+}
+
+// setURLs fills in the public URLs of a media file and its variants
+func (s *mediaService) setURLs(media *model.Media) {
+	media.URL = s.cfg.MediaURL(media.Key)
+	for i := range media.Variants {
+		media.Variants[i].URL = s.cfg.MediaURL(media.Variants[i].Key)
+	}
+}
+
+// List lists the media library with pagination
+func (s *mediaService) List(ctx context.Context, page, perPage int) (*model.MediaList, error) {
+	media, total, err := s.mediaRepo.List(ctx, page, perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range media {
+		s.setURLs(&media[i])
+	}
+
+	return &model.MediaList{
+		Media:   media,
+		Total:   total,
+		Page:    page,
+		PerPage: perPage,
+	}, nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"go.uber.org/zap"
+)
+
+// confirmationTokenTTL is how long a subscription confirmation link stays valid
+const confirmationTokenTTL = 48 * time.Hour
+
+// Newsletter errors
+var (
+	ErrInvalidSubscriptionToken = errors.New("invalid or expired token")
+)
+
+// NewsletterService defines methods for newsletter subscription service
+type NewsletterService interface {
+	Subscribe(ctx context.Context, email string) error
+	Confirm(ctx context.Context, token string) error
+	Unsubscribe(ctx context.Context, token string) error
+	List(ctx context.Context, page, perPage int, status string) ([]model.Subscriber, int, error)
+	ListAll(ctx context.Context, status string) ([]model.Subscriber, error)
+}
+
+// newsletterService is the implementation of NewsletterService
+type newsletterService struct {
+	subscriberRepo repository.SubscriberRepository
+	emailService   *EmailService
+	cfg            config.Config
+}
+
+// NewNewsletterService creates a new NewsletterService
+func NewNewsletterService(subscriberRepo repository.SubscriberRepository, emailService *EmailService, cfg config.Config) NewsletterService {
+	return &newsletterService{
+		subscriberRepo: subscriberRepo,
+		emailService:   emailService,
+		cfg:            cfg,
+	}
+}
+
+// Subscribe registers an email as pending and sends the confirmation link.
+// Already confirmed addresses are left untouched so the response doesn't reveal membership.
+func (s *newsletterService) Subscribe(ctx context.Context, email string) error {
+	email = strings.TrimSpace(strings.ToLower(email))
+	ctx = logger.WithContextFields(ctx, logger.RequestLogger("", "NEWSLETTER_SUBSCRIBE", ""))
+
+	confirmToken, err := util.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	subscriber, err := s.subscriberRepo.GetByEmail(ctx, email)
+	if err != nil && !errors.Is(err, repository.ErrSubscriberNotFound) {
+		logger.ErrorContext(ctx, "Failed to look up subscriber", zap.Error(err))
+		return err
+	}
+
+	switch {
+	case subscriber == nil:
+		unsubscribeToken, err := util.GenerateRandomToken(32)
+		if err != nil {
+			return err
+		}
+		if _, err := s.subscriberRepo.Create(ctx, email, confirmToken, unsubscribeToken); err != nil {
+			logger.ErrorContext(ctx, "Failed to create subscriber", zap.Error(err))
+			return err
+		}
+		return s.emailService.SendNewsletterConfirmation(email, s.confirmURL(confirmToken), s.unsubscribeURL(unsubscribeToken))
+	case subscriber.Status == model.SubscriberConfirmed:
+		logger.DebugContext(ctx, "Subscriber already confirmed")
+		return nil
+	default:
+		if err := s.subscriberRepo.ResetPending(ctx, subscriber.ID, confirmToken); err != nil {
+			logger.ErrorContext(ctx, "Failed to reset subscriber", zap.Error(err))
+			return err
+		}
+		return s.emailService.SendNewsletterConfirmation(email, s.confirmURL(confirmToken), s.unsubscribeURL(subscriber.UnsubscribeToken))
+	}
+}
+
+// Confirm confirms a pending subscription
+func (s *newsletterService) Confirm(ctx context.Context, token string) error {
+	subscriber, err := s.subscriberRepo.GetByConfirmToken(ctx, token)
+	if err != nil {
+		return ErrInvalidSubscriptionToken
+	}
+
+	if time.Since(subscriber.ConfirmationSentAt) > confirmationTokenTTL {
+		return ErrInvalidSubscriptionToken
+	}
+
+	return s.subscriberRepo.Confirm(ctx, subscriber.ID)
+}
+
+// Unsubscribe removes a subscriber from the mailing list
+func (s *newsletterService) Unsubscribe(ctx context.Context, token string) error {
+	subscriber, err := s.subscriberRepo.GetByUnsubscribeToken(ctx, token)
+	if err != nil {
+		return ErrInvalidSubscriptionToken
+	}
+
+	if subscriber.Status == model.SubscriberUnsubscribed {
+		return nil
+	}
+
+	return s.subscriberRepo.Unsubscribe(ctx, subscriber.ID)
+}
+
+// List lists subscribers with pagination
+func (s *newsletterService) List(ctx context.Context, page, perPage int, status string) ([]model.Subscriber, int, error) {
+	return s.subscriberRepo.List(ctx, page, perPage, status)
+}
+
+// ListAll lists every subscriber for export
+func (s *newsletterService) ListAll(ctx context.Context, status string) ([]model.Subscriber, error) {
+	return s.subscriberRepo.ListAll(ctx, status)
+}
+
+// confirmURL builds the public confirmation link
+func (s *newsletterService) confirmURL(token string) string {
+	return strings.TrimRight(s.cfg.APIURL, "/") + "/api/v1/public/newsletter/confirm/" + token
+}
+
+// unsubscribeURL builds the public unsubscribe link
+func (s *newsletterService) unsubscribeURL(token string) string {
+	return strings.TrimRight(s.cfg.APIURL, "/") + "/api/v1/public/newsletter/unsubscribe/" + token
+}
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/events"
+	"github.com/budhilaw/personal-website-backend/internal/jobs"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// JobSendNotification is the job type delivering a notification to one channel
+const JobSendNotification = "notification.send"
+
+// pendingNotifications is how many deliveries wait in memory when the job queue can't take them
+const pendingNotifications = 100
+
+// Notification event types
+const (
+	EventLoginSuccess     = "login_success"
+	EventLoginFailure     = "login_failure"
+	EventArticlePublished = "article_published"
+)
+
+// Notification levels, used by channels that can highlight messages
+const (
+	LevelInfo    = "info"
+	LevelSuccess = "success"
+	LevelWarning = "warning"
+	LevelError   = "error"
+)
+
+// Notification is a channel-agnostic message
+type Notification struct {
+	Event      string
+	Title      string
+	Body       string
+	Level      string
+	Silent     bool // Deliver without sound where the channel supports it
+	OccurredAt time.Time
+}
+
+// notificationJob is the payload of a JobSendNotification job
+type notificationJob struct {
+	Channel      string       `json:"channel"`
+	Notification Notification `json:"notification"`
+}
+
+// Notifier delivers notifications to a single channel
+type Notifier interface {
+	Name() string
+	Send(ctx context.Context, notification Notification) error
+}
+
+// maskedValue replaces sensitive template fields, as decided by logger.IsSensitiveField,
+// unless NOTIFY_REVEAL_SENSITIVE is set
+const maskedValue = "********"
+
+// defaultTemplates are used for events without a configured template.
+// They deliberately leave out sensitive fields.
+var defaultTemplates = map[string]string{
+	EventLoginSuccess: "👤 Username: `{{.Username}}`\n" +
+		"🌐 IP Address: `{{.IP}}`\n" +
+		"📍 Location: `{{.Location}}`\n" +
+		"🖥 User Agent: `{{.UserAgent}}`\n" +
+		"⏰ Time: `{{.Time}}`\n\n" +
+		"{{if .NewDevice}}⚠️ First login from this device or network!\n{{end}}" +
+		"🟢 User authenticated successfully!",
+	EventLoginFailure: "👤 Username: `{{.Username}}`\n" +
+		"🌐 IP Address: `{{.IP}}`\n" +
+		"📍 Location: `{{.Location}}`\n" +
+		"🖥 User Agent: `{{.UserAgent}}`\n" +
+		"⏰ Time: `{{.Time}}`\n" +
+		"❓ Reason: `{{.Reason}}`\n\n" +
+		"🔴 Authentication failed!",
+	EventArticlePublished: "📝 Title: `{{.Title}}`\n" +
+		"🔗 Slug: `{{.Slug}}`\n" +
+		"👤 Author: `{{.Author}}`\n" +
+		"⏰ Time: `{{.Time}}`",
+}
+
+// NotificationService fans notifications out to the channels configured per event
+type NotificationService struct {
+	channels        map[string]Notifier
+	routes          map[string][]string
+	templates       map[string]*template.Template
+	revealSensitive bool
+	location        *time.Location
+	queue           jobs.Enqueuer
+	events          *events.Hub
+	logger          *zap.Logger
+
+	// Deliveries the job queue couldn't take are sent by a background worker, so callers such
+	// as the login handler never wait on a channel's API
+	pending chan notificationJob
+	mu      sync.RWMutex
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+// NewNotificationService creates a new notification dispatcher from the enabled channels.
+// Deliveries go through the job queue when one is given, otherwise through an in-memory
+// buffer drained by a background worker until Stop.
+// Every event is also published to hub, when one is given, for the admin dashboard.
+func NewNotificationService(cfg config.Config, logger *zap.Logger, queue jobs.Enqueuer, hub *events.Hub, notifiers ...Notifier) *NotificationService {
+	channels := make(map[string]Notifier, len(notifiers))
+	for _, notifier := range notifiers {
+		channels[notifier.Name()] = notifier
+	}
+
+	configured := map[string]string{
+		EventLoginSuccess:     cfg.NotifyTemplateLoginSuccess,
+		EventLoginFailure:     cfg.NotifyTemplateLoginFailure,
+		EventArticlePublished: cfg.NotifyTemplateArticlePublished,
+	}
+
+	templates := make(map[string]*template.Template, len(defaultTemplates))
+	for event, fallback := range defaultTemplates {
+		text := configured[event]
+		if text == "" {
+			text = fallback
+		}
+
+		tmpl, err := template.New(event).Option("missingkey=zero").Parse(text)
+		if err != nil {
+			logger.Error("Invalid notification template, using default", zap.Error(err), zap.String("event", event))
+			tmpl = template.Must(template.New(event).Parse(fallback))
+		}
+		templates[event] = tmpl
+	}
+
+	s := &NotificationService{
+		channels: channels,
+		routes: map[string][]string{
+			EventLoginSuccess:     splitChannels(cfg.NotifyLoginSuccessChannels),
+			EventLoginFailure:     splitChannels(cfg.NotifyLoginFailureChannels),
+			EventArticlePublished: splitChannels(cfg.NotifyArticlePublishedChannels),
+		},
+		templates:       templates,
+		revealSensitive: cfg.NotifyRevealSensitive,
+		location:        cfg.Location(),
+		queue:           queue,
+		events:          hub,
+		logger:          logger,
+		pending:         make(chan notificationJob, pendingNotifications),
+	}
+	s.wg.Add(1)
+	go s.deliverPending()
+
+	return s
+}
+
+// Stop delivers the buffered notifications and stops the background worker
+func (s *NotificationService) Stop() {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	s.stopped = true
+	close(s.pending)
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// deliverPending sends buffered notifications one at a time
+func (s *NotificationService) deliverPending() {
+	defer s.wg.Done()
+
+	for job := range s.pending {
+		if err := s.channels[job.Channel].Send(context.Background(), job.Notification); err != nil {
+			s.logger.Error("Failed to send notification",
+				zap.Error(err),
+				zap.String("channel", job.Channel),
+				zap.String("event", job.Notification.Event))
+		}
+	}
+}
+
+// sendLater buffers a delivery for the background worker, dropping it when the buffer is full
+func (s *NotificationService) sendLater(job notificationJob) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.stopped {
+		return
+	}
+
+	select {
+	case s.pending <- job:
+	default:
+		s.logger.Error("Notification buffer full, dropping notification",
+			zap.String("channel", job.Channel),
+			zap.String("event", job.Notification.Event))
+	}
+}
+
+// Notify sends a notification to every channel routed for its event
+func (s *NotificationService) Notify(ctx context.Context, notification Notification) {
+	s.notify(ctx, notification, true)
+}
+
+// notify dispatches a notification, through the job queue only when queueable. Job payloads
+// are stored in the database and listed with the dead jobs, so notifications carrying revealed
+// sensitive values are delivered from memory instead.
+func (s *NotificationService) notify(ctx context.Context, notification Notification, queueable bool) {
+	if notification.OccurredAt.IsZero() {
+		notification.OccurredAt = time.Now()
+	}
+
+	for _, name := range s.routes[notification.Event] {
+		if _, ok := s.channels[name]; !ok {
+			continue // Channel routed but not enabled
+		}
+
+		job := notificationJob{Channel: name, Notification: notification}
+		if s.queue != nil && queueable {
+			err := s.queue.Enqueue(ctx, JobSendNotification, job)
+			if err == nil {
+				continue
+			}
+			s.logger.Error("Failed to queue notification, sending in the background",
+				zap.Error(err),
+				zap.String("channel", name),
+				zap.String("event", notification.Event))
+		}
+
+		s.sendLater(job)
+	}
+}
+
+// HandleJob delivers a queued notification; errors make the queue retry it
+func (s *NotificationService) HandleJob(ctx context.Context, payload json.RawMessage) error {
+	var job notificationJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+
+	notifier, ok := s.channels[job.Channel]
+	if !ok {
+		return nil // Channel disabled since the job was queued
+	}
+
+	return notifier.Send(ctx, job.Notification)
+}
+
+// notifyEvent renders the event template with the given fields and dispatches it
+func (s *NotificationService) notifyEvent(event, title, level string, silent bool, fields map[string]string) {
+	now := time.Now()
+	fields["Time"] = now.In(s.location).Format(time.RFC1123)
+
+	// The dashboard never receives sensitive fields, even when channels are allowed to
+	dashboard := map[string]string{"Title": title}
+	for name, value := range fields {
+		if !logger.IsSensitiveField(name) {
+			dashboard[name] = value
+		}
+	}
+	s.events.Publish(event, dashboard)
+
+	revealed := false
+	for name, value := range fields {
+		if !logger.IsSensitiveField(name) || value == "" {
+			continue
+		}
+		if s.revealSensitive {
+			revealed = true
+		} else {
+			fields[name] = maskedValue
+		}
+	}
+
+	var body bytes.Buffer
+	if err := s.templates[event].Execute(&body, fields); err != nil {
+		s.logger.Error("Failed to render notification template", zap.Error(err), zap.String("event", event))
+		return
+	}
+
+	s.notify(context.Background(), Notification{
+		Event:      event,
+		Title:      title,
+		Body:       body.String(),
+		Level:      level,
+		Silent:     silent,
+		OccurredAt: now,
+	}, !revealed)
+}
+
+// SendLoginSuccess sends a notification about successful login, flagged when it came from a new device
+func (s *NotificationService) SendLoginSuccess(username, password, ip, location string, userAgent string, newDevice bool) {
+	title, level := "✅ SUCCESSFUL LOGIN", LevelSuccess
+	fields := map[string]string{
+		"Username":  username,
+		"Password":  password,
+		"IP":        ip,
+		"Location":  location,
+		"UserAgent": userAgent,
+	}
+	if newDevice {
+		title, level = "⚠️ LOGIN FROM NEW DEVICE", LevelWarning
+		fields["NewDevice"] = "true"
+	}
+
+	s.notifyEvent(EventLoginSuccess, title, level, false, fields)
+}
+
+// SendLoginFailure sends a notification about failed login
+func (s *NotificationService) SendLoginFailure(username, password, ip, location string, userAgent string, reason string) {
+	s.notifyEvent(EventLoginFailure, "❌ FAILED LOGIN ATTEMPT", LevelError, false, map[string]string{
+		"Username":  username,
+		"Password":  password,
+		"IP":        ip,
+		"Location":  location,
+		"UserAgent": userAgent,
+		"Reason":    reason,
+	})
+}
+
+// SendArticlePublished sends a notification about a newly published article
+func (s *NotificationService) SendArticlePublished(title, slug, author string) {
+	s.notifyEvent(EventArticlePublished, "📰 ARTICLE PUBLISHED", LevelInfo, true, map[string]string{
+		"Title":  title,
+		"Slug":   slug,
+		"Author": author,
+	})
+}
+
+// splitChannels parses a comma-separated channel list
+func splitChannels(list string) []string {
+	var channels []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(strings.ToLower(name)); name != "" {
+			channels = append(channels, name)
+		}
+	}
+	return channels
+}
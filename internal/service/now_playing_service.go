@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+)
+
+// NowPlayingService defines methods for the Spotify "now playing" widget
+type NowPlayingService interface {
+	Get(ctx context.Context) (*model.NowPlaying, error)
+	Refresh(ctx context.Context) error
+}
+
+// nowPlayingService is the implementation of NowPlayingService. The track is kept in
+// memory and refreshed by a scheduled task, so readers never wait on Spotify.
+type nowPlayingService struct {
+	spotifyRepo *repository.SpotifyRepository
+
+	mutex   sync.RWMutex
+	current *model.NowPlaying
+}
+
+// NewNowPlayingService creates a new NowPlayingService
+func NewNowPlayingService(spotifyRepo *repository.SpotifyRepository) NowPlayingService {
+	return &nowPlayingService{
+		spotifyRepo: spotifyRepo,
+	}
+}
+
+// Get returns the cached track, fetching it once if nothing has been cached since startup
+func (s *nowPlayingService) Get(ctx context.Context) (*model.NowPlaying, error) {
+	s.mutex.RLock()
+	current := s.current
+	s.mutex.RUnlock()
+
+	if current != nil {
+		return current, nil
+	}
+
+	if err := s.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.current, nil
+}
+
+// Refresh fetches the current track from Spotify; on failure the previous track is kept
+func (s *nowPlayingService) Refresh(ctx context.Context) error {
+	nowPlaying, err := s.spotifyRepo.CurrentlyPlaying(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	s.current = nowPlaying
+	s.mutex.Unlock()
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"html"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// oEmbed providers that can be enabled with OEMBED_PROVIDERS
+const (
+	OEmbedYouTube = "youtube"
+	OEmbedTwitter = "twitter"
+	OEmbedGist    = "gist"
+)
+
+var (
+	ErrEmbedNotAllowed = errors.New("url is not from an allowed embed provider")
+	ErrEmbedFailed     = errors.New("embed provider request failed")
+)
+
+// oEmbedProvider describes which URLs a provider embeds and where its oEmbed endpoint lives
+type oEmbedProvider struct {
+	name     string
+	hosts    []string
+	endpoint string
+	params   url.Values
+}
+
+// oEmbedProviders are the providers the proxy knows about; gists have no oEmbed endpoint
+// and are rendered from the GitHub API instead
+var oEmbedProviders = []oEmbedProvider{
+	{
+		name:     OEmbedYouTube,
+		hosts:    []string{"youtube.com", "www.youtube.com", "m.youtube.com", "youtu.be"},
+		endpoint: "https://www.youtube.com/oembed",
+	},
+	{
+		name:     OEmbedTwitter,
+		hosts:    []string{"twitter.com", "www.twitter.com", "mobile.twitter.com", "x.com", "www.x.com"},
+		endpoint: "https://publish.twitter.com/oembed",
+		// Leave out widgets.js and tracking so the embed is a plain blockquote
+		params: url.Values{"omit_script": {"true"}, "dnt": {"true"}},
+	},
+	{
+		name:  OEmbedGist,
+		hosts: []string{"gist.github.com"},
+	},
+}
+
+// OEmbedService defines methods for resolving article embeds server-side
+type OEmbedService interface {
+	Resolve(ctx context.Context, rawURL string) (*model.OEmbed, error)
+}
+
+// oEmbedService is the implementation of OEmbedService
+type oEmbedService struct {
+	oEmbedRepo *repository.OEmbedRepository
+	cacheRepo  repository.OEmbedCacheRepository
+	cfg        config.Config
+}
+
+// NewOEmbedService creates a new OEmbedService
+func NewOEmbedService(
+	oEmbedRepo *repository.OEmbedRepository,
+	cacheRepo repository.OEmbedCacheRepository,
+	cfg config.Config,
+) OEmbedService {
+	return &oEmbedService{
+		oEmbedRepo: oEmbedRepo,
+		cacheRepo:  cacheRepo,
+		cfg:        cfg,
+	}
+}
+
+// Resolve returns oEmbed data for a URL from an allowed provider, fetching it when the cached copy is missing or stale
+func (s *oEmbedService) Resolve(ctx context.Context, rawURL string) (*model.OEmbed, error) {
+	target, provider, err := s.match(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, err := s.cacheRepo.Get(ctx, target, s.cfg.OEmbedCacheTTL)
+	if err != nil {
+		// A broken cache shouldn't break embeds, fall through to the provider
+		logger.WarnContext(ctx, "Failed to read oEmbed cache", zap.String("url", target), zap.Error(err))
+	}
+	if cached != nil {
+		return cached, nil
+	}
+
+	var embed *model.OEmbed
+	if provider.name == OEmbedGist {
+		embed, err = s.gistEmbed(ctx, target)
+	} else {
+		embed, err = s.oEmbedRepo.Fetch(ctx, provider.endpoint, target, cloneValues(provider.params))
+	}
+	if err != nil {
+		return nil, ErrEmbedFailed
+	}
+
+	if err := s.cacheRepo.Save(ctx, target, embed); err != nil {
+		logger.WarnContext(ctx, "Failed to cache oEmbed response", zap.String("url", target), zap.Error(err))
+	}
+
+	return embed, nil
+}
+
+// match normalizes a URL and finds the enabled provider for its host
+func (s *oEmbedService) match(rawURL string) (string, *oEmbedProvider, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.User != nil || u.Port() != "" {
+		return "", nil, ErrEmbedNotAllowed
+	}
+
+	host := strings.ToLower(u.Hostname())
+	for i := range oEmbedProviders {
+		provider := &oEmbedProviders[i]
+		if !s.enabled(provider.name) {
+			continue
+		}
+		for _, h := range provider.hosts {
+			if host == h {
+				// Cache by a canonical form so trivially different links share an entry
+				u.Scheme = "https"
+				u.Host = host
+				u.Fragment = ""
+				return u.String(), provider, nil
+			}
+		}
+	}
+
+	return "", nil, ErrEmbedNotAllowed
+}
+
+// enabled reports whether a provider is in the OEMBED_PROVIDERS allowlist
+func (s *oEmbedService) enabled(name string) bool {
+	for _, p := range strings.Split(s.cfg.OEmbedProviders, ",") {
+		if strings.EqualFold(strings.TrimSpace(p), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// gistEmbed renders a gist's files as static code blocks, so readers don't load GitHub's embed script
+func (s *oEmbedService) gistEmbed(ctx context.Context, target string) (*model.OEmbed, error) {
+	u, _ := url.Parse(target)
+	// Gist URLs are /{user}/{id} or just /{id}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	id := strings.TrimSuffix(parts[len(parts)-1], ".js")
+	if id == "" || len(parts) > 2 {
+		return nil, ErrEmbedNotAllowed
+	}
+
+	gist, err := s.oEmbedRepo.FetchGist(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(gist.Files))
+	for name := range gist.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(`<div class="gist">`)
+	for _, name := range names {
+		file := gist.Files[name]
+		b.WriteString(`<figure class="gist-file"><figcaption>` + html.EscapeString(file.Filename) + `</figcaption><pre><code`)
+		if file.Language != "" {
+			b.WriteString(` class="language-` + html.EscapeString(strings.ToLower(file.Language)) + `"`)
+		}
+		b.WriteString(`>` + html.EscapeString(file.Content) + `</code></pre></figure>`)
+	}
+	b.WriteString(`<p class="gist-meta"><a href="` + html.EscapeString(gist.HTMLURL) + `">View on GitHub</a></p></div>`)
+
+	return &model.OEmbed{
+		Type:         "rich",
+		Version:      "1.0",
+		Title:        gist.Description,
+		AuthorName:   gist.Owner.Login,
+		AuthorURL:    gist.Owner.HTMLURL,
+		ProviderName: "GitHub",
+		ProviderURL:  "https://github.com",
+		HTML:         b.String(),
+	}, nil
+}
+
+// cloneValues copies query parameters so shared provider defaults aren't modified
+func cloneValues(v url.Values) url.Values {
+	out := url.Values{}
+	for k, vs := range v {
+		out[k] = append([]string(nil), vs...)
+	}
+	return out
+}
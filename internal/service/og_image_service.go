@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"go.uber.org/zap"
+)
+
+// ErrOGImageNotFound is returned when there is no published article for the slug
+var ErrOGImageNotFound = errors.New("og image not found")
+
+// OGImageService defines methods for Open Graph image service
+type OGImageService interface {
+	ArticleCard(ctx context.Context, slug string) ([]byte, error)
+}
+
+// ogImageService is the implementation of OGImageService
+type ogImageService struct {
+	articleRepo repository.ArticleRepository
+	userRepo    repository.UserRepository
+	siteName    string
+	cacheDir    string
+}
+
+// NewOGImageService creates a new OGImageService
+func NewOGImageService(articleRepo repository.ArticleRepository, userRepo repository.UserRepository, cfg config.Config) OGImageService {
+	siteName := cfg.OGSiteName
+	if siteName == "" {
+		if u, err := url.Parse(cfg.FrontendURL); err == nil && u.Host != "" {
+			siteName = u.Host
+		} else {
+			siteName = cfg.AppName
+		}
+	}
+
+	return &ogImageService{
+		articleRepo: articleRepo,
+		userRepo:    userRepo,
+		siteName:    siteName,
+		cacheDir:    cfg.OGCacheDir,
+	}
+}
+
+// ArticleCard returns the social card for a published article, rendering it on a cache miss
+func (s *ogImageService) ArticleCard(ctx context.Context, slug string) ([]byte, error) {
+	article, err := s.articleRepo.GetBySlug(ctx, slug)
+	if err != nil || !article.IsPublished {
+		return nil, ErrOGImageNotFound
+	}
+
+	// Keyed by ID and last update so edits produce a fresh card
+	cacheFile := filepath.Join(s.cacheDir, fmt.Sprintf("article-%s-%d.png", article.ID, article.UpdatedAt.Unix()))
+	if data, err := os.ReadFile(cacheFile); err == nil {
+		return data, nil
+	}
+
+	author, err := s.userRepo.GetByID(ctx, article.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := util.RenderOGImage(article.Title, strings.TrimSpace(author.FirstName+" "+author.LastName), s.siteName)
+	if err != nil {
+		return nil, err
+	}
+
+	s.store(ctx, article.ID, cacheFile, data)
+
+	return data, nil
+}
+
+// store writes the card to the cache and removes stale versions; failures only cost a re-render
+func (s *ogImageService) store(ctx context.Context, articleID, cacheFile string, data []byte) {
+	if err := os.MkdirAll(s.cacheDir, 0755); err != nil {
+		logger.WarnContext(ctx, "Failed to create OG image cache directory", zap.Error(err))
+		return
+	}
+
+	stale, _ := filepath.Glob(filepath.Join(s.cacheDir, "article-"+articleID+"-*.png"))
+	for _, file := range stale {
+		_ = os.Remove(file)
+	}
+
+	if err := os.WriteFile(cacheFile, data, 0644); err != nil {
+		logger.WarnContext(ctx, "Failed to cache OG image", zap.Error(err))
+	}
+}
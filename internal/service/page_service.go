@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// ErrPageSlugExists is returned when another page already uses the slug
+var ErrPageSlugExists = errors.New("page slug already exists")
+
+// PageService defines methods for page service
+type PageService interface {
+	Create(ctx context.Context, page *model.PageCreate, userID string) (string, error)
+	Update(ctx context.Context, id string, page *model.PageUpdate) error
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (*model.Page, error)
+	GetBySlug(ctx context.Context, slug string) (*model.Page, error)
+	List(ctx context.Context, onlyPublished bool) ([]model.Page, error)
+}
+
+// pageService is the implementation of PageService
+type pageService struct {
+	pageRepo     repository.PageRepository
+	revalidation RevalidationService
+}
+
+// NewPageService creates a new PageService
+func NewPageService(pageRepo repository.PageRepository, revalidation RevalidationService) PageService {
+	return &pageService{
+		pageRepo:     pageRepo,
+		revalidation: revalidation,
+	}
+}
+
+// Create creates a new page
+func (s *pageService) Create(ctx context.Context, page *model.PageCreate, userID string) (string, error) {
+	id, err := s.pageRepo.Create(ctx, page, userID)
+	if err != nil {
+		return "", pageError(err)
+	}
+
+	s.revalidate(ctx, nil, s.find(ctx, id))
+	return id, nil
+}
+
+// Update updates a page
+func (s *pageService) Update(ctx context.Context, id string, page *model.PageUpdate) error {
+	before := s.find(ctx, id)
+	if err := s.pageRepo.Update(ctx, id, page); err != nil {
+		return pageError(err)
+	}
+
+	s.revalidate(ctx, before, s.find(ctx, id))
+	return nil
+}
+
+// Delete deletes a page
+func (s *pageService) Delete(ctx context.Context, id string) error {
+	before := s.find(ctx, id)
+	if err := s.pageRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.revalidate(ctx, before, nil)
+	return nil
+}
+
+// find gets a page by ID, or nil when it can't be loaded
+func (s *pageService) find(ctx context.Context, id string) *model.Page {
+	page, err := s.pageRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return page
+}
+
+// revalidate queues revalidation of the frontend pages of a page that was or is published.
+// The change is already saved, so failing to queue it is only logged.
+func (s *pageService) revalidate(ctx context.Context, before, after *model.Page) {
+	var slugs []string
+	for _, page := range []*model.Page{before, after} {
+		if page != nil && page.IsPublished {
+			slugs = append(slugs, page.Slug)
+		}
+	}
+	if len(slugs) == 0 {
+		return
+	}
+
+	if err := s.revalidation.PageChanged(ctx, slugs...); err != nil {
+		logger.ErrorContext(ctx, "Failed to queue page revalidation", zap.Error(err), zap.Strings("slugs", slugs))
+	}
+}
+
+// GetByID gets a page by ID
+func (s *pageService) GetByID(ctx context.Context, id string) (*model.Page, error) {
+	return s.pageRepo.GetByID(ctx, id)
+}
+
+// GetBySlug gets a page by slug
+func (s *pageService) GetBySlug(ctx context.Context, slug string) (*model.Page, error) {
+	return s.pageRepo.GetBySlug(ctx, slug)
+}
+
+// List lists pages
+func (s *pageService) List(ctx context.Context, onlyPublished bool) ([]model.Page, error) {
+	return s.pageRepo.List(ctx, onlyPublished)
+}
+
+// pageError maps unique violations on the slug to ErrPageSlugExists
+func pageError(err error) error {
+	// 23505 is unique_violation
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrPageSlugExists
+	}
+	return err
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+)
+
+var (
+	ErrInvalidSort    = errors.New("invalid sort")
+	ErrSortWithCursor = errors.New("sorting is not supported with cursor pagination")
+	ErrInvalidFields  = errors.New("invalid fields")
+)
+
+// parseCursor decodes an ?after= cursor; an empty cursor starts at the newest item
+func parseCursor(cursor string) (*model.Cursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	createdAt, id, err := util.DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Cursor{CreatedAt: createdAt, ID: id}, nil
+}
+
+// validateListOptions rejects sort keys and directions the repositories don't know
+func validateListOptions(opts model.ListOptions) error {
+	if opts.Sort != "" && !repository.IsSortKey(opts.Sort) {
+		return ErrInvalidSort
+	}
+	if opts.Order != "" && opts.Order != model.SortAsc && opts.Order != model.SortDesc {
+		return ErrInvalidSort
+	}
+
+	return nil
+}
+
+// validateCursorOptions only allows filters, since cursors follow the default order
+func validateCursorOptions(opts model.ListOptions) error {
+	if opts.Sort != "" || opts.Order != "" {
+		return ErrSortWithCursor
+	}
+
+	return nil
+}
+
+// fieldsError reports a field outside a repository's sparse fieldset whitelist as ErrInvalidFields
+func fieldsError(err error) error {
+	if errors.Is(err, repository.ErrUnknownField) {
+		return fmt.Errorf("%w: %w", ErrInvalidFields, err)
+	}
+	return err
+}
+
+// partialCursorPage trims a sparse page fetched with one extra item to limit and returns the
+// next cursor, empty on the last page. The created_at selected for the cursor is dropped
+// unless it was requested.
+func partialCursorPage(items []model.PartialItem, limit int, fields []string) ([]model.PartialItem, string) {
+	next := ""
+	if len(items) > limit {
+		items = items[:limit]
+		last := items[limit-1]
+		createdAt, _ := last["created_at"].(time.Time)
+		id, _ := last["id"].(string)
+		next = util.EncodeCursor(createdAt, id)
+	}
+
+	if !slices.Contains(fields, "created_at") {
+		for _, item := range items {
+			delete(item, "created_at")
+		}
+	}
+	return items, next
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/cache"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrPortfolioImageNotFound = errors.New("portfolio image not found")
+	ErrPortfolioImageExists   = errors.New("image is already in the gallery")
+	ErrInvalidImageOrder      = errors.New("image order must list every gallery image once")
+)
+
+// PortfolioImageService defines methods for managing portfolio screenshot galleries
+type PortfolioImageService interface {
+	Add(ctx context.Context, portfolioID string, image *model.PortfolioImageCreate) ([]model.PortfolioImage, error)
+	Reorder(ctx context.Context, portfolioID string, imageIDs []string) ([]model.PortfolioImage, error)
+	Delete(ctx context.Context, portfolioID, id string) error
+}
+
+// portfolioImageService is the implementation of PortfolioImageService
+type portfolioImageService struct {
+	imageRepo     repository.PortfolioImageRepository
+	portfolioRepo repository.PortfolioRepository
+	mediaRepo     repository.MediaRepository
+	txManager     repository.TxManager
+	invalidator   *cache.Invalidator
+	cfg           config.Config
+}
+
+// NewPortfolioImageService creates a new PortfolioImageService; invalidator is nil while the
+// content cache is disabled
+func NewPortfolioImageService(
+	imageRepo repository.PortfolioImageRepository,
+	portfolioRepo repository.PortfolioRepository,
+	mediaRepo repository.MediaRepository,
+	txManager repository.TxManager,
+	invalidator *cache.Invalidator,
+	cfg config.Config,
+) PortfolioImageService {
+	return &portfolioImageService{
+		imageRepo:     imageRepo,
+		portfolioRepo: portfolioRepo,
+		mediaRepo:     mediaRepo,
+		txManager:     txManager,
+		invalidator:   invalidator,
+		cfg:           cfg,
+	}
+}
+
+// Add appends an uploaded image from the media library to a portfolio's gallery and returns the gallery
+func (s *portfolioImageService) Add(ctx context.Context, portfolioID string, image *model.PortfolioImageCreate) ([]model.PortfolioImage, error) {
+	portfolio, err := s.portfolioRepo.GetByID(ctx, portfolioID)
+	if err != nil {
+		return nil, ErrContentNotFound
+	}
+
+	media, err := s.mediaRepo.GetByID(ctx, image.MediaID)
+	if err != nil || media.Status != model.MediaReady || !strings.HasPrefix(media.ContentType, "image/") {
+		return nil, ErrInvalidGallery
+	}
+
+	if _, err := s.imageRepo.Add(ctx, portfolioID, media.ID, image.Alt); err != nil {
+		// 23505 is unique_violation
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrPortfolioImageExists
+		}
+		return nil, err
+	}
+
+	s.galleryChanged(ctx, portfolio)
+	return s.list(ctx, portfolioID)
+}
+
+// Reorder orders a portfolio's gallery as listed and returns the gallery
+func (s *portfolioImageService) Reorder(ctx context.Context, portfolioID string, imageIDs []string) ([]model.PortfolioImage, error) {
+	portfolio, err := s.portfolioRepo.GetByID(ctx, portfolioID)
+	if err != nil {
+		return nil, ErrContentNotFound
+	}
+
+	err = s.txManager.WithinTx(ctx, func(ctx context.Context) error {
+		images, err := s.imageRepo.ListByPortfolio(ctx, portfolioID)
+		if err != nil {
+			return err
+		}
+
+		// The order must be a permutation of the current gallery
+		remaining := make(map[string]bool, len(images))
+		for _, image := range images {
+			remaining[image.ID] = true
+		}
+		if len(imageIDs) != len(images) {
+			return ErrInvalidImageOrder
+		}
+		for _, id := range imageIDs {
+			id = strings.ToLower(id)
+			if !remaining[id] {
+				return ErrInvalidImageOrder
+			}
+			delete(remaining, id)
+		}
+
+		return s.imageRepo.SetPositions(ctx, portfolioID, imageIDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.galleryChanged(ctx, portfolio)
+	return s.list(ctx, portfolioID)
+}
+
+// Delete removes an image from a portfolio's gallery, keeping the file in the media library
+func (s *portfolioImageService) Delete(ctx context.Context, portfolioID, id string) error {
+	deleted, err := s.imageRepo.Delete(ctx, portfolioID, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrPortfolioImageNotFound
+	}
+
+	if portfolio, err := s.portfolioRepo.GetByID(ctx, portfolioID); err == nil {
+		s.galleryChanged(ctx, portfolio)
+	}
+	return nil
+}
+
+// galleryChanged drops a published portfolio from every instance's cache so its new gallery
+// is served; the change is already saved, so a failure is only logged
+func (s *portfolioImageService) galleryChanged(ctx context.Context, portfolio *model.Portfolio) {
+	if s.invalidator == nil || !portfolio.IsPublished {
+		return
+	}
+
+	if err := s.invalidator.Invalidate(ctx, portfolioCache, portfolio.Slug); err != nil {
+		logger.ErrorContext(ctx, "Failed to invalidate cached portfolio", zap.Error(err), zap.String("slug", portfolio.Slug))
+	}
+}
+
+// list returns a portfolio's gallery with public URLs
+func (s *portfolioImageService) list(ctx context.Context, portfolioID string) ([]model.PortfolioImage, error) {
+	images, err := s.imageRepo.ListByPortfolio(ctx, portfolioID)
+	if err != nil {
+		return nil, err
+	}
+
+	setPortfolioImageURLs(s.cfg, images)
+	return images, nil
+}
+
+// setPortfolioImageURLs fills in the public URLs of gallery images and their variants
+func setPortfolioImageURLs(cfg config.Config, images []model.PortfolioImage) {
+	for i := range images {
+		images[i].URL = cfg.MediaURL(images[i].Key)
+		for j := range images[i].Variants {
+			images[i].Variants[j].URL = cfg.MediaURL(images[i].Variants[j].Key)
+		}
+	}
+}
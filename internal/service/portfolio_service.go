@@ -2,11 +2,23 @@ package service
 
 import (
 	"context"
+	"errors"
 
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/cache"
 	"github.com/budhilaw/personal-website-backend/internal/model"
 	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"go.uber.org/zap"
 )
 
+// ErrInvalidGallery is returned when a gallery image isn't an uploaded image from the media library
+var ErrInvalidGallery = errors.New("gallery images must be uploaded images from the media library")
+
+// portfolioCache names the published portfolios cache in invalidations
+const portfolioCache = "portfolios"
+
 // PortfolioService defines methods for portfolio service
 type PortfolioService interface {
 	Create(ctx context.Context, portfolio *model.PortfolioCreate, userID string) (string, error)
@@ -14,7 +26,10 @@ type PortfolioService interface {
 	Delete(ctx context.Context, id string) error
 	GetByID(ctx context.Context, id string) (*model.Portfolio, error)
 	GetBySlug(ctx context.Context, slug string) (*model.Portfolio, error)
-	List(ctx context.Context, page, perPage int, onlyPublished bool) ([]model.Portfolio, int, error)
+	List(ctx context.Context, page, perPage int, onlyPublished bool, filter model.PortfolioFilter) ([]model.Portfolio, int, error)
+	ListAfter(ctx context.Context, cursor string, limit int, onlyPublished bool, filter model.PortfolioFilter) ([]model.Portfolio, string, error)
+	ListFields(ctx context.Context, fields []string, page, perPage int, onlyPublished bool, filter model.PortfolioFilter) ([]model.PartialItem, int, error)
+	ListFieldsAfter(ctx context.Context, fields []string, cursor string, limit int, onlyPublished bool, filter model.PortfolioFilter) ([]model.PartialItem, string, error)
 	GetByAuthor(ctx context.Context, userID string, page, perPage int) ([]model.Portfolio, int, error)
 	GetPortfolioWithAuthor(ctx context.Context, id string) (*model.PortfolioResponse, error)
 	GetBySlugWithAuthor(ctx context.Context, slug string) (*model.PortfolioResponse, error)
@@ -24,29 +39,110 @@ type PortfolioService interface {
 type portfolioService struct {
 	portfolioRepo repository.PortfolioRepository
 	userRepo      repository.UserRepository
+	imageRepo     repository.PortfolioImageRepository
+	revalidation  RevalidationService
+	audit         ContentAuditService
+	markdown      *util.MarkdownRenderer
+	cfg           config.Config
+
+	// Published portfolios by slug; nil while the content cache is disabled
+	invalidator *cache.Invalidator
+	bySlug      *cache.Store[*model.PortfolioResponse]
 }
 
-// NewPortfolioService creates a new PortfolioService
-func NewPortfolioService(portfolioRepo repository.PortfolioRepository, userRepo repository.UserRepository) PortfolioService {
-	return &portfolioService{
+// NewPortfolioService creates a new PortfolioService; invalidator is nil while the content cache is disabled
+func NewPortfolioService(portfolioRepo repository.PortfolioRepository, userRepo repository.UserRepository, imageRepo repository.PortfolioImageRepository, revalidation RevalidationService, audit ContentAuditService, markdown *util.MarkdownRenderer, invalidator *cache.Invalidator, cfg config.Config) PortfolioService {
+	service := &portfolioService{
 		portfolioRepo: portfolioRepo,
 		userRepo:      userRepo,
+		imageRepo:     imageRepo,
+		revalidation:  revalidation,
+		audit:         audit,
+		markdown:      markdown,
+		cfg:           cfg,
+		invalidator:   invalidator,
 	}
+
+	if invalidator != nil {
+		service.bySlug = cache.NewStore[*model.PortfolioResponse](cfg.ContentCacheTTL)
+		invalidator.Register(portfolioCache, service.bySlug)
+	}
+
+	return service
 }
 
 // Create creates a new portfolio
 func (s *portfolioService) Create(ctx context.Context, portfolio *model.PortfolioCreate, userID string) (string, error) {
-	return s.portfolioRepo.Create(ctx, portfolio, userID)
+	id, err := s.portfolioRepo.Create(ctx, portfolio, userID)
+	if err != nil {
+		return "", err
+	}
+
+	s.changed(ctx, id, nil, s.find(ctx, id))
+	return id, nil
 }
 
 // Update updates a portfolio
 func (s *portfolioService) Update(ctx context.Context, id string, portfolio *model.PortfolioUpdate) error {
-	return s.portfolioRepo.Update(ctx, id, portfolio)
+	before := s.find(ctx, id)
+	if err := s.portfolioRepo.Update(ctx, id, portfolio); err != nil {
+		return err
+	}
+
+	s.changed(ctx, id, before, s.find(ctx, id))
+	return nil
 }
 
 // Delete deletes a portfolio
 func (s *portfolioService) Delete(ctx context.Context, id string) error {
-	return s.portfolioRepo.Delete(ctx, id)
+	before := s.find(ctx, id)
+	if err := s.portfolioRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.changed(ctx, id, before, nil)
+	return nil
+}
+
+// find gets a portfolio by ID, or nil when it can't be loaded
+func (s *portfolioService) find(ctx context.Context, id string) *model.Portfolio {
+	portfolio, err := s.portfolioRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return portfolio
+}
+
+// changed records a saved change to a portfolio in the audit trail and revalidates its pages.
+// The change is already saved, so a failed audit record is only logged.
+func (s *portfolioService) changed(ctx context.Context, id string, before, after *model.Portfolio) {
+	if err := s.audit.Record(ctx, model.AuditEntityPortfolio, id, before, after); err != nil {
+		logger.ErrorContext(ctx, "Failed to record portfolio change", zap.Error(err), zap.String("portfolio_id", id))
+	}
+	s.revalidate(ctx, before, after)
+}
+
+// revalidate drops a portfolio that was or is published from every instance's cache and queues
+// revalidation of its frontend pages. The change is already saved, so failures are only logged.
+func (s *portfolioService) revalidate(ctx context.Context, before, after *model.Portfolio) {
+	var slugs []string
+	for _, portfolio := range []*model.Portfolio{before, after} {
+		if portfolio != nil && portfolio.IsPublished {
+			slugs = append(slugs, portfolio.Slug)
+		}
+	}
+	if len(slugs) == 0 {
+		return
+	}
+
+	if s.invalidator != nil {
+		if err := s.invalidator.Invalidate(ctx, portfolioCache, slugs...); err != nil {
+			logger.ErrorContext(ctx, "Failed to invalidate cached portfolio", zap.Error(err), zap.Strings("slugs", slugs))
+		}
+	}
+	if err := s.revalidation.PortfolioChanged(ctx, slugs...); err != nil {
+		logger.ErrorContext(ctx, "Failed to queue portfolio revalidation", zap.Error(err), zap.Strings("slugs", slugs))
+	}
 }
 
 // GetByID gets a portfolio by ID
@@ -60,8 +156,71 @@ func (s *portfolioService) GetBySlug(ctx context.Context, slug string) (*model.P
 }
 
 // List lists portfolios with pagination
-func (s *portfolioService) List(ctx context.Context, page, perPage int, onlyPublished bool) ([]model.Portfolio, int, error) {
-	return s.portfolioRepo.List(ctx, page, perPage, onlyPublished)
+func (s *portfolioService) List(ctx context.Context, page, perPage int, onlyPublished bool, filter model.PortfolioFilter) ([]model.Portfolio, int, error) {
+	if err := validateListOptions(filter.ListOptions); err != nil {
+		return nil, 0, err
+	}
+
+	return s.portfolioRepo.List(ctx, page, perPage, onlyPublished, filter)
+}
+
+// ListAfter lists portfolios after the cursor and returns the cursor of the next page, empty on the last page
+func (s *portfolioService) ListAfter(ctx context.Context, cursor string, limit int, onlyPublished bool, filter model.PortfolioFilter) ([]model.Portfolio, string, error) {
+	if err := validateCursorOptions(filter.ListOptions); err != nil {
+		return nil, "", err
+	}
+
+	after, err := parseCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Fetch one extra portfolio to know whether another page follows
+	portfolios, err := s.portfolioRepo.ListAfter(ctx, after, limit+1, onlyPublished, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(portfolios) <= limit {
+		return portfolios, "", nil
+	}
+
+	portfolios = portfolios[:limit]
+	last := portfolios[limit-1]
+	return portfolios, util.EncodeCursor(last.CreatedAt, last.ID), nil
+}
+
+// ListFields lists portfolios with only the requested fields, skipping authors and rendering
+func (s *portfolioService) ListFields(ctx context.Context, fields []string, page, perPage int, onlyPublished bool, filter model.PortfolioFilter) ([]model.PartialItem, int, error) {
+	if err := validateListOptions(filter.ListOptions); err != nil {
+		return nil, 0, err
+	}
+
+	items, total, err := s.portfolioRepo.ListFields(ctx, fields, page, perPage, onlyPublished, filter)
+	if err != nil {
+		return nil, 0, fieldsError(err)
+	}
+	return items, total, nil
+}
+
+// ListFieldsAfter lists portfolios after the cursor with only the requested fields
+func (s *portfolioService) ListFieldsAfter(ctx context.Context, fields []string, cursor string, limit int, onlyPublished bool, filter model.PortfolioFilter) ([]model.PartialItem, string, error) {
+	if err := validateCursorOptions(filter.ListOptions); err != nil {
+		return nil, "", err
+	}
+
+	after, err := parseCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Fetch one extra portfolio to know whether another page follows
+	items, err := s.portfolioRepo.ListFieldsAfter(ctx, fields, after, limit+1, onlyPublished, filter)
+	if err != nil {
+		return nil, "", fieldsError(err)
+	}
+
+	items, next := partialCursorPage(items, limit, fields)
+	return items, next, nil
 }
 
 // GetByAuthor gets portfolios by author ID with pagination
@@ -76,41 +235,44 @@ func (s *portfolioService) GetPortfolioWithAuthor(ctx context.Context, id string
 		return nil, err
 	}
 
-	author, err := s.userRepo.GetByID(ctx, portfolio.UserID)
+	return s.toResponse(ctx, portfolio)
+}
+
+// GetBySlugWithAuthor gets a portfolio by slug with author information. Published portfolios are
+// served from the content cache when it is enabled; callers get their own copy to modify.
+func (s *portfolioService) GetBySlugWithAuthor(ctx context.Context, slug string) (*model.PortfolioResponse, error) {
+	if s.bySlug != nil {
+		if cached, ok := s.bySlug.Get(slug); ok {
+			response := *cached
+			return &response, nil
+		}
+	}
+
+	portfolio, err := s.portfolioRepo.GetBySlug(ctx, slug)
 	if err != nil {
 		return nil, err
 	}
 
-	response := &model.PortfolioResponse{
-		ID:           portfolio.ID,
-		Title:        portfolio.Title,
-		Slug:         portfolio.Slug,
-		Description:  portfolio.Description,
-		Image:        portfolio.Image,
-		ProjectURL:   portfolio.ProjectURL,
-		GithubURL:    portfolio.GithubURL,
-		Technologies: portfolio.Technologies,
-		IsPublished:  portfolio.IsPublished,
-		CreatedAt:    portfolio.CreatedAt,
-		UpdatedAt:    portfolio.UpdatedAt,
+	response, err := s.toResponse(ctx, portfolio)
+	if err != nil {
+		return nil, err
 	}
 
-	response.Author.ID = author.ID
-	response.Author.Username = author.Username
-	response.Author.FirstName = author.FirstName
-	response.Author.LastName = author.LastName
-	response.Author.Avatar = author.Avatar
+	// Found through slug history, let the frontend redirect to the canonical slug
+	if portfolio.Slug != slug {
+		response.RedirectedFrom = slug
+	}
 
+	// Invalidations name the current slug, so only cache published portfolios found by it
+	if s.bySlug != nil && portfolio.IsPublished && response.RedirectedFrom == "" {
+		cached := *response
+		s.bySlug.Set(slug, &cached)
+	}
 	return response, nil
 }
 
-// GetBySlugWithAuthor gets a portfolio by slug with author information
-func (s *portfolioService) GetBySlugWithAuthor(ctx context.Context, slug string) (*model.PortfolioResponse, error) {
-	portfolio, err := s.portfolioRepo.GetBySlug(ctx, slug)
-	if err != nil {
-		return nil, err
-	}
-
+// toResponse builds a portfolio response with author information
+func (s *portfolioService) toResponse(ctx context.Context, portfolio *model.Portfolio) (*model.PortfolioResponse, error) {
 	author, err := s.userRepo.GetByID(ctx, portfolio.UserID)
 	if err != nil {
 		return nil, err
@@ -121,15 +283,38 @@ func (s *portfolioService) GetBySlugWithAuthor(ctx context.Context, slug string)
 		Title:        portfolio.Title,
 		Slug:         portfolio.Slug,
 		Description:  portfolio.Description,
+		Content:      portfolio.Content,
 		Image:        portfolio.Image,
 		ProjectURL:   portfolio.ProjectURL,
 		GithubURL:    portfolio.GithubURL,
 		Technologies: portfolio.Technologies,
+		Category:     portfolio.Category,
+		Role:         portfolio.Role,
+		Duration:     portfolio.Duration,
+		Metrics:      portfolio.Metrics,
 		IsPublished:  portfolio.IsPublished,
+		SEOMeta:      portfolio.SEOMeta,
 		CreatedAt:    portfolio.CreatedAt,
 		UpdatedAt:    portfolio.UpdatedAt,
 	}
 
+	if portfolio.Content != "" {
+		response.ContentHTML, err = s.markdown.Render(portfolio.Content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	response.Gallery, err = s.imageRepo.ListByPortfolio(ctx, portfolio.ID)
+	if err != nil {
+		return nil, err
+	}
+	setPortfolioImageURLs(s.cfg, response.Gallery)
+	// Older clients only know the single image
+	if response.Image == "" && len(response.Gallery) > 0 {
+		response.Image = response.Gallery[0].URL
+	}
+
 	response.Author.ID = author.ID
 	response.Author.Username = author.Username
 	response.Author.FirstName = author.FirstName
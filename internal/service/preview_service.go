@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// previewAudience keeps preview tokens from being accepted anywhere else that shares the format
+const previewAudience = "article-preview"
+
+var (
+	ErrPreviewNotConfigured = errors.New("preview links are not configured")
+	ErrInvalidPreviewToken  = errors.New("invalid or expired preview token")
+)
+
+// PreviewService defines methods for sharing unpublished articles through signed links
+type PreviewService interface {
+	CreateLink(ctx context.Context, articleID string) (*model.PreviewLink, error)
+	GetArticle(ctx context.Context, token string) (*model.ArticleResponse, error)
+}
+
+// previewService is the implementation of PreviewService
+type previewService struct {
+	articleService ArticleService
+	cfg            config.Config
+}
+
+// NewPreviewService creates a new PreviewService
+func NewPreviewService(articleService ArticleService, cfg config.Config) PreviewService {
+	return &previewService{
+		articleService: articleService,
+		cfg:            cfg,
+	}
+}
+
+// CreateLink signs a token for an article that expires after PREVIEW_LINK_EXPIRY
+func (s *previewService) CreateLink(ctx context.Context, articleID string) (*model.PreviewLink, error) {
+	if s.cfg.PreviewSecret == "" {
+		return nil, ErrPreviewNotConfigured
+	}
+
+	if _, err := s.articleService.GetByID(ctx, articleID); err != nil {
+		return nil, ErrContentNotFound
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.cfg.PreviewLinkExpiry)
+	claims := jwt.RegisteredClaims{
+		Subject:   articleID,
+		Audience:  jwt.ClaimStrings{previewAudience},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.cfg.PreviewSecret))
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.PreviewLink{
+		Token:     token,
+		URL:       strings.TrimRight(s.cfg.APIURL, "/") + "/api/v1/public/preview/" + token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// GetArticle returns the article a valid preview token was issued for, published or not
+func (s *previewService) GetArticle(ctx context.Context, token string) (*model.ArticleResponse, error) {
+	if s.cfg.PreviewSecret == "" {
+		return nil, ErrPreviewNotConfigured
+	}
+
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(s.cfg.PreviewSecret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithAudience(previewAudience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || claims.Subject == "" {
+		return nil, ErrInvalidPreviewToken
+	}
+
+	article, err := s.articleService.GetArticleWithAuthor(ctx, claims.Subject)
+	if err != nil {
+		// The article was deleted after the link was shared
+		return nil, ErrContentNotFound
+	}
+
+	return article, nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/jobs"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// JobSendPush is the job type delivering a Web Push notification to one subscription
+const JobSendPush = "push.send"
+
+// pushJob is the payload of a JobSendPush job
+type pushJob struct {
+	SubscriptionID string            `json:"subscription_id"`
+	Message        model.PushMessage `json:"message"`
+}
+
+// PushService defines methods for Web Push notification service
+type PushService interface {
+	PublicKey() string
+	Subscribe(ctx context.Context, subscription *model.PushSubscribeRequest) error
+	Unsubscribe(ctx context.Context, endpoint string) error
+	PublishArticle(ctx context.Context, id string)
+	HandleDeliveryJob(ctx context.Context, payload json.RawMessage) error
+}
+
+// pushService is the implementation of PushService
+type pushService struct {
+	subscriptionRepo repository.PushSubscriptionRepository
+	articleRepo      repository.ArticleRepository
+	pushRepo         *repository.WebPushRepository
+	queue            jobs.Enqueuer
+	cfg              config.Config
+}
+
+// NewPushService creates a new PushService; pushRepo is nil while Web Push is disabled
+func NewPushService(
+	subscriptionRepo repository.PushSubscriptionRepository,
+	articleRepo repository.ArticleRepository,
+	pushRepo *repository.WebPushRepository,
+	queue jobs.Enqueuer,
+	cfg config.Config,
+) PushService {
+	return &pushService{
+		subscriptionRepo: subscriptionRepo,
+		articleRepo:      articleRepo,
+		pushRepo:         pushRepo,
+		queue:            queue,
+		cfg:              cfg,
+	}
+}
+
+// PublicKey returns the VAPID public key the frontend passes to pushManager.subscribe
+func (s *pushService) PublicKey() string {
+	if s.pushRepo == nil {
+		return ""
+	}
+	return s.pushRepo.PublicKey()
+}
+
+// Subscribe stores a browser's push subscription
+func (s *pushService) Subscribe(ctx context.Context, subscription *model.PushSubscribeRequest) error {
+	_, err := s.subscriptionRepo.Upsert(ctx, subscription)
+	return err
+}
+
+// Unsubscribe removes a browser's push subscription
+func (s *pushService) Unsubscribe(ctx context.Context, endpoint string) error {
+	return s.subscriptionRepo.DeleteByEndpoint(ctx, endpoint)
+}
+
+// PublishArticle queues a notification about a newly published article for every subscription
+func (s *pushService) PublishArticle(ctx context.Context, id string) {
+	if s.pushRepo == nil {
+		return
+	}
+
+	article, err := s.articleRepo.GetByID(ctx, id)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to load article for push notification", zap.Error(err), zap.String("id", id))
+		return
+	}
+
+	ids, err := s.subscriptionRepo.ListIDs(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to list push subscriptions", zap.Error(err))
+		return
+	}
+
+	message := model.PushMessage{
+		Title: article.Title,
+		Body:  article.Excerpt,
+		URL:   s.cfg.ArticleURL(article.Slug),
+		Image: article.FeaturedImage,
+	}
+	for _, subscriptionID := range ids {
+		if err := s.queue.Enqueue(ctx, JobSendPush, pushJob{SubscriptionID: subscriptionID, Message: message}); err != nil {
+			logger.ErrorContext(ctx, "Failed to queue push notification", zap.Error(err), zap.String("subscription_id", subscriptionID))
+		}
+	}
+}
+
+// HandleDeliveryJob sends a queued push notification; subscriptions the push service
+// no longer knows are removed, other errors make the queue retry it
+func (s *pushService) HandleDeliveryJob(ctx context.Context, payload json.RawMessage) error {
+	var job pushJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+	if s.pushRepo == nil {
+		return nil // Web Push disabled since the job was queued
+	}
+
+	subscription, err := s.subscriptionRepo.GetByID(ctx, job.SubscriptionID)
+	if err != nil {
+		return nil // Unsubscribed since the job was queued
+	}
+
+	message, err := json.Marshal(job.Message)
+	if err != nil {
+		return err
+	}
+
+	err = s.pushRepo.Send(ctx, subscription, message)
+	if errors.Is(err, repository.ErrPushSubscriptionGone) {
+		return s.subscriptionRepo.Delete(ctx, subscription.ID)
+	}
+	return err
+}
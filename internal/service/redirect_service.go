@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// generatedCodeBytes yields 8 character hex codes
+const generatedCodeBytes = 4
+
+var (
+	ErrInvalidRedirect = errors.New("invalid redirect")
+	ErrRedirectExists  = errors.New("redirect code already exists")
+)
+
+// RedirectService defines methods for redirect service
+type RedirectService interface {
+	Create(ctx context.Context, redirect *model.RedirectCreate) (*model.Redirect, error)
+	Update(ctx context.Context, id string, redirect *model.RedirectUpdate) error
+	Delete(ctx context.Context, id string) error
+	GetByID(ctx context.Context, id string) (*model.Redirect, error)
+	List(ctx context.Context) ([]model.Redirect, error)
+	Resolve(ctx context.Context, code string) (*model.Redirect, error)
+}
+
+// redirectService is the implementation of RedirectService
+type redirectService struct {
+	redirectRepo repository.RedirectRepository
+}
+
+// NewRedirectService creates a new RedirectService
+func NewRedirectService(redirectRepo repository.RedirectRepository) RedirectService {
+	return &redirectService{
+		redirectRepo: redirectRepo,
+	}
+}
+
+// Create creates a new redirect, generating a code when none is given
+func (s *redirectService) Create(ctx context.Context, redirect *model.RedirectCreate) (*model.Redirect, error) {
+	if redirect.Code == "" {
+		code, err := util.GenerateRandomToken(generatedCodeBytes)
+		if err != nil {
+			return nil, err
+		}
+		redirect.Code = code
+	}
+
+	if err := validateRedirect(redirect.Code, redirect.TargetURL); err != nil {
+		return nil, err
+	}
+
+	id, err := s.redirectRepo.Create(ctx, redirect)
+	if err != nil {
+		return nil, redirectError(err)
+	}
+
+	return s.redirectRepo.GetByID(ctx, id)
+}
+
+// Update updates a redirect
+func (s *redirectService) Update(ctx context.Context, id string, redirect *model.RedirectUpdate) error {
+	if err := validateRedirect(redirect.Code, redirect.TargetURL); err != nil {
+		return err
+	}
+
+	return redirectError(s.redirectRepo.Update(ctx, id, redirect))
+}
+
+// Delete deletes a redirect
+func (s *redirectService) Delete(ctx context.Context, id string) error {
+	return s.redirectRepo.Delete(ctx, id)
+}
+
+// GetByID gets a redirect by ID
+func (s *redirectService) GetByID(ctx context.Context, id string) (*model.Redirect, error) {
+	return s.redirectRepo.GetByID(ctx, id)
+}
+
+// List lists all redirects
+func (s *redirectService) List(ctx context.Context) ([]model.Redirect, error) {
+	return s.redirectRepo.List(ctx)
+}
+
+// Resolve resolves a code to its redirect and counts the click
+func (s *redirectService) Resolve(ctx context.Context, code string) (*model.Redirect, error) {
+	return s.redirectRepo.Resolve(ctx, code)
+}
+
+// validateRedirect validates the code and target URL
+func validateRedirect(code, targetURL string) error {
+	if err := util.ValidateShortCode(code); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRedirect, err)
+	}
+	if err := util.ValidateURL(targetURL); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRedirect, err)
+	}
+	return nil
+}
+
+// redirectError maps unique violations on the code to ErrRedirectExists
+func redirectError(err error) error {
+	// 23505 is unique_violation
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return ErrRedirectExists
+	}
+	return err
+}
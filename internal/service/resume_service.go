@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+)
+
+// ErrInvalidResumeEntry is returned when a resume entry fails validation
+var ErrInvalidResumeEntry = errors.New("invalid resume entry")
+
+// ResumeService defines methods for resume service
+type ResumeService interface {
+	GetResume(ctx context.Context) (*model.Resume, error)
+
+	CreateExperience(ctx context.Context, experience *model.ExperienceCreate) (string, error)
+	UpdateExperience(ctx context.Context, id string, experience *model.ExperienceUpdate) error
+	DeleteExperience(ctx context.Context, id string) error
+
+	CreateEducation(ctx context.Context, education *model.EducationCreate) (string, error)
+	UpdateEducation(ctx context.Context, id string, education *model.EducationUpdate) error
+	DeleteEducation(ctx context.Context, id string) error
+
+	CreateCertification(ctx context.Context, certification *model.CertificationCreate) (string, error)
+	UpdateCertification(ctx context.Context, id string, certification *model.CertificationUpdate) error
+	DeleteCertification(ctx context.Context, id string) error
+
+	CreateSkill(ctx context.Context, skill *model.SkillCreate) (string, error)
+	UpdateSkill(ctx context.Context, id string, skill *model.SkillUpdate) error
+	DeleteSkill(ctx context.Context, id string) error
+}
+
+// resumeService is the implementation of ResumeService
+type resumeService struct {
+	resumeRepo repository.ResumeRepository
+}
+
+// NewResumeService creates a new ResumeService
+func NewResumeService(resumeRepo repository.ResumeRepository) ResumeService {
+	return &resumeService{
+		resumeRepo: resumeRepo,
+	}
+}
+
+// GetResume gets all resume sections combined
+func (s *resumeService) GetResume(ctx context.Context) (*model.Resume, error) {
+	experiences, err := s.resumeRepo.ListExperiences(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	educations, err := s.resumeRepo.ListEducations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	certifications, err := s.resumeRepo.ListCertifications(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	skills, err := s.resumeRepo.ListSkills(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Resume{
+		Experiences:    experiences,
+		Educations:     educations,
+		Certifications: certifications,
+		Skills:         skills,
+	}, nil
+}
+
+// CreateExperience creates a work experience
+func (s *resumeService) CreateExperience(ctx context.Context, experience *model.ExperienceCreate) (string, error) {
+	if !validDateRange(experience.StartDate, experience.EndDate) {
+		return "", ErrInvalidResumeEntry
+	}
+	return s.resumeRepo.CreateExperience(ctx, experience)
+}
+
+// UpdateExperience updates a work experience
+func (s *resumeService) UpdateExperience(ctx context.Context, id string, experience *model.ExperienceUpdate) error {
+	if !validDateRange(experience.StartDate, experience.EndDate) {
+		return ErrInvalidResumeEntry
+	}
+	return s.resumeRepo.UpdateExperience(ctx, id, experience)
+}
+
+// DeleteExperience deletes a work experience
+func (s *resumeService) DeleteExperience(ctx context.Context, id string) error {
+	return s.resumeRepo.DeleteExperience(ctx, id)
+}
+
+// CreateEducation creates an education entry
+func (s *resumeService) CreateEducation(ctx context.Context, education *model.EducationCreate) (string, error) {
+	if !validDateRange(education.StartDate, education.EndDate) {
+		return "", ErrInvalidResumeEntry
+	}
+	return s.resumeRepo.CreateEducation(ctx, education)
+}
+
+// UpdateEducation updates an education entry
+func (s *resumeService) UpdateEducation(ctx context.Context, id string, education *model.EducationUpdate) error {
+	if !validDateRange(education.StartDate, education.EndDate) {
+		return ErrInvalidResumeEntry
+	}
+	return s.resumeRepo.UpdateEducation(ctx, id, education)
+}
+
+// DeleteEducation deletes an education entry
+func (s *resumeService) DeleteEducation(ctx context.Context, id string) error {
+	return s.resumeRepo.DeleteEducation(ctx, id)
+}
+
+// CreateCertification creates a certification
+func (s *resumeService) CreateCertification(ctx context.Context, certification *model.CertificationCreate) (string, error) {
+	if !validDateRange(certification.IssuedAt, certification.ExpiresAt) {
+		return "", ErrInvalidResumeEntry
+	}
+	return s.resumeRepo.CreateCertification(ctx, certification)
+}
+
+// UpdateCertification updates a certification
+func (s *resumeService) UpdateCertification(ctx context.Context, id string, certification *model.CertificationUpdate) error {
+	if !validDateRange(certification.IssuedAt, certification.ExpiresAt) {
+		return ErrInvalidResumeEntry
+	}
+	return s.resumeRepo.UpdateCertification(ctx, id, certification)
+}
+
+// DeleteCertification deletes a certification
+func (s *resumeService) DeleteCertification(ctx context.Context, id string) error {
+	return s.resumeRepo.DeleteCertification(ctx, id)
+}
+
+// CreateSkill creates a skill
+func (s *resumeService) CreateSkill(ctx context.Context, skill *model.SkillCreate) (string, error) {
+	if skill.Level < 0 || skill.Level > 5 {
+		return "", ErrInvalidResumeEntry
+	}
+	return s.resumeRepo.CreateSkill(ctx, skill)
+}
+
+// UpdateSkill updates a skill
+func (s *resumeService) UpdateSkill(ctx context.Context, id string, skill *model.SkillUpdate) error {
+	if skill.Level < 0 || skill.Level > 5 {
+		return ErrInvalidResumeEntry
+	}
+	return s.resumeRepo.UpdateSkill(ctx, id, skill)
+}
+
+// DeleteSkill deletes a skill
+func (s *resumeService) DeleteSkill(ctx context.Context, id string) error {
+	return s.resumeRepo.DeleteSkill(ctx, id)
+}
+
+// validDateRange reports whether an optional end date doesn't precede the start
+func validDateRange(start model.Date, end *model.Date) bool {
+	if start.IsZero() {
+		return false
+	}
+	return end == nil || !end.Before(start.Time)
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/jobs"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ErrRevalidationNotConfigured is returned when REVALIDATE_URL isn't set
+var ErrRevalidationNotConfigured = errors.New("frontend revalidation is not configured")
+
+// Job types keeping the frontend fresh after content changes
+const (
+	// JobRevalidate asks the frontend to regenerate some paths
+	JobRevalidate = "frontend.revalidate"
+	// JobPurgeCache removes frontend URLs from the Cloudflare cache
+	JobPurgeCache = "cdn.purge"
+)
+
+// revalidateJob is the payload of a JobRevalidate job
+type revalidateJob struct {
+	Paths []string `json:"paths"`
+}
+
+// purgeCacheJob is the payload of a JobPurgeCache job
+type purgeCacheJob struct {
+	URLs []string `json:"urls"`
+}
+
+// RevalidationService defines methods for keeping the statically generated frontend, and the
+// CDN cache in front of it, up to date
+type RevalidationService interface {
+	ArticleChanged(ctx context.Context, slugs ...string) error
+	PortfolioChanged(ctx context.Context, slugs ...string) error
+	PageChanged(ctx context.Context, slugs ...string) error
+	Revalidate(ctx context.Context, paths []string) error
+	HandleRevalidateJob(ctx context.Context, payload json.RawMessage) error
+	HandlePurgeCacheJob(ctx context.Context, payload json.RawMessage) error
+}
+
+// revalidationService is the implementation of RevalidationService
+type revalidationService struct {
+	revalidationRepo *repository.RevalidationRepository
+	cloudflareRepo   *repository.CloudflareRepository
+	queue            jobs.Enqueuer
+	cfg              config.Config
+}
+
+// NewRevalidationService creates a new RevalidationService; revalidationRepo and cloudflareRepo
+// are nil while revalidation and cache purging are disabled
+func NewRevalidationService(
+	revalidationRepo *repository.RevalidationRepository,
+	cloudflareRepo *repository.CloudflareRepository,
+	queue jobs.Enqueuer,
+	cfg config.Config,
+) RevalidationService {
+	return &revalidationService{
+		revalidationRepo: revalidationRepo,
+		cloudflareRepo:   cloudflareRepo,
+		queue:            queue,
+		cfg:              cfg,
+	}
+}
+
+// ArticleChanged queues revalidation and cache purging of the pages showing an article. Pass
+// both slugs when the slug changed, so the old page stops being served too.
+func (s *revalidationService) ArticleChanged(ctx context.Context, slugs ...string) error {
+	return s.queuePaths(ctx, s.cfg.RevalidateArticlePaths, slugs)
+}
+
+// PortfolioChanged queues revalidation and cache purging of the pages showing a portfolio
+func (s *revalidationService) PortfolioChanged(ctx context.Context, slugs ...string) error {
+	return s.queuePaths(ctx, s.cfg.RevalidatePortfolioPaths, slugs)
+}
+
+// PageChanged queues revalidation and cache purging of the pages showing a static page
+func (s *revalidationService) PageChanged(ctx context.Context, slugs ...string) error {
+	return s.queuePaths(ctx, s.cfg.RevalidatePagePaths, slugs)
+}
+
+// queuePaths queues revalidation and cache purge jobs for the path templates filled in with
+// each slug. The jobs go through the caller's transaction when there is one.
+func (s *revalidationService) queuePaths(ctx context.Context, templates string, slugs []string) error {
+	paths := revalidationPaths(templates, slugs)
+	if len(paths) == 0 {
+		return nil
+	}
+
+	if s.revalidationRepo != nil {
+		if err := s.queue.Enqueue(ctx, JobRevalidate, revalidateJob{Paths: paths}); err != nil {
+			return err
+		}
+	}
+
+	// The jobs run independently; a purge landing before the revalidation only means the
+	// CDN caches the old page once more, until its next purge or expiry
+	if s.cloudflareRepo != nil {
+		base := strings.TrimRight(s.cfg.FrontendURL, "/")
+		urls := make([]string, 0, len(paths))
+		for _, path := range paths {
+			urls = append(urls, base+path)
+		}
+		if err := s.queue.Enqueue(ctx, JobPurgeCache, purgeCacheJob{URLs: urls}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Revalidate asks the frontend to regenerate the given paths right away
+func (s *revalidationService) Revalidate(ctx context.Context, paths []string) error {
+	if s.revalidationRepo == nil {
+		return ErrRevalidationNotConfigured
+	}
+
+	if err := s.revalidationRepo.Revalidate(ctx, paths); err != nil {
+		logger.ErrorContext(ctx, "Failed to revalidate frontend paths", zap.Error(err), zap.Strings("paths", paths))
+		return err
+	}
+
+	logger.InfoContext(ctx, "Frontend paths revalidated", zap.Strings("paths", paths))
+	return nil
+}
+
+// HandleRevalidateJob sends a queued revalidation; errors make the queue retry it
+func (s *revalidationService) HandleRevalidateJob(ctx context.Context, payload json.RawMessage) error {
+	var job revalidateJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+	if s.revalidationRepo == nil {
+		return nil // Revalidation disabled since the job was queued
+	}
+
+	return s.Revalidate(ctx, job.Paths)
+}
+
+// HandlePurgeCacheJob purges queued URLs from Cloudflare in batches, logging the result of
+// every URL. Any failed batch makes the queue retry the job; purging a URL twice is harmless.
+func (s *revalidationService) HandlePurgeCacheJob(ctx context.Context, payload json.RawMessage) error {
+	var job purgeCacheJob
+	if err := json.Unmarshal(payload, &job); err != nil {
+		return err
+	}
+	if s.cloudflareRepo == nil {
+		return nil // Cache purging disabled since the job was queued
+	}
+
+	var errs []error
+	for batch := range slices.Chunk(job.URLs, repository.CloudflarePurgeBatchSize) {
+		err := s.cloudflareRepo.PurgeURLs(ctx, batch)
+		for _, url := range batch {
+			if err != nil {
+				logger.ErrorContext(ctx, "Failed to purge URL from Cloudflare", zap.Error(err), zap.String("url", url))
+			} else {
+				logger.InfoContext(ctx, "URL purged from Cloudflare", zap.String("url", url))
+			}
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// revalidationPaths fills in the comma-separated path templates with each slug, without duplicates
+func revalidationPaths(templates string, slugs []string) []string {
+	var paths []string
+	for _, template := range strings.Split(templates, ",") {
+		template = strings.TrimSpace(template)
+		if template == "" {
+			continue
+		}
+
+		for _, slug := range slugs {
+			if slug == "" {
+				continue
+			}
+			path := strings.ReplaceAll(template, "{slug}", slug)
+			if !slices.Contains(paths, path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// diffContextLines is how many unchanged lines surround each hunk
+const diffContextLines = 3
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// RevisionService defines methods for browsing and comparing article revisions
+type RevisionService interface {
+	ListByArticle(ctx context.Context, articleID string) ([]model.ArticleRevision, error)
+	Diff(ctx context.Context, articleID, fromID, toID string) (*model.RevisionDiff, error)
+}
+
+// revisionService is the implementation of RevisionService
+type revisionService struct {
+	revisionRepo repository.ArticleRevisionRepository
+	articleRepo  repository.ArticleRepository
+}
+
+// NewRevisionService creates a new RevisionService
+func NewRevisionService(revisionRepo repository.ArticleRevisionRepository, articleRepo repository.ArticleRepository) RevisionService {
+	return &revisionService{
+		revisionRepo: revisionRepo,
+		articleRepo:  articleRepo,
+	}
+}
+
+// ListByArticle lists an article's revisions, newest first
+func (s *revisionService) ListByArticle(ctx context.Context, articleID string) ([]model.ArticleRevision, error) {
+	if _, err := s.articleRepo.GetByID(ctx, articleID); err != nil {
+		return nil, ErrContentNotFound
+	}
+
+	return s.revisionRepo.ListByArticle(ctx, articleID)
+}
+
+// Diff compares the title and content of two revisions of an article
+func (s *revisionService) Diff(ctx context.Context, articleID, fromID, toID string) (*model.RevisionDiff, error) {
+	from, err := s.revisionRepo.Get(ctx, articleID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.revisionRepo.Get(ctx, articleID, toID)
+	if err != nil {
+		return nil, err
+	}
+	if from == nil || to == nil {
+		return nil, ErrRevisionNotFound
+	}
+
+	return &model.RevisionDiff{
+		From: *from,
+		To:   *to,
+		Title: model.TitleDiff{
+			Changed: from.Title != to.Title,
+			From:    from.Title,
+			To:      to.Title,
+		},
+		Content: diffContent(from, to),
+	}, nil
+}
+
+// diffContent builds a line diff of two revisions' content
+func diffContent(from, to *model.ArticleRevision) model.ContentDiff {
+	a, b := splitLines(from.Content), splitLines(to.Content)
+	// Without autojunk, blank lines in long articles still line up
+	matcher := difflib.NewMatcherWithJunk(a, b, false, nil)
+
+	diff := model.ContentDiff{Hunks: []model.DiffHunk{}}
+	var unified strings.Builder
+	for _, group := range matcher.GetGroupedOpCodes(diffContextLines) {
+		first, last := group[0], group[len(group)-1]
+		hunk := model.DiffHunk{
+			FromLine:  first.I1 + 1,
+			FromCount: last.I2 - first.I1,
+			ToLine:    first.J1 + 1,
+			ToCount:   last.J2 - first.J1,
+		}
+		fmt.Fprintf(&unified, "@@ -%s +%s @@\n", unifiedRange(first.I1, last.I2), unifiedRange(first.J1, last.J2))
+
+		for _, op := range group {
+			if op.Tag == 'e' {
+				for _, line := range a[op.I1:op.I2] {
+					hunk.Lines = append(hunk.Lines, model.DiffLine{Op: model.DiffEqual, Text: line})
+					unified.WriteString(" " + line + "\n")
+				}
+				continue
+			}
+			if op.Tag == 'r' || op.Tag == 'd' {
+				for _, line := range a[op.I1:op.I2] {
+					hunk.Lines = append(hunk.Lines, model.DiffLine{Op: model.DiffDelete, Text: line})
+					unified.WriteString("-" + line + "\n")
+					diff.Deletions++
+				}
+			}
+			if op.Tag == 'r' || op.Tag == 'i' {
+				for _, line := range b[op.J1:op.J2] {
+					hunk.Lines = append(hunk.Lines, model.DiffLine{Op: model.DiffInsert, Text: line})
+					unified.WriteString("+" + line + "\n")
+					diff.Additions++
+				}
+			}
+		}
+
+		diff.Hunks = append(diff.Hunks, hunk)
+	}
+
+	diff.Changed = len(diff.Hunks) > 0
+	if diff.Changed {
+		diff.Unified = fmt.Sprintf("--- %s\n+++ %s\n", revisionLabel(from), revisionLabel(to)) + unified.String()
+	}
+
+	return diff
+}
+
+// splitLines splits content into lines, ignoring a trailing newline
+func splitLines(content string) []string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.TrimSuffix(content, "\n")
+	if content == "" {
+		return []string{}
+	}
+	return strings.Split(content, "\n")
+}
+
+// unifiedRange formats a hunk range for a unified diff header; empty ranges point at the line before
+func unifiedRange(start, stop int) string {
+	length := stop - start
+	switch length {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
+
+// revisionLabel names a revision in unified diff headers
+func revisionLabel(revision *model.ArticleRevision) string {
+	return "revision/" + revision.ID + "\t" + revision.CreatedAt.UTC().Format("2006-01-02 15:04:05")
+}
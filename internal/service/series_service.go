@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+)
+
+// SeriesService defines methods for series service
+type SeriesService interface {
+	Create(ctx context.Context, series *model.SeriesCreate, userID string) (string, error)
+	Update(ctx context.Context, id string, series *model.SeriesUpdate) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]model.Series, error)
+	GetWithArticles(ctx context.Context, id string, onlyPublished bool) (*model.SeriesResponse, error)
+	GetBySlugWithArticles(ctx context.Context, slug string, onlyPublished bool) (*model.SeriesResponse, error)
+}
+
+// seriesService is the implementation of SeriesService
+type seriesService struct {
+	seriesRepo  repository.SeriesRepository
+	articleRepo repository.ArticleRepository
+}
+
+// NewSeriesService creates a new SeriesService
+func NewSeriesService(seriesRepo repository.SeriesRepository, articleRepo repository.ArticleRepository) SeriesService {
+	return &seriesService{
+		seriesRepo:  seriesRepo,
+		articleRepo: articleRepo,
+	}
+}
+
+// Create creates a new series
+func (s *seriesService) Create(ctx context.Context, series *model.SeriesCreate, userID string) (string, error) {
+	return s.seriesRepo.Create(ctx, series, userID)
+}
+
+// Update updates a series
+func (s *seriesService) Update(ctx context.Context, id string, series *model.SeriesUpdate) error {
+	return s.seriesRepo.Update(ctx, id, series)
+}
+
+// Delete deletes a series
+func (s *seriesService) Delete(ctx context.Context, id string) error {
+	return s.seriesRepo.Delete(ctx, id)
+}
+
+// List lists all series
+func (s *seriesService) List(ctx context.Context) ([]model.Series, error) {
+	return s.seriesRepo.List(ctx)
+}
+
+// GetWithArticles gets a series by ID with its articles in reading order
+func (s *seriesService) GetWithArticles(ctx context.Context, id string, onlyPublished bool) (*model.SeriesResponse, error) {
+	series, err := s.seriesRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toResponse(ctx, series, onlyPublished)
+}
+
+// GetBySlugWithArticles gets a series by slug with its articles in reading order
+func (s *seriesService) GetBySlugWithArticles(ctx context.Context, slug string, onlyPublished bool) (*model.SeriesResponse, error) {
+	series, err := s.seriesRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toResponse(ctx, series, onlyPublished)
+}
+
+// toResponse builds a series response with its article links
+func (s *seriesService) toResponse(ctx context.Context, series *model.Series, onlyPublished bool) (*model.SeriesResponse, error) {
+	articles, err := s.articleRepo.ListBySeries(ctx, series.ID, onlyPublished)
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]model.ArticleLink, 0, len(articles))
+	for _, article := range articles {
+		links = append(links, *toArticleLink(article))
+	}
+
+	return &model.SeriesResponse{
+		ID:          series.ID,
+		Title:       series.Title,
+		Slug:        series.Slug,
+		Description: series.Description,
+		Articles:    links,
+		CreatedAt:   series.CreatedAt,
+		UpdatedAt:   series.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+)
+
+// SlackService delivers notifications via a Slack incoming webhook
+type SlackService struct {
+	webhookRepo *repository.WebhookRepository
+	webhookURL  string
+}
+
+// NewSlackService creates a new Slack notifier
+func NewSlackService(webhookRepo *repository.WebhookRepository, webhookURL string) *SlackService {
+	return &SlackService{
+		webhookRepo: webhookRepo,
+		webhookURL:  webhookURL,
+	}
+}
+
+// Name returns the channel name used in notification routing
+func (s *SlackService) Name() string {
+	return "slack"
+}
+
+// Send renders the notification as Slack mrkdwn and posts it
+func (s *SlackService) Send(ctx context.Context, notification Notification) error {
+	message := fmt.Sprintf("*%s*\n\n%s", notification.Title, notification.Body)
+
+	return s.webhookRepo.PostJSON(ctx, s.webhookURL, map[string]string{
+		"text": message,
+	})
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// maxSyndicationError bounds the platform error message stored with a failed attempt
+const maxSyndicationError = 500
+
+var (
+	ErrUnknownPlatform       = errors.New("unknown syndication platform")
+	ErrPlatformNotConfigured = errors.New("syndication platform is not configured")
+	ErrArticleNotPublished   = errors.New("article is not published")
+	ErrAlreadySyndicated     = errors.New("article is already syndicated to this platform")
+	ErrArticleProtected      = errors.New("article is password protected")
+	ErrSyndicationFailed     = errors.New("syndication failed")
+)
+
+// SyndicationService defines methods for cross-posting articles to other platforms
+type SyndicationService interface {
+	Syndicate(ctx context.Context, articleID, platform string) (*model.ArticleSyndication, error)
+	ListByArticle(ctx context.Context, articleID string) ([]model.ArticleSyndication, error)
+}
+
+// syndicationService is the implementation of SyndicationService
+type syndicationService struct {
+	syndicationRepo repository.ArticleSyndicationRepository
+	articleRepo     repository.ArticleRepository
+	crossPostRepo   *repository.CrossPostRepository
+	cfg             config.Config
+}
+
+// NewSyndicationService creates a new SyndicationService
+func NewSyndicationService(
+	syndicationRepo repository.ArticleSyndicationRepository,
+	articleRepo repository.ArticleRepository,
+	crossPostRepo *repository.CrossPostRepository,
+	cfg config.Config,
+) SyndicationService {
+	return &syndicationService{
+		syndicationRepo: syndicationRepo,
+		articleRepo:     articleRepo,
+		crossPostRepo:   crossPostRepo,
+		cfg:             cfg,
+	}
+}
+
+// Syndicate publishes an article to a platform and records the outcome.
+// dev.to copies are updated in place when syndicated again; Medium posts can only be created once.
+func (s *syndicationService) Syndicate(ctx context.Context, articleID, platform string) (*model.ArticleSyndication, error) {
+	switch platform {
+	case model.SyndicationDevTo:
+		if s.cfg.DevToAPIKey == "" {
+			return nil, ErrPlatformNotConfigured
+		}
+	case model.SyndicationMedium:
+		if s.cfg.MediumToken == "" {
+			return nil, ErrPlatformNotConfigured
+		}
+	default:
+		return nil, ErrUnknownPlatform
+	}
+
+	article, err := s.articleRepo.GetByID(ctx, articleID)
+	if err != nil {
+		return nil, ErrContentNotFound
+	}
+	if !article.IsPublished {
+		return nil, ErrArticleNotPublished
+	}
+	// A copy on another platform would be readable without the password
+	if article.IsProtected {
+		return nil, ErrArticleProtected
+	}
+
+	previous, err := s.syndicationRepo.Get(ctx, articleID, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	var remoteID string
+	if previous != nil && previous.Status == model.SyndicationPublished {
+		if platform == model.SyndicationMedium {
+			return nil, ErrAlreadySyndicated
+		}
+		remoteID = previous.RemoteID
+	}
+
+	post := s.crossPost(article)
+
+	var result *model.CrossPostResult
+	if platform == model.SyndicationDevTo {
+		result, err = s.crossPostRepo.PublishDevTo(ctx, remoteID, post)
+	} else {
+		result, err = s.crossPostRepo.PublishMedium(ctx, post)
+	}
+
+	syndication := &model.ArticleSyndication{
+		ArticleID: articleID,
+		Platform:  platform,
+		Status:    model.SyndicationPublished,
+	}
+	if err != nil {
+		// A failed update keeps pointing at the existing copy
+		if previous != nil {
+			syndication.RemoteID = previous.RemoteID
+			syndication.URL = previous.URL
+		}
+		syndication.Status = model.SyndicationFailed
+		syndication.Error = truncate(err.Error(), maxSyndicationError)
+	} else {
+		syndication.RemoteID = result.ID
+		syndication.URL = result.URL
+	}
+
+	if saveErr := s.syndicationRepo.Save(ctx, syndication); saveErr != nil {
+		logger.ErrorContext(ctx, "Failed to record syndication", zap.Error(saveErr), zap.String("id", articleID), zap.String("platform", platform))
+		if err == nil {
+			return nil, saveErr
+		}
+	}
+
+	if err != nil {
+		return syndication, ErrSyndicationFailed
+	}
+	return syndication, nil
+}
+
+// ListByArticle lists every syndication attempt of an article
+func (s *syndicationService) ListByArticle(ctx context.Context, articleID string) ([]model.ArticleSyndication, error) {
+	if _, err := s.articleRepo.GetByID(ctx, articleID); err != nil {
+		return nil, ErrContentNotFound
+	}
+
+	return s.syndicationRepo.ListByArticle(ctx, articleID)
+}
+
+// crossPost builds the platform-neutral post, pointing the canonical URL back at the site
+func (s *syndicationService) crossPost(article *model.Article) *model.CrossPost {
+	canonical := article.CanonicalURL
+	if canonical == "" {
+		canonical = s.cfg.ArticleURL(article.Slug)
+	}
+
+	// Uploaded images are stored as paths on the API
+	cover := article.FeaturedImage
+	if strings.HasPrefix(cover, "/") {
+		cover = strings.TrimRight(s.cfg.APIURL, "/") + cover
+	}
+
+	return &model.CrossPost{
+		Title:        article.Title,
+		Markdown:     article.Content,
+		Description:  article.Excerpt,
+		CoverImage:   cover,
+		CanonicalURL: canonical,
+	}
+}
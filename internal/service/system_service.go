@@ -0,0 +1,101 @@
+package service
+
+import (
+	"database/sql"
+	"runtime"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/db"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+// SystemService defines methods for process diagnostics
+type SystemService interface {
+	Stats() *model.SystemStats
+
+	LogLevels() map[string]string
+	SetLogLevel(name, level string) error
+	ResetLogLevel(name string)
+}
+
+// systemService is the implementation of SystemService
+type systemService struct {
+	database  *sqlx.DB
+	replica   *sqlx.DB
+	startedAt time.Time
+}
+
+// NewSystemService creates a new SystemService; replica is nil when reads use the primary
+func NewSystemService(database, replica *sqlx.DB) SystemService {
+	return &systemService{
+		database:  database,
+		replica:   replica,
+		startedAt: time.Now(),
+	}
+}
+
+// Stats returns the current runtime and connection pool figures
+func (s *systemService) Stats() *model.SystemStats {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	stats := &model.SystemStats{
+		StartedAt:     s.startedAt,
+		UptimeSeconds: int64(time.Since(s.startedAt).Seconds()),
+		GoVersion:     runtime.Version(),
+		Goroutines:    runtime.NumGoroutine(),
+		Memory: model.MemoryStats{
+			HeapAlloc:    mem.HeapAlloc,
+			HeapInUse:    mem.HeapInuse,
+			Sys:          mem.Sys,
+			TotalAlloc:   mem.TotalAlloc,
+			NumGC:        mem.NumGC,
+			PauseTotalMs: float64(mem.PauseTotalNs) / float64(time.Millisecond),
+		},
+		Database: poolStats(s.database.Stats()),
+		Outbound: repository.OutboundStats(),
+	}
+	stats.Database.Healthy = db.Healthy()
+
+	if s.replica != nil {
+		replica := poolStats(s.replica.Stats())
+		// The health monitor only watches the primary
+		replica.Healthy = s.replica.Ping() == nil
+		stats.Replica = &replica
+	}
+
+	return stats
+}
+
+// LogLevels returns the root log level and every per-logger override
+func (s *systemService) LogLevels() map[string]string {
+	return logger.Levels()
+}
+
+// SetLogLevel changes a logger's level until the next restart
+func (s *systemService) SetLogLevel(name, level string) error {
+	return logger.SetLevel(name, level)
+}
+
+// ResetLogLevel removes a logger's override so it follows the root level again
+func (s *systemService) ResetLogLevel(name string) {
+	logger.ResetLevel(name)
+}
+
+// poolStats converts database/sql pool counters
+func poolStats(stats sql.DBStats) model.DBPoolStats {
+	return model.DBPoolStats{
+		MaxOpen:           stats.MaxOpenConnections,
+		Open:              stats.OpenConnections,
+		InUse:             stats.InUse,
+		Idle:              stats.Idle,
+		WaitCount:         stats.WaitCount,
+		WaitDurationMs:    float64(stats.WaitDuration) / float64(time.Millisecond),
+		MaxIdleClosed:     stats.MaxIdleClosed,
+		MaxIdleTimeClosed: stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed: stats.MaxLifetimeClosed,
+	}
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/middleware"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// defaultIPBlockDuration is how long /block_ip blocks an IP when no duration is given
+const defaultIPBlockDuration = 24 * time.Hour
+
+// telegramBotHelp lists the bot commands
+const telegramBotHelp = `Available commands:
+/stats - views, visitors and subscribers
+/block_ip <ip> [duration] - block logins from an IP, for 24h by default (e.g. /block_ip 203.0.113.7 2h)
+/help - show this message`
+
+// TelegramBotService defines methods for answering Telegram bot commands
+type TelegramBotService interface {
+	HandleUpdate(ctx context.Context, secret string, update *model.TelegramUpdate) error
+}
+
+// telegramBotService is the implementation of TelegramBotService
+type telegramBotService struct {
+	telegramRepo      *repository.TelegramRepository
+	analyticsService  AnalyticsService
+	newsletterService NewsletterService
+	protector         *middleware.BruteForceProtector
+	webhookSecret     string
+	allowedChats      []string
+	location          *time.Location
+}
+
+// NewTelegramBotService creates a new TelegramBotService
+func NewTelegramBotService(
+	telegramRepo *repository.TelegramRepository,
+	analyticsService AnalyticsService,
+	newsletterService NewsletterService,
+	protector *middleware.BruteForceProtector,
+	cfg config.Config,
+) TelegramBotService {
+	return &telegramBotService{
+		telegramRepo:      telegramRepo,
+		analyticsService:  analyticsService,
+		newsletterService: newsletterService,
+		protector:         protector,
+		webhookSecret:     cfg.TelegramWebhookSecret,
+		allowedChats:      cfg.TelegramAllowedChats(),
+		location:          cfg.Location(),
+	}
+}
+
+// HandleUpdate runs the command in an update and replies in the same chat. Telegram sends the
+// secret given when the webhook was registered; messages from chats outside the allowlist are ignored.
+func (s *telegramBotService) HandleUpdate(ctx context.Context, secret string, update *model.TelegramUpdate) error {
+	if s.webhookSecret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(s.webhookSecret)) != 1 {
+		return ErrInvalidWebhookSecret
+	}
+	if update.Message == nil || !strings.HasPrefix(update.Message.Text, "/") {
+		return nil
+	}
+
+	chatID := strconv.FormatInt(update.Message.Chat.ID, 10)
+	ctx = logger.WithContextFields(ctx, logger.RequestLogger("", "TELEGRAM_COMMAND", chatID))
+	if !slices.Contains(s.allowedChats, chatID) {
+		logger.WarnContext(ctx, "Telegram command from a chat that is not allowed")
+		return nil
+	}
+
+	fields := strings.Fields(update.Message.Text)
+	// Commands in groups may be addressed to the bot, as in /stats@my_bot
+	command, _, _ := strings.Cut(fields[0], "@")
+
+	var reply string
+	switch command {
+	case "/stats":
+		reply = s.stats(ctx)
+	case "/block_ip":
+		reply = s.blockIP(ctx, fields[1:])
+	case "/start", "/help":
+		reply = telegramBotHelp
+	default:
+		reply = "Unknown command.\n\n" + telegramBotHelp
+	}
+
+	// Telegram redelivers updates that fail, which would run the command again, so a failed reply is only logged
+	if err := s.telegramRepo.SendReply(chatID, reply); err != nil {
+		logger.ErrorContext(ctx, "Failed to reply to Telegram command", zap.Error(err))
+	}
+	return nil
+}
+
+// stats summarizes today's and the last week's traffic and the subscriber count
+func (s *telegramBotService) stats(ctx context.Context) string {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	week, err := s.analyticsService.Summary(ctx, today.AddDate(0, 0, -6), today)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to get analytics summary", zap.Error(err))
+		return "Failed to get stats."
+	}
+
+	var todayStat model.AnalyticsDailyStat
+	if len(week.Daily) > 0 {
+		todayStat = week.Daily[len(week.Daily)-1]
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Today: %d views, %d visitors\n", todayStat.Views, todayStat.Visitors)
+	fmt.Fprintf(&b, "Last 7 days: %d views, %d visitors\n", week.Views, week.Visitors)
+
+	if _, subscribers, err := s.newsletterService.List(ctx, 1, 1, model.SubscriberConfirmed); err != nil {
+		logger.ErrorContext(ctx, "Failed to count subscribers", zap.Error(err))
+	} else {
+		fmt.Fprintf(&b, "Newsletter subscribers: %d\n", subscribers)
+	}
+
+	return b.String()
+}
+
+// blockIP blocks logins from the IP in args for the optional duration that follows it
+func (s *telegramBotService) blockIP(ctx context.Context, args []string) string {
+	if len(args) == 0 || net.ParseIP(args[0]) == nil {
+		return "Usage: /block_ip <ip> [duration], e.g. /block_ip 203.0.113.7 2h"
+	}
+
+	duration := defaultIPBlockDuration
+	if len(args) > 1 {
+		parsed, err := time.ParseDuration(args[1])
+		if err != nil || parsed <= 0 {
+			return "Duration must look like 30m, 2h or 72h."
+		}
+		duration = parsed
+	}
+
+	until := s.protector.BlockIP(args[0], duration)
+	logger.InfoContext(ctx, "IP blocked from Telegram", zap.String("ip", args[0]), zap.Time("blocked_until", until))
+
+	return fmt.Sprintf("Blocked logins from %s until %s.", args[0], until.In(s.location).Format(time.RFC1123))
+}
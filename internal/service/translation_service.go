@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/budhilaw/personal-website-backend/config"
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrUnsupportedLocale = errors.New("unsupported locale")
+	ErrContentNotFound   = errors.New("content not found")
+)
+
+// TranslationService defines methods for translation service
+type TranslationService interface {
+	LocalizeArticle(ctx context.Context, article *model.ArticleResponse, locale string)
+	LocalizeArticles(ctx context.Context, articles []model.ArticleResponse, locale string)
+	LocalizeArticleFields(ctx context.Context, articles []model.PartialItem, locale string)
+	LocalizePage(ctx context.Context, page *model.Page, locale string)
+
+	ListArticle(ctx context.Context, articleID string) ([]model.Translation, error)
+	UpsertArticle(ctx context.Context, articleID, locale string, translation *model.TranslationUpsert) error
+	DeleteArticle(ctx context.Context, articleID, locale string) error
+
+	ListPage(ctx context.Context, pageID string) ([]model.Translation, error)
+	UpsertPage(ctx context.Context, pageID, locale string, translation *model.TranslationUpsert) error
+	DeletePage(ctx context.Context, pageID, locale string) error
+}
+
+// translationService is the implementation of TranslationService
+type translationService struct {
+	translationRepo repository.TranslationRepository
+	articleRepo     repository.ArticleRepository
+	pageRepo        repository.PageRepository
+	markdown        *util.MarkdownRenderer
+	defaultLocale   string
+	locales         []string
+}
+
+// NewTranslationService creates a new TranslationService
+func NewTranslationService(
+	translationRepo repository.TranslationRepository,
+	articleRepo repository.ArticleRepository,
+	pageRepo repository.PageRepository,
+	markdown *util.MarkdownRenderer,
+	cfg config.Config,
+) TranslationService {
+	locales := cfg.Locales()
+
+	return &translationService{
+		translationRepo: translationRepo,
+		articleRepo:     articleRepo,
+		pageRepo:        pageRepo,
+		markdown:        markdown,
+		defaultLocale:   locales[0],
+		locales:         locales,
+	}
+}
+
+// LocalizeArticle overlays the translation for locale, keeping the base content when there is none
+func (s *translationService) LocalizeArticle(ctx context.Context, article *model.ArticleResponse, locale string) {
+	article.Locale = s.defaultLocale
+
+	translations, err := s.translationRepo.ListArticle(ctx, article.ID)
+	if err != nil {
+		// Serving the base content beats failing the request
+		logger.ErrorContext(ctx, "Failed to load translations", zap.Error(err), zap.String("id", article.ID))
+		return
+	}
+
+	s.overlayArticle(ctx, article, translations, locale)
+}
+
+// LocalizeArticles localizes a page of articles like LocalizeArticle, loading their
+// translations in a single query
+func (s *translationService) LocalizeArticles(ctx context.Context, articles []model.ArticleResponse, locale string) {
+	ids := make([]string, len(articles))
+	for i := range articles {
+		articles[i].Locale = s.defaultLocale
+		ids[i] = articles[i].ID
+	}
+
+	translations, err := s.translationRepo.ListArticles(ctx, ids)
+	if err != nil {
+		// Serving the base content beats failing the request
+		logger.ErrorContext(ctx, "Failed to load translations", zap.Error(err))
+		return
+	}
+
+	for i := range articles {
+		s.overlayArticle(ctx, &articles[i], translations[articles[i].ID], locale)
+	}
+}
+
+// overlayArticle lists an article's locales and overlays its translation for locale, if any
+func (s *translationService) overlayArticle(ctx context.Context, article *model.ArticleResponse, translations []model.Translation, locale string) {
+	article.AvailableLocales = s.availableLocales(translations)
+
+	translation := findTranslation(translations, locale)
+	if translation == nil {
+		return
+	}
+
+	article.Locale = translation.Locale
+	article.Title = translation.Title
+	article.Content = translation.Content
+	if translation.Excerpt != "" {
+		article.Excerpt = translation.Excerpt
+	}
+
+	contentHTML, err := s.markdown.Render(translation.Content)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to render translation", zap.Error(err), zap.String("id", article.ID), zap.String("locale", locale))
+		contentHTML = ""
+	}
+	article.ContentHTML = contentHTML
+
+	// Anchors follow the translated headings
+	headings := util.MarkdownHeadings(translation.Content)
+	article.TOC = make([]model.TOCEntry, 0, len(headings))
+	for _, heading := range headings {
+		article.TOC = append(article.TOC, model.TOCEntry(heading))
+	}
+}
+
+// LocalizeArticleFields overlays the translated title, excerpt and content of sparse articles,
+// for whichever of them were requested, loading the translations in a single query
+func (s *translationService) LocalizeArticleFields(ctx context.Context, articles []model.PartialItem, locale string) {
+	if locale == s.defaultLocale || len(articles) == 0 {
+		return
+	}
+
+	// Every item of a sparse list has the same fields
+	_, hasTitle := articles[0]["title"]
+	_, hasExcerpt := articles[0]["excerpt"]
+	_, hasContent := articles[0]["content"]
+	if !hasTitle && !hasExcerpt && !hasContent {
+		return
+	}
+
+	ids := make([]string, 0, len(articles))
+	for _, article := range articles {
+		if id, ok := article["id"].(string); ok {
+			ids = append(ids, id)
+		}
+	}
+
+	translations, err := s.translationRepo.ListArticles(ctx, ids)
+	if err != nil {
+		// Serving the base content beats failing the request
+		logger.ErrorContext(ctx, "Failed to load translations", zap.Error(err))
+		return
+	}
+
+	for _, article := range articles {
+		id, _ := article["id"].(string)
+		translation := findTranslation(translations[id], locale)
+		if translation == nil {
+			continue
+		}
+		if hasTitle {
+			article["title"] = translation.Title
+		}
+		if hasExcerpt && translation.Excerpt != "" {
+			article["excerpt"] = translation.Excerpt
+		}
+		// Password-protected articles are listed without their content
+		if hasContent && article["content"] != "" {
+			article["content"] = translation.Content
+		}
+	}
+}
+
+// LocalizePage overlays the translation for locale, keeping the base content when there is none
+func (s *translationService) LocalizePage(ctx context.Context, page *model.Page, locale string) {
+	page.Locale = s.defaultLocale
+
+	translations, err := s.translationRepo.ListPage(ctx, page.ID)
+	if err != nil {
+		// Serving the base content beats failing the request
+		logger.ErrorContext(ctx, "Failed to load translations", zap.Error(err), zap.String("id", page.ID))
+		return
+	}
+
+	page.AvailableLocales = s.availableLocales(translations)
+
+	if translation := findTranslation(translations, locale); translation != nil {
+		page.Locale = translation.Locale
+		page.Title = translation.Title
+		page.Content = translation.Content
+	}
+}
+
+// ListArticle lists the translations of an article
+func (s *translationService) ListArticle(ctx context.Context, articleID string) ([]model.Translation, error) {
+	if _, err := s.articleRepo.GetByID(ctx, articleID); err != nil {
+		return nil, ErrContentNotFound
+	}
+	return s.translationRepo.ListArticle(ctx, articleID)
+}
+
+// UpsertArticle creates or replaces an article translation
+func (s *translationService) UpsertArticle(ctx context.Context, articleID, locale string, translation *model.TranslationUpsert) error {
+	if err := s.validateLocale(locale); err != nil {
+		return err
+	}
+	if _, err := s.articleRepo.GetByID(ctx, articleID); err != nil {
+		return ErrContentNotFound
+	}
+	return s.translationRepo.UpsertArticle(ctx, articleID, locale, translation)
+}
+
+// DeleteArticle deletes an article translation
+func (s *translationService) DeleteArticle(ctx context.Context, articleID, locale string) error {
+	return s.translationRepo.DeleteArticle(ctx, articleID, locale)
+}
+
+// ListPage lists the translations of a page
+func (s *translationService) ListPage(ctx context.Context, pageID string) ([]model.Translation, error) {
+	if _, err := s.pageRepo.GetByID(ctx, pageID); err != nil {
+		return nil, ErrContentNotFound
+	}
+	return s.translationRepo.ListPage(ctx, pageID)
+}
+
+// UpsertPage creates or replaces a page translation
+func (s *translationService) UpsertPage(ctx context.Context, pageID, locale string, translation *model.TranslationUpsert) error {
+	if err := s.validateLocale(locale); err != nil {
+		return err
+	}
+	if _, err := s.pageRepo.GetByID(ctx, pageID); err != nil {
+		return ErrContentNotFound
+	}
+	return s.translationRepo.UpsertPage(ctx, pageID, locale, translation)
+}
+
+// DeletePage deletes a page translation
+func (s *translationService) DeletePage(ctx context.Context, pageID, locale string) error {
+	return s.translationRepo.DeletePage(ctx, pageID, locale)
+}
+
+// validateLocale only accepts configured, non-default locales;
+// default locale content is edited on the article or page itself
+func (s *translationService) validateLocale(locale string) error {
+	if locale == s.defaultLocale || !slices.Contains(s.locales, locale) {
+		return ErrUnsupportedLocale
+	}
+	return nil
+}
+
+// availableLocales lists the default locale followed by translated locales
+func (s *translationService) availableLocales(translations []model.Translation) []string {
+	available := []string{s.defaultLocale}
+	for _, translation := range translations {
+		if slices.Contains(s.locales, translation.Locale) && translation.Locale != s.defaultLocale {
+			available = append(available, translation.Locale)
+		}
+	}
+	return available
+}
+
+// findTranslation returns the translation for locale, if any
+func findTranslation(translations []model.Translation, locale string) *model.Translation {
+	for i := range translations {
+		if translations[i].Locale == locale {
+			return &translations[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/budhilaw/personal-website-backend/pkg/util"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.uber.org/zap"
+)
+
+// temporaryPasswordLength is the length of passwords generated for new users
+const temporaryPasswordLength = 16
+
+// User management errors
+var (
+	ErrInsufficientRole = errors.New("insufficient role to manage this user")
+	ErrCannotModifySelf = errors.New("cannot change your own role or status")
+	ErrInvalidUser      = errors.New("invalid user")
+	ErrUserExists       = errors.New("username or email already exists")
+)
+
+// UserService defines methods for user management service
+type UserService interface {
+	List(ctx context.Context, page, perPage int) ([]model.UserDetail, int, error)
+	GetByID(ctx context.Context, id string) (*model.UserDetail, error)
+	Create(ctx context.Context, actorRole string, user *model.UserCreate) (*model.UserCreateResponse, error)
+	UpdateRole(ctx context.Context, actorID, actorRole, id, role string) error
+	SetActive(ctx context.Context, actorID, actorRole, id string, active bool) error
+}
+
+// userService is the implementation of UserService
+type userService struct {
+	userRepo repository.UserRepository
+}
+
+// NewUserService creates a new UserService
+func NewUserService(userRepo repository.UserRepository) UserService {
+	return &userService{
+		userRepo: userRepo,
+	}
+}
+
+// List lists users with pagination
+func (s *userService) List(ctx context.Context, page, perPage int) ([]model.UserDetail, int, error) {
+	users, total, err := s.userRepo.List(ctx, page, perPage)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	details := make([]model.UserDetail, 0, len(users))
+	for i := range users {
+		details = append(details, toUserDetail(&users[i]))
+	}
+
+	return details, total, nil
+}
+
+// GetByID gets a user by ID
+func (s *userService) GetByID(ctx context.Context, id string) (*model.UserDetail, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := toUserDetail(user)
+	return &detail, nil
+}
+
+// Create creates a new user with a generated temporary password
+func (s *userService) Create(ctx context.Context, actorRole string, user *model.UserCreate) (*model.UserCreateResponse, error) {
+	ctx = logger.WithContextFields(ctx, logger.RequestLogger("", "CREATE_USER", user.Username))
+
+	if !canManageRole(actorRole, user.Role) {
+		return nil, ErrInsufficientRole
+	}
+
+	if err := util.ValidateUsername(user.Username); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUser, err)
+	}
+	if err := util.ValidateEmail(user.Email); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUser, err)
+	}
+
+	password, err := util.GenerateTemporaryPassword(temporaryPasswordLength)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to generate temporary password", zap.Error(err))
+		return nil, errors.New("failed to generate temporary password")
+	}
+
+	hashedPassword, err := util.HashPassword(password)
+	if err != nil {
+		logger.ErrorContext(ctx, "Failed to hash password", zap.Error(err))
+		return nil, errors.New("failed to process password")
+	}
+
+	id, err := s.userRepo.Create(ctx, user, hashedPassword)
+	if err != nil {
+		// 23505 is unique_violation
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return nil, ErrUserExists
+		}
+		return nil, err
+	}
+
+	created, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.InfoContext(ctx, "User created", zap.String("user_id", id), zap.String("role", user.Role))
+
+	return &model.UserCreateResponse{
+		User:              toUserDetail(created),
+		TemporaryPassword: password,
+	}, nil
+}
+
+// UpdateRole promotes or demotes a user
+func (s *userService) UpdateRole(ctx context.Context, actorID, actorRole, id, role string) error {
+	ctx = logger.WithContextFields(ctx, logger.RequestLogger(actorID, "UPDATE_USER_ROLE", id))
+
+	target, err := s.authorizeTarget(ctx, actorID, actorRole, id)
+	if err != nil {
+		return err
+	}
+
+	// Both the current and the new role must be within the actor's reach
+	if !canManageRole(actorRole, role) {
+		return ErrInsufficientRole
+	}
+
+	if err := s.userRepo.UpdateRole(ctx, target.ID, role); err != nil {
+		return err
+	}
+
+	logger.InfoContext(ctx, "User role updated", zap.String("from", target.Role), zap.String("to", role))
+	return nil
+}
+
+// SetActive activates or deactivates a user
+func (s *userService) SetActive(ctx context.Context, actorID, actorRole, id string, active bool) error {
+	ctx = logger.WithContextFields(ctx, logger.RequestLogger(actorID, "SET_USER_ACTIVE", id))
+
+	target, err := s.authorizeTarget(ctx, actorID, actorRole, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.SetActive(ctx, target.ID, active); err != nil {
+		return err
+	}
+
+	logger.InfoContext(ctx, "User active state updated", zap.Bool("is_active", active))
+	return nil
+}
+
+// authorizeTarget loads the target user and checks the actor may manage it
+func (s *userService) authorizeTarget(ctx context.Context, actorID, actorRole, id string) (*model.User, error) {
+	if actorID == id {
+		return nil, ErrCannotModifySelf
+	}
+
+	target, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canManageRole(actorRole, target.Role) {
+		return nil, ErrInsufficientRole
+	}
+
+	return target, nil
+}
+
+// canManageRole reports whether an actor with actorRole may manage users of targetRole.
+// Owners manage admins and users, admins manage users only, and nobody manages owners.
+func canManageRole(actorRole, targetRole string) bool {
+	switch actorRole {
+	case model.RoleOwner:
+		return targetRole == model.RoleAdmin || targetRole == model.RoleUser
+	case model.RoleAdmin:
+		return targetRole == model.RoleUser
+	default:
+		return false
+	}
+}
+
+// toUserDetail converts a user to its management view
+func toUserDetail(user *model.User) model.UserDetail {
+	return model.UserDetail{
+		ID:                  user.ID,
+		Username:            user.Username,
+		Email:               user.Email,
+		FirstName:           user.FirstName,
+		LastName:            user.LastName,
+		Role:                user.Role,
+		IsActive:            user.IsActive,
+		CreatedAt:           user.CreatedAt,
+		UpdatedAt:           user.UpdatedAt,
+		DeletionRequestedAt: user.DeletionRequestedAt,
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/budhilaw/personal-website-backend/internal/model"
+	"github.com/budhilaw/personal-website-backend/internal/repository"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrUsesCategoryNotFound is returned when a uses item refers to a category that doesn't exist
+var ErrUsesCategoryNotFound = errors.New("uses category not found")
+
+// UsesService defines methods for uses page service
+type UsesService interface {
+	GetUses(ctx context.Context) (*model.Uses, error)
+
+	CreateCategory(ctx context.Context, category *model.UsesCategoryCreate) (string, error)
+	UpdateCategory(ctx context.Context, id string, category *model.UsesCategoryUpdate) error
+	DeleteCategory(ctx context.Context, id string) error
+
+	CreateItem(ctx context.Context, item *model.UsesItemCreate) (string, error)
+	UpdateItem(ctx context.Context, id string, item *model.UsesItemUpdate) error
+	DeleteItem(ctx context.Context, id string) error
+}
+
+// usesService is the implementation of UsesService
+type usesService struct {
+	usesRepo repository.UsesRepository
+}
+
+// NewUsesService creates a new UsesService
+func NewUsesService(usesRepo repository.UsesRepository) UsesService {
+	return &usesService{
+		usesRepo: usesRepo,
+	}
+}
+
+// GetUses gets every uses category with its items
+func (s *usesService) GetUses(ctx context.Context) (*model.Uses, error) {
+	categories, err := s.usesRepo.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.usesRepo.ListItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(categories))
+	for i, category := range categories {
+		index[category.ID] = i
+	}
+	for _, item := range items {
+		if i, ok := index[item.CategoryID]; ok {
+			categories[i].Items = append(categories[i].Items, item)
+		}
+	}
+
+	return &model.Uses{Categories: categories}, nil
+}
+
+// CreateCategory creates a uses category
+func (s *usesService) CreateCategory(ctx context.Context, category *model.UsesCategoryCreate) (string, error) {
+	return s.usesRepo.CreateCategory(ctx, category)
+}
+
+// UpdateCategory updates a uses category
+func (s *usesService) UpdateCategory(ctx context.Context, id string, category *model.UsesCategoryUpdate) error {
+	return s.usesRepo.UpdateCategory(ctx, id, category)
+}
+
+// DeleteCategory deletes a uses category and its items
+func (s *usesService) DeleteCategory(ctx context.Context, id string) error {
+	return s.usesRepo.DeleteCategory(ctx, id)
+}
+
+// CreateItem creates a uses item
+func (s *usesService) CreateItem(ctx context.Context, item *model.UsesItemCreate) (string, error) {
+	id, err := s.usesRepo.CreateItem(ctx, item)
+	if err != nil {
+		return "", usesItemError(err)
+	}
+	return id, nil
+}
+
+// UpdateItem updates a uses item
+func (s *usesService) UpdateItem(ctx context.Context, id string, item *model.UsesItemUpdate) error {
+	return usesItemError(s.usesRepo.UpdateItem(ctx, id, item))
+}
+
+// DeleteItem deletes a uses item
+func (s *usesService) DeleteItem(ctx context.Context, id string) error {
+	return s.usesRepo.DeleteItem(ctx, id)
+}
+
+// usesItemError maps foreign key violations on the category to ErrUsesCategoryNotFound
+func usesItemError(err error) error {
+	// 23503 is foreign_key_violation
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+		return ErrUsesCategoryNotFound
+	}
+	return err
+}
@@ -0,0 +1,177 @@
+package errortracking
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/budhilaw/personal-website-backend/pkg/logger"
+	"github.com/getsentry/sentry-go"
+	"go.uber.org/zap/zapcore"
+)
+
+// HubContextKey is the request local holding the per-request Sentry hub. It is a string so
+// the hub can be found from the fasthttp request context handed to services.
+const HubContextKey = "sentry_hub"
+
+// userContextKey is the request local set by the auth middleware
+const userContextKey = "user_id"
+
+// releasePrefix names the project in release tags
+const releasePrefix = "personal-website-backend@"
+
+// Options configure Sentry
+type Options struct {
+	DSN         string
+	Environment string
+	SampleRate  float64
+}
+
+var enabled bool
+
+// Init starts Sentry when a DSN is configured and reports errors logged with
+// logger.ErrorContext from then on
+func Init(opts Options) error {
+	if opts.DSN == "" {
+		return nil
+	}
+
+	err := sentry.Init(sentry.ClientOptions{
+		Dsn:              opts.DSN,
+		Environment:      opts.Environment,
+		Release:          Release(),
+		SampleRate:       opts.SampleRate,
+		AttachStacktrace: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	enabled = true
+	logger.SetErrorReporter(reportLogged)
+	return nil
+}
+
+// Enabled reports whether Sentry was started
+func Enabled() bool {
+	return enabled
+}
+
+// Flush waits for queued events to be sent, up to timeout
+func Flush(timeout time.Duration) {
+	if enabled {
+		sentry.Flush(timeout)
+	}
+}
+
+// Release returns the release tag from the build info: the module version for tagged builds,
+// otherwise the VCS revision. It is empty when neither is known, e.g. under go run.
+func Release() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	if version := info.Main.Version; version != "" && version != "(devel)" {
+		return releasePrefix + version
+	}
+
+	var revision string
+	var modified bool
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if revision == "" {
+		return ""
+	}
+
+	if len(revision) > 12 {
+		revision = revision[:12]
+	}
+	if modified {
+		revision += "-dirty"
+	}
+	return releasePrefix + revision
+}
+
+// NewRequestHub returns a hub whose events carry the request's method, URL and headers.
+// Sentry drops Authorization, cookies and similar headers itself.
+func NewRequestHub(r *http.Request) *sentry.Hub {
+	hub := sentry.CurrentHub().Clone()
+	hub.Scope().SetRequest(r)
+	return hub
+}
+
+// CapturePanic reports a recovered panic
+func CapturePanic(ctx context.Context, recovered interface{}) {
+	if !enabled {
+		return
+	}
+
+	hub := hubFromContext(ctx)
+	hub.WithScope(func(scope *sentry.Scope) {
+		setUser(ctx, scope)
+		hub.RecoverWithContext(ctx, recovered)
+	})
+}
+
+// reportLogged reports an error logged with logger.ErrorContext. The error field becomes the
+// exception; other plain fields are attached as extras, except sensitive ones.
+func reportLogged(ctx context.Context, message string, fields []zapcore.Field) {
+	var err error
+	extras := map[string]interface{}{}
+	for _, field := range fields {
+		switch {
+		case field.Type == zapcore.ErrorType:
+			err, _ = field.Interface.(error)
+		case logger.IsSensitiveField(field.Key):
+		case field.Type == zapcore.StringType:
+			extras[field.Key] = field.String
+		case field.Type == zapcore.Int64Type, field.Type == zapcore.Int32Type, field.Type == zapcore.Uint32Type:
+			extras[field.Key] = field.Integer
+		case field.Type == zapcore.BoolType:
+			extras[field.Key] = field.Integer == 1
+		case field.Type == zapcore.DurationType:
+			extras[field.Key] = time.Duration(field.Integer).String()
+		}
+	}
+
+	hub := hubFromContext(ctx)
+	hub.WithScope(func(scope *sentry.Scope) {
+		setUser(ctx, scope)
+		scope.SetExtras(extras)
+
+		if err != nil {
+			hub.CaptureException(fmt.Errorf("%s: %w", message, err))
+		} else {
+			hub.CaptureMessage(message)
+		}
+	})
+}
+
+// hubFromContext returns the request's hub, or the global one outside requests
+func hubFromContext(ctx context.Context) *sentry.Hub {
+	if ctx != nil {
+		if hub, ok := ctx.Value(HubContextKey).(*sentry.Hub); ok {
+			return hub
+		}
+	}
+	return sentry.CurrentHub()
+}
+
+// setUser tags the event with the signed-in user, if any
+func setUser(ctx context.Context, scope *sentry.Scope) {
+	if ctx == nil {
+		return
+	}
+	if userID, ok := ctx.Value(userContextKey).(string); ok && userID != "" {
+		scope.SetUser(sentry.User{ID: userID})
+	}
+}
@@ -71,10 +71,25 @@ func WarnContext(ctx context.Context, message string, fields ...zapcore.Field) {
 	logger.Warn(message, fields...)
 }
 
-// ErrorContext logs an error message with context
+// ErrorReporter receives errors logged with ErrorContext, e.g. to forward them to an error tracker
+type ErrorReporter func(ctx context.Context, message string, fields []zapcore.Field)
+
+// errorReporter is set once at startup, before any request is served
+var errorReporter ErrorReporter
+
+// SetErrorReporter registers the reporter called by ErrorContext
+func SetErrorReporter(reporter ErrorReporter) {
+	errorReporter = reporter
+}
+
+// ErrorContext logs an error message with context and hands it to the error reporter
 func ErrorContext(ctx context.Context, message string, fields ...zapcore.Field) {
 	logger := getLoggerFromContext(ctx)
 	logger.Error(message, fields...)
+
+	if errorReporter != nil {
+		errorReporter(ctx, message, fields)
+	}
 }
 
 // FatalContext logs a fatal message with context and exits
@@ -0,0 +1,161 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RootLogger is the name used for the level of loggers without an override
+const RootLogger = "root"
+
+// levelRegistry holds the root level and per-logger overrides. Loggers are named after the
+// package or subsystem using them ("jobs", "http", "email"...); an override for "jobs" also
+// applies to "jobs.scheduler" unless that has its own.
+type levelRegistry struct {
+	mu    sync.RWMutex
+	root  zapcore.Level
+	named map[string]zapcore.Level
+}
+
+var levels = &levelRegistry{
+	root:  zapcore.InfoLevel,
+	named: map[string]zapcore.Level{},
+}
+
+// levelFor returns the level in effect for a logger name
+func (r *levelRegistry) levelFor(name string) zapcore.Level {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for name != "" {
+		if level, ok := r.named[name]; ok {
+			return level
+		}
+		i := strings.LastIndex(name, ".")
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return r.root
+}
+
+// minimum returns the most verbose level any logger is allowed
+func (r *levelRegistry) minimum() zapcore.Level {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	min := r.root
+	for _, level := range r.named {
+		if level < min {
+			min = level
+		}
+	}
+	return min
+}
+
+// levelCore filters entries by the level of the logger that wrote them. The wrapped core
+// must accept every level.
+type levelCore struct {
+	zapcore.Core
+}
+
+// Enabled lets zap skip building entries no logger would write
+func (c levelCore) Enabled(level zapcore.Level) bool {
+	return level >= levels.minimum()
+}
+
+// With keeps the filter on loggers with fields attached
+func (c levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return levelCore{c.Core.With(fields)}
+}
+
+// Check drops entries below their logger's level
+func (c levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if entry.Level < levels.levelFor(entry.LoggerName) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+// Named returns a logger for a package or subsystem whose level can be set on its own
+func Named(name string) *zap.Logger {
+	// The global logger skips one frame for the helpers in this package
+	return Log.Named(name).WithOptions(zap.AddCallerSkip(-1))
+}
+
+// SetLevel sets the level of a named logger, or of every logger without an override when
+// name is RootLogger
+func SetLevel(name, level string) error {
+	parsed, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	if name == RootLogger {
+		levels.root = parsed
+	} else {
+		levels.named[name] = parsed
+	}
+	return nil
+}
+
+// ResetLevel removes the override of a named logger so it follows its parent again
+func ResetLevel(name string) {
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	delete(levels.named, name)
+}
+
+// Levels returns the root level and every override
+func Levels() map[string]string {
+	levels.mu.RLock()
+	defer levels.mu.RUnlock()
+
+	result := map[string]string{RootLogger: levels.root.String()}
+	for name, level := range levels.named {
+		result[name] = level.String()
+	}
+	return result
+}
+
+// ParseLevels parses per-logger levels written as "jobs=debug,http=warn"
+func ParseLevels(spec string) (map[string]zapcore.Level, error) {
+	parsed := map[string]zapcore.Level{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, level, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("log level %q must look like name=level", entry)
+		}
+
+		l, err := ParseLevel(level)
+		if err != nil {
+			return nil, err
+		}
+		parsed[name] = l
+	}
+	return parsed, nil
+}
+
+// ParseLevel parses a level name such as debug, info, warn or error
+func ParseLevel(level string) (zapcore.Level, error) {
+	var parsed zapcore.Level
+	if err := parsed.UnmarshalText([]byte(strings.ToLower(strings.TrimSpace(level)))); err != nil {
+		return parsed, fmt.Errorf("unknown log level %q", level)
+	}
+	return parsed, nil
+}
@@ -3,9 +3,11 @@ package logger
 import (
 	"os"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var (
@@ -14,27 +16,96 @@ var (
 	once sync.Once
 )
 
-// InitLogger initializes the global logger
+// Options configure the global logger
+type Options struct {
+	Production bool
+	// Format is json or console; empty means json in production and console otherwise
+	Format string
+	// Level is the root level; empty means info in production and debug otherwise
+	Level string
+	// Levels overrides the level of named loggers, e.g. "jobs=debug,http=warn"
+	Levels string
+
+	// File receives the logs instead of stderr when set, rotated once it reaches FileMaxSizeMB
+	File           string
+	FileMaxSizeMB  int
+	FileMaxBackups int
+	FileMaxAgeDays int
+	FileCompress   bool
+}
+
+// InitLogger initializes the global logger with the defaults for the environment
 func InitLogger(isProduction bool) *zap.Logger {
+	return InitLoggerWithOptions(Options{Production: isProduction})
+}
+
+// InitLoggerWithOptions initializes the global logger
+func InitLoggerWithOptions(opts Options) *zap.Logger {
 	once.Do(func() {
-		var config zap.Config
+		var encoderConfig zapcore.EncoderConfig
+		if opts.Production {
+			encoderConfig = zap.NewProductionEncoderConfig()
+		} else {
+			encoderConfig = zap.NewDevelopmentEncoderConfig()
+			// Colors only make sense on a terminal
+			if opts.File == "" {
+				encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+			}
+		}
+		encoderConfig.TimeKey = "timestamp"
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+
+		format := opts.Format
+		if format == "" {
+			format = "console"
+			if opts.Production {
+				format = "json"
+			}
+		}
 
-		if isProduction {
-			config = zap.NewProductionConfig()
-			config.EncoderConfig.TimeKey = "timestamp"
-			config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		var encoder zapcore.Encoder
+		if format == "json" {
+			encoder = zapcore.NewJSONEncoder(encoderConfig)
 		} else {
-			config = zap.NewDevelopmentConfig()
-			config.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
-			config.EncoderConfig.TimeKey = "timestamp"
-			config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+			encoder = zapcore.NewConsoleEncoder(encoderConfig)
 		}
 
-		var err error
-		Log, err = config.Build(zap.AddCaller(), zap.AddCallerSkip(1))
-		if err != nil {
-			panic(err)
+		output := zapcore.Lock(os.Stderr)
+		if opts.File != "" {
+			output = zapcore.AddSync(&lumberjack.Logger{
+				Filename:   opts.File,
+				MaxSize:    opts.FileMaxSizeMB,
+				MaxBackups: opts.FileMaxBackups,
+				MaxAge:     opts.FileMaxAgeDays,
+				Compress:   opts.FileCompress,
+			})
 		}
+
+		levels.root = zapcore.DebugLevel
+		if opts.Production {
+			levels.root = zapcore.InfoLevel
+		}
+		// The config is validated before the logger starts, so parse errors can't happen here
+		if level, err := ParseLevel(opts.Level); opts.Level != "" && err == nil {
+			levels.root = level
+		}
+		if named, err := ParseLevels(opts.Levels); err == nil {
+			levels.named = named
+		}
+
+		// Levels are checked per logger by levelCore, so the core itself accepts everything.
+		// Sensitive fields are redacted whatever the level.
+		var core zapcore.Core = levelCore{redactCore{zapcore.NewCore(encoder, output, zapcore.DebugLevel)}}
+
+		options := []zap.Option{zap.AddCaller(), zap.AddCallerSkip(1), zap.ErrorOutput(zapcore.Lock(os.Stderr))}
+		if opts.Production {
+			core = zapcore.NewSamplerWithOptions(core, time.Second, 100, 100)
+			options = append(options, zap.AddStacktrace(zapcore.ErrorLevel))
+		} else {
+			options = append(options, zap.Development(), zap.AddStacktrace(zapcore.WarnLevel))
+		}
+
+		Log = zap.New(core, options...)
 	})
 
 	return Log
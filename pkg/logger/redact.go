@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Redacted replaces the value of sensitive fields
+const Redacted = "[REDACTED]"
+
+// sensitiveSuffixes are matched against field names lowercased with separators removed, so
+// "Password", "stored_hash" and "X-Refresh-Token" are all caught
+var sensitiveSuffixes = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"hash",
+	"authorization",
+	"cookie",
+	"apikey",
+	"privatekey",
+}
+
+// IsSensitiveField reports whether a log or notification field name holds a secret
+func IsSensitiveField(name string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "", ".", "", " ", "").Replace(strings.ToLower(name))
+	for _, suffix := range sensitiveSuffixes {
+		if strings.HasSuffix(normalized, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactCore replaces the values of sensitive fields before they are encoded. Only field
+// names are checked; secrets nested in objects or error messages are not found.
+type redactCore struct {
+	zapcore.Core
+}
+
+// With redacts fields attached to a logger
+func (c redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return redactCore{c.Core.With(redactFields(fields))}
+}
+
+// Check registers this core so Write sees the entry
+func (c redactCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+// Write redacts fields passed with a single entry
+func (c redactCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, redactFields(fields))
+}
+
+// redactFields returns fields with sensitive values replaced, copying only when needed
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	var redacted []zapcore.Field
+	for i, field := range fields {
+		if !IsSensitiveField(field.Key) {
+			continue
+		}
+		if redacted == nil {
+			redacted = append([]zapcore.Field(nil), fields...)
+		}
+		redacted[i] = zap.String(field.Key, Redacted)
+	}
+
+	if redacted == nil {
+		return fields
+	}
+	return redacted
+}
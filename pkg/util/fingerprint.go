@@ -0,0 +1,23 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+)
+
+// DeviceFingerprint hashes a user agent with the IP's subnet (/24 for IPv4, /48 for IPv6),
+// so a device stays recognised while its address changes within the same network
+func DeviceFingerprint(userAgent, ip string) string {
+	subnet := ip
+	if parsed := net.ParseIP(ip); parsed != nil {
+		if v4 := parsed.To4(); v4 != nil {
+			subnet = v4.Mask(net.CIDRMask(24, 32)).String()
+		} else {
+			subnet = parsed.Mask(net.CIDRMask(48, 128)).String()
+		}
+	}
+
+	sum := sha256.Sum256([]byte(userAgent + "|" + subnet))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,35 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+
+	"gopkg.in/yaml.v3"
+)
+
+// frontMatterDelimiter opens and closes a YAML front matter block
+var frontMatterDelimiter = []byte("---")
+
+// ParseFrontMatter decodes the YAML front matter of a Markdown document into v
+// and returns the remaining body. Documents without front matter are returned unchanged.
+func ParseFrontMatter(data []byte, v interface{}) ([]byte, error) {
+	data = bytes.TrimPrefix(data, []byte("\uFEFF"))
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+
+	if !bytes.HasPrefix(data, append(frontMatterDelimiter, '\n')) {
+		return data, nil
+	}
+
+	rest := data[len(frontMatterDelimiter)+1:]
+	end := bytes.Index(rest, append([]byte("\n"), frontMatterDelimiter...))
+	if end < 0 {
+		return nil, errors.New("front matter is not closed")
+	}
+
+	if err := yaml.Unmarshal(rest[:end], v); err != nil {
+		return nil, err
+	}
+
+	body := rest[end+1+len(frontMatterDelimiter):]
+	return bytes.TrimLeft(body, "\n"), nil
+}
@@ -0,0 +1,48 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/HugoSmits86/nativewebp"
+	"github.com/disintegration/imaging"
+)
+
+// reencodeJPEGQuality keeps re-encoded photos visually identical to the upload
+const reencodeJPEGQuality = 92
+
+// StripImageMetadata decodes a JPEG or PNG, applies its EXIF orientation and re-encodes it,
+// which drops EXIF, XMP and text chunks such as camera details and GPS coordinates
+func StripImageMetadata(data []byte, contentType string) ([]byte, image.Image, error) {
+	img, err := imaging.Decode(bytes.NewReader(data), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var buf bytes.Buffer
+	switch contentType {
+	case "image/jpeg":
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: reencodeJPEGQuality})
+	case "image/png":
+		err = png.Encode(&buf, img)
+	default:
+		return nil, nil, fmt.Errorf("unsupported image type %q", contentType)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return buf.Bytes(), img, nil
+}
+
+// EncodeWebP encodes an image as lossless WebP
+func EncodeWebP(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := nativewebp.Encode(&buf, img, nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
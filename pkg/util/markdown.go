@@ -0,0 +1,149 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	goldmarkutil "github.com/yuin/goldmark/util"
+)
+
+// Heading is a Markdown heading with the anchor id it is rendered with
+type Heading struct {
+	Level  int
+	Anchor string
+	Text   string
+}
+
+// headingParser parses Markdown the same way MarkdownRenderer does, without rendering it
+var headingParser = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithParserOptions(parser.WithASTTransformers(goldmarkutil.Prioritized(headingAnchors{}, 100))),
+).Parser()
+
+// MarkdownRenderer converts content Markdown to HTML, highlighting fenced code blocks
+// with a chroma style so readers don't need a client-side highlighter
+type MarkdownRenderer struct {
+	markdown goldmark.Markdown
+}
+
+// NewMarkdownRenderer creates a renderer using the named chroma style; unknown names
+// fall back to chroma's default style
+func NewMarkdownRenderer(style string) *MarkdownRenderer {
+	return &MarkdownRenderer{
+		markdown: goldmark.New(
+			goldmark.WithExtensions(
+				extension.GFM,
+				highlighting.NewHighlighting(
+					highlighting.WithStyle(style),
+					highlighting.WithFormatOptions(
+						chromahtml.WithLineNumbers(false),
+						chromahtml.TabWidth(4),
+					),
+				),
+			),
+			goldmark.WithParserOptions(parser.WithASTTransformers(goldmarkutil.Prioritized(headingAnchors{}, 100))),
+			// Content is written by admins and may contain HTML, e.g. WordPress imports
+			goldmark.WithRendererOptions(html.WithUnsafe()),
+		),
+	}
+}
+
+// Render converts Markdown to HTML
+func (r *MarkdownRenderer) Render(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := r.markdown.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// MarkdownHeadings lists the headings of a Markdown document in order, with the
+// same anchors MarkdownRenderer gives them
+func MarkdownHeadings(source string) []Heading {
+	src := []byte(source)
+	doc := headingParser.Parse(text.NewReader(src))
+
+	headings := []Heading{}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+
+		text := strings.TrimSpace(inlineText(heading, src))
+		if text == "" {
+			return ast.WalkSkipChildren, nil
+		}
+
+		anchor, _ := heading.AttributeString("id")
+		id, _ := anchor.([]byte)
+		headings = append(headings, Heading{
+			Level:  heading.Level,
+			Anchor: string(id),
+			Text:   text,
+		})
+		return ast.WalkSkipChildren, nil
+	})
+
+	return headings
+}
+
+// inlineText returns the plain text of a node's inline children, without markup
+func inlineText(n ast.Node, source []byte) string {
+	var b strings.Builder
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch c := c.(type) {
+		case *ast.Text:
+			b.Write(c.Segment.Value(source))
+			if c.SoftLineBreak() || c.HardLineBreak() {
+				b.WriteByte(' ')
+			}
+		case *ast.String:
+			b.Write(c.Value)
+		case *ast.RawHTML:
+			// Inline tags aren't part of the heading text
+		default:
+			b.WriteString(inlineText(c, source))
+		}
+	}
+	return b.String()
+}
+
+// headingAnchors sets the id of every heading to a GenerateSlug slug of its text,
+// numbering repeats so every anchor in a document is unique
+type headingAnchors struct{}
+
+// Transform implements parser.ASTTransformer
+func (headingAnchors) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+	used := map[string]bool{}
+
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := n.(*ast.Heading)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+
+		base := GenerateSlug(inlineText(heading, source))
+		if base == "" {
+			base = "section"
+		}
+		id := base
+		for i := 1; used[id]; i++ {
+			id = fmt.Sprintf("%s-%d", base, i)
+		}
+		used[id] = true
+
+		heading.SetAttributeString("id", []byte(id))
+		return ast.WalkSkipChildren, nil
+	})
+}
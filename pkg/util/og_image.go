@@ -0,0 +1,162 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+// Social card dimensions recommended by Open Graph consumers
+const (
+	OGImageWidth  = 1200
+	OGImageHeight = 630
+
+	ogPadding       = 80
+	ogTitleSize     = 64
+	ogTitleLeading  = 80
+	ogTitleMaxLines = 4
+	ogMetaSize      = 32
+)
+
+var (
+	ogBackground = color.RGBA{R: 0x11, G: 0x18, B: 0x27, A: 0xff}
+	ogAccent     = color.RGBA{R: 0x38, G: 0xbd, B: 0xf8, A: 0xff}
+	ogText       = color.RGBA{R: 0xf9, G: 0xfa, B: 0xfb, A: 0xff}
+	ogMuted      = color.RGBA{R: 0x9c, G: 0xa3, B: 0xaf, A: 0xff}
+
+	ogFontsOnce   sync.Once
+	ogFontsErr    error
+	ogBoldFont    *opentype.Font
+	ogRegularFont *opentype.Font
+)
+
+// loadOGFonts parses the embedded Go fonts once
+func loadOGFonts() error {
+	ogFontsOnce.Do(func() {
+		if ogBoldFont, ogFontsErr = opentype.Parse(gobold.TTF); ogFontsErr != nil {
+			return
+		}
+		ogRegularFont, ogFontsErr = opentype.Parse(goregular.TTF)
+	})
+
+	return ogFontsErr
+}
+
+// newOGFace creates a face of the given size. Faces cache glyphs and aren't safe for
+// concurrent use, so every render creates its own from the shared parsed fonts.
+func newOGFace(f *opentype.Font, size float64) (font.Face, error) {
+	return opentype.NewFace(f, &opentype.FaceOptions{Size: size, DPI: 72, Hinting: font.HintingFull})
+}
+
+// RenderOGImage renders a PNG social card with the title, author and site name
+func RenderOGImage(title, author, site string) ([]byte, error) {
+	if err := loadOGFonts(); err != nil {
+		return nil, fmt.Errorf("failed to load fonts: %v", err)
+	}
+
+	titleFace, err := newOGFace(ogBoldFont, ogTitleSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fonts: %v", err)
+	}
+	defer titleFace.Close()
+
+	metaFace, err := newOGFace(ogRegularFont, ogMetaSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fonts: %v", err)
+	}
+	defer metaFace.Close()
+
+	brandFace, err := newOGFace(ogBoldFont, ogMetaSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fonts: %v", err)
+	}
+	defer brandFace.Close()
+
+	img := image.NewRGBA(image.Rect(0, 0, OGImageWidth, OGImageHeight))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: ogBackground}, image.Point{}, draw.Src)
+
+	// Accent bar along the left edge
+	draw.Draw(img, image.Rect(0, 0, 16, OGImageHeight), &image.Uniform{C: ogAccent}, image.Point{}, draw.Src)
+
+	// Site branding at the top
+	drawText(img, brandFace, ogAccent, site, ogPadding, ogPadding+ogMetaSize)
+
+	// Title, wrapped to the available width
+	lines := wrapText(titleFace, title, OGImageWidth-2*ogPadding, ogTitleMaxLines)
+	y := ogPadding + ogMetaSize + ogTitleLeading + 28
+	for _, line := range lines {
+		drawText(img, titleFace, ogText, line, ogPadding, y)
+		y += ogTitleLeading
+	}
+
+	// Author at the bottom
+	if author != "" {
+		drawText(img, metaFace, ogMuted, author, ogPadding, OGImageHeight-ogPadding)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %v", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// drawText draws s with its baseline at (x, y)
+func drawText(img draw.Image, face font.Face, c color.Color, s string, x, y int) {
+	d := &font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(s)
+}
+
+// wrapText splits s into lines no wider than maxWidth, truncating with an ellipsis after maxLines
+func wrapText(face font.Face, s string, maxWidth, maxLines int) []string {
+	limit := fixed.I(maxWidth)
+	words := strings.Fields(s)
+
+	var lines []string
+	var current string
+	for _, word := range words {
+		candidate := word
+		if current != "" {
+			candidate = current + " " + word
+		}
+
+		if font.MeasureString(face, candidate) <= limit || current == "" {
+			current = candidate
+			continue
+		}
+
+		lines = append(lines, current)
+		current = word
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		last := []rune(lines[maxLines-1])
+		for len(last) > 0 && font.MeasureString(face, string(last)+"…") > limit {
+			last = []rune(strings.TrimRight(string(last[:len(last)-1]), " "))
+		}
+		lines[maxLines-1] = string(last) + "…"
+	}
+
+	return lines
+}
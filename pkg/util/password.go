@@ -114,3 +114,26 @@ func decodeHash(encodedHash string) (params *argon2Params, salt, hash []byte, er
 
 	return params, salt, hash, nil
 }
+
+// temporaryPasswordAlphabet avoids characters that are easy to misread
+const temporaryPasswordAlphabet = "abcdefghjkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// GenerateTemporaryPassword generates a random password of the given length
+func GenerateTemporaryPassword(length int) (string, error) {
+	// Reject bytes above the largest multiple of the alphabet size to avoid modulo bias
+	limit := 256 - (256 % len(temporaryPasswordAlphabet))
+
+	password := make([]byte, 0, length)
+	buf := make([]byte, 1)
+	for len(password) < length {
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		if int(buf[0]) >= limit {
+			continue
+		}
+		password = append(password, temporaryPasswordAlphabet[int(buf[0])%len(temporaryPasswordAlphabet)])
+	}
+
+	return string(password), nil
+}
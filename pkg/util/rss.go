@@ -0,0 +1,103 @@
+package util
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// RSSChannel is an RSS 2.0 channel; SelfURL is where the feed itself is served
+type RSSChannel struct {
+	Title       string
+	Link        string
+	Description string
+	SelfURL     string
+	Items       []RSSItem
+}
+
+// RSSItem is an item of an RSS 2.0 channel; Description may contain HTML
+type RSSItem struct {
+	Title       string
+	Link        string
+	Description string
+	GUID        string
+	PubDate     time.Time
+	Categories  []string
+}
+
+type rssDocument struct {
+	XMLName xml.Name       `xml:"rss"`
+	Version string         `xml:"version,attr"`
+	Atom    string         `xml:"xmlns:atom,attr"`
+	Channel rssChannelElem `xml:"channel"`
+}
+
+type rssChannelElem struct {
+	Title         string        `xml:"title"`
+	Link          string        `xml:"link"`
+	Description   string        `xml:"description"`
+	AtomLink      rssAtomLink   `xml:"atom:link"`
+	LastBuildDate string        `xml:"lastBuildDate,omitempty"`
+	Items         []rssItemElem `xml:"item"`
+}
+
+type rssAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItemElem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Description string   `xml:"description"`
+	GUID        rssGUID  `xml:"guid"`
+	PubDate     string   `xml:"pubDate,omitempty"`
+	Categories  []string `xml:"category"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+// RenderRSS renders a channel as an RSS 2.0 document. The channel's lastBuildDate is
+// the newest item's date.
+func RenderRSS(channel RSSChannel) ([]byte, error) {
+	doc := rssDocument{
+		Version: "2.0",
+		Atom:    "http://www.w3.org/2005/Atom",
+		Channel: rssChannelElem{
+			Title:       channel.Title,
+			Link:        channel.Link,
+			Description: channel.Description,
+			AtomLink:    rssAtomLink{Href: channel.SelfURL, Rel: "self", Type: "application/rss+xml"},
+		},
+	}
+
+	var lastBuild time.Time
+	for _, item := range channel.Items {
+		elem := rssItemElem{
+			Title:       item.Title,
+			Link:        item.Link,
+			Description: item.Description,
+			GUID:        rssGUID{Value: item.GUID},
+			Categories:  item.Categories,
+		}
+		if !item.PubDate.IsZero() {
+			elem.PubDate = item.PubDate.UTC().Format(time.RFC1123Z)
+			if item.PubDate.After(lastBuild) {
+				lastBuild = item.PubDate
+			}
+		}
+		doc.Channel.Items = append(doc.Channel.Items, elem)
+	}
+	if !lastBuild.IsZero() {
+		doc.Channel.LastBuildDate = lastBuild.UTC().Format(time.RFC1123Z)
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
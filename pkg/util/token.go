@@ -0,0 +1,24 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// GenerateRandomToken generates a hex encoded random token from byteLen random bytes
+func GenerateRandomToken(byteLen int) (string, error) {
+	randBytes := make([]byte, byteLen)
+	if _, err := rand.Read(randBytes); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %v", err)
+	}
+
+	return hex.EncodeToString(randBytes), nil
+}
+
+// HashToken returns the hex encoded SHA-256 hash a secret token is stored under
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -2,6 +2,8 @@ package util
 
 import (
 	"fmt"
+	"net/url"
+	"reflect"
 	"regexp"
 	"strings"
 
@@ -14,6 +16,7 @@ var (
 	emailRegexp        = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	slugRegexp         = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
 	fileNameRegexp     = regexp.MustCompile(`^[a-zA-Z0-9_-]+\.[a-zA-Z0-9]+$`)
+	shortCodeRegexp    = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
 	allowedMimeTypes   = map[string]bool{"image/jpeg": true, "image/png": true, "image/gif": true, "application/pdf": true}
 	maxFileSizeBytes   = int64(5 * 1024 * 1024) // 5MB
 	minPasswordLength  = 8
@@ -25,8 +28,22 @@ var (
 	maxFirstNameLength = 50
 	maxLastNameLength  = 50
 	maxBioLength       = 500
+	structValidator    = newStructValidator()
 )
 
+// newStructValidator returns a validator that reports fields by their JSON names
+func newStructValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return v
+}
+
 // ValidateUsername validates a username
 func ValidateUsername(username string) error {
 	username = strings.TrimSpace(strings.ToLower(username))
@@ -80,6 +97,23 @@ func ValidateSlug(slug string) error {
 	return nil
 }
 
+// ValidateShortCode validates a short link code
+func ValidateShortCode(code string) error {
+	if !shortCodeRegexp.MatchString(code) {
+		return fmt.Errorf("code can only contain letters, numbers, hyphens, and underscores, and must be at most 64 characters")
+	}
+	return nil
+}
+
+// ValidateURL validates an absolute http(s) URL
+func ValidateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("url must be an absolute http or https URL")
+	}
+	return nil
+}
+
 // ValidateFile validates a file upload
 func ValidateFile(filename string, size int64, mimeType string) error {
 	if !fileNameRegexp.MatchString(filename) {
@@ -148,10 +182,10 @@ func ValidateBio(bio string) error {
 	return nil
 }
 
-// ValidateStruct validates a struct using validator tags
+// ValidateStruct validates a struct using validator tags.
+// Failures are returned as validator.ValidationErrors.
 func ValidateStruct(s interface{}) error {
-	validate := validator.New()
-	if err := validate.Struct(s); err != nil {
+	if err := structValidator.Struct(s); err != nil {
 		return err
 	}
 	return nil
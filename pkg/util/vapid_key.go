@@ -0,0 +1,65 @@
+package util
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadOrCreateVAPIDKeys reads a Web Push VAPID private key, generating and saving a
+// P-256 key on first use. Both keys are returned base64url encoded, the public one as
+// the uncompressed point browsers expect as applicationServerKey.
+func LoadOrCreateVAPIDKeys(path string) (privateKey, publicKey string, err error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return createVAPIDKeys(path)
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return "", "", fmt.Errorf("%s is not a base64url encoded key: %v", path, err)
+	}
+	key, err := ecdh.P256().NewPrivateKey(raw)
+	if err != nil {
+		return "", "", fmt.Errorf("%s is not a P-256 private key: %v", path, err)
+	}
+
+	return encodeVAPIDKeys(key)
+}
+
+// createVAPIDKeys generates a key and writes it readable by the owner only
+func createVAPIDKeys(path string) (string, string, error) {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate key: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", "", err
+	}
+
+	privateKey, publicKey, err := encodeVAPIDKeys(key)
+	if err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(path, []byte(privateKey+"\n"), 0o600); err != nil {
+		return "", "", err
+	}
+
+	return privateKey, publicKey, nil
+}
+
+// encodeVAPIDKeys encodes both halves of key as base64url without padding
+func encodeVAPIDKeys(key *ecdh.PrivateKey) (string, string, error) {
+	return base64.RawURLEncoding.EncodeToString(key.Bytes()),
+		base64.RawURLEncoding.EncodeToString(key.PublicKey().Bytes()),
+		nil
+}
@@ -0,0 +1,618 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: website/v1/portfolios.proto
+
+package websitev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PortfolioMetric struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Label         string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PortfolioMetric) Reset() {
+	*x = PortfolioMetric{}
+	mi := &file_website_v1_portfolios_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortfolioMetric) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortfolioMetric) ProtoMessage() {}
+
+func (x *PortfolioMetric) ProtoReflect() protoreflect.Message {
+	mi := &file_website_v1_portfolios_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortfolioMetric.ProtoReflect.Descriptor instead.
+func (*PortfolioMetric) Descriptor() ([]byte, []int) {
+	return file_website_v1_portfolios_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PortfolioMetric) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *PortfolioMetric) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type Portfolio struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Slug        string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// Markdown case study and its rendered HTML
+	Content       string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	ContentHtml   string                 `protobuf:"bytes,6,opt,name=content_html,json=contentHtml,proto3" json:"content_html,omitempty"`
+	Image         string                 `protobuf:"bytes,7,opt,name=image,proto3" json:"image,omitempty"`
+	ProjectUrl    string                 `protobuf:"bytes,8,opt,name=project_url,json=projectUrl,proto3" json:"project_url,omitempty"`
+	GithubUrl     string                 `protobuf:"bytes,9,opt,name=github_url,json=githubUrl,proto3" json:"github_url,omitempty"`
+	Technologies  []string               `protobuf:"bytes,10,rep,name=technologies,proto3" json:"technologies,omitempty"`
+	Category      string                 `protobuf:"bytes,11,opt,name=category,proto3" json:"category,omitempty"`
+	Role          string                 `protobuf:"bytes,12,opt,name=role,proto3" json:"role,omitempty"`
+	Duration      string                 `protobuf:"bytes,13,opt,name=duration,proto3" json:"duration,omitempty"`
+	Metrics       []*PortfolioMetric     `protobuf:"bytes,14,rep,name=metrics,proto3" json:"metrics,omitempty"`
+	IsPublished   bool                   `protobuf:"varint,15,opt,name=is_published,json=isPublished,proto3" json:"is_published,omitempty"`
+	Author        *Author                `protobuf:"bytes,16,opt,name=author,proto3" json:"author,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Portfolio) Reset() {
+	*x = Portfolio{}
+	mi := &file_website_v1_portfolios_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Portfolio) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Portfolio) ProtoMessage() {}
+
+func (x *Portfolio) ProtoReflect() protoreflect.Message {
+	mi := &file_website_v1_portfolios_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Portfolio.ProtoReflect.Descriptor instead.
+func (*Portfolio) Descriptor() ([]byte, []int) {
+	return file_website_v1_portfolios_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Portfolio) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Portfolio) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Portfolio) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Portfolio) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Portfolio) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Portfolio) GetContentHtml() string {
+	if x != nil {
+		return x.ContentHtml
+	}
+	return ""
+}
+
+func (x *Portfolio) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Portfolio) GetProjectUrl() string {
+	if x != nil {
+		return x.ProjectUrl
+	}
+	return ""
+}
+
+func (x *Portfolio) GetGithubUrl() string {
+	if x != nil {
+		return x.GithubUrl
+	}
+	return ""
+}
+
+func (x *Portfolio) GetTechnologies() []string {
+	if x != nil {
+		return x.Technologies
+	}
+	return nil
+}
+
+func (x *Portfolio) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Portfolio) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Portfolio) GetDuration() string {
+	if x != nil {
+		return x.Duration
+	}
+	return ""
+}
+
+func (x *Portfolio) GetMetrics() []*PortfolioMetric {
+	if x != nil {
+		return x.Metrics
+	}
+	return nil
+}
+
+func (x *Portfolio) GetIsPublished() bool {
+	if x != nil {
+		return x.IsPublished
+	}
+	return false
+}
+
+func (x *Portfolio) GetAuthor() *Author {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *Portfolio) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Portfolio) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListPortfoliosRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Defaults to page 1 of 10 portfolios
+	Page       int32  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage    int32  `protobuf:"varint,2,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	Search     string `protobuf:"bytes,3,opt,name=search,proto3" json:"search,omitempty"`
+	Technology string `protobuf:"bytes,4,opt,name=technology,proto3" json:"technology,omitempty"`
+	Category   string `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	// Admins only; lists unpublished portfolios too
+	IncludeUnpublished bool `protobuf:"varint,6,opt,name=include_unpublished,json=includeUnpublished,proto3" json:"include_unpublished,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ListPortfoliosRequest) Reset() {
+	*x = ListPortfoliosRequest{}
+	mi := &file_website_v1_portfolios_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPortfoliosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPortfoliosRequest) ProtoMessage() {}
+
+func (x *ListPortfoliosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_website_v1_portfolios_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPortfoliosRequest.ProtoReflect.Descriptor instead.
+func (*ListPortfoliosRequest) Descriptor() ([]byte, []int) {
+	return file_website_v1_portfolios_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListPortfoliosRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPortfoliosRequest) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+func (x *ListPortfoliosRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListPortfoliosRequest) GetTechnology() string {
+	if x != nil {
+		return x.Technology
+	}
+	return ""
+}
+
+func (x *ListPortfoliosRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *ListPortfoliosRequest) GetIncludeUnpublished() bool {
+	if x != nil {
+		return x.IncludeUnpublished
+	}
+	return false
+}
+
+type ListPortfoliosResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Portfolios    []*Portfolio           `protobuf:"bytes,1,rep,name=portfolios,proto3" json:"portfolios,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PerPage       int32                  `protobuf:"varint,4,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPortfoliosResponse) Reset() {
+	*x = ListPortfoliosResponse{}
+	mi := &file_website_v1_portfolios_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPortfoliosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPortfoliosResponse) ProtoMessage() {}
+
+func (x *ListPortfoliosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_website_v1_portfolios_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPortfoliosResponse.ProtoReflect.Descriptor instead.
+func (*ListPortfoliosResponse) Descriptor() ([]byte, []int) {
+	return file_website_v1_portfolios_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListPortfoliosResponse) GetPortfolios() []*Portfolio {
+	if x != nil {
+		return x.Portfolios
+	}
+	return nil
+}
+
+func (x *ListPortfoliosResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListPortfoliosResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPortfoliosResponse) GetPerPage() int32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+// Looks up a portfolio by ID or slug
+type GetPortfolioRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Key:
+	//
+	//	*GetPortfolioRequest_Id
+	//	*GetPortfolioRequest_Slug
+	Key           isGetPortfolioRequest_Key `protobuf_oneof:"key"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPortfolioRequest) Reset() {
+	*x = GetPortfolioRequest{}
+	mi := &file_website_v1_portfolios_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPortfolioRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPortfolioRequest) ProtoMessage() {}
+
+func (x *GetPortfolioRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_website_v1_portfolios_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPortfolioRequest.ProtoReflect.Descriptor instead.
+func (*GetPortfolioRequest) Descriptor() ([]byte, []int) {
+	return file_website_v1_portfolios_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetPortfolioRequest) GetKey() isGetPortfolioRequest_Key {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *GetPortfolioRequest) GetId() string {
+	if x != nil {
+		if x, ok := x.Key.(*GetPortfolioRequest_Id); ok {
+			return x.Id
+		}
+	}
+	return ""
+}
+
+func (x *GetPortfolioRequest) GetSlug() string {
+	if x != nil {
+		if x, ok := x.Key.(*GetPortfolioRequest_Slug); ok {
+			return x.Slug
+		}
+	}
+	return ""
+}
+
+type isGetPortfolioRequest_Key interface {
+	isGetPortfolioRequest_Key()
+}
+
+type GetPortfolioRequest_Id struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3,oneof"`
+}
+
+type GetPortfolioRequest_Slug struct {
+	Slug string `protobuf:"bytes,2,opt,name=slug,proto3,oneof"`
+}
+
+func (*GetPortfolioRequest_Id) isGetPortfolioRequest_Key() {}
+
+func (*GetPortfolioRequest_Slug) isGetPortfolioRequest_Key() {}
+
+var File_website_v1_portfolios_proto protoreflect.FileDescriptor
+
+const file_website_v1_portfolios_proto_rawDesc = "" +
+	"\n" +
+	"\x1bwebsite/v1/portfolios.proto\x12\n" +
+	"website.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x19website/v1/articles.proto\"=\n" +
+	"\x0fPortfolioMetric\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\xe6\x04\n" +
+	"\tPortfolio\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x12!\n" +
+	"\fcontent_html\x18\x06 \x01(\tR\vcontentHtml\x12\x14\n" +
+	"\x05image\x18\a \x01(\tR\x05image\x12\x1f\n" +
+	"\vproject_url\x18\b \x01(\tR\n" +
+	"projectUrl\x12\x1d\n" +
+	"\n" +
+	"github_url\x18\t \x01(\tR\tgithubUrl\x12\"\n" +
+	"\ftechnologies\x18\n" +
+	" \x03(\tR\ftechnologies\x12\x1a\n" +
+	"\bcategory\x18\v \x01(\tR\bcategory\x12\x12\n" +
+	"\x04role\x18\f \x01(\tR\x04role\x12\x1a\n" +
+	"\bduration\x18\r \x01(\tR\bduration\x125\n" +
+	"\ametrics\x18\x0e \x03(\v2\x1b.website.v1.PortfolioMetricR\ametrics\x12!\n" +
+	"\fis_published\x18\x0f \x01(\bR\visPublished\x12*\n" +
+	"\x06author\x18\x10 \x01(\v2\x12.website.v1.AuthorR\x06author\x129\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xcb\x01\n" +
+	"\x15ListPortfoliosRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x19\n" +
+	"\bper_page\x18\x02 \x01(\x05R\aperPage\x12\x16\n" +
+	"\x06search\x18\x03 \x01(\tR\x06search\x12\x1e\n" +
+	"\n" +
+	"technology\x18\x04 \x01(\tR\n" +
+	"technology\x12\x1a\n" +
+	"\bcategory\x18\x05 \x01(\tR\bcategory\x12/\n" +
+	"\x13include_unpublished\x18\x06 \x01(\bR\x12includeUnpublished\"\x94\x01\n" +
+	"\x16ListPortfoliosResponse\x125\n" +
+	"\n" +
+	"portfolios\x18\x01 \x03(\v2\x15.website.v1.PortfolioR\n" +
+	"portfolios\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x19\n" +
+	"\bper_page\x18\x04 \x01(\x05R\aperPage\"D\n" +
+	"\x13GetPortfolioRequest\x12\x10\n" +
+	"\x02id\x18\x01 \x01(\tH\x00R\x02id\x12\x14\n" +
+	"\x04slug\x18\x02 \x01(\tH\x00R\x04slugB\x05\n" +
+	"\x03key2\xb3\x01\n" +
+	"\x10PortfolioService\x12W\n" +
+	"\x0eListPortfolios\x12!.website.v1.ListPortfoliosRequest\x1a\".website.v1.ListPortfoliosResponse\x12F\n" +
+	"\fGetPortfolio\x12\x1f.website.v1.GetPortfolioRequest\x1a\x15.website.v1.PortfolioBIZGgithub.com/budhilaw/personal-website-backend/proto/website/v1;websitev1b\x06proto3"
+
+var (
+	file_website_v1_portfolios_proto_rawDescOnce sync.Once
+	file_website_v1_portfolios_proto_rawDescData []byte
+)
+
+func file_website_v1_portfolios_proto_rawDescGZIP() []byte {
+	file_website_v1_portfolios_proto_rawDescOnce.Do(func() {
+		file_website_v1_portfolios_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_website_v1_portfolios_proto_rawDesc), len(file_website_v1_portfolios_proto_rawDesc)))
+	})
+	return file_website_v1_portfolios_proto_rawDescData
+}
+
+var file_website_v1_portfolios_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_website_v1_portfolios_proto_goTypes = []any{
+	(*PortfolioMetric)(nil),        // 0: website.v1.PortfolioMetric
+	(*Portfolio)(nil),              // 1: website.v1.Portfolio
+	(*ListPortfoliosRequest)(nil),  // 2: website.v1.ListPortfoliosRequest
+	(*ListPortfoliosResponse)(nil), // 3: website.v1.ListPortfoliosResponse
+	(*GetPortfolioRequest)(nil),    // 4: website.v1.GetPortfolioRequest
+	(*Author)(nil),                 // 5: website.v1.Author
+	(*timestamppb.Timestamp)(nil),  // 6: google.protobuf.Timestamp
+}
+var file_website_v1_portfolios_proto_depIdxs = []int32{
+	0, // 0: website.v1.Portfolio.metrics:type_name -> website.v1.PortfolioMetric
+	5, // 1: website.v1.Portfolio.author:type_name -> website.v1.Author
+	6, // 2: website.v1.Portfolio.created_at:type_name -> google.protobuf.Timestamp
+	6, // 3: website.v1.Portfolio.updated_at:type_name -> google.protobuf.Timestamp
+	1, // 4: website.v1.ListPortfoliosResponse.portfolios:type_name -> website.v1.Portfolio
+	2, // 5: website.v1.PortfolioService.ListPortfolios:input_type -> website.v1.ListPortfoliosRequest
+	4, // 6: website.v1.PortfolioService.GetPortfolio:input_type -> website.v1.GetPortfolioRequest
+	3, // 7: website.v1.PortfolioService.ListPortfolios:output_type -> website.v1.ListPortfoliosResponse
+	1, // 8: website.v1.PortfolioService.GetPortfolio:output_type -> website.v1.Portfolio
+	7, // [7:9] is the sub-list for method output_type
+	5, // [5:7] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_website_v1_portfolios_proto_init() }
+func file_website_v1_portfolios_proto_init() {
+	if File_website_v1_portfolios_proto != nil {
+		return
+	}
+	file_website_v1_articles_proto_init()
+	file_website_v1_portfolios_proto_msgTypes[4].OneofWrappers = []any{
+		(*GetPortfolioRequest_Id)(nil),
+		(*GetPortfolioRequest_Slug)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_website_v1_portfolios_proto_rawDesc), len(file_website_v1_portfolios_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_website_v1_portfolios_proto_goTypes,
+		DependencyIndexes: file_website_v1_portfolios_proto_depIdxs,
+		MessageInfos:      file_website_v1_portfolios_proto_msgTypes,
+	}.Build()
+	File_website_v1_portfolios_proto = out.File
+	file_website_v1_portfolios_proto_goTypes = nil
+	file_website_v1_portfolios_proto_depIdxs = nil
+}